@@ -0,0 +1,14 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package backendhiring
+
+import (
+	_ "embed"
+)
+
+// OpenAPI - The OpenAPI 3 specification of the public API, kept in api/openapi.yaml.
+//
+//go:embed api/openapi.yaml
+var OpenAPI []byte
@@ -0,0 +1,15 @@
+CREATE TABLE customers (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+
+    name                VARCHAR(255) NOT NULL,
+    birth_date          VARCHAR(10),
+    email               VARCHAR(255) NOT NULL,
+    ssn                 VARCHAR(11) NOT NULL,
+
+    created_on          TIMESTAMPTZ NOT NULL,
+    updated_on          TIMESTAMPTZ NOT NULL,
+    disabled_on         TIMESTAMPTZ,
+
+    CONSTRAINT customer_pk PRIMARY KEY (tenant_id, customer_id)
+);
@@ -0,0 +1,11 @@
+-- Each tenant's changes are numbered from 1 by its counter in customer_change_sequences.
+CREATE TABLE customer_changes (
+    tenant_id           VARCHAR(36) NOT NULL,
+    sequence_number     BIGINT NOT NULL,
+
+    customer_id         VARCHAR(36) NOT NULL,
+    change_type         VARCHAR(10) NOT NULL,
+    changed_on          TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT customer_changes_pk PRIMARY KEY (tenant_id, sequence_number)
+);
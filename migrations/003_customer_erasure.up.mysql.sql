@@ -0,0 +1,9 @@
+-- Encrypted values are longer than the plaintext they replace.
+ALTER TABLE customers
+    MODIFY name VARCHAR(512) NOT NULL,
+    MODIFY birth_date VARCHAR(128),
+    MODIFY email VARCHAR(512) NOT NULL,
+    MODIFY ssn VARCHAR(128) NOT NULL,
+    ADD COLUMN erased_on DATETIME(6),
+    ADD COLUMN legal_hold_on DATETIME(6),
+    ADD COLUMN legal_hold_reason VARCHAR(255);
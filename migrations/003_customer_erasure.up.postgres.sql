@@ -0,0 +1,9 @@
+-- Encrypted values are longer than the plaintext they replace.
+ALTER TABLE customers
+    ALTER COLUMN name TYPE VARCHAR(512),
+    ALTER COLUMN birth_date TYPE VARCHAR(128),
+    ALTER COLUMN email TYPE VARCHAR(512),
+    ALTER COLUMN ssn TYPE VARCHAR(128),
+    ADD COLUMN erased_on TIMESTAMPTZ,
+    ADD COLUMN legal_hold_on TIMESTAMPTZ,
+    ADD COLUMN legal_hold_reason VARCHAR(255);
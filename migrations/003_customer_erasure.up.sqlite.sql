@@ -0,0 +1,4 @@
+-- SQLite doesn't enforce VARCHAR lengths so the encrypted columns don't need widening like the other dialects.
+ALTER TABLE customers ADD COLUMN erased_on TIMESTAMP;
+ALTER TABLE customers ADD COLUMN legal_hold_on TIMESTAMP;
+ALTER TABLE customers ADD COLUMN legal_hold_reason VARCHAR(255);
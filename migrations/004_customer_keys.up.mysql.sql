@@ -0,0 +1,10 @@
+CREATE TABLE customer_keys (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+
+    -- The customer's data key wrapped by the key encryption key, deleting it crypto-shreds the customer.
+    wrapped_key         VARCHAR(255) NOT NULL,
+    created_on          DATETIME(6) NOT NULL,
+
+    CONSTRAINT customer_keys_pk PRIMARY KEY (tenant_id, customer_id)
+);
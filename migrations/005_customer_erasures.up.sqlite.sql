@@ -0,0 +1,12 @@
+CREATE TABLE customer_erasures (
+    erasure_id          VARCHAR(36) NOT NULL,
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+
+    reason              VARCHAR(255) NOT NULL,
+    erased_fields       VARCHAR(255) NOT NULL,
+    erased_on           TIMESTAMP NOT NULL,
+
+    -- A customer can only be erased once.
+    CONSTRAINT customer_erasures_pk PRIMARY KEY (tenant_id, customer_id)
+);
@@ -0,0 +1,2 @@
+-- Lets the retention job find customers disabled long enough ago to purge without scanning the table.
+CREATE INDEX customers_disabled_idx ON customers (disabled_on);
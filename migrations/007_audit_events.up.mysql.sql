@@ -0,0 +1,19 @@
+-- Append only, rows are never updated or deleted. Each tenant's events are hash chained, see audit.Event.
+CREATE TABLE audit_events (
+    tenant_id           VARCHAR(36) NOT NULL,
+    sequence_number     BIGINT NOT NULL,
+
+    actor_id            VARCHAR(255) NOT NULL,
+    route               VARCHAR(255) NOT NULL,
+    request_id          VARCHAR(255) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+    action              VARCHAR(10) NOT NULL,
+    fields              VARCHAR(255) NOT NULL,
+    occurred_on         DATETIME(6) NOT NULL,
+
+    previous_hash       VARCHAR(64) NOT NULL,
+    hash                VARCHAR(64) NOT NULL,
+
+    CONSTRAINT audit_events_pk PRIMARY KEY (tenant_id, sequence_number),
+    INDEX audit_events_customer_idx (tenant_id, customer_id, sequence_number)
+);
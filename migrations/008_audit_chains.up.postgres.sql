@@ -0,0 +1,8 @@
+-- The end of each tenant's audit chain. Appending locks the tenant's row so events are chained one at a time.
+CREATE TABLE audit_chains (
+    tenant_id           VARCHAR(36) NOT NULL,
+    sequence_number     BIGINT NOT NULL,
+    hash                VARCHAR(64) NOT NULL,
+
+    CONSTRAINT audit_chains_pk PRIMARY KEY (tenant_id)
+);
@@ -0,0 +1,22 @@
+CREATE TABLE customer_addresses (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+    address_id          VARCHAR(36) NOT NULL,
+
+    address_type        VARCHAR(11) NOT NULL,
+    is_primary          BOOLEAN NOT NULL,
+
+    -- Encrypted with the customer's data key like the personal data in customers.
+    line1               VARCHAR(512) NOT NULL,
+    line2               VARCHAR(512) NOT NULL,
+    city                VARCHAR(512) NOT NULL,
+    postal_code         VARCHAR(255) NOT NULL,
+
+    state               VARCHAR(2) NOT NULL,
+    country             VARCHAR(2) NOT NULL,
+
+    created_on          DATETIME(6) NOT NULL,
+    updated_on          DATETIME(6) NOT NULL,
+
+    CONSTRAINT customer_addresses_pk PRIMARY KEY (tenant_id, customer_id, address_id)
+);
@@ -0,0 +1,20 @@
+CREATE TABLE customer_phones (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+    phone_id            VARCHAR(36) NOT NULL,
+
+    phone_type          VARCHAR(6) NOT NULL,
+    is_primary          BOOLEAN NOT NULL,
+
+    -- E.164, encrypted with the customer's data key like the personal data in customers.
+    phone_number        VARCHAR(255) NOT NULL,
+    country             VARCHAR(2) NOT NULL,
+
+    status              VARCHAR(10) NOT NULL,
+    verified_on         TIMESTAMPTZ,
+
+    created_on          TIMESTAMPTZ NOT NULL,
+    updated_on          TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT customer_phones_pk PRIMARY KEY (tenant_id, customer_id, phone_id)
+);
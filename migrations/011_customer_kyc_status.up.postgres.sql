@@ -0,0 +1,2 @@
+-- Existing customers haven't been through any verification yet.
+ALTER TABLE customers ADD COLUMN kyc_status VARCHAR(15) NOT NULL DEFAULT 'unverified';
@@ -0,0 +1,2 @@
+-- Backs filtering the customer listing by KYC status.
+CREATE INDEX customers_kyc_status_idx ON customers (tenant_id, kyc_status);
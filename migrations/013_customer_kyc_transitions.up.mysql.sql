@@ -0,0 +1,13 @@
+CREATE TABLE customer_kyc_transitions (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+    transition_id       VARCHAR(36) NOT NULL,
+
+    from_status         VARCHAR(15) NOT NULL,
+    to_status           VARCHAR(15) NOT NULL,
+    reason              VARCHAR(255) NOT NULL,
+
+    transitioned_on     DATETIME(6) NOT NULL,
+
+    CONSTRAINT customer_kyc_transitions_pk PRIMARY KEY (tenant_id, customer_id, transition_id)
+);
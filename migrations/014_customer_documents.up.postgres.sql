@@ -0,0 +1,16 @@
+CREATE TABLE customer_documents (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+    document_id         VARCHAR(36) NOT NULL,
+
+    document_type       VARCHAR(20) NOT NULL,
+    content_type        VARCHAR(100) NOT NULL,
+    size                BIGINT NOT NULL,
+
+    -- Of the file as uploaded, the blob store holds it encrypted with the customer's data key.
+    sha256              VARCHAR(64) NOT NULL,
+
+    created_on          TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT customer_documents_pk PRIMARY KEY (tenant_id, customer_id, document_id)
+);
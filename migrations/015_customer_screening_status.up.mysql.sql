@@ -0,0 +1,2 @@
+-- Empty until the customer is screened, existing customers are screened the next time their name or birth date changes.
+ALTER TABLE customers ADD COLUMN screening_status VARCHAR(10) NOT NULL DEFAULT '';
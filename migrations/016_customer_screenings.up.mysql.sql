@@ -0,0 +1,13 @@
+-- Kept when the customer is erased, and for customers that were blocked and never created.
+CREATE TABLE customer_screenings (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+    screening_id        VARCHAR(36) NOT NULL,
+
+    status              VARCHAR(10) NOT NULL,
+    score               DOUBLE NOT NULL,
+
+    screened_on         DATETIME(6) NOT NULL,
+
+    CONSTRAINT customer_screenings_pk PRIMARY KEY (tenant_id, customer_id, screening_id)
+);
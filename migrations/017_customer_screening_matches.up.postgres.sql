@@ -0,0 +1,13 @@
+CREATE TABLE customer_screening_matches (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+    screening_id        VARCHAR(36) NOT NULL,
+
+    list_name           VARCHAR(20) NOT NULL,
+    entry_id            VARCHAR(20) NOT NULL,
+    matched_name        VARCHAR(350) NOT NULL,
+    programs            VARCHAR(255) NOT NULL,
+    score               DOUBLE PRECISION NOT NULL,
+
+    CONSTRAINT customer_screening_matches_pk PRIMARY KEY (tenant_id, customer_id, screening_id, list_name, entry_id)
+);
@@ -0,0 +1,18 @@
+CREATE TABLE customer_screening_alerts (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+    alert_id            VARCHAR(36) NOT NULL,
+    screening_id        VARCHAR(36) NOT NULL,
+    run_id              VARCHAR(36) NOT NULL,
+
+    status              VARCHAR(10) NOT NULL,
+    list_name           VARCHAR(20) NOT NULL,
+    entry_id            VARCHAR(20) NOT NULL,
+    matched_name        VARCHAR(350) NOT NULL,
+    programs            VARCHAR(255) NOT NULL,
+    score               DOUBLE NOT NULL,
+
+    created_on          DATETIME(6) NOT NULL,
+
+    CONSTRAINT customer_screening_alerts_pk PRIMARY KEY (tenant_id, customer_id, alert_id)
+);
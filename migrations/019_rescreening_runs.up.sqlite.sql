@@ -0,0 +1,20 @@
+-- Runs cover every tenant so they aren't keyed by one.
+CREATE TABLE rescreening_runs (
+    run_id              VARCHAR(36) NOT NULL,
+    list_version        VARCHAR(64) NOT NULL,
+    status              VARCHAR(10) NOT NULL,
+
+    screened            INT NOT NULL,
+    alerts              INT NOT NULL,
+    failed              INT NOT NULL,
+
+    -- The last customer the run got to.
+    after_tenant_id     VARCHAR(36) NOT NULL,
+    after_customer_id   VARCHAR(36) NOT NULL,
+
+    started_on          TIMESTAMP NOT NULL,
+    updated_on          TIMESTAMP NOT NULL,
+    finished_on         TIMESTAMP,
+
+    CONSTRAINT rescreening_runs_pk PRIMARY KEY (run_id)
+);
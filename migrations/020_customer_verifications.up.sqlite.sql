@@ -0,0 +1,20 @@
+-- Only the outcome of each check is kept, not the personal data sent to the provider.
+CREATE TABLE customer_verifications (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+    verification_id     VARCHAR(36) NOT NULL,
+
+    provider            VARCHAR(40) NOT NULL,
+    status              VARCHAR(20) NOT NULL,
+    reference           VARCHAR(255) NOT NULL,
+    reasons             VARCHAR(255) NOT NULL,
+
+    attempts            INT NOT NULL,
+    last_error          VARCHAR(255) NOT NULL,
+    next_attempt_on     TIMESTAMP NOT NULL,
+
+    created_on          TIMESTAMP NOT NULL,
+    completed_on        TIMESTAMP,
+
+    CONSTRAINT customer_verifications_pk PRIMARY KEY (tenant_id, customer_id, verification_id)
+);
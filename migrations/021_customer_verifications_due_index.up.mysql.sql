@@ -0,0 +1,2 @@
+-- Lets the verification worker find the pending checks due an attempt without scanning the table.
+CREATE INDEX customer_verifications_due_idx ON customer_verifications (status, next_attempt_on);
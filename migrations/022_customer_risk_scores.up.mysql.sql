@@ -0,0 +1,14 @@
+-- The customer's latest risk score, factors are the matching rules as space separated name:score pairs.
+CREATE TABLE customer_risk_scores (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+
+    score               INT NOT NULL,
+    tier                VARCHAR(40) NOT NULL,
+    factors             VARCHAR(1000) NOT NULL,
+    rules_version       VARCHAR(40) NOT NULL,
+
+    scored_on           DATETIME(6) NOT NULL,
+
+    CONSTRAINT customer_risk_scores_pk PRIMARY KEY (tenant_id, customer_id)
+);
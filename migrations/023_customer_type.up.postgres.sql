@@ -0,0 +1,2 @@
+-- Every customer from before there were businesses is an individual.
+ALTER TABLE customers ADD COLUMN customer_type VARCHAR(20) NOT NULL DEFAULT 'individual';
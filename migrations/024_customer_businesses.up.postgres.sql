@@ -0,0 +1,16 @@
+-- What a business customer is registered as, one row for each customer of type business.
+CREATE TABLE customer_businesses (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+
+    -- Encrypted with the customer's data key like the personal data in customers.
+    legal_name          VARCHAR(512) NOT NULL,
+    dba                 VARCHAR(512) NOT NULL,
+    ein                 VARCHAR(255) NOT NULL,
+
+    entity_type         VARCHAR(20) NOT NULL,
+    formation_date      VARCHAR(10),
+    incorporation_state VARCHAR(2) NOT NULL,
+
+    CONSTRAINT customer_businesses_pk PRIMARY KEY (tenant_id, customer_id)
+);
@@ -0,0 +1,14 @@
+-- Links a business customer to the individual customers that own or control it.
+CREATE TABLE customer_beneficial_owners (
+    tenant_id            VARCHAR(36) NOT NULL,
+    customer_id          VARCHAR(36) NOT NULL,
+    owner_id             VARCHAR(36) NOT NULL,
+
+    ownership_percentage DOUBLE NOT NULL,
+    control_person       BOOLEAN NOT NULL,
+
+    created_on           DATETIME(6) NOT NULL,
+    updated_on           DATETIME(6) NOT NULL,
+
+    CONSTRAINT customer_beneficial_owners_pk PRIMARY KEY (tenant_id, customer_id, owner_id)
+);
@@ -0,0 +1,13 @@
+-- An individual customer's tax ID, one row for each. It replaces customers.ssn, which is still written for SSNs
+-- so instances from before tax IDs read them while they're replaced.
+CREATE TABLE customer_tax_ids (
+    tenant_id           VARCHAR(36) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+
+    tax_id_type         VARCHAR(20) NOT NULL,
+    -- Encrypted with the customer's data key like the personal data in customers.
+    tax_id              VARCHAR(255) NOT NULL,
+    country             VARCHAR(2) NOT NULL,
+
+    CONSTRAINT customer_tax_ids_pk PRIMARY KEY (tenant_id, customer_id)
+);
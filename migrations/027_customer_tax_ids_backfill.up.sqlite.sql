@@ -0,0 +1,14 @@
+-- Copies every SSN into customer_tax_ids as it's stored, SSNs are still encrypted as ssn. Customers already there
+-- are left alone. customers.ssn stays the source of truth whenever it isn't what was written alongside the tax ID,
+-- so customers saved by instances from before tax IDs, during or after this, are read from it and the service can
+-- keep running while this does.
+INSERT INTO customer_tax_ids (tenant_id, customer_id, tax_id_type, tax_id, country)
+SELECT customers.tenant_id, customers.customer_id, 'ssn', customers.ssn, ''
+FROM customers
+WHERE customers.ssn <> ''
+  AND NOT EXISTS (
+    SELECT 1
+    FROM customer_tax_ids
+    WHERE customer_tax_ids.tenant_id = customers.tenant_id
+      AND customer_tax_ids.customer_id = customers.customer_id
+  );
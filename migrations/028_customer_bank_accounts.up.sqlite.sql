@@ -0,0 +1,23 @@
+CREATE TABLE customer_bank_accounts (
+    tenant_id               VARCHAR(36) NOT NULL,
+    customer_id             VARCHAR(36) NOT NULL,
+    bank_account_id         VARCHAR(36) NOT NULL,
+
+    account_type            VARCHAR(8) NOT NULL,
+    routing_number          VARCHAR(9) NOT NULL,
+    -- Encrypted with the customer's data key like the personal data in customers.
+    account_number          VARCHAR(255) NOT NULL,
+    bank_name               VARCHAR(36) NOT NULL,
+
+    status                  VARCHAR(20) NOT NULL,
+    -- The simulated amounts in cents, e.g. 12,34, encrypted like the account number.
+    micro_deposits          VARCHAR(255),
+    micro_deposits_sent_on  TIMESTAMP,
+    micro_deposit_attempts  INT NOT NULL,
+    verified_on             TIMESTAMP,
+
+    created_on              TIMESTAMP NOT NULL,
+    updated_on              TIMESTAMP NOT NULL,
+
+    CONSTRAINT customer_bank_accounts_pk PRIMARY KEY (tenant_id, customer_id, bank_account_id)
+);
@@ -0,0 +1,9 @@
+-- The last change sequence number handed out to each tenant. Recording a change moves it forward in the writing
+-- transaction, locking the tenant's row, so a tenant's changes are committed in sequence order. It's apart from
+-- customer_changes since MySQL migrations run one statement each.
+CREATE TABLE customer_change_sequences (
+    tenant_id           VARCHAR(36) NOT NULL,
+    sequence_number     BIGINT NOT NULL,
+
+    CONSTRAINT customer_change_sequences_pk PRIMARY KEY (tenant_id)
+);
@@ -0,0 +1,14 @@
+-- The failed micro-deposit confirmations of each bank account a customer ever had, kept when the account is deleted
+-- so deleting and adding it again doesn't reset them. Accounts are found by the key encryption key's fingerprint of
+-- the account number.
+CREATE TABLE customer_bank_account_attempts (
+    tenant_id               VARCHAR(36) NOT NULL,
+    customer_id             VARCHAR(36) NOT NULL,
+    routing_number          VARCHAR(9) NOT NULL,
+    account_fingerprint     VARCHAR(64) NOT NULL,
+
+    failed_attempts         INT NOT NULL,
+    updated_on              TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT customer_bank_account_attempts_pk PRIMARY KEY (tenant_id, customer_id, routing_number, account_fingerprint)
+);
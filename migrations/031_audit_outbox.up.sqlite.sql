@@ -0,0 +1,16 @@
+-- Audit events staged in the transaction of the change they record, so the change can't be committed without them.
+-- The relay chains them onto the tenant's log in audit_events and removes them from here in the same transaction.
+CREATE TABLE audit_outbox (
+    tenant_id           VARCHAR(36) NOT NULL,
+    outbox_id           VARCHAR(36) NOT NULL,
+
+    actor_id            VARCHAR(255) NOT NULL,
+    route               VARCHAR(255) NOT NULL,
+    request_id          VARCHAR(255) NOT NULL,
+    customer_id         VARCHAR(36) NOT NULL,
+    action              VARCHAR(10) NOT NULL,
+    fields              VARCHAR(255) NOT NULL,
+    occurred_on         TIMESTAMP NOT NULL,
+
+    CONSTRAINT audit_outbox_pk PRIMARY KEY (tenant_id, outbox_id)
+);
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+)
+
+// ErrMissingTenantID - The request didn't say which tenant it's for.
+var ErrMissingTenantID = errors.New("missing X-Tenant-ID header")
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+func NewController(logger log.Logger, service Service) Controller {
+	return &controller{logger: logger, service: service}
+}
+
+type controller struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("Audit.list").
+		Methods("GET").
+		Path("/audit-events").
+		HandlerFunc(c.list)
+
+	return router
+}
+
+func (c *controller) list(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		c.errorResponse(w, r, ErrMissingTenantID)
+		return
+	}
+
+	query := r.URL.Query()
+
+	limit := 0
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			c.errorResponse(w, r, ErrInvalidLimit)
+			return
+		}
+	}
+
+	result, err := c.service.List(r.Context(), tenantID, query.Get("customerID"), query.Get("cursor"), limit)
+	if err != nil {
+		c.errorResponse(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (c *controller) errorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrMissingTenantID), errors.Is(err, ErrInvalidCursor), errors.Is(err, ErrInvalidLimit):
+		problem.Write(w, problem.New(r, http.StatusBadRequest, err.Error()))
+	case errors.Is(err, context.DeadlineExceeded):
+		c.logger.Warn().LogErrorf("timed out: %w", err)
+		problem.Write(w, problem.New(r, http.StatusGatewayTimeout, ""))
+	default:
+		c.logger.LogErrorf("unexpected: %w", err)
+		problem.Write(w, problem.New(r, http.StatusInternalServerError, ""))
+	}
+}
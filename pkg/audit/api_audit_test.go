@@ -0,0 +1,55 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/stime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+)
+
+func Test_Audit_ListAPI(t *testing.T) {
+	a := require.New(t)
+	service := audit.NewService(stime.NewStaticTimeService(), audit.NewInMemoryRepository(testChainKey), testChainKey)
+	router := audit.NewController(log.NewNopLogger(), service).AppendRoutes(mux.NewRouter())
+
+	tenantID := uuid.NewString()
+	for _, customerID := range []string{"c1", "c2", "c1"} {
+		a.NoError(service.Record(context.Background(), tenantID, audit.Event{CustomerID: customerID, Action: audit.ActionRead}))
+	}
+
+	call := func(target string) (audit.Events, *http.Response) {
+		req := httptest.NewRequest("GET", target, nil)
+		req.Header.Set("X-Tenant-ID", tenantID)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		page := audit.Events{}
+		json.NewDecoder(rec.Body).Decode(&page)
+		return page, rec.Result()
+	}
+
+	page, res := call("/audit-events?limit=2")
+	a.Equal(http.StatusOK, res.StatusCode)
+	a.Len(page.Events, 2)
+	a.True(page.HasMore)
+
+	page, res = call("/audit-events?cursor=" + page.Cursor)
+	a.Equal(http.StatusOK, res.StatusCode)
+	a.Len(page.Events, 1)
+	a.False(page.HasMore)
+
+	page, _ = call("/audit-events?customerID=c1")
+	a.Len(page.Events, 2)
+
+	_, res = call("/audit-events?cursor=nope")
+	a.Equal(http.StatusBadRequest, res.StatusCode)
+}
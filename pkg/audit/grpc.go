@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor - The gRPC counterpart of Middleware, the route is the full method name.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		first := func(key string) string {
+			if values := md.Get(key); len(values) > 0 {
+				return values[0]
+			}
+			return ""
+		}
+
+		return handler(WithRequest(ctx, Request{
+			ActorID:   first(ActorIDMetadata),
+			Route:     info.FullMethod,
+			RequestID: first("x-request-id"),
+		}), req)
+	}
+}
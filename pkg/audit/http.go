@@ -0,0 +1,23 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Middleware - Attributes the events recorded while handling a request to its actor, route and request ID. It goes
+// on the router so the route has been matched, and after the request logger has assigned the request ID.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := Request{
+			ActorID:   r.Header.Get(ActorIDHeader),
+			RequestID: r.Header.Get("X-Request-ID"),
+		}
+		if route := mux.CurrentRoute(r); route != nil {
+			request.Route = route.GetName()
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithRequest(r.Context(), request)))
+	})
+}
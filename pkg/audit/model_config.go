@@ -0,0 +1,8 @@
+package audit
+
+// Config - Settings for the audit log.
+type Config struct {
+	// ChainKey keys the hashes linking each tenant's events, 32 base64 encoded bytes. Keeping it out of the database
+	// is what stops someone who can write to the database from rewriting the log and its hashes to match.
+	ChainKey string
+}
@@ -0,0 +1,185 @@
+package audit
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultLimit - How many events are returned when the caller doesn't ask for a number.
+	DefaultLimit = 100
+	// MaxLimit - The most events returned by a single call.
+	MaxLimit = 1000
+)
+
+// ErrNoChainKey - No chain key was configured. There's no default, every deployment needs its own.
+var ErrNoChainKey = errors.New("no audit chain key configured")
+
+// ErrInvalidCursor - The cursor wasn't one handed out by a previous call.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrInvalidLimit - The limit was outside 1 to MaxLimit.
+var ErrInvalidLimit = errors.New("limit must be between 1 and 1000")
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	// ActionRead - Personal data was returned to the actor.
+	ActionRead   Action = "read"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	ActionErase  Action = "erase"
+)
+
+// Event - Records that an actor acted on a customer. Each tenant's events form a chain, every event's hash covers
+// the hash of the one before it so editing or removing an event breaks the chain from there on. The hashes are keyed
+// by the ChainKey, which is kept out of the database so nobody who can only write to it can recompute them.
+type Event struct {
+	TenantID string `json:"tenantID"`
+	Sequence int64  `json:"sequence"`
+
+	ActorID    string `json:"actorID"`
+	Route      string `json:"route"`
+	RequestID  string `json:"requestID"`
+	CustomerID string `json:"customerID"`
+	Action     Action `json:"action"`
+	// Fields are the names of the fields changed, or read for ActionRead.
+	Fields     []string  `json:"fields"`
+	OccurredOn time.Time `json:"occurredOn"`
+
+	PreviousHash string `json:"previousHash"`
+	Hash         string `json:"hash"`
+}
+
+// seal - Links the event onto the end of its tenant's chain.
+func (e *Event) seal(key *ChainKey, sequence int64, previousHash string) {
+	e.Sequence = sequence
+	e.PreviousHash = previousHash
+	e.Hash = e.computeHash(key)
+}
+
+// computeHash - HMAC-SHA-256 of everything in the event but its own hash. The databases only keep microseconds so
+// events are recorded with their time truncated to match.
+func (e Event) computeHash(key *ChainKey) string {
+	fields := e.Fields
+	if fields == nil {
+		fields = []string{}
+	}
+
+	// A struct rather than a map so the order of the encoded fields never changes.
+	encoded, _ := json.Marshal(struct {
+		TenantID     string
+		Sequence     int64
+		ActorID      string
+		Route        string
+		RequestID    string
+		CustomerID   string
+		Action       Action
+		Fields       []string
+		OccurredOn   string
+		PreviousHash string
+	}{
+		TenantID:     e.TenantID,
+		Sequence:     e.Sequence,
+		ActorID:      e.ActorID,
+		Route:        e.Route,
+		RequestID:    e.RequestID,
+		CustomerID:   e.CustomerID,
+		Action:       e.Action,
+		Fields:       fields,
+		OccurredOn:   e.OccurredOn.UTC().Format(time.RFC3339Nano),
+		PreviousHash: e.PreviousHash,
+	})
+
+	h := hmac.New(sha256.New, key.key)
+	h.Write(encoded)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ChainKey - Keys the hashes that link each tenant's events.
+type ChainKey struct {
+	key []byte
+}
+
+// chainKeySize - Bytes in a chain key, the size of the SHA-256 output it keys.
+const chainKeySize = 32
+
+// NewChainKey - Loads a chain key from 32 base64 encoded bytes.
+func NewChainKey(encoded string) (*ChainKey, error) {
+	if encoded == "" {
+		return nil, ErrNoChainKey
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding audit chain key: %w", err)
+	}
+	if len(key) != chainKeySize {
+		return nil, fmt.Errorf("audit chain key must be %d bytes, got %d", chainKeySize, len(key))
+	}
+	return &ChainKey{key: key}, nil
+}
+
+// NewRandomChainKey - A chain key for logs that don't outlive the process, like the in-memory repository's.
+func NewRandomChainKey() (*ChainKey, error) {
+	key := make([]byte, chainKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return &ChainKey{key: key}, nil
+}
+
+// Filter - Selects a tenant's events, oldest first.
+type Filter struct {
+	TenantID string
+	// CustomerID limits the events to one customer when set.
+	CustomerID string
+	After      int64
+	Limit      int
+}
+
+// Events - A page of the audit log.
+type Events struct {
+	Events []Event `json:"events"`
+	// Cursor is passed back to get the events after this page, it's returned even when the page is empty.
+	Cursor  string `json:"cursor"`
+	HasMore bool   `json:"hasMore"`
+}
+
+func encodeCursor(sequence int64) string {
+	return strconv.FormatInt(sequence, 10)
+}
+
+func decodeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	sequence, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil || sequence < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return sequence, nil
+}
+
+// Verification - The result of checking a tenant's chain.
+type Verification struct {
+	TenantID string `json:"tenantID"`
+	// Events is how many events were checked.
+	Events int64 `json:"events"`
+	Valid  bool  `json:"valid"`
+	// BrokenAt is the sequence number where the chain stops verifying, Problem says why.
+	BrokenAt int64  `json:"brokenAt,omitempty"`
+	Problem  string `json:"problem,omitempty"`
+	// Head is the hash of the last event, recording it elsewhere lets a later check prove nothing was rewritten.
+	Head string `json:"head"`
+}
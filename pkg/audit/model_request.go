@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"context"
+)
+
+const (
+	// ActorIDHeader - Who is making the request, set by the gateway alongside X-Tenant-ID.
+	ActorIDHeader = "X-Actor-ID"
+	// ActorIDMetadata - The gRPC counterpart of ActorIDHeader.
+	ActorIDMetadata = "x-actor-id"
+)
+
+// Request - Where the actions recorded while handling a request came from.
+type Request struct {
+	ActorID   string
+	Route     string
+	RequestID string
+}
+
+type requestKey struct{}
+
+// WithRequest - Attributes the events recorded with ctx to the request.
+func WithRequest(ctx context.Context, request Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, request)
+}
+
+// RequestFromContext - The request set by WithRequest, or an empty one.
+func RequestFromContext(ctx context.Context) Request {
+	request, _ := ctx.Value(requestKey{}).(Request)
+	return request
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/moov-io/base/log"
+)
+
+// DefaultRelayInterval - How often the relay job looks for staged events that weren't chained after their change.
+const DefaultRelayInterval = 10 * time.Second
+
+// RelayJob - Chains the events left in the outbox, those whose change was saved but couldn't be chained right after
+// it or whose service stopped in between.
+type RelayJob struct {
+	logger   log.Logger
+	service  Service
+	interval time.Duration
+}
+
+func NewRelayJob(logger log.Logger, service Service, interval time.Duration) *RelayJob {
+	if interval <= 0 {
+		interval = DefaultRelayInterval
+	}
+	return &RelayJob{logger: logger, service: service, interval: interval}
+}
+
+// Start - Relays every interval until the returned func is called, which waits for a relay in progress to finish.
+func (j *RelayJob) Start() func() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Errors are logged by Run, the next tick tries again.
+				j.Run(ctx)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// Run - Chains every tenant's staged events onto their logs.
+func (j *RelayJob) Run(ctx context.Context) error {
+	if err := j.service.Relay(ctx, ""); err != nil {
+		j.logger.LogErrorf("relaying staged audit events: %v", err)
+		return err
+	}
+	return nil
+}
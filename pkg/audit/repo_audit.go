@@ -0,0 +1,259 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+// Repository - Stores the audit log, events can only be appended.
+type Repository interface {
+	// Append - Chains the tenant's events onto the end of its log in one go and returns them sealed.
+	Append(ctx context.Context, tenantID string, events []Event) ([]Event, error)
+	List(ctx context.Context, filter Filter) ([]Event, error)
+	// Head - The sequence number and hash of the tenant's last event, 0 and "" when it has none.
+	Head(ctx context.Context, tenantID string) (int64, string, error)
+	// Tenants - Every tenant with an audit log.
+	Tenants(ctx context.Context) ([]string, error)
+
+	// Relay - Chains up to limit of the tenant's events staged by Stage onto its log, oldest first, and removes them
+	// from the outbox in the same transaction. Returns them sealed.
+	Relay(ctx context.Context, tenantID string, limit int) ([]Event, error)
+	// OutboxTenants - Every tenant with events staged in the outbox.
+	OutboxTenants(ctx context.Context) ([]string, error)
+}
+
+type auditRepo struct {
+	db      *sql.DB
+	dialect sqldb.Dialect
+	key     *ChainKey
+}
+
+func NewRepository(db *sql.DB, dialect sqldb.Dialect, key *ChainKey) Repository {
+	return &auditRepo{db: db, dialect: dialect, key: key}
+}
+
+// eventColumns - Selected by every query that returns events, in the order scanEvent reads them.
+const eventColumns = `
+			audit_events.tenant_id,
+			audit_events.sequence_number,
+			audit_events.actor_id,
+			audit_events.route,
+			audit_events.request_id,
+			audit_events.customer_id,
+			audit_events.action,
+			audit_events.fields,
+			audit_events.occurred_on,
+			audit_events.previous_hash,
+			audit_events.hash`
+
+func (r *auditRepo) Append(ctx context.Context, tenantID string, events []Event) ([]Event, error) {
+	if len(events) == 0 {
+		return events, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	sealed, err := r.appendTx(ctx, tx, tenantID, events)
+	if err != nil {
+		return nil, err
+	}
+	return sealed, tx.Commit()
+}
+
+// appendTx - Chains the events onto the tenant's log in tx.
+func (r *auditRepo) appendTx(ctx context.Context, tx *sql.Tx, tenantID string, events []Event) ([]Event, error) {
+	// Start the tenant's chain if this is its first event, then take the lock on it by moving it forward.
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(r.startChain()), tenantID); err != nil {
+		return nil, err
+	}
+
+	qry := `
+		UPDATE audit_chains
+		SET sequence_number = sequence_number + ?
+		WHERE tenant_id = ?
+	`
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), len(events), tenantID); err != nil {
+		return nil, err
+	}
+
+	qry = `
+		SELECT sequence_number, hash
+		FROM audit_chains
+		WHERE tenant_id = ?
+	`
+	var last int64
+	var previousHash string
+	if err := tx.QueryRowContext(ctx, r.dialect.Rebind(qry), tenantID).Scan(&last, &previousHash); err != nil {
+		return nil, err
+	}
+
+	qry = `
+		INSERT INTO audit_events(
+			tenant_id,
+			sequence_number,
+			actor_id,
+			route,
+			request_id,
+			customer_id,
+			action,
+			fields,
+			occurred_on,
+			previous_hash,
+			hash
+		) VALUES (?,?,?,?,?,?,?,?,?,?,?)
+	`
+	sealed := make([]Event, len(events))
+	sequence := last - int64(len(events))
+	for i, event := range events {
+		sequence++
+		event.TenantID = tenantID
+		event.seal(r.key, sequence, previousHash)
+
+		_, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+			event.TenantID,
+			event.Sequence,
+			event.ActorID,
+			event.Route,
+			event.RequestID,
+			event.CustomerID,
+			event.Action,
+			strings.Join(event.Fields, ","),
+			event.OccurredOn,
+			event.PreviousHash,
+			event.Hash,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sealed[i] = event
+		previousHash = event.Hash
+	}
+
+	qry = `
+		UPDATE audit_chains
+		SET hash = ?
+		WHERE tenant_id = ?
+	`
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), previousHash, tenantID); err != nil {
+		return nil, err
+	}
+
+	return sealed, nil
+}
+
+// startChain - Adds the tenant's chain unless it already has one.
+func (r *auditRepo) startChain() string {
+	switch r.dialect {
+	case sqldb.MySQL:
+		return `INSERT IGNORE INTO audit_chains(tenant_id, sequence_number, hash) VALUES (?, 0, '')`
+	case sqldb.Postgres:
+		return `INSERT INTO audit_chains(tenant_id, sequence_number, hash) VALUES (?, 0, '') ON CONFLICT DO NOTHING`
+	default:
+		return `INSERT OR IGNORE INTO audit_chains(tenant_id, sequence_number, hash) VALUES (?, 0, '')`
+	}
+}
+
+func (r *auditRepo) List(ctx context.Context, filter Filter) ([]Event, error) {
+	qry := `
+		SELECT ` + eventColumns + `
+		FROM audit_events
+		WHERE audit_events.tenant_id = ?
+		  AND audit_events.sequence_number > ?
+	`
+	args := []interface{}{filter.TenantID, filter.After}
+
+	if filter.CustomerID != "" {
+		qry += ` AND audit_events.customer_id = ?`
+		args = append(args, filter.CustomerID)
+	}
+
+	qry += `
+		ORDER BY audit_events.sequence_number
+		LIMIT ?
+	`
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Event{}
+	for rows.Next() {
+		event := Event{}
+		var fields string
+		err := rows.Scan(
+			&event.TenantID,
+			&event.Sequence,
+			&event.ActorID,
+			&event.Route,
+			&event.RequestID,
+			&event.CustomerID,
+			&event.Action,
+			&fields,
+			&event.OccurredOn,
+			&event.PreviousHash,
+			&event.Hash,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Fields = splitFields(fields)
+		event.OccurredOn = event.OccurredOn.UTC()
+		items = append(items, event)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *auditRepo) Head(ctx context.Context, tenantID string) (int64, string, error) {
+	qry := `
+		SELECT sequence_number, hash
+		FROM audit_chains
+		WHERE tenant_id = ?
+	`
+
+	var sequence int64
+	var hash string
+	err := r.db.QueryRowContext(ctx, r.dialect.Rebind(qry), tenantID).Scan(&sequence, &hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, "", nil
+	}
+	return sequence, hash, err
+}
+
+func (r *auditRepo) Tenants(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT tenant_id FROM audit_chains ORDER BY tenant_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenants := []string{}
+	for rows.Next() {
+		var tenantID string
+		if err := rows.Scan(&tenantID); err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, tenantID)
+	}
+	return tenants, rows.Err()
+}
+
+func splitFields(fields string) []string {
+	if fields == "" {
+		return []string{}
+	}
+	return strings.Split(fields, ",")
+}
@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// memoryRepo - Keeps each tenant's chain in a slice, for tests and local demos. Nothing is persisted.
+type memoryRepo struct {
+	mu     sync.RWMutex
+	key    *ChainKey
+	chains map[string][]Event
+}
+
+func NewInMemoryRepository(key *ChainKey) Repository {
+	return &memoryRepo{key: key, chains: map[string][]Event{}}
+}
+
+func (r *memoryRepo) Append(ctx context.Context, tenantID string, events []Event) ([]Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chain := r.chains[tenantID]
+	previousHash := ""
+	if len(chain) > 0 {
+		previousHash = chain[len(chain)-1].Hash
+	}
+
+	sealed := make([]Event, len(events))
+	for i, event := range events {
+		event.TenantID = tenantID
+		event.Fields = append([]string{}, event.Fields...)
+		event.seal(r.key, int64(len(chain)+1), previousHash)
+
+		chain = append(chain, event)
+		sealed[i] = copyEvent(event)
+		previousHash = event.Hash
+	}
+	r.chains[tenantID] = chain
+
+	return sealed, nil
+}
+
+func (r *memoryRepo) List(ctx context.Context, filter Filter) ([]Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []Event{}
+	for _, event := range r.chains[filter.TenantID] {
+		if len(items) == filter.Limit {
+			break
+		}
+		if event.Sequence <= filter.After || (filter.CustomerID != "" && event.CustomerID != filter.CustomerID) {
+			continue
+		}
+		items = append(items, copyEvent(event))
+	}
+	return items, nil
+}
+
+func (r *memoryRepo) Head(ctx context.Context, tenantID string) (int64, string, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, "", err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	chain := r.chains[tenantID]
+	if len(chain) == 0 {
+		return 0, "", nil
+	}
+	return chain[len(chain)-1].Sequence, chain[len(chain)-1].Hash, nil
+}
+
+func (r *memoryRepo) Tenants(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenants := []string{}
+	for tenantID := range r.chains {
+		tenants = append(tenants, tenantID)
+	}
+	sort.Strings(tenants)
+	return tenants, nil
+}
+
+// Relay - Nothing is staged in memory, the in-memory customer repository can't share a transaction with it.
+func (r *memoryRepo) Relay(ctx context.Context, tenantID string, limit int) ([]Event, error) {
+	return []Event{}, ctx.Err()
+}
+
+func (r *memoryRepo) OutboxTenants(ctx context.Context) ([]string, error) {
+	return []string{}, ctx.Err()
+}
+
+// copyEvent - Detaches the fields so callers can't modify what's stored.
+func copyEvent(e Event) Event {
+	e.Fields = append([]string{}, e.Fields...)
+	return e
+}
@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+// Stage - Adds the event to the outbox in tx, the transaction of the change it records, so the change can't be
+// committed without it. Relay chains it onto the tenant's log once it is. The event is attributed to the request in
+// ctx like Record's.
+func Stage(ctx context.Context, tx *sql.Tx, dialect sqldb.Dialect, tenantID string, outboxID string, event Event) error {
+	request := RequestFromContext(ctx)
+
+	qry := `
+		INSERT INTO audit_outbox(
+			tenant_id,
+			outbox_id,
+			actor_id,
+			route,
+			request_id,
+			customer_id,
+			action,
+			fields,
+			occurred_on
+		) VALUES (?,?,?,?,?,?,?,?,?)
+	`
+	_, err := tx.ExecContext(ctx, dialect.Rebind(qry),
+		tenantID,
+		outboxID,
+		request.ActorID,
+		request.Route,
+		request.RequestID,
+		event.CustomerID,
+		event.Action,
+		strings.Join(event.Fields, ","),
+		event.OccurredOn.UTC().Truncate(time.Microsecond),
+	)
+	return err
+}
+
+func (r *auditRepo) Relay(ctx context.Context, tenantID string, limit int) ([]Event, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	outboxIDs, events, err := r.staged(ctx, tx, tenantID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Removed before they're chained, another relay that listed them too finds them gone once this one commits.
+	relayed := []Event{}
+	for i, outboxID := range outboxIDs {
+		qry := `
+			DELETE FROM audit_outbox
+			WHERE tenant_id = ?
+			  AND outbox_id = ?
+		`
+		res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), tenantID, outboxID)
+		if err != nil {
+			return nil, err
+		}
+
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if cnt == 1 {
+			relayed = append(relayed, events[i])
+		}
+	}
+	if len(relayed) == 0 {
+		return relayed, nil
+	}
+
+	sealed, err := r.appendTx(ctx, tx, tenantID, relayed)
+	if err != nil {
+		return nil, err
+	}
+	return sealed, tx.Commit()
+}
+
+// staged - Up to limit of the tenant's events in the outbox, oldest first, along with their outbox IDs.
+func (r *auditRepo) staged(ctx context.Context, tx *sql.Tx, tenantID string, limit int) ([]string, []Event, error) {
+	qry := `
+		SELECT
+			outbox_id,
+			actor_id,
+			route,
+			request_id,
+			customer_id,
+			action,
+			fields,
+			occurred_on
+		FROM audit_outbox
+		WHERE tenant_id = ?
+		ORDER BY occurred_on, outbox_id
+		LIMIT ?
+	`
+	rows, err := tx.QueryContext(ctx, r.dialect.Rebind(qry), tenantID, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	outboxIDs := []string{}
+	events := []Event{}
+	for rows.Next() {
+		var outboxID, fields string
+		event := Event{}
+		err := rows.Scan(
+			&outboxID,
+			&event.ActorID,
+			&event.Route,
+			&event.RequestID,
+			&event.CustomerID,
+			&event.Action,
+			&fields,
+			&event.OccurredOn,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		event.Fields = splitFields(fields)
+		event.OccurredOn = event.OccurredOn.UTC()
+		outboxIDs = append(outboxIDs, outboxID)
+		events = append(events, event)
+	}
+
+	return outboxIDs, events, rows.Err()
+}
+
+func (r *auditRepo) OutboxTenants(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT tenant_id FROM audit_outbox ORDER BY tenant_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenants := []string{}
+	for rows.Next() {
+		var tenantID string
+		if err := rows.Scan(&tenantID); err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, tenantID)
+	}
+	return tenants, rows.Err()
+}
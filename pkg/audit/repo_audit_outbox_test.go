@@ -0,0 +1,111 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+func Test_Audit_Outbox(t *testing.T) {
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			a := require.New(t)
+			repository := audit.NewRepository(db.DB, db.Dialect, testChainKey)
+			tenantID := uuid.NewString()
+			customerID := uuid.NewString()
+
+			ctx := audit.WithRequest(context.Background(), audit.Request{ActorID: "jane@moov.io", Route: "Customer.update", RequestID: "req-1"})
+			stage := func(event audit.Event, commit bool) {
+				tx, err := db.DB.BeginTx(ctx, nil)
+				a.NoError(err)
+				defer tx.Rollback()
+
+				a.NoError(audit.Stage(ctx, tx, db.Dialect, tenantID, uuid.NewString(), event))
+				if commit {
+					a.NoError(tx.Commit())
+				}
+			}
+
+			created := newEvent(customerID, audit.ActionCreate, "name", "email")
+			stage(created, true)
+			updated := newEvent(customerID, audit.ActionUpdate, "email")
+			updated.OccurredOn = created.OccurredOn.Add(time.Millisecond)
+			stage(updated, true)
+
+			// Nothing is left behind by a change that was rolled back.
+			stage(newEvent(customerID, audit.ActionDelete, "disabledOn"), false)
+
+			tenants, err := repository.OutboxTenants(ctx)
+			a.NoError(err)
+			a.Contains(tenants, tenantID)
+
+			// Chained oldest first, attributed to the request that staged them.
+			relayed, err := repository.Relay(ctx, tenantID, 10)
+			a.NoError(err)
+			a.Len(relayed, 2)
+			a.Equal(audit.ActionCreate, relayed[0].Action)
+			a.Equal([]string{"name", "email"}, relayed[0].Fields)
+			a.Equal(audit.ActionUpdate, relayed[1].Action)
+			a.Equal("jane@moov.io", relayed[1].ActorID)
+			a.Equal("req-1", relayed[1].RequestID)
+			a.Equal(updated.OccurredOn, relayed[1].OccurredOn)
+
+			listed, err := repository.List(ctx, audit.Filter{TenantID: tenantID, Limit: 10})
+			a.NoError(err)
+			a.Equal(relayed, listed)
+
+			// They're removed once chained.
+			relayed, err = repository.Relay(ctx, tenantID, 10)
+			a.NoError(err)
+			a.Empty(relayed)
+			tenants, err = repository.OutboxTenants(ctx)
+			a.NoError(err)
+			a.NotContains(tenants, tenantID)
+
+			result, err := audit.NewService(nil, repository, testChainKey).Verify(ctx, tenantID)
+			a.NoError(err)
+			a.True(result.Valid, result.Problem)
+			a.EqualValues(2, result.Events)
+		})
+	}
+}
+
+func Test_Audit_Relay(t *testing.T) {
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			a := require.New(t)
+			ctx := context.Background()
+			repository := audit.NewRepository(db.DB, db.Dialect, testChainKey)
+			service := audit.NewService(nil, repository, testChainKey)
+
+			// More than a batch for one tenant, and another tenant.
+			tenantID, otherTenantID := uuid.NewString(), uuid.NewString()
+			tx, err := db.DB.BeginTx(ctx, nil)
+			a.NoError(err)
+			for i := 0; i < audit.MaxLimit+1; i++ {
+				a.NoError(audit.Stage(ctx, tx, db.Dialect, tenantID, uuid.NewString(), newEvent(uuid.NewString(), audit.ActionUpdate)))
+			}
+			a.NoError(audit.Stage(ctx, tx, db.Dialect, otherTenantID, uuid.NewString(), newEvent(uuid.NewString(), audit.ActionUpdate)))
+			a.NoError(tx.Commit())
+
+			a.NoError(service.Relay(ctx, ""))
+
+			result, err := service.Verify(ctx, tenantID)
+			a.NoError(err)
+			a.True(result.Valid, result.Problem)
+			a.EqualValues(audit.MaxLimit+1, result.Events)
+
+			result, err = service.Verify(ctx, otherTenantID)
+			a.NoError(err)
+			a.EqualValues(1, result.Events)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package audit_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+// testChainKey - Shared by every repository and service in the tests so whichever seals a chain can verify it.
+var testChainKey = func() *audit.ChainKey {
+	key, err := audit.NewChainKey("YXVkaXQtY2hhaW4ta2V5LWZvci10aGUtdGVzdHMtMDA=")
+	if err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+func newEvent(customerID string, action audit.Action, fields ...string) audit.Event {
+	return audit.Event{
+		ActorID:    "jane@moov.io",
+		Route:      "Customer.update",
+		RequestID:  uuid.NewString(),
+		CustomerID: customerID,
+		Action:     action,
+		Fields:     append([]string{}, fields...),
+		OccurredOn: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func Test_Audit_AppendAndList(t *testing.T) {
+	AuditTestEachRepository(t, func(t *testing.T, repository audit.Repository) {
+		a := require.New(t)
+		ctx := context.Background()
+		tenantID := uuid.NewString()
+		customerID := uuid.NewString()
+
+		first, err := repository.Append(ctx, tenantID, []audit.Event{newEvent(customerID, audit.ActionCreate, "name", "email")})
+		a.NoError(err)
+		a.Len(first, 1)
+		a.EqualValues(1, first[0].Sequence)
+		a.Empty(first[0].PreviousHash)
+		a.NotEmpty(first[0].Hash)
+
+		rest, err := repository.Append(ctx, tenantID, []audit.Event{
+			newEvent(uuid.NewString(), audit.ActionRead, "name"),
+			newEvent(customerID, audit.ActionUpdate),
+		})
+		a.NoError(err)
+		a.EqualValues(2, rest[0].Sequence)
+		a.Equal(first[0].Hash, rest[0].PreviousHash)
+		a.Equal(rest[0].Hash, rest[1].PreviousHash)
+
+		// Another tenant has a chain of its own.
+		other, err := repository.Append(ctx, uuid.NewString(), []audit.Event{newEvent(customerID, audit.ActionRead)})
+		a.NoError(err)
+		a.EqualValues(1, other[0].Sequence)
+
+		listed, err := repository.List(ctx, audit.Filter{TenantID: tenantID, Limit: 10})
+		a.NoError(err)
+		a.Equal(append(first, rest...), listed)
+
+		listed, err = repository.List(ctx, audit.Filter{TenantID: tenantID, CustomerID: customerID, After: 1, Limit: 10})
+		a.NoError(err)
+		a.Equal([]audit.Event{rest[1]}, listed)
+
+		sequence, hash, err := repository.Head(ctx, tenantID)
+		a.NoError(err)
+		a.EqualValues(3, sequence)
+		a.Equal(rest[1].Hash, hash)
+
+		tenants, err := repository.Tenants(ctx)
+		a.NoError(err)
+		a.Contains(tenants, tenantID)
+	})
+}
+
+func Test_Audit_ConcurrentAppends(t *testing.T) {
+	AuditTestEachRepository(t, func(t *testing.T, repository audit.Repository) {
+		a := require.New(t)
+		ctx := context.Background()
+		tenantID := uuid.NewString()
+
+		// Start the chain first, SQLite can't have two transactions create it at once.
+		_, err := repository.Append(ctx, tenantID, []audit.Event{newEvent(uuid.NewString(), audit.ActionCreate)})
+		a.NoError(err)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repository.Append(ctx, tenantID, []audit.Event{newEvent(uuid.NewString(), audit.ActionRead)})
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		appended := int64(1)
+		for err := range errs {
+			if err == nil {
+				appended++
+			}
+		}
+
+		// Whatever made it in is one unbroken chain.
+		result, err := audit.NewService(nil, repository, testChainKey).Verify(ctx, tenantID)
+		a.NoError(err)
+		a.True(result.Valid, result.Problem)
+		a.Equal(appended, result.Events)
+	})
+}
+
+// AuditTestEachRepository - Runs the repository contract against the in-memory repository and SQLite, plus MySQL or
+// PostgreSQL when configured.
+func AuditTestEachRepository(t *testing.T, run func(t *testing.T, repository audit.Repository)) {
+	t.Run("memory", func(t *testing.T) {
+		run(t, audit.NewInMemoryRepository(testChainKey))
+	})
+
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			run(t, audit.NewRepository(db.DB, db.Dialect, testChainKey))
+		})
+	}
+}
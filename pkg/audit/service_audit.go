@@ -0,0 +1,151 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/moov-io/base/stime"
+)
+
+type Service interface {
+	// Record - Appends the events to the tenant's log, attributed to the request in ctx.
+	Record(ctx context.Context, tenantID string, events ...Event) error
+	// List - The tenant's events after cursor, for one customer when customerID is set.
+	List(ctx context.Context, tenantID string, customerID string, cursor string, limit int) (*Events, error)
+	// Verify - Walks the tenant's chain checking every event still hashes to what was recorded.
+	Verify(ctx context.Context, tenantID string) (*Verification, error)
+	// Relay - Chains the tenant's events staged in the outbox onto its log, every tenant's when tenantID is empty.
+	Relay(ctx context.Context, tenantID string) error
+}
+
+// NewService - The key has to be the one the repository seals events with for Verify to pass.
+func NewService(time stime.TimeService, repository Repository, key *ChainKey) Service {
+	return &auditService{time: time, repository: repository, key: key}
+}
+
+type auditService struct {
+	time       stime.TimeService
+	repository Repository
+	key        *ChainKey
+}
+
+func (s *auditService) Record(ctx context.Context, tenantID string, events ...Event) error {
+	request := RequestFromContext(ctx)
+	occurredOn := s.time.Now().UTC().Truncate(time.Microsecond)
+
+	for i := range events {
+		events[i].ActorID = request.ActorID
+		events[i].Route = request.Route
+		events[i].RequestID = request.RequestID
+		events[i].OccurredOn = occurredOn
+	}
+
+	_, err := s.repository.Append(ctx, tenantID, events)
+	return err
+}
+
+func (s *auditService) Relay(ctx context.Context, tenantID string) error {
+	tenants := []string{tenantID}
+	if tenantID == "" {
+		var err error
+		if tenants, err = s.repository.OutboxTenants(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, tenantID := range tenants {
+		for {
+			relayed, err := s.repository.Relay(ctx, tenantID, MaxLimit)
+			if err != nil {
+				return err
+			}
+			if len(relayed) < MaxLimit {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (s *auditService) List(ctx context.Context, tenantID string, customerID string, cursor string, limit int) (*Events, error) {
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 1 || limit > MaxLimit {
+		return nil, ErrInvalidLimit
+	}
+
+	// One extra tells us whether there's another page without a second query.
+	events, err := s.repository.List(ctx, Filter{TenantID: tenantID, CustomerID: customerID, After: after, Limit: limit + 1})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Events{Events: events, Cursor: cursor}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.HasMore = true
+	}
+	if len(page.Events) > 0 {
+		page.Cursor = encodeCursor(page.Events[len(page.Events)-1].Sequence)
+	}
+	return page, nil
+}
+
+func (s *auditService) Verify(ctx context.Context, tenantID string) (*Verification, error) {
+	result := &Verification{TenantID: tenantID, Valid: true}
+	broken := func(sequence int64, format string, args ...interface{}) (*Verification, error) {
+		result.Valid = false
+		result.BrokenAt = sequence
+		result.Problem = fmt.Sprintf(format, args...)
+		return result, nil
+	}
+
+	var last int64
+	for {
+		events, err := s.repository.List(ctx, Filter{TenantID: tenantID, After: last, Limit: MaxLimit})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			expected := last + 1
+			switch {
+			case event.Sequence != expected:
+				return broken(expected, "event %d is missing", expected)
+			case event.PreviousHash != result.Head:
+				return broken(event.Sequence, "event %d doesn't follow on from the event before it", event.Sequence)
+			case event.computeHash(s.key) != event.Hash:
+				return broken(event.Sequence, "event %d was modified", event.Sequence)
+			}
+
+			last = event.Sequence
+			result.Head = event.Hash
+			result.Events++
+		}
+
+		if len(events) < MaxLimit {
+			break
+		}
+	}
+
+	// Catches events removed from the end of the chain.
+	headSequence, headHash, err := s.repository.Head(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if headSequence != last {
+		return broken(last+1, "the chain ends at event %d but %d events were recorded", last, headSequence)
+	}
+	if headHash != result.Head {
+		return broken(last, "event %d isn't the event recorded as the end of the chain", last)
+	}
+
+	return result, nil
+}
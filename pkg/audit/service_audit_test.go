@@ -0,0 +1,116 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moov-io/base/stime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+func Test_Audit_Record(t *testing.T) {
+	a := require.New(t)
+	times := stime.NewStaticTimeService()
+	service := audit.NewService(times, audit.NewInMemoryRepository(testChainKey), testChainKey)
+	tenantID := uuid.NewString()
+
+	ctx := audit.WithRequest(context.Background(), audit.Request{ActorID: "jane@moov.io", Route: "Customer.get", RequestID: "req-1"})
+	a.NoError(service.Record(ctx, tenantID, audit.Event{CustomerID: "c1", Action: audit.ActionRead, Fields: []string{"ssn"}}))
+
+	page, err := service.List(context.Background(), tenantID, "", "", 0)
+	a.NoError(err)
+	a.Len(page.Events, 1)
+	a.False(page.HasMore)
+	a.Equal("1", page.Cursor)
+
+	event := page.Events[0]
+	a.Equal("jane@moov.io", event.ActorID)
+	a.Equal("Customer.get", event.Route)
+	a.Equal("req-1", event.RequestID)
+	a.Equal(times.Now().UTC(), event.OccurredOn)
+
+	_, err = service.List(context.Background(), tenantID, "", "nope", 0)
+	a.ErrorIs(err, audit.ErrInvalidCursor)
+	_, err = service.List(context.Background(), tenantID, "", "", 5000)
+	a.ErrorIs(err, audit.ErrInvalidLimit)
+}
+
+func Test_Audit_Verify(t *testing.T) {
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			a := require.New(t)
+			ctx := context.Background()
+			service := audit.NewService(stime.NewSystemTimeService(), audit.NewRepository(db.DB, db.Dialect, testChainKey), testChainKey)
+
+			record := func() string {
+				tenantID := uuid.NewString()
+				for i := 0; i < 3; i++ {
+					a.NoError(service.Record(ctx, tenantID, newEvent(uuid.NewString(), audit.ActionRead, "ssn")))
+				}
+				return tenantID
+			}
+			exec := func(qry string, args ...interface{}) {
+				_, err := db.DB.ExecContext(ctx, db.Dialect.Rebind(qry), args...)
+				a.NoError(err)
+			}
+
+			tenantID := record()
+			result, err := service.Verify(ctx, tenantID)
+			a.NoError(err)
+			a.True(result.Valid)
+			a.EqualValues(3, result.Events)
+			a.NotEmpty(result.Head)
+
+			// Editing an event.
+			tenantID = record()
+			exec(`UPDATE audit_events SET actor_id = 'someone-else' WHERE tenant_id = ? AND sequence_number = 2`, tenantID)
+			result, err = service.Verify(ctx, tenantID)
+			a.NoError(err)
+			a.False(result.Valid)
+			a.EqualValues(2, result.BrokenAt)
+
+			// Removing one from the middle.
+			tenantID = record()
+			exec(`DELETE FROM audit_events WHERE tenant_id = ? AND sequence_number = 2`, tenantID)
+			result, err = service.Verify(ctx, tenantID)
+			a.NoError(err)
+			a.False(result.Valid)
+			a.EqualValues(2, result.BrokenAt)
+
+			// Removing the last one.
+			tenantID = record()
+			exec(`DELETE FROM audit_events WHERE tenant_id = ? AND sequence_number = 3`, tenantID)
+			result, err = service.Verify(ctx, tenantID)
+			a.NoError(err)
+			a.False(result.Valid)
+			a.EqualValues(3, result.BrokenAt)
+
+			// Rewriting the chain without the key, here with a key of its own.
+			otherKey, err := audit.NewRandomChainKey()
+			a.NoError(err)
+			forger := audit.NewService(stime.NewSystemTimeService(), audit.NewRepository(db.DB, db.Dialect, otherKey), otherKey)
+			tenantID = uuid.NewString()
+			a.NoError(forger.Record(ctx, tenantID, newEvent(uuid.NewString(), audit.ActionRead, "ssn")))
+			result, err = service.Verify(ctx, tenantID)
+			a.NoError(err)
+			a.False(result.Valid)
+			a.EqualValues(1, result.BrokenAt)
+		})
+	}
+}
+
+func Test_Audit_ChainKey(t *testing.T) {
+	a := require.New(t)
+
+	_, err := audit.NewChainKey("")
+	a.ErrorIs(err, audit.ErrNoChainKey)
+	_, err = audit.NewChainKey("not base64")
+	a.Error(err)
+	_, err = audit.NewChainKey("dG9vIHNob3J0")
+	a.Error(err)
+}
@@ -0,0 +1,73 @@
+// Package blob stores files too large to keep in the database, such as uploaded documents, under keys chosen by the
+// caller.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DriverFilesystem - Stores blobs as files under a directory.
+	DriverFilesystem = "filesystem"
+	// DriverMemory - Keeps blobs in memory only, for tests and local demos. The default.
+	DriverMemory = "memory"
+)
+
+var (
+	// ErrNotFound - Nothing is stored under the key.
+	ErrNotFound = errors.New("blob not found")
+	// ErrInvalidKey - Keys are slash separated names of letters, digits, dots, dashes and underscores.
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Store - Somewhere to keep blobs. Put replaces anything already stored under the key.
+type Store interface {
+	// Put - Stores everything read from r, a blob isn't visible until it's complete.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get - Opens the blob for reading, the caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete - Removes the blob, deleting a key with nothing stored under it isn't an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Config - Picks and configures the Store.
+type Config struct {
+	// Driver is one of DriverFilesystem or DriverMemory
+	Driver     string
+	Filesystem FilesystemConfig
+}
+
+// FilesystemConfig - Settings for DriverFilesystem.
+type FilesystemConfig struct {
+	// Directory holds the blobs, it's created if it doesn't exist.
+	Directory string
+}
+
+// New - The Store described by config.
+func New(config Config) (Store, error) {
+	switch config.Driver {
+	case "", DriverMemory:
+		return NewInMemoryStore(), nil
+	case DriverFilesystem:
+		return NewFilesystemStore(config.Filesystem.Directory)
+	default:
+		return nil, fmt.Errorf("unknown blob driver %q", config.Driver)
+	}
+}
+
+var keySegment = regexp.MustCompile(`^[A-Za-z0-9_\-][A-Za-z0-9._\-]*$`)
+
+// validKey - Keys become file paths, so they can't climb out of the store with .. or be absolute.
+func validKey(key string) error {
+	for _, segment := range strings.Split(key, "/") {
+		if !keySegment.MatchString(segment) {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
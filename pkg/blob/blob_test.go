@@ -0,0 +1,61 @@
+package blob_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/blob"
+)
+
+func Test_Blob_Stores(t *testing.T) {
+	filesystem, err := blob.NewFilesystemStore(t.TempDir())
+	require.NoError(t, err)
+
+	for name, store := range map[string]blob.Store{
+		"filesystem": filesystem,
+		"memory":     blob.NewInMemoryStore(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			a := require.New(t)
+			ctx := context.Background()
+
+			_, err := store.Get(ctx, "tenant/customer/missing")
+			a.ErrorIs(err, blob.ErrNotFound)
+
+			a.NoError(store.Put(ctx, "tenant/customer/doc", strings.NewReader("first")))
+			a.NoError(store.Put(ctx, "tenant/customer/doc", strings.NewReader("second")))
+
+			r, err := store.Get(ctx, "tenant/customer/doc")
+			a.NoError(err)
+			data, err := io.ReadAll(r)
+			a.NoError(err)
+			a.NoError(r.Close())
+			a.Equal("second", string(data))
+
+			a.NoError(store.Delete(ctx, "tenant/customer/doc"))
+			a.NoError(store.Delete(ctx, "tenant/customer/doc"))
+			_, err = store.Get(ctx, "tenant/customer/doc")
+			a.ErrorIs(err, blob.ErrNotFound)
+
+			for _, key := range []string{"", "../escape", "tenant/../../escape", "/absolute", "tenant//doc", ".hidden"} {
+				a.ErrorIs(store.Put(ctx, key, strings.NewReader("x")), blob.ErrInvalidKey, key)
+			}
+		})
+	}
+}
+
+func Test_Blob_New(t *testing.T) {
+	store, err := blob.New(blob.Config{})
+	require.NoError(t, err)
+	require.NotNil(t, store)
+
+	_, err = blob.New(blob.Config{Driver: blob.DriverFilesystem})
+	require.Error(t, err)
+
+	_, err = blob.New(blob.Config{Driver: "s3"})
+	require.Error(t, err)
+}
@@ -0,0 +1,267 @@
+// Package client is the supported Go client for the customers API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+// Client - Calls the customers API for a single tenant.
+type Client struct {
+	baseURL  *url.URL
+	tenantID string
+	token    string
+	http     *http.Client
+	retry    RetryConfig
+}
+
+func New(config Config) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(config.BaseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing base url: %w", err)
+	}
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("base url %q must include the scheme and host", config.BaseURL)
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &Client{
+		baseURL:  baseURL,
+		tenantID: config.TenantID,
+		token:    config.Token,
+		http:     httpClient,
+		retry:    config.Retry.withDefaults(),
+	}, nil
+}
+
+// WithTenant - Copy of the client that acts for tenantID.
+func (c *Client) WithTenant(tenantID string) *Client {
+	cp := *c
+	cp.tenantID = tenantID
+	return &cp
+}
+
+func (c *Client) CreateCustomer(ctx context.Context, create customers.Customer) (*customers.Customer, error) {
+	created := &customers.Customer{}
+	if err := c.do(ctx, http.MethodPost, "/customers", create, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (c *Client) ListCustomers(ctx context.Context) ([]customers.Customer, error) {
+	found := []customers.Customer{}
+	if err := c.do(ctx, http.MethodGet, "/customers", nil, &found); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func (c *Client) GetCustomer(ctx context.Context, customerID string) (*customers.Customer, error) {
+	found := &customers.Customer{}
+	if err := c.do(ctx, http.MethodGet, "/customers/"+url.PathEscape(customerID), nil, found); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func (c *Client) UpdateCustomer(ctx context.Context, customerID string, update customers.Customer) (*customers.Customer, error) {
+	updated := &customers.Customer{}
+	if err := c.do(ctx, http.MethodPut, "/customers/"+url.PathEscape(customerID), update, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// ListCustomerChanges - A page of the change feed after cursor, pass an empty cursor to start from the beginning
+// and a limit of 0 for the server's default.
+func (c *Client) ListCustomerChanges(ctx context.Context, cursor string, limit int) (*customers.CustomerChanges, error) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	path := "/customers/changes"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	found := &customers.CustomerChanges{}
+	if err := c.do(ctx, http.MethodGet, path, nil, found); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// DeleteCustomer - Disables the customer, it can still be fetched with GetCustomer.
+func (c *Client) DeleteCustomer(ctx context.Context, customerID string) error {
+	return c.do(ctx, http.MethodDelete, "/customers/"+url.PathEscape(customerID), nil, nil)
+}
+
+// do - Sends the request, retrying idempotent methods, and decodes a successful response into result.
+func (c *Client) do(ctx context.Context, method string, path string, body interface{}, result interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	// Every attempt shares the request ID so retries can be tied together in the server's logs.
+	requestID := uuid.NewString()
+
+	attempts := 1
+	if isIdempotent(method) {
+		attempts = c.retry.MaxAttempts
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, c.backoff(attempt, lastErr)); err != nil {
+				return err
+			}
+		}
+
+		res, err := c.send(ctx, method, path, requestID, payload)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+
+		lastErr = c.decode(res, result)
+		if !isRetryable(lastErr) {
+			return lastErr
+		}
+	}
+
+	return lastErr
+}
+
+func (c *Client) send(ctx context.Context, method string, path string, requestID string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL.String()+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Request-ID", requestID)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.tenantID != "" {
+		req.Header.Set("X-Tenant-ID", c.tenantID)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return c.http.Do(req)
+}
+
+func (c *Client) decode(res *http.Response, result interface{}) error {
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		apiErr := &Error{StatusCode: res.StatusCode}
+		// Not every error has a body, the status is enough to go on when it doesn't.
+		json.NewDecoder(res.Body).Decode(&apiErr.Problem)
+		apiErr.retryAfter = parseRetryAfter(res.Header.Get("Retry-After"))
+		return apiErr
+	}
+
+	if result == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(result); err != nil {
+		return fmt.Errorf("decoding %s response: %w", res.Request.URL.Path, err)
+	}
+	return nil
+}
+
+// backoff - Exponential backoff with jitter, a Retry-After from the server wins if it's longer.
+func (c *Client) backoff(attempt int, lastErr error) time.Duration {
+	wait := c.retry.MinBackoff << uint(attempt-1)
+	if wait <= 0 || wait > c.retry.MaxBackoff {
+		wait = c.retry.MaxBackoff
+	}
+	wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+
+	var apiErr *Error
+	if errors.As(lastErr, &apiErr) && apiErr.retryAfter > wait {
+		wait = apiErr.retryAfter
+	}
+	return wait
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRetryable - Connection problems are retried by the caller, these are the responses worth another try.
+func isRetryable(err error) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
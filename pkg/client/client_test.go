@@ -0,0 +1,149 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/client"
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/service"
+	"github.com/moovfinancial/backendhiring/pkg/test"
+)
+
+// setupClient - Client talking to the real customers controller over HTTP.
+func setupClient(t *testing.T) (*client.Client, *test.TestEnvironment) {
+	router := mux.NewRouter()
+	env := test.NewEnvironment(t, router)
+	customers.NewCustomerController(env.Logger, env.Config.Customers, env.CustomerService).AppendRoutes(router)
+
+	server := httptest.NewServer(service.RequestLogger(env.Logger, router, "public"))
+	t.Cleanup(server.Close)
+
+	c, err := client.New(client.Config{
+		BaseURL:  server.URL,
+		TenantID: env.TenantID,
+		Token:    "secret",
+	})
+	require.NoError(t, err)
+
+	return c, env
+}
+
+func newCustomer() customers.Customer {
+	bd := "1980/03/31"
+	return customers.Customer{
+		Name:      "Jane Doe",
+		Email:     "jane.doe@moov.io",
+		BirthDate: &bd,
+		Ssn:       "123-45-6789",
+	}
+}
+
+func Test_Client_CRUD(t *testing.T) {
+	a := require.New(t)
+	c, env := setupClient(t)
+	ctx := context.Background()
+
+	created, err := c.CreateCustomer(ctx, newCustomer())
+	a.NoError(err)
+	a.NotEmpty(created.CustomerID)
+	a.Equal(env.TenantID, created.TenantID)
+	a.Equal("Jane Doe", created.Name)
+
+	found, err := c.GetCustomer(ctx, created.CustomerID)
+	a.NoError(err)
+	a.Equal(created, found)
+
+	listed, err := c.ListCustomers(ctx)
+	a.NoError(err)
+	a.Equal([]customers.Customer{*created}, listed)
+
+	update := newCustomer()
+	update.Name = "Jane Q Doe"
+	updated, err := c.UpdateCustomer(ctx, created.CustomerID, update)
+	a.NoError(err)
+	a.Equal("Jane Q Doe", updated.Name)
+
+	a.NoError(c.DeleteCustomer(ctx, created.CustomerID))
+
+	listed, err = c.ListCustomers(ctx)
+	a.NoError(err)
+	a.Empty(listed)
+
+	changes, err := c.ListCustomerChanges(ctx, "", 2)
+	a.NoError(err)
+	a.True(changes.HasMore)
+	changes, err = c.ListCustomerChanges(ctx, changes.Cursor, 0)
+	a.NoError(err)
+	a.Len(changes.Changes, 1)
+	a.Equal(customers.ChangeDisabled, changes.Changes[0].Type)
+
+	// Other tenants can't see it.
+	_, err = c.WithTenant(uuid.NewString()).GetCustomer(ctx, created.CustomerID)
+	a.True(client.IsNotFound(err))
+}
+
+func Test_Client_TypedErrors(t *testing.T) {
+	a := require.New(t)
+	c, _ := setupClient(t)
+
+	_, err := c.GetCustomer(context.Background(), uuid.NewString())
+	a.True(client.IsNotFound(err))
+
+	invalid := newCustomer()
+	invalid.Email = "not an email"
+	_, err = c.CreateCustomer(context.Background(), invalid)
+	a.True(client.IsInvalid(err))
+
+	var apiErr *client.Error
+	a.ErrorAs(err, &apiErr)
+	a.Contains(apiErr.Problem.Errors, "email")
+	a.NotEmpty(apiErr.Problem.Instance)
+}
+
+func Test_Client_Retries(t *testing.T) {
+	a := require.New(t)
+
+	var calls int32
+	var requestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get("X-Request-ID"))
+		a.Equal("Bearer secret", r.Header.Get("Authorization"))
+		a.Equal("tenant", r.Header.Get("X-Tenant-ID"))
+
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c, err := client.New(client.Config{
+		BaseURL:  server.URL,
+		TenantID: "tenant",
+		Token:    "secret",
+		Retry:    client.RetryConfig{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond},
+	})
+	a.NoError(err)
+
+	found, err := c.ListCustomers(context.Background())
+	a.NoError(err)
+	a.Empty(found)
+	a.EqualValues(3, calls)
+	a.Equal(requestIDs[0], requestIDs[2])
+
+	// Creating isn't idempotent so it's only tried once.
+	atomic.StoreInt32(&calls, 0)
+	_, err = c.CreateCustomer(context.Background(), newCustomer())
+	a.Error(err)
+	a.EqualValues(1, calls)
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+)
+
+// Error - Returned for every non-2xx response, Problem is decoded from the problem details body when there is one.
+type Error struct {
+	StatusCode int
+	Problem    problem.Details
+
+	retryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Problem.Title == "" {
+		return fmt.Sprintf("customers api: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("customers api: %d %s", e.StatusCode, e.Problem.Error())
+}
+
+// IsNotFound - The customer doesn't exist for the tenant.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsInvalid - The request was rejected by validation, see Problem.Errors for the fields.
+func IsInvalid(err error) bool {
+	return hasStatus(err, http.StatusBadRequest) || hasStatus(err, http.StatusUnprocessableEntity)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// Config - Where the customers API lives and how to authenticate with it.
+type Config struct {
+	// BaseURL of the public server, ie. "http://localhost:8216"
+	BaseURL string
+	// TenantID is sent as X-Tenant-ID on every request, use Client.WithTenant to act for another tenant.
+	TenantID string
+	// Token is sent as a bearer token when set.
+	Token string
+
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+	Retry      RetryConfig
+}
+
+// RetryConfig - How GET, PUT and DELETE requests are retried after connection errors, 429s and 5xx gateway errors.
+// POST isn't retried since creating a customer twice isn't safe.
+type RetryConfig struct {
+	// MaxAttempts includes the first request, 0 uses 3 and 1 disables retries.
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry and doubles after that, up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+func (c RetryConfig) withDefaults() RetryConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 3
+	}
+	if c.MinBackoff <= 0 {
+		c.MinBackoff = 100 * time.Millisecond
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 5 * time.Second
+	}
+	return c
+}
@@ -1,43 +1,71 @@
 package customers
 
 import (
-	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/moov-io/base/log"
+
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
 )
 
+// ErrMissingTenantID - The request didn't say which tenant it's for.
+var ErrMissingTenantID = errors.New("missing X-Tenant-ID header")
+
 type CustomerController interface {
 	AppendRoutes(router *mux.Router) *mux.Router
 }
 
-func NewCustomerController(logger log.Logger, service CustomerService) CustomerController {
+func NewCustomerController(logger log.Logger, config Config, service CustomerService) CustomerController {
 	return &customerController{
 		logger:  logger,
+		config:  config,
 		service: service,
+		streams: newStreamLimiter(config.Stream.MaxPerTenant),
 	}
 }
 
 type customerController struct {
 	logger  log.Logger
+	config  Config
 	service CustomerService
+	streams *streamLimiter
 }
 
 func (c customerController) AppendRoutes(router *mux.Router) *mux.Router {
 	router.
 		Name("Customer.create").
-		Methods("PUT").
+		Methods("POST").
 		Path("/customers").
 		HandlerFunc(c.create)
 
+	router.
+		Name("Customer.createDeprecated").
+		Methods("PUT").
+		Path("/customers").
+		HandlerFunc(deprecated("/customers", c.create))
+
 	router.
 		Name("Customer.list").
 		Methods("GET").
 		Path("/customers").
 		HandlerFunc(c.list)
 
+	// Registered ahead of Customer.get so "changes" and "stream" aren't taken as customer IDs.
+	router.
+		Name("Customer.changes").
+		Methods("GET").
+		Path("/customers/changes").
+		HandlerFunc(c.changes)
+
+	router.
+		Name("Customer.stream").
+		Methods("GET").
+		Path("/customers/stream").
+		HandlerFunc(c.stream)
+
 	router.
 		Name("Customer.get").
 		Methods("GET").
@@ -47,22 +75,232 @@ func (c customerController) AppendRoutes(router *mux.Router) *mux.Router {
 	router.
 		Name("Customer.update").
 		Methods("PUT").
-		Path("/customer/{ID}").
+		Path("/customers/{ID}").
 		HandlerFunc(c.update)
 
+	router.
+		Name("Customer.updateDeprecated").
+		Methods("PUT").
+		Path("/customer/{ID}").
+		HandlerFunc(deprecated("/customers/{ID}", c.update))
+
 	router.
 		Name("Customer.delete").
 		Methods("DELETE").
 		Path("/customers/{ID}").
 		HandlerFunc(c.delete)
 
+	router.
+		Name("Customer.placeLegalHold").
+		Methods("PUT").
+		Path("/customers/{ID}/legal-hold").
+		HandlerFunc(c.placeLegalHold)
+
+	router.
+		Name("Customer.releaseLegalHold").
+		Methods("DELETE").
+		Path("/customers/{ID}/legal-hold").
+		HandlerFunc(c.releaseLegalHold)
+
+	router.
+		Name("Customer.erase").
+		Methods("POST").
+		Path("/customers/{ID}/erasure").
+		HandlerFunc(c.erase)
+
+	router.
+		Name("Customer.getErasure").
+		Methods("GET").
+		Path("/customers/{ID}/erasure").
+		HandlerFunc(c.getErasure)
+
+	router.
+		Name("Customer.createAddress").
+		Methods("POST").
+		Path("/customers/{ID}/addresses").
+		HandlerFunc(c.createAddress)
+
+	router.
+		Name("Customer.listAddresses").
+		Methods("GET").
+		Path("/customers/{ID}/addresses").
+		HandlerFunc(c.listAddresses)
+
+	router.
+		Name("Customer.updateAddress").
+		Methods("PUT").
+		Path("/customers/{ID}/addresses/{addressID}").
+		HandlerFunc(c.updateAddress)
+
+	router.
+		Name("Customer.deleteAddress").
+		Methods("DELETE").
+		Path("/customers/{ID}/addresses/{addressID}").
+		HandlerFunc(c.deleteAddress)
+
+	router.
+		Name("Customer.createBeneficialOwner").
+		Methods("POST").
+		Path("/customers/{ID}/beneficial-owners").
+		HandlerFunc(c.createBeneficialOwner)
+
+	router.
+		Name("Customer.listBeneficialOwners").
+		Methods("GET").
+		Path("/customers/{ID}/beneficial-owners").
+		HandlerFunc(c.listBeneficialOwners)
+
+	router.
+		Name("Customer.updateBeneficialOwner").
+		Methods("PUT").
+		Path("/customers/{ID}/beneficial-owners/{ownerID}").
+		HandlerFunc(c.updateBeneficialOwner)
+
+	router.
+		Name("Customer.deleteBeneficialOwner").
+		Methods("DELETE").
+		Path("/customers/{ID}/beneficial-owners/{ownerID}").
+		HandlerFunc(c.deleteBeneficialOwner)
+
+	router.
+		Name("Customer.createPhone").
+		Methods("POST").
+		Path("/customers/{ID}/phones").
+		HandlerFunc(c.createPhone)
+
+	router.
+		Name("Customer.listPhones").
+		Methods("GET").
+		Path("/customers/{ID}/phones").
+		HandlerFunc(c.listPhones)
+
+	router.
+		Name("Customer.updatePhone").
+		Methods("PUT").
+		Path("/customers/{ID}/phones/{phoneID}").
+		HandlerFunc(c.updatePhone)
+
+	router.
+		Name("Customer.deletePhone").
+		Methods("DELETE").
+		Path("/customers/{ID}/phones/{phoneID}").
+		HandlerFunc(c.deletePhone)
+
+	router.
+		Name("Customer.verifyPhone").
+		Methods("POST").
+		Path("/customers/{ID}/phones/{phoneID}/verification").
+		HandlerFunc(c.verifyPhone)
+
+	router.
+		Name("Customer.createBankAccount").
+		Methods("POST").
+		Path("/customers/{ID}/bank-accounts").
+		HandlerFunc(c.createBankAccount)
+
+	router.
+		Name("Customer.listBankAccounts").
+		Methods("GET").
+		Path("/customers/{ID}/bank-accounts").
+		HandlerFunc(c.listBankAccounts)
+
+	router.
+		Name("Customer.getBankAccount").
+		Methods("GET").
+		Path("/customers/{ID}/bank-accounts/{bankAccountID}").
+		HandlerFunc(c.getBankAccount)
+
+	router.
+		Name("Customer.deleteBankAccount").
+		Methods("DELETE").
+		Path("/customers/{ID}/bank-accounts/{bankAccountID}").
+		HandlerFunc(c.deleteBankAccount)
+
+	router.
+		Name("Customer.sendMicroDeposits").
+		Methods("POST").
+		Path("/customers/{ID}/bank-accounts/{bankAccountID}/micro-deposits").
+		HandlerFunc(c.sendMicroDeposits)
+
+	router.
+		Name("Customer.confirmMicroDeposits").
+		Methods("POST").
+		Path("/customers/{ID}/bank-accounts/{bankAccountID}/micro-deposits/confirmation").
+		HandlerFunc(c.confirmMicroDeposits)
+
+	router.
+		Name("Customer.transitionKYC").
+		Methods("POST").
+		Path("/customers/{ID}/kyc-transitions").
+		HandlerFunc(c.transitionKYC)
+
+	router.
+		Name("Customer.listKYCTransitions").
+		Methods("GET").
+		Path("/customers/{ID}/kyc-transitions").
+		HandlerFunc(c.listKYCTransitions)
+
+	router.
+		Name("Customer.createDocument").
+		Methods("POST").
+		Path("/customers/{ID}/documents").
+		HandlerFunc(c.createDocument)
+
+	router.
+		Name("Customer.listDocuments").
+		Methods("GET").
+		Path("/customers/{ID}/documents").
+		HandlerFunc(c.listDocuments)
+
+	router.
+		Name("Customer.getDocument").
+		Methods("GET").
+		Path("/customers/{ID}/documents/{documentID}").
+		HandlerFunc(c.getDocument)
+
+	router.
+		Name("Customer.downloadDocument").
+		Methods("GET").
+		Path("/customers/{ID}/documents/{documentID}/content").
+		HandlerFunc(c.downloadDocument)
+
+	router.
+		Name("Customer.deleteDocument").
+		Methods("DELETE").
+		Path("/customers/{ID}/documents/{documentID}").
+		HandlerFunc(c.deleteDocument)
+
+	router.
+		Name("Customer.listScreenings").
+		Methods("GET").
+		Path("/customers/{ID}/screenings").
+		HandlerFunc(c.listScreenings)
+
+	router.
+		Name("Customer.listScreeningAlerts").
+		Methods("GET").
+		Path("/screening-alerts").
+		HandlerFunc(c.listScreeningAlerts)
+
+	router.
+		Name("Customer.createVerification").
+		Methods("POST").
+		Path("/customers/{ID}/verifications").
+		HandlerFunc(c.createVerification)
+
+	router.
+		Name("Customer.listVerifications").
+		Methods("GET").
+		Path("/customers/{ID}/verifications").
+		HandlerFunc(c.listVerifications)
+
 	return router
 }
 
 func (c *customerController) GetTenantID(r *http.Request) (string, error) {
 	tenID := r.Header.Get("X-Tenant-ID")
 	if tenID == "" {
-		return "", errors.New("Missing tenantID")
+		return "", ErrMissingTenantID
 	}
 	return tenID, nil
 }
@@ -70,64 +308,93 @@ func (c *customerController) GetTenantID(r *http.Request) (string, error) {
 func (c *customerController) create(w http.ResponseWriter, r *http.Request) {
 	tenantID, err := c.GetTenantID(r)
 	if err != nil {
-		errorResponse(w, err, c.logger)
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
 	create := Customer{}
-	if err := json.NewDecoder(r.Body).Decode(&create); err != nil {
-		errorResponse(w, err, c.logger)
+	_, span := tracing.Start(r.Context(), "Customer.decode", tenantID)
+	err = decodeJSON(r, &create)
+	tracing.End(span, err)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
-	result, err := c.service.Create(tenantID, create)
+	result, err := c.service.Create(r.Context(), tenantID, create)
 	if err != nil {
-		errorResponse(w, err, c.logger)
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
-	jsonResponse(w, result)
+	jsonResponse(w, result.masked())
 }
 
 func (c *customerController) list(w http.ResponseWriter, r *http.Request) {
 	tenantID, err := c.GetTenantID(r)
 	if err != nil {
-		errorResponse(w, err, c.logger)
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
-	result, err := c.service.List(tenantID)
+	filter := ListFilter{KYCStatus: KYCStatus(r.URL.Query().Get("kycStatus"))}
+
+	result, err := c.service.List(r.Context(), tenantID, filter)
 	if err != nil {
-		errorResponse(w, err, c.logger)
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
-	jsonResponse(w, result)
+	jsonResponse(w, maskCustomers(result))
+}
+
+func (c *customerController) changes(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			errorResponse(w, r, ErrInvalidLimit, c.logger)
+			return
+		}
+	}
+
+	result, err := c.service.Changes(r.Context(), tenantID, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result.masked())
 }
 
 func (c *customerController) get(w http.ResponseWriter, r *http.Request) {
 	tenantID, err := c.GetTenantID(r)
 	if err != nil {
-		errorResponse(w, err, c.logger)
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
 	params := mux.Vars(r)
 	customerID := params["ID"]
 
-	result, err := c.service.Get(customerID, tenantID)
+	result, err := c.service.Get(r.Context(), tenantID, customerID)
 	if err != nil {
-		errorResponse(w, err, c.logger)
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
-	jsonResponse(w, result)
+	jsonResponse(w, result.masked())
 }
 
 func (c *customerController) update(w http.ResponseWriter, r *http.Request) {
 	tenantID, err := c.GetTenantID(r)
 	if err != nil {
-		errorResponse(w, err, c.logger)
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
@@ -135,33 +402,36 @@ func (c *customerController) update(w http.ResponseWriter, r *http.Request) {
 	customerID := params["ID"]
 
 	update := Customer{}
-	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
-		errorResponse(w, err, c.logger)
+	_, span := tracing.Start(r.Context(), "Customer.decode", tenantID)
+	err = decodeJSON(r, &update)
+	tracing.End(span, err)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
-	result, err := c.service.Update(tenantID, customerID, update)
+	result, err := c.service.Update(r.Context(), tenantID, customerID, update)
 	if err != nil {
-		errorResponse(w, err, c.logger)
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
-	jsonResponse(w, result)
+	jsonResponse(w, result.masked())
 }
 
 func (c *customerController) delete(w http.ResponseWriter, r *http.Request) {
 	tenantID, err := c.GetTenantID(r)
 	if err != nil {
-		errorResponse(w, err, c.logger)
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
 	params := mux.Vars(r)
 	customerID := params["ID"]
 
-	err = c.service.Delete(tenantID, customerID)
+	err = c.service.Delete(r.Context(), tenantID, customerID)
 	if err != nil {
-		errorResponse(w, err, c.logger)
+		errorResponse(w, r, err, c.logger)
 		return
 	}
 
@@ -0,0 +1,104 @@
+package customers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+func (c *customerController) createAddress(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	create := Address{}
+	_, span := tracing.Start(r.Context(), "Address.decode", tenantID)
+	err = decodeJSON(r, &create)
+	tracing.End(span, err)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	result, err := c.service.AddAddress(r.Context(), tenantID, customerID, create)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
+
+func (c *customerController) listAddresses(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	result, err := c.service.ListAddresses(r.Context(), tenantID, customerID)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
+
+func (c *customerController) updateAddress(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+	addressID := params["addressID"]
+
+	update := Address{}
+	_, span := tracing.Start(r.Context(), "Address.decode", tenantID)
+	err = decodeJSON(r, &update)
+	tracing.End(span, err)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	result, err := c.service.UpdateAddress(r.Context(), tenantID, customerID, addressID, update)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
+
+func (c *customerController) deleteAddress(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+	addressID := params["addressID"]
+
+	if err := c.service.DeleteAddress(r.Context(), tenantID, customerID, addressID); err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,105 @@
+package customers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+)
+
+func Test_Customer_AddressesAPI(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+
+	address, res := clientCustomerAddAddress(s, created.CustomerID, customers.Address{
+		Type:       customers.AddressResidential,
+		Primary:    true,
+		Line1:      "  123   Main St ",
+		City:       "Cedar Rapids",
+		State:      "iowa",
+		PostalCode: "524011234",
+	})
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.NotEmpty(address.AddressID)
+	s.Assert.Equal("123 Main St", address.Line1)
+	s.Assert.Equal("IA", address.State)
+	s.Assert.Equal("52401-1234", address.PostalCode)
+	s.Assert.Equal(customers.CountryUS, address.Country)
+	s.Assert.Equal(s.Env.TimeService.Now(), address.CreatedOn)
+
+	update := address
+	update.Type = customers.AddressMailing
+	update.Line1 = "PO Box 12"
+	updated := customers.Address{}
+	res = s.MakeCall(s.MakeRequest("PUT", "/customers/"+created.CustomerID+"/addresses/"+address.AddressID, &update), &updated)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal("PO Box 12", updated.Line1)
+	s.Assert.Equal(address.CreatedOn, updated.CreatedOn)
+
+	found := []customers.Address{}
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+created.CustomerID+"/addresses", nil), &found)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal([]customers.Address{updated}, found)
+
+	res = s.MakeCall(s.MakeRequest("DELETE", "/customers/"+created.CustomerID+"/addresses/"+address.AddressID, nil), nil)
+	s.Assert.Equal(http.StatusNoContent, res.StatusCode)
+	res = s.MakeCall(s.MakeRequest("DELETE", "/customers/"+created.CustomerID+"/addresses/"+address.AddressID, nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/does-not-exist/addresses", nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func Test_Customer_AddressesAPI_Invalid(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+	valid := customers.Address{Type: customers.AddressResidential, Line1: "123 Main St", City: "Cedar Rapids", State: "IA", PostalCode: "52401"}
+
+	cases := []struct {
+		name   string
+		modify func(a *customers.Address)
+		field  string
+	}{
+		{"PO box", func(a *customers.Address) { a.Line1 = "P.O. Box 12" }, "line1"},
+		{"Post office box", func(a *customers.Address) { a.Line2 = "Post Office Box 12" }, "line2"},
+		{"Unknown state", func(a *customers.Address) { a.State = "Atlantis" }, "state"},
+		{"Short ZIP", func(a *customers.Address) { a.PostalCode = "5240" }, "postalCode"},
+		{"ZIP+3", func(a *customers.Address) { a.PostalCode = "52401-123" }, "postalCode"},
+		{"Canada", func(a *customers.Address) { a.Country = "CA" }, "country"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			address := valid
+			tc.modify(&address)
+
+			details := problem.Details{}
+			res := s.MakeCall(s.MakeRequest("POST", "/customers/"+created.CustomerID+"/addresses", &address), &details)
+			s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+			s.Assert.Contains(details.Errors, tc.field)
+		})
+	}
+
+	// Mailing addresses can be PO boxes.
+	mailing := valid
+	mailing.Type = customers.AddressMailing
+	mailing.Line1 = "PO Box 12"
+	_, res := clientCustomerAddAddress(s, created.CustomerID, mailing)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+
+	invalid := valid
+	invalid.Type = "vacation"
+	_, res = clientCustomerAddAddress(s, created.CustomerID, invalid)
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	_, res = clientCustomerAddAddress(s, "does-not-exist", valid)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func clientCustomerAddAddress(s CustomerTestScope, customerID string, create customers.Address) (customers.Address, *http.Response) {
+	address := customers.Address{}
+	res := s.MakeCall(s.MakeRequest("POST", "/customers/"+customerID+"/addresses", &create), &address)
+	return address, res
+}
@@ -0,0 +1,67 @@
+package customers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+)
+
+func Test_Customer_AuditAPI(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	call := func(req *http.Request, body interface{}) *http.Response {
+		req.Header.Set(audit.ActorIDHeader, "jane@moov.io")
+		req.Header.Set("X-Request-ID", "req-"+req.Method)
+		return s.MakeCall(req, body)
+	}
+
+	create := NewTestCustomer(s.Env.TimeService)
+	create.BirthDate = nil
+	created := create
+	res := call(s.MakeRequest("POST", "/customers", &create), &created)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+
+	res = call(s.MakeRequest("GET", "/customers/"+created.CustomerID, nil), nil)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+
+	update := created
+	update.Email = "jane.doe@moov.io"
+	res = call(s.MakeRequest("PUT", "/customers/"+created.CustomerID, &update), nil)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+
+	res = call(s.MakeRequest("DELETE", "/customers/"+created.CustomerID, nil), nil)
+	s.Assert.Equal(http.StatusNoContent, res.StatusCode)
+
+	// Failed calls change nothing so they aren't recorded.
+	res = call(s.MakeRequest("GET", "/customers/does-not-exist", nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+
+	page, err := s.Env.AuditService.List(context.Background(), s.Env.TenantID, created.CustomerID, "", 0)
+	s.Assert.NoError(err)
+	s.Assert.Len(page.Events, 4)
+
+	expected := []struct {
+		route  string
+		action audit.Action
+		fields []string
+	}{
+		{"Customer.create", audit.ActionCreate, []string{"name", "email", "taxID"}},
+		{"Customer.get", audit.ActionRead, []string{"name", "email", "taxID"}},
+		{"Customer.update", audit.ActionUpdate, []string{"email"}},
+		{"Customer.delete", audit.ActionDelete, []string{"disabledOn"}},
+	}
+	for i, event := range page.Events {
+		s.Assert.Equal("jane@moov.io", event.ActorID)
+		s.Assert.Equal(expected[i].route, event.Route)
+		s.Assert.Equal(expected[i].action, event.Action)
+		s.Assert.Equal(expected[i].fields, event.Fields)
+		s.Assert.Equal(created.CustomerID, event.CustomerID)
+	}
+	s.Assert.Equal("req-PUT", page.Events[2].RequestID)
+
+	verification, err := s.Env.AuditService.Verify(context.Background(), s.Env.TenantID)
+	s.Assert.NoError(err)
+	s.Assert.True(verification.Valid)
+}
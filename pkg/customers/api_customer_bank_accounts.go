@@ -0,0 +1,144 @@
+package customers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+func (c *customerController) createBankAccount(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	create := BankAccount{}
+	_, span := tracing.Start(r.Context(), "BankAccount.decode", tenantID)
+	err = decodeJSON(r, &create)
+	tracing.End(span, err)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	result, err := c.service.AddBankAccount(r.Context(), tenantID, customerID, create)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result.masked(c.config.BankAccounts.MicroDeposits.ShowAmounts))
+}
+
+func (c *customerController) listBankAccounts(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	result, err := c.service.ListBankAccounts(r.Context(), tenantID, customerID)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, maskBankAccounts(result, c.config.BankAccounts.MicroDeposits.ShowAmounts))
+}
+
+func (c *customerController) getBankAccount(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+	bankAccountID := params["bankAccountID"]
+
+	result, err := c.service.GetBankAccount(r.Context(), tenantID, customerID, bankAccountID)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result.masked(c.config.BankAccounts.MicroDeposits.ShowAmounts))
+}
+
+func (c *customerController) deleteBankAccount(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+	bankAccountID := params["bankAccountID"]
+
+	if err := c.service.DeleteBankAccount(r.Context(), tenantID, customerID, bankAccountID); err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *customerController) sendMicroDeposits(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+	bankAccountID := params["bankAccountID"]
+
+	result, err := c.service.SendMicroDeposits(r.Context(), tenantID, customerID, bankAccountID)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result.masked(c.config.BankAccounts.MicroDeposits.ShowAmounts))
+}
+
+func (c *customerController) confirmMicroDeposits(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+	bankAccountID := params["bankAccountID"]
+
+	confirmation := MicroDepositConfirmation{}
+	_, span := tracing.Start(r.Context(), "MicroDepositConfirmation.decode", tenantID)
+	err = decodeJSON(r, &confirmation)
+	tracing.End(span, err)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	result, err := c.service.ConfirmMicroDeposits(r.Context(), tenantID, customerID, bankAccountID, confirmation)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result.masked(c.config.BankAccounts.MicroDeposits.ShowAmounts))
+}
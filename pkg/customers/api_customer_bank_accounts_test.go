@@ -0,0 +1,252 @@
+package customers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+	"github.com/moovfinancial/backendhiring/pkg/service"
+)
+
+func Test_Customer_BankAccountsAPI(t *testing.T) {
+	s := bankAccountsTestSetup(t, true)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+
+	// The status and bank are ours, whatever the request says.
+	account, res := clientCustomerAddBankAccount(s, created.CustomerID, customers.BankAccount{
+		Type:          customers.BankAccountChecking,
+		RoutingNumber: "0210-0002-1",
+		AccountNumber: "0001 2345 6789",
+		BankName:      "ACME BANK",
+		Status:        customers.BankAccountVerified,
+	})
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.NotEmpty(account.BankAccountID)
+	s.Assert.Equal("021000021", account.RoutingNumber)
+	s.Assert.Empty(account.AccountNumber)
+	s.Assert.Equal("********6789", account.AccountNumberMasked)
+	s.Assert.Equal("JPMORGAN CHASE BANK, NA", account.BankName)
+	s.Assert.Equal(customers.BankAccountUnverified, account.Status)
+
+	_, res = clientCustomerAddBankAccount(s, created.CustomerID, customers.BankAccount{
+		Type:          customers.BankAccountSavings,
+		RoutingNumber: "021000021",
+		AccountNumber: "000123456789",
+	})
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+
+	// Confirming before anything was sent.
+	res = s.MakeCall(s.MakeRequest("POST", bankAccountPath(created.CustomerID, account.BankAccountID)+"/micro-deposits/confirmation", &customers.MicroDepositConfirmation{Amounts: []int{1, 2}}), nil)
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+
+	s.Env.StaticTime.Add(time.Minute)
+	sent, res := clientCustomerSendMicroDeposits(s, created.CustomerID, account.BankAccountID)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.BankAccountPending, sent.Status)
+	s.Assert.Len(sent.MicroDeposits.Amounts, customers.MicroDepositCount)
+	s.Assert.Equal(s.Env.TimeService.Now(), sent.MicroDeposits.SentOn)
+
+	_, res = clientCustomerSendMicroDeposits(s, created.CustomerID, account.BankAccountID)
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+
+	// In any order.
+	amounts := sent.MicroDeposits.Amounts
+	verified, res := clientCustomerConfirmMicroDeposits(s, created.CustomerID, account.BankAccountID, []int{amounts[1], amounts[0]})
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.BankAccountVerified, verified.Status)
+	s.Assert.Equal(s.Env.TimeService.Now(), *verified.VerifiedOn)
+
+	found := []customers.BankAccount{}
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+created.CustomerID+"/bank-accounts", nil), &found)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal([]customers.BankAccount{verified}, found)
+
+	one := customers.BankAccount{}
+	res = s.MakeCall(s.MakeRequest("GET", bankAccountPath(created.CustomerID, account.BankAccountID), nil), &one)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(verified, one)
+
+	res = s.MakeCall(s.MakeRequest("DELETE", bankAccountPath(created.CustomerID, account.BankAccountID), nil), nil)
+	s.Assert.Equal(http.StatusNoContent, res.StatusCode)
+	res = s.MakeCall(s.MakeRequest("GET", bankAccountPath(created.CustomerID, account.BankAccountID), nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func Test_Customer_BankAccountsAPI_Attempts(t *testing.T) {
+	s := bankAccountsTestSetup(t, true)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+	account, _ := clientCustomerAddBankAccount(s, created.CustomerID, customers.BankAccount{
+		Type:          customers.BankAccountSavings,
+		RoutingNumber: "121000248",
+		AccountNumber: "4455667788",
+	})
+	sent, _ := clientCustomerSendMicroDeposits(s, created.CustomerID, account.BankAccountID)
+
+	wrong := wrongMicroDeposits(sent)
+
+	for _, left := range []string{"2 attempts left", "1 attempts left", "failed verification"} {
+		details := problem.Details{}
+		res := s.MakeCall(s.MakeRequest("POST", bankAccountPath(created.CustomerID, account.BankAccountID)+"/micro-deposits/confirmation", &customers.MicroDepositConfirmation{Amounts: wrong}), &details)
+		s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+		s.Assert.Contains(details.Errors["amounts"], left)
+	}
+
+	failed := customers.BankAccount{}
+	res := s.MakeCall(s.MakeRequest("GET", bankAccountPath(created.CustomerID, account.BankAccountID), nil), &failed)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.BankAccountVerificationFailed, failed.Status)
+	s.Assert.Equal(3, failed.MicroDeposits.FailedAttempts)
+
+	// Even the right amounts don't help now.
+	_, res = clientCustomerConfirmMicroDeposits(s, created.CustomerID, account.BankAccountID, sent.MicroDeposits.Amounts)
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+}
+
+func Test_Customer_BankAccountsAPI_AttemptsKeptOnDelete(t *testing.T) {
+	s := bankAccountsTestSetup(t, true)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+	bankAccount := customers.BankAccount{
+		Type:          customers.BankAccountChecking,
+		RoutingNumber: "121000248",
+		AccountNumber: "4455667788",
+	}
+	account, _ := clientCustomerAddBankAccount(s, created.CustomerID, bankAccount)
+	sent, _ := clientCustomerSendMicroDeposits(s, created.CustomerID, account.BankAccountID)
+
+	confirmWrong := func(sent customers.BankAccount) problem.Details {
+		details := problem.Details{}
+		res := s.MakeCall(s.MakeRequest("POST", bankAccountPath(created.CustomerID, sent.BankAccountID)+"/micro-deposits/confirmation", &customers.MicroDepositConfirmation{Amounts: wrongMicroDeposits(sent)}), &details)
+		s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+		return details
+	}
+	confirmWrong(sent)
+	confirmWrong(sent)
+
+	// Deleting and adding it again picks up where it left off.
+	res := s.MakeCall(s.MakeRequest("DELETE", bankAccountPath(created.CustomerID, account.BankAccountID), nil), nil)
+	s.Assert.Equal(http.StatusNoContent, res.StatusCode)
+	again, res := clientCustomerAddBankAccount(s, created.CustomerID, bankAccount)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	sent, res = clientCustomerSendMicroDeposits(s, created.CustomerID, again.BankAccountID)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(2, sent.MicroDeposits.FailedAttempts)
+
+	details := confirmWrong(sent)
+	s.Assert.Contains(details.Errors["amounts"], "failed verification")
+
+	// And once it's failed it can't be added again at all.
+	res = s.MakeCall(s.MakeRequest("DELETE", bankAccountPath(created.CustomerID, again.BankAccountID), nil), nil)
+	s.Assert.Equal(http.StatusNoContent, res.StatusCode)
+	_, res = clientCustomerAddBankAccount(s, created.CustomerID, bankAccount)
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+
+	// Other accounts of the customer's are fine.
+	bankAccount.AccountNumber = "4455667789"
+	_, res = clientCustomerAddBankAccount(s, created.CustomerID, bankAccount)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+}
+
+func Test_Customer_BankAccountsAPI_HiddenAmounts(t *testing.T) {
+	s := bankAccountsTestSetup(t, false)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+	account, _ := clientCustomerAddBankAccount(s, created.CustomerID, customers.BankAccount{
+		Type:          customers.BankAccountChecking,
+		RoutingNumber: "053000196",
+		AccountNumber: "12345",
+	})
+	// Too short to show any of it.
+	s.Assert.Equal("*****", account.AccountNumberMasked)
+
+	sent, res := clientCustomerSendMicroDeposits(s, created.CustomerID, account.BankAccountID)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.BankAccountPending, sent.Status)
+	s.Assert.NotNil(sent.MicroDeposits)
+	s.Assert.Empty(sent.MicroDeposits.Amounts)
+}
+
+func Test_Customer_BankAccountsAPI_Invalid(t *testing.T) {
+	s := bankAccountsTestSetup(t, true)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+
+	cases := []struct {
+		name    string
+		account customers.BankAccount
+		field   string
+	}{
+		{"Check digit", customers.BankAccount{Type: customers.BankAccountChecking, RoutingNumber: "021000022", AccountNumber: "123456789"}, "routingNumber"},
+		{"Short routing number", customers.BankAccount{Type: customers.BankAccountChecking, RoutingNumber: "02100002", AccountNumber: "123456789"}, "routingNumber"},
+		{"Not in the directory", customers.BankAccount{Type: customers.BankAccountChecking, RoutingNumber: "026009593", AccountNumber: "123456789"}, "routingNumber"},
+		{"Federal Reserve Bank", customers.BankAccount{Type: customers.BankAccountChecking, RoutingNumber: "011000015", AccountNumber: "123456789"}, "routingNumber"},
+		{"Replaced routing number", customers.BankAccount{Type: customers.BankAccountChecking, RoutingNumber: "231380104", AccountNumber: "123456789"}, "routingNumber"},
+		{"Short account number", customers.BankAccount{Type: customers.BankAccountChecking, RoutingNumber: "021000021", AccountNumber: "123"}, "accountNumber"},
+		{"Letters", customers.BankAccount{Type: customers.BankAccountChecking, RoutingNumber: "021000021", AccountNumber: "12AB34"}, "accountNumber"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			details := problem.Details{}
+			res := s.MakeCall(s.MakeRequest("POST", "/customers/"+created.CustomerID+"/bank-accounts", &tc.account), &details)
+			s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+			s.Assert.Contains(details.Errors, tc.field)
+		})
+	}
+
+	_, res := clientCustomerAddBankAccount(s, created.CustomerID, customers.BankAccount{Type: "brokerage", RoutingNumber: "021000021", AccountNumber: "123456789"})
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	_, res = clientCustomerAddBankAccount(s, "does-not-exist", customers.BankAccount{Type: customers.BankAccountChecking, RoutingNumber: "021000021", AccountNumber: "123456789"})
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+
+	account, _ := clientCustomerAddBankAccount(s, created.CustomerID, customers.BankAccount{Type: customers.BankAccountChecking, RoutingNumber: "021000021", AccountNumber: "123456789"})
+	_, res = clientCustomerSendMicroDeposits(s, created.CustomerID, account.BankAccountID)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+
+	_, res = clientCustomerConfirmMicroDeposits(s, created.CustomerID, account.BankAccountID, []int{12})
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+	_, res = clientCustomerConfirmMicroDeposits(s, created.CustomerID, account.BankAccountID, []int{0, 100})
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+}
+
+func bankAccountsTestSetup(t *testing.T, showAmounts bool) CustomerTestScope {
+	return CustomerTestSetupWithConfig(t, func(cfg *service.Config) {
+		cfg.Customers.BankAccounts.RoutingDirectoryPath = "../fedach/testdata/FedACHdir.txt"
+		cfg.Customers.BankAccounts.MicroDeposits.ShowAmounts = showAmounts
+	})
+}
+
+// wrongMicroDeposits - Amounts that can't have been sent, one above the other.
+func wrongMicroDeposits(sent customers.BankAccount) []int {
+	if sent.MicroDeposits.Amounts[0] == customers.MaxMicroDeposit && sent.MicroDeposits.Amounts[1] == customers.MaxMicroDeposit {
+		return []int{customers.MinMicroDeposit, customers.MinMicroDeposit}
+	}
+	return []int{customers.MaxMicroDeposit, customers.MaxMicroDeposit}
+}
+
+func bankAccountPath(customerID string, bankAccountID string) string {
+	return "/customers/" + customerID + "/bank-accounts/" + bankAccountID
+}
+
+func clientCustomerAddBankAccount(s CustomerTestScope, customerID string, create customers.BankAccount) (customers.BankAccount, *http.Response) {
+	account := customers.BankAccount{}
+	res := s.MakeCall(s.MakeRequest("POST", "/customers/"+customerID+"/bank-accounts", &create), &account)
+	return account, res
+}
+
+func clientCustomerSendMicroDeposits(s CustomerTestScope, customerID string, bankAccountID string) (customers.BankAccount, *http.Response) {
+	account := customers.BankAccount{}
+	res := s.MakeCall(s.MakeRequest("POST", bankAccountPath(customerID, bankAccountID)+"/micro-deposits", nil), &account)
+	return account, res
+}
+
+func clientCustomerConfirmMicroDeposits(s CustomerTestScope, customerID string, bankAccountID string, amounts []int) (customers.BankAccount, *http.Response) {
+	account := customers.BankAccount{}
+	confirmation := customers.MicroDepositConfirmation{Amounts: amounts}
+	res := s.MakeCall(s.MakeRequest("POST", bankAccountPath(customerID, bankAccountID)+"/micro-deposits/confirmation", &confirmation), &account)
+	return account, res
+}
@@ -0,0 +1,104 @@
+package customers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+func (c *customerController) createBeneficialOwner(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	create := BeneficialOwner{}
+	_, span := tracing.Start(r.Context(), "BeneficialOwner.decode", tenantID)
+	err = decodeJSON(r, &create)
+	tracing.End(span, err)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	result, err := c.service.AddBeneficialOwner(r.Context(), tenantID, customerID, create)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
+
+func (c *customerController) listBeneficialOwners(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	result, err := c.service.ListBeneficialOwners(r.Context(), tenantID, customerID)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
+
+func (c *customerController) updateBeneficialOwner(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+	ownerID := params["ownerID"]
+
+	update := BeneficialOwner{}
+	_, span := tracing.Start(r.Context(), "BeneficialOwner.decode", tenantID)
+	err = decodeJSON(r, &update)
+	tracing.End(span, err)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	result, err := c.service.UpdateBeneficialOwner(r.Context(), tenantID, customerID, ownerID, update)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
+
+func (c *customerController) deleteBeneficialOwner(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+	ownerID := params["ownerID"]
+
+	if err := c.service.DeleteBeneficialOwner(r.Context(), tenantID, customerID, ownerID); err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,191 @@
+package customers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/moov-io/base/stime"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+)
+
+func Test_Customer_BusinessAPI(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	business := newTestBusiness(s.Env.TimeService)
+	business.Business.EIN = " 123456789 "
+	business.Business.IncorporationState = "delaware"
+	created, res, _ := clientCustomerCreate(s, business)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.CustomerBusiness, created.Type)
+	s.Assert.Equal("12-3456789", created.Business.EIN)
+	s.Assert.Equal("DE", created.Business.IncorporationState)
+
+	found, res, _ := clientCustomerGet(s, created.CustomerID)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(created.Business, found.Business)
+
+	update := found
+	update.Business.DBA = "Acme Co"
+	updated, res, _ := clientCustomerUpdate(s, created.CustomerID, update)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal("Acme Co", updated.Business.DBA)
+
+	// Customers created without a type are individuals.
+	individual := NewTestCustomer(s.Env.TimeService)
+	individual.Type = ""
+	created, res, _ = clientCustomerCreate(s, individual)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.CustomerIndividual, created.Type)
+	s.Assert.Nil(created.Business)
+}
+
+func Test_Customer_BusinessAPI_Invalid(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	cases := []struct {
+		name   string
+		modify func(c *customers.Customer)
+		field  string
+	}{
+		{"Unissued EIN prefix", func(c *customers.Customer) { c.Business.EIN = "07-1234567" }, "business"},
+		{"Short EIN", func(c *customers.Customer) { c.Business.EIN = "12-345678" }, "business"},
+		{"LLC without a state", func(c *customers.Customer) { c.Business.IncorporationState = "" }, "business"},
+		{"Missing business", func(c *customers.Customer) { c.Business = nil }, "business"},
+		{"SSN", func(c *customers.Customer) { c.Ssn = "123-45-6789" }, "ssn"},
+		{"Birth date", func(c *customers.Customer) { birthDate := "1990/01/01"; c.BirthDate = &birthDate }, "birthDate"},
+		{"Individual with a business", func(c *customers.Customer) { c.Type = customers.CustomerIndividual }, "business"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			business := newTestBusiness(s.Env.TimeService)
+			tc.modify(&business)
+
+			details := problem.Details{}
+			res := s.MakeCall(s.MakeRequest("POST", "/customers", &business), &details)
+			s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+			s.Assert.Contains(details.Errors, tc.field)
+		})
+	}
+
+	// Sole proprietorships aren't registered with a state.
+	sole := newTestBusiness(s.Env.TimeService)
+	sole.Business.EntityType = customers.EntitySoleProprietorship
+	sole.Business.IncorporationState = ""
+	created, res, _ := clientCustomerCreate(s, sole)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+
+	// A business stays a business.
+	update := NewTestCustomer(s.Env.TimeService)
+	details := problem.Details{}
+	res = s.MakeCall(s.MakeRequest("PUT", "/customers/"+created.CustomerID, &update), &details)
+	s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+	s.Assert.Contains(details.Errors, "type")
+}
+
+func Test_Customer_BeneficialOwnersAPI(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	business, _, _ := clientCustomerCreate(s, newTestBusiness(s.Env.TimeService))
+	owner, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+
+	added, res := clientCustomerAddBeneficialOwner(s, business.CustomerID, customers.BeneficialOwner{
+		OwnerID:             owner.CustomerID,
+		OwnershipPercentage: 60,
+	})
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(business.CustomerID, added.CustomerID)
+	s.Assert.Equal(owner.CustomerID, added.OwnerID)
+	s.Assert.Equal(s.Env.TimeService.Now(), added.CreatedOn)
+
+	update := added
+	update.OwnershipPercentage = 10.5
+	update.ControlPerson = true
+	updated := customers.BeneficialOwner{}
+	res = s.MakeCall(s.MakeRequest("PUT", "/customers/"+business.CustomerID+"/beneficial-owners/"+owner.CustomerID, &update), &updated)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(10.5, updated.OwnershipPercentage)
+	s.Assert.True(updated.ControlPerson)
+	s.Assert.Equal(added.CreatedOn, updated.CreatedOn)
+
+	found := []customers.BeneficialOwner{}
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+business.CustomerID+"/beneficial-owners", nil), &found)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal([]customers.BeneficialOwner{updated}, found)
+
+	res = s.MakeCall(s.MakeRequest("DELETE", "/customers/"+business.CustomerID+"/beneficial-owners/"+owner.CustomerID, nil), nil)
+	s.Assert.Equal(http.StatusNoContent, res.StatusCode)
+	res = s.MakeCall(s.MakeRequest("DELETE", "/customers/"+business.CustomerID+"/beneficial-owners/"+owner.CustomerID, nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/does-not-exist/beneficial-owners", nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func Test_Customer_BeneficialOwnersAPI_Invalid(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	business, _, _ := clientCustomerCreate(s, newTestBusiness(s.Env.TimeService))
+	other, _, _ := clientCustomerCreate(s, newTestBusiness(s.Env.TimeService))
+	first, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+	second, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+
+	_, res := clientCustomerAddBeneficialOwner(s, business.CustomerID, customers.BeneficialOwner{OwnerID: first.CustomerID, OwnershipPercentage: 75})
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+
+	cases := []struct {
+		name  string
+		owner customers.BeneficialOwner
+		field string
+	}{
+		{"Below the threshold", customers.BeneficialOwner{OwnerID: second.CustomerID, OwnershipPercentage: 24.99}, "ownershipPercentage"},
+		{"Too precise", customers.BeneficialOwner{OwnerID: second.CustomerID, OwnershipPercentage: 25.001}, "ownershipPercentage"},
+		{"Owns too much in total", customers.BeneficialOwner{OwnerID: second.CustomerID, OwnershipPercentage: 25.01}, "ownershipPercentage"},
+		{"Owner is a business", customers.BeneficialOwner{OwnerID: other.CustomerID, OwnershipPercentage: 25}, "ownerID"},
+		{"Owner doesn't exist", customers.BeneficialOwner{OwnerID: NewCustomer().CustomerID, OwnershipPercentage: 25}, "ownerID"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			details := problem.Details{}
+			res := s.MakeCall(s.MakeRequest("POST", "/customers/"+business.CustomerID+"/beneficial-owners", &tc.owner), &details)
+			s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+			s.Assert.Contains(details.Errors, tc.field)
+		})
+	}
+
+	_, res = clientCustomerAddBeneficialOwner(s, business.CustomerID, customers.BeneficialOwner{OwnerID: second.CustomerID, OwnershipPercentage: 101})
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	// Control persons can own less, or nothing at all.
+	_, res = clientCustomerAddBeneficialOwner(s, business.CustomerID, customers.BeneficialOwner{OwnerID: second.CustomerID, ControlPerson: true})
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+
+	_, res = clientCustomerAddBeneficialOwner(s, business.CustomerID, customers.BeneficialOwner{OwnerID: first.CustomerID, OwnershipPercentage: 25})
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+
+	// Only businesses have owners.
+	_, res = clientCustomerAddBeneficialOwner(s, first.CustomerID, customers.BeneficialOwner{OwnerID: second.CustomerID, OwnershipPercentage: 25})
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+}
+
+func newTestBusiness(times stime.TimeService) customers.Customer {
+	c := NewTestCustomer(times)
+	c.Type = customers.CustomerBusiness
+	c.Name = "Acme"
+	c.BirthDate = nil
+	c.TaxID = nil
+	c.Business = &customers.Business{
+		LegalName:          "Acme Widgets LLC",
+		EIN:                "12-3456789",
+		EntityType:         customers.EntityLLC,
+		IncorporationState: "DE",
+	}
+	return c
+}
+
+func clientCustomerAddBeneficialOwner(s CustomerTestScope, customerID string, create customers.BeneficialOwner) (customers.BeneficialOwner, *http.Response) {
+	owner := customers.BeneficialOwner{}
+	res := s.MakeCall(s.MakeRequest("POST", "/customers/"+customerID+"/beneficial-owners", &create), &owner)
+	return owner, res
+}
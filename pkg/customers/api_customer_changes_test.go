@@ -0,0 +1,72 @@
+package customers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+func Test_Customer_ChangesAPI(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+	other, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+	_, err := clientCustomerDelete(s, created.CustomerID)
+	s.Assert.Nil(err)
+
+	page, res := clientCustomerChanges(s, "", 2)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.True(page.HasMore)
+	s.Assert.Len(page.Changes, 2)
+	s.Assert.Equal(customers.ChangeCreated, page.Changes[0].Type)
+	s.Assert.Equal(created.CustomerID, page.Changes[0].Customer.CustomerID)
+	s.Assert.Equal(other.CustomerID, page.Changes[1].Customer.CustomerID)
+
+	page, res = clientCustomerChanges(s, page.Cursor, 2)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.False(page.HasMore)
+	s.Assert.Len(page.Changes, 1)
+	s.Assert.Equal(customers.ChangeDisabled, page.Changes[0].Type)
+	s.Assert.NotNil(page.Changes[0].Customer.DisabledOn)
+
+	// Polling at the head of the feed keeps the cursor where it is.
+	next, res := clientCustomerChanges(s, page.Cursor, 0)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Empty(next.Changes)
+	s.Assert.Equal(page.Cursor, next.Cursor)
+
+	// Later changes are picked up from the cursor.
+	_, err = clientCustomerDelete(s, other.CustomerID)
+	s.Assert.Nil(err)
+	next, _ = clientCustomerChanges(s, page.Cursor, 0)
+	s.Assert.Len(next.Changes, 1)
+	s.Assert.Equal(other.CustomerID, next.Changes[0].Customer.CustomerID)
+}
+
+func Test_Customer_ChangesAPI_BadRequest(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	res := s.MakeCall(httptest.NewRequest("GET", "/customers/changes?cursor=nope", nil), nil)
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	res = s.MakeCall(httptest.NewRequest("GET", "/customers/changes?limit=5000", nil), nil)
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+}
+
+func clientCustomerChanges(s CustomerTestScope, cursor string, limit int) (customers.CustomerChanges, *http.Response) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	page := customers.CustomerChanges{}
+	res := s.MakeCall(httptest.NewRequest("GET", "/customers/changes?"+query.Encode(), nil), &page)
+	return page, res
+}
@@ -0,0 +1,167 @@
+package customers
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gorilla/mux"
+)
+
+// ErrInvalidUpload - The request body wasn't a multipart/form-data upload.
+var ErrInvalidUpload = errors.New("invalid multipart upload")
+
+// documentFormOverhead - Room for the form fields and part headers around the file in an upload.
+const documentFormOverhead = 64 << 10
+
+// createDocument - Reads the upload as it arrives instead of buffering it, so the type field has to come before the
+// file.
+func (c *customerController) createDocument(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	r.Body = http.MaxBytesReader(w, r.Body, c.config.Documents.maxSize()+documentFormOverhead)
+	form, err := r.MultipartReader()
+	if err != nil {
+		errorResponse(w, r, fmt.Errorf("%w: %v", ErrInvalidUpload, err), c.logger)
+		return
+	}
+
+	create := Document{}
+	for {
+		part, err := form.NextPart()
+		if errors.Is(err, io.EOF) {
+			errorResponse(w, r, validation.Errors{"file": validation.ErrRequired}, c.logger)
+			return
+		}
+		if err != nil {
+			errorResponse(w, r, fmt.Errorf("%w: %v", ErrInvalidUpload, err), c.logger)
+			return
+		}
+
+		switch part.FormName() {
+		case "type":
+			value, err := io.ReadAll(io.LimitReader(part, 64))
+			if err != nil {
+				errorResponse(w, r, fmt.Errorf("%w: %v", ErrInvalidUpload, err), c.logger)
+				return
+			}
+			create.Type = DocumentType(strings.TrimSpace(string(value)))
+
+		case "file":
+			result, err := c.service.AddDocument(r.Context(), tenantID, customerID, create, part)
+			if err != nil {
+				errorResponse(w, r, err, c.logger)
+				return
+			}
+
+			jsonResponse(w, result)
+			return
+		}
+	}
+}
+
+func (c *customerController) listDocuments(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	result, err := c.service.ListDocuments(r.Context(), tenantID, customerID)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
+
+func (c *customerController) getDocument(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+	documentID := params["documentID"]
+
+	result, err := c.service.GetDocument(r.Context(), tenantID, customerID, documentID)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
+
+// downloadDocument - Streams the file as it's decrypted. Once the body has started an error can only cut it short,
+// which the client sees as fewer bytes than the Content-Length.
+func (c *customerController) downloadDocument(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+	documentID := params["documentID"]
+
+	doc, content, err := c.service.OpenDocument(r.Context(), tenantID, customerID, documentID)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(doc.Size, 10))
+	w.Header().Set("Content-Disposition", `attachment; filename="`+doc.DocumentID+`"`)
+	w.Header().Set("Cache-Control", "no-store")
+	if checksum, err := hex.DecodeString(doc.SHA256); err == nil {
+		// RFC 9530
+		w.Header().Set("Content-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(checksum)+":")
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, content); err != nil {
+		c.logger.LogErrorf("streaming document %s: %w", doc.DocumentID, err)
+	}
+}
+
+func (c *customerController) deleteDocument(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+	documentID := params["documentID"]
+
+	if err := c.service.DeleteDocument(r.Context(), tenantID, customerID, documentID); err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
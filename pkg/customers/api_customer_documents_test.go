@@ -0,0 +1,164 @@
+package customers_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+)
+
+// testPNG - Enough of a PNG for its content type to be detected.
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte("pixels"), 20000)...)
+
+func Test_Customer_DocumentsAPI(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+
+	doc, res := clientCustomerAddDocument(s, created.CustomerID, []string{"type", "passport"}, testPNG)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.NotEmpty(doc.DocumentID)
+	s.Assert.Equal(customers.DocumentPassport, doc.Type)
+	s.Assert.Equal("image/png", doc.ContentType)
+	s.Assert.Equal(int64(len(testPNG)), doc.Size)
+	checksum := sha256.Sum256(testPNG)
+	s.Assert.Equal(hex.EncodeToString(checksum[:]), doc.SHA256)
+
+	// The file is stored encrypted.
+	stored := storedDocuments(s)
+	s.Assert.Len(stored, 1)
+	s.Assert.NotContains(string(stored[0]), "pixels")
+
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+created.CustomerID+"/documents/"+doc.DocumentID+"/content", nil), nil)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal("image/png", res.Header.Get("Content-Type"))
+	s.Assert.Equal("sha-256=:"+base64.StdEncoding.EncodeToString(checksum[:])+":", res.Header.Get("Content-Digest"))
+	downloaded, err := io.ReadAll(res.Body)
+	s.Assert.NoError(err)
+	s.Assert.Equal(testPNG, downloaded)
+
+	found := []customers.Document{}
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+created.CustomerID+"/documents", nil), &found)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal([]customers.Document{doc}, found)
+
+	got := customers.Document{}
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+created.CustomerID+"/documents/"+doc.DocumentID, nil), &got)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(doc, got)
+
+	res = s.MakeCall(s.MakeRequest("DELETE", "/customers/"+created.CustomerID+"/documents/"+doc.DocumentID, nil), nil)
+	s.Assert.Equal(http.StatusNoContent, res.StatusCode)
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+created.CustomerID+"/documents/"+doc.DocumentID+"/content", nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+	s.Assert.Empty(storedDocuments(s))
+
+	// Erasing the customer removes its files too.
+	_, res = clientCustomerAddDocument(s, created.CustomerID, []string{"type", "drivers_license"}, testPNG)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Len(storedDocuments(s), 1)
+
+	_, err = clientCustomerDelete(s, created.CustomerID)
+	s.Assert.Nil(err)
+	s.Env.StaticTime.Add(s.Env.Config.Customers.Erasure.RetentionPeriod)
+	_, res = clientCustomerErase(s, created.CustomerID, customers.ErasureRequest{Reason: "Ticket 1234"})
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Empty(storedDocuments(s))
+}
+
+// storedDocuments - The contents of every file in the test environment's document store.
+func storedDocuments(s CustomerTestScope) [][]byte {
+	var stored [][]byte
+	err := filepath.WalkDir(s.Env.Config.Customers.Documents.Storage.Filesystem.Directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		stored = append(stored, data)
+		return err
+	})
+	s.Assert.NoError(err)
+	return stored
+}
+
+func Test_Customer_DocumentsAPI_Invalid(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+
+	cases := []struct {
+		name   string
+		fields []string
+		file   []byte
+		field  string
+	}{
+		{name: "Unknown type", fields: []string{"type", "utility_bill"}, file: testPNG, field: "type"},
+		{name: "Missing type", file: testPNG, field: "type"},
+		{name: "Not an image", fields: []string{"type", "passport"}, file: []byte("just some text"), field: "file"},
+		{name: "Empty file", fields: []string{"type", "passport"}, file: []byte{}, field: "file"},
+		{name: "No file", fields: []string{"type", "passport"}, field: "file"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			details := problem.Details{}
+			res := s.MakeCall(newDocumentUpload(s, created.CustomerID, tc.fields, tc.file), &details)
+			s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+			s.Assert.Contains(details.Errors, tc.field)
+		})
+	}
+
+	// Over the size limit.
+	tooLarge := append(append([]byte(nil), testPNG...), make([]byte, customers.DefaultDocumentMaxSize)...)
+	_, res := clientCustomerAddDocument(s, created.CustomerID, []string{"type", "passport"}, tooLarge)
+	s.Assert.Equal(http.StatusRequestEntityTooLarge, res.StatusCode)
+
+	// Not an upload at all.
+	res = s.MakeCall(s.MakeRequest("POST", "/customers/"+created.CustomerID+"/documents", customers.Document{}), nil)
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	// Nothing was stored by the failed uploads.
+	found := []customers.Document{}
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+created.CustomerID+"/documents", nil), &found)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Empty(found)
+
+	_, res = clientCustomerAddDocument(s, "missing", []string{"type", "passport"}, testPNG)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func clientCustomerAddDocument(s CustomerTestScope, customerID string, fields []string, file []byte) (customers.Document, *http.Response) {
+	doc := customers.Document{}
+	res := s.MakeCall(newDocumentUpload(s, customerID, fields, file), &doc)
+	return doc, res
+}
+
+// newDocumentUpload - A multipart upload of the fields, as name and value pairs, followed by the file if there is one.
+func newDocumentUpload(s CustomerTestScope, customerID string, fields []string, file []byte) *http.Request {
+	body := bytes.Buffer{}
+	form := multipart.NewWriter(&body)
+	for i := 0; i+1 < len(fields); i += 2 {
+		s.Assert.NoError(form.WriteField(fields[i], fields[i+1]))
+	}
+	if file != nil {
+		w, err := form.CreateFormFile("file", "document")
+		s.Assert.NoError(err)
+		_, err = w.Write(file)
+		s.Assert.NoError(err)
+	}
+	s.Assert.NoError(form.Close())
+
+	req := httptest.NewRequest("POST", "/customers/"+customerID+"/documents", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
@@ -0,0 +1,102 @@
+package customers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+func (c *customerController) placeLegalHold(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	hold := LegalHold{}
+	_, span := tracing.Start(r.Context(), "LegalHold.decode", tenantID)
+	err = decodeJSON(r, &hold)
+	tracing.End(span, err)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	result, err := c.service.PlaceLegalHold(r.Context(), tenantID, customerID, hold)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result.masked())
+}
+
+func (c *customerController) releaseLegalHold(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	if err := c.service.ReleaseLegalHold(r.Context(), tenantID, customerID); err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *customerController) erase(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	request := ErasureRequest{}
+	_, span := tracing.Start(r.Context(), "ErasureRequest.decode", tenantID)
+	err = decodeJSON(r, &request)
+	tracing.End(span, err)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	result, err := c.service.Erase(r.Context(), tenantID, customerID, request)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
+
+func (c *customerController) getErasure(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	result, err := c.service.GetErasure(r.Context(), tenantID, customerID)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
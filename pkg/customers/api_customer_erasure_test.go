@@ -0,0 +1,89 @@
+package customers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+func Test_Customer_EraseAPI(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+	request := customers.ErasureRequest{Reason: "Ticket 1234"}
+
+	// Active customers are within the retention period.
+	_, res := clientCustomerErase(s, created.CustomerID, request)
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+
+	_, err := clientCustomerDelete(s, created.CustomerID)
+	s.Assert.Nil(err)
+	_, res = clientCustomerErase(s, created.CustomerID, request)
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+
+	s.Env.StaticTime.Add(s.Env.Config.Customers.Erasure.RetentionPeriod)
+
+	// A legal hold blocks the erasure until it's released.
+	held, res := clientCustomerPlaceLegalHold(s, created.CustomerID, customers.LegalHold{Reason: "Litigation"})
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal("Litigation", held.LegalHold.Reason)
+	_, res = clientCustomerErase(s, created.CustomerID, request)
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+
+	res = s.MakeCall(s.MakeRequest("DELETE", "/customers/"+created.CustomerID+"/legal-hold", nil), nil)
+	s.Assert.Equal(http.StatusNoContent, res.StatusCode)
+
+	erasure, res := clientCustomerErase(s, created.CustomerID, request)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(created.CustomerID, erasure.CustomerID)
+	s.Assert.Equal(request.Reason, erasure.Reason)
+	s.Assert.Equal(customers.ErasedFields, erasure.ErasedFields)
+
+	found, res, _ := clientCustomerGet(s, created.CustomerID)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.NotNil(found.ErasedOn)
+	s.Assert.Empty(found.Name)
+	s.Assert.Nil(found.TaxID)
+
+	certificate := customers.CustomerErasure{}
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+created.CustomerID+"/erasure", nil), &certificate)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(erasure.ErasureID, certificate.ErasureID)
+
+	_, res = clientCustomerErase(s, created.CustomerID, request)
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+}
+
+func Test_Customer_EraseAPI_Invalid(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+
+	_, res := clientCustomerErase(s, created.CustomerID, customers.ErasureRequest{})
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	_, res = clientCustomerErase(s, "does-not-exist", customers.ErasureRequest{Reason: "Ticket 1234"})
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+created.CustomerID+"/erasure", nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+
+	// Holds are placed as of now, whatever the request says.
+	held, res := clientCustomerPlaceLegalHold(s, created.CustomerID, customers.LegalHold{Reason: "Audit", PlacedOn: time.Unix(0, 0)})
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(s.Env.TimeService.Now(), held.LegalHold.PlacedOn)
+}
+
+func clientCustomerErase(s CustomerTestScope, customerID string, request customers.ErasureRequest) (customers.CustomerErasure, *http.Response) {
+	erasure := customers.CustomerErasure{}
+	res := s.MakeCall(s.MakeRequest("POST", "/customers/"+customerID+"/erasure", &request), &erasure)
+	return erasure, res
+}
+
+func clientCustomerPlaceLegalHold(s CustomerTestScope, customerID string, hold customers.LegalHold) (customers.Customer, *http.Response) {
+	cus := customers.Customer{}
+	res := s.MakeCall(s.MakeRequest("PUT", "/customers/"+customerID+"/legal-hold", &hold), &cus)
+	return cus, res
+}
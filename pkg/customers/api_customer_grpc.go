@@ -0,0 +1,364 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/moov-io/base/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers/customerspb"
+)
+
+// TenantIDMetadata - gRPC metadata key carrying the tenant, the equivalent of the X-Tenant-ID header.
+const TenantIDMetadata = "x-tenant-id"
+
+// CustomerGRPCController - Serves the CustomerService defined in api/customers/v1/customers.proto.
+type CustomerGRPCController interface {
+	Register(server *grpc.Server)
+}
+
+func NewCustomerGRPCController(logger log.Logger, service CustomerService) CustomerGRPCController {
+	return &customerGRPCController{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type customerGRPCController struct {
+	customerspb.UnimplementedCustomerServiceServer
+
+	logger  log.Logger
+	service CustomerService
+}
+
+func (c *customerGRPCController) Register(server *grpc.Server) {
+	customerspb.RegisterCustomerServiceServer(server, c)
+}
+
+// GetTenantID - Reads the tenant from the incoming metadata.
+func (c *customerGRPCController) GetTenantID(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(TenantIDMetadata)
+	if len(values) == 0 || values[0] == "" {
+		return "", ErrMissingTenantID
+	}
+	return values[0], nil
+}
+
+func (c *customerGRPCController) CreateCustomer(ctx context.Context, req *customerspb.CreateCustomerRequest) (*customerspb.Customer, error) {
+	tenantID, err := c.GetTenantID(ctx)
+	if err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	result, err := c.service.Create(ctx, tenantID, customerFromProto(req.GetCustomer()))
+	if err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	return customerToProto(*result), nil
+}
+
+func (c *customerGRPCController) ListCustomers(ctx context.Context, req *customerspb.ListCustomersRequest) (*customerspb.ListCustomersResponse, error) {
+	tenantID, err := c.GetTenantID(ctx)
+	if err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	result, err := c.service.List(ctx, tenantID, ListFilter{KYCStatus: kycStatusFromProto[req.GetKycStatus()]})
+	if err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	res := &customerspb.ListCustomersResponse{Customers: make([]*customerspb.Customer, 0, len(result))}
+	for _, customer := range result {
+		res.Customers = append(res.Customers, customerToProto(customer))
+	}
+	return res, nil
+}
+
+func (c *customerGRPCController) GetCustomer(ctx context.Context, req *customerspb.GetCustomerRequest) (*customerspb.Customer, error) {
+	tenantID, err := c.GetTenantID(ctx)
+	if err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	result, err := c.service.Get(ctx, tenantID, req.GetCustomerId())
+	if err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	return customerToProto(*result), nil
+}
+
+func (c *customerGRPCController) UpdateCustomer(ctx context.Context, req *customerspb.UpdateCustomerRequest) (*customerspb.Customer, error) {
+	tenantID, err := c.GetTenantID(ctx)
+	if err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	result, err := c.service.Update(ctx, tenantID, req.GetCustomerId(), customerFromProto(req.GetCustomer()))
+	if err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	return customerToProto(*result), nil
+}
+
+func (c *customerGRPCController) DeleteCustomer(ctx context.Context, req *customerspb.DeleteCustomerRequest) (*emptypb.Empty, error) {
+	tenantID, err := c.GetTenantID(ctx)
+	if err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	if err := c.service.Delete(ctx, tenantID, req.GetCustomerId()); err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *customerGRPCController) ListCustomerChanges(ctx context.Context, req *customerspb.ListCustomerChangesRequest) (*customerspb.ListCustomerChangesResponse, error) {
+	tenantID, err := c.GetTenantID(ctx)
+	if err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	result, err := c.service.Changes(ctx, tenantID, req.GetCursor(), int(req.GetLimit()))
+	if err != nil {
+		return nil, grpcError(err, c.logger)
+	}
+
+	res := &customerspb.ListCustomerChangesResponse{
+		Changes: make([]*customerspb.CustomerChange, 0, len(result.Changes)),
+		Cursor:  result.Cursor,
+		HasMore: result.HasMore,
+	}
+	for _, change := range result.Changes {
+		res.Changes = append(res.Changes, &customerspb.CustomerChange{
+			Sequence:  change.Sequence,
+			Type:      changeTypeToProto[change.Type],
+			ChangedOn: timestampToProto(change.ChangedOn),
+			Customer:  customerToProto(change.Customer),
+		})
+	}
+	return res, nil
+}
+
+// grpcError - The gRPC counterpart of errorResponse, maps service errors onto status codes.
+func grpcError(err error, logger log.Logger) error {
+	validationErrs := validation.Errors{}
+
+	switch true {
+	case errors.Is(err, ErrMissingTenantID), errors.Is(err, ErrInvalidCursor), errors.Is(err, ErrInvalidLimit):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrLegalHold), errors.Is(err, ErrRetentionPeriod), errors.Is(err, ErrCustomerErased),
+		errors.Is(err, ErrKYCTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrSanctionsMatch):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.As(err, &validationErrs):
+		st := status.New(codes.InvalidArgument, "request failed validation")
+		details := &errdetails.BadRequest{}
+		for field, fieldErr := range validationErrs {
+			details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: fieldErr.Error(),
+			})
+		}
+		if withDetails, detailsErr := st.WithDetails(details); detailsErr == nil {
+			st = withDetails
+		}
+		return st.Err()
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, "customer not found")
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.Warn().LogErrorf("timed out: %w", err)
+		return status.Error(codes.DeadlineExceeded, "")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "")
+	default:
+		logger.LogErrorf("unexpected: %w", err)
+		return status.Error(codes.Internal, "")
+	}
+}
+
+var changeTypeToProto = map[ChangeType]customerspb.CustomerChange_Type{
+	ChangeCreated:  customerspb.CustomerChange_CREATED,
+	ChangeUpdated:  customerspb.CustomerChange_UPDATED,
+	ChangeDisabled: customerspb.CustomerChange_DISABLED,
+	ChangeErased:   customerspb.CustomerChange_ERASED,
+}
+
+var kycStatusToProto = map[KYCStatus]customerspb.KYCStatus{
+	KYCUnverified:     customerspb.KYCStatus_KYC_STATUS_UNVERIFIED,
+	KYCPending:        customerspb.KYCStatus_KYC_STATUS_PENDING,
+	KYCVerified:       customerspb.KYCStatus_KYC_STATUS_VERIFIED,
+	KYCFailed:         customerspb.KYCStatus_KYC_STATUS_FAILED,
+	KYCReviewRequired: customerspb.KYCStatus_KYC_STATUS_REVIEW_REQUIRED,
+}
+
+// kycStatusFromProto - Unspecified isn't in here so it reads as no status.
+var kycStatusFromProto = func() map[customerspb.KYCStatus]KYCStatus {
+	statuses := map[customerspb.KYCStatus]KYCStatus{}
+	for status, pb := range kycStatusToProto {
+		statuses[pb] = status
+	}
+	return statuses
+}()
+
+var screeningStatusToProto = map[ScreeningStatus]customerspb.ScreeningStatus{
+	ScreeningClear:   customerspb.ScreeningStatus_SCREENING_STATUS_CLEAR,
+	ScreeningFlagged: customerspb.ScreeningStatus_SCREENING_STATUS_FLAGGED,
+	ScreeningBlocked: customerspb.ScreeningStatus_SCREENING_STATUS_BLOCKED,
+}
+
+var customerTypeToProto = map[CustomerType]customerspb.CustomerType{
+	CustomerIndividual: customerspb.CustomerType_CUSTOMER_TYPE_INDIVIDUAL,
+	CustomerBusiness:   customerspb.CustomerType_CUSTOMER_TYPE_BUSINESS,
+}
+
+// customerTypeFromProto - Unspecified isn't in here so it reads as no type, which is an individual.
+var customerTypeFromProto = func() map[customerspb.CustomerType]CustomerType {
+	types := map[customerspb.CustomerType]CustomerType{}
+	for t, pb := range customerTypeToProto {
+		types[pb] = t
+	}
+	return types
+}()
+
+var entityTypeToProto = map[EntityType]customerspb.EntityType{
+	EntitySoleProprietorship: customerspb.EntityType_ENTITY_TYPE_SOLE_PROPRIETORSHIP,
+	EntityPartnership:        customerspb.EntityType_ENTITY_TYPE_PARTNERSHIP,
+	EntityLLC:                customerspb.EntityType_ENTITY_TYPE_LLC,
+	EntityCorporation:        customerspb.EntityType_ENTITY_TYPE_CORPORATION,
+	EntityNonprofit:          customerspb.EntityType_ENTITY_TYPE_NONPROFIT,
+	EntityTrust:              customerspb.EntityType_ENTITY_TYPE_TRUST,
+}
+
+// entityTypeFromProto - Unspecified isn't in here so it reads as no entity type, which fails validation.
+var entityTypeFromProto = func() map[customerspb.EntityType]EntityType {
+	types := map[customerspb.EntityType]EntityType{}
+	for t, pb := range entityTypeToProto {
+		types[pb] = t
+	}
+	return types
+}()
+
+var taxIDTypeToProto = map[TaxIDType]customerspb.TaxIDType{
+	TaxIDSSN:     customerspb.TaxIDType_TAX_ID_TYPE_SSN,
+	TaxIDITIN:    customerspb.TaxIDType_TAX_ID_TYPE_ITIN,
+	TaxIDEIN:     customerspb.TaxIDType_TAX_ID_TYPE_EIN,
+	TaxIDForeign: customerspb.TaxIDType_TAX_ID_TYPE_FOREIGN_TIN,
+}
+
+// taxIDTypeFromProto - Unspecified isn't in here so it reads as no tax ID type, which fails validation.
+var taxIDTypeFromProto = func() map[customerspb.TaxIDType]TaxIDType {
+	types := map[customerspb.TaxIDType]TaxIDType{}
+	for t, pb := range taxIDTypeToProto {
+		types[pb] = t
+	}
+	return types
+}()
+
+// customerToProto - The customer as it's returned, its tax ID is masked.
+func customerToProto(c Customer) *customerspb.Customer {
+	pb := &customerspb.Customer{
+		TenantId:        c.TenantID,
+		CustomerId:      c.CustomerID,
+		Type:            customerTypeToProto[c.Type],
+		Name:            c.Name,
+		BirthDate:       c.BirthDate,
+		Email:           c.Email,
+		CreatedOn:       timestampToProto(c.CreatedOn),
+		UpdatedOn:       timestampToProto(c.UpdatedOn),
+		KycStatus:       kycStatusToProto[c.KYCStatus],
+		ScreeningStatus: screeningStatusToProto[c.ScreeningStatus],
+	}
+	if c.DisabledOn != nil {
+		pb.DisabledOn = timestamppb.New(*c.DisabledOn)
+	}
+	if t := c.TaxID.masked(); t != nil {
+		pb.TaxId = &customerspb.TaxID{
+			Type:    taxIDTypeToProto[t.Type],
+			Country: t.Country,
+			Masked:  t.Masked,
+		}
+	}
+	if c.Business != nil {
+		pb.Business = &customerspb.Business{
+			LegalName:          c.Business.LegalName,
+			Dba:                c.Business.DBA,
+			Ein:                c.Business.EIN,
+			EntityType:         entityTypeToProto[c.Business.EntityType],
+			FormationDate:      c.Business.FormationDate,
+			IncorporationState: c.Business.IncorporationState,
+		}
+	}
+	if c.Risk != nil {
+		pb.Risk = riskScoreToProto(*c.Risk)
+	}
+	return pb
+}
+
+func riskScoreToProto(r RiskScore) *customerspb.RiskScore {
+	pb := &customerspb.RiskScore{
+		Score:        int32(r.Score),
+		Tier:         r.Tier,
+		RulesVersion: r.RulesVersion,
+		ScoredOn:     timestampToProto(r.ScoredOn),
+	}
+	for _, f := range r.Factors {
+		pb.Factors = append(pb.Factors, &customerspb.RiskFactor{Name: f.Name, Score: int32(f.Score)})
+	}
+	return pb
+}
+
+// customerFromProto - Only copies the fields a client provides, the read only ones are assigned by the service.
+func customerFromProto(pb *customerspb.Customer) Customer {
+	if pb == nil {
+		return Customer{}
+	}
+	c := Customer{
+		Type:      customerTypeFromProto[pb.GetType()],
+		Name:      pb.GetName(),
+		BirthDate: pb.BirthDate,
+		Email:     pb.GetEmail(),
+		Ssn:       pb.GetSsn(),
+	}
+	if t := pb.GetTaxId(); t != nil {
+		c.TaxID = &TaxID{
+			Type:    taxIDTypeFromProto[t.GetType()],
+			Value:   t.GetValue(),
+			Country: t.GetCountry(),
+		}
+	}
+	if b := pb.GetBusiness(); b != nil {
+		c.Business = &Business{
+			LegalName:          b.GetLegalName(),
+			DBA:                b.GetDba(),
+			EIN:                b.GetEin(),
+			EntityType:         entityTypeFromProto[b.GetEntityType()],
+			FormationDate:      b.FormationDate,
+			IncorporationState: b.GetIncorporationState(),
+		}
+	}
+	return c
+}
+
+func timestampToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,131 @@
+package customers_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/customers/customerspb"
+	"github.com/moovfinancial/backendhiring/pkg/test"
+)
+
+// setupGRPC - Client connected over an in-memory listener to the environment's gRPC server.
+func setupGRPC(t *testing.T) (*grpc.ClientConn, *test.TestEnvironment) {
+	env := test.NewEnvironment(t, mux.NewRouter())
+
+	listener := bufconn.Listen(1024 * 1024)
+	go env.GRPCServer.Serve(listener)
+	t.Cleanup(env.GRPCServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, env
+}
+
+func tenantContext(tenantID string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), customers.TenantIDMetadata, tenantID)
+}
+
+func Test_Customer_GRPC_CRUD(t *testing.T) {
+	a := require.New(t)
+	conn, env := setupGRPC(t)
+	client := customerspb.NewCustomerServiceClient(conn)
+	ctx := tenantContext(env.TenantID)
+
+	bd := "1980/03/31"
+	created, err := client.CreateCustomer(ctx, &customerspb.CreateCustomerRequest{Customer: &customerspb.Customer{
+		Name:      "Jane Doe",
+		BirthDate: &bd,
+		Email:     "jane.doe@moov.io",
+		Ssn:       "123-45-6789",
+	}})
+	a.NoError(err)
+	a.NotEmpty(created.CustomerId)
+	a.Equal(env.TenantID, created.TenantId)
+	a.Equal(bd, created.GetBirthDate())
+	a.NotNil(created.CreatedOn)
+	a.Nil(created.DisabledOn)
+
+	var header metadata.MD
+	found, err := client.GetCustomer(ctx, &customerspb.GetCustomerRequest{CustomerId: created.CustomerId}, grpc.Header(&header))
+	a.NoError(err)
+	a.Equal(created.CustomerId, found.CustomerId)
+	a.NotEmpty(header.Get("x-request-id"))
+
+	listed, err := client.ListCustomers(ctx, &customerspb.ListCustomersRequest{})
+	a.NoError(err)
+	a.Len(listed.Customers, 1)
+	a.Equal(customerspb.KYCStatus_KYC_STATUS_UNVERIFIED, listed.Customers[0].KycStatus)
+
+	listed, err = client.ListCustomers(ctx, &customerspb.ListCustomersRequest{KycStatus: customerspb.KYCStatus_KYC_STATUS_VERIFIED})
+	a.NoError(err)
+	a.Empty(listed.Customers)
+
+	created.Name = "Jane Q Doe"
+	updated, err := client.UpdateCustomer(ctx, &customerspb.UpdateCustomerRequest{CustomerId: created.CustomerId, Customer: created})
+	a.NoError(err)
+	a.Equal("Jane Q Doe", updated.Name)
+
+	_, err = client.DeleteCustomer(ctx, &customerspb.DeleteCustomerRequest{CustomerId: created.CustomerId})
+	a.NoError(err)
+
+	found, err = client.GetCustomer(ctx, &customerspb.GetCustomerRequest{CustomerId: created.CustomerId})
+	a.NoError(err)
+	a.NotNil(found.DisabledOn)
+
+	// Other tenants can't see it.
+	_, err = client.GetCustomer(tenantContext(uuid.NewString()), &customerspb.GetCustomerRequest{CustomerId: created.CustomerId})
+	a.Equal(codes.NotFound, status.Code(err))
+}
+
+func Test_Customer_GRPC_Errors(t *testing.T) {
+	a := require.New(t)
+	conn, env := setupGRPC(t)
+	client := customerspb.NewCustomerServiceClient(conn)
+
+	_, err := client.ListCustomers(context.Background(), &customerspb.ListCustomersRequest{})
+	a.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateCustomer(tenantContext(env.TenantID), &customerspb.CreateCustomerRequest{Customer: &customerspb.Customer{
+		Name:  "Jane Doe",
+		Email: "not an email",
+		Ssn:   "123-45-6789",
+	}})
+	a.Equal(codes.InvalidArgument, status.Code(err))
+
+	details := status.Convert(err).Details()
+	a.Len(details, 1)
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	a.True(ok)
+	a.Len(badRequest.FieldViolations, 1)
+	a.Equal("email", badRequest.FieldViolations[0].Field)
+}
+
+func Test_Customer_GRPC_Health(t *testing.T) {
+	a := require.New(t)
+	conn, _ := setupGRPC(t)
+
+	res, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	a.NoError(err)
+	a.Equal(healthpb.HealthCheckResponse_SERVING, res.Status)
+}
@@ -0,0 +1,56 @@
+package customers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+func (c *customerController) transitionKYC(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	transition := KYCTransition{}
+	_, span := tracing.Start(r.Context(), "KYCTransition.decode", tenantID)
+	err = decodeJSON(r, &transition)
+	tracing.End(span, err)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	result, err := c.service.TransitionKYC(r.Context(), tenantID, customerID, transition)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result.masked())
+}
+
+func (c *customerController) listKYCTransitions(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	result, err := c.service.ListKYCTransitions(r.Context(), tenantID, customerID)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
@@ -0,0 +1,78 @@
+package customers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+func Test_Customer_KYCAPI(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+	s.Assert.Equal(customers.KYCUnverified, created.KYCStatus)
+
+	// Skipping pending isn't allowed.
+	_, res := clientCustomerTransitionKYC(s, created.CustomerID, customers.KYCVerified)
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+
+	for _, status := range []customers.KYCStatus{customers.KYCPending, customers.KYCReviewRequired, customers.KYCVerified} {
+		found, res := clientCustomerTransitionKYC(s, created.CustomerID, status)
+		s.Assert.Equal(http.StatusOK, res.StatusCode)
+		s.Assert.Equal(status, found.KYCStatus)
+	}
+
+	listed := []customers.Customer{}
+	res = s.MakeCall(s.MakeRequest("GET", "/customers?kycStatus=verified", nil), &listed)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Len(listed, 1)
+	res = s.MakeCall(s.MakeRequest("GET", "/customers?kycStatus=pending", nil), &listed)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Empty(listed)
+
+	// Changing the email doesn't need verifying again, changing the name does.
+	update := created
+	update.Email = "jane.doe@moov.io"
+	updated, res, _ := clientCustomerUpdate(s, created.CustomerID, update)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.KYCVerified, updated.KYCStatus)
+
+	update.Name = "Jane Q Doe"
+	updated, res, _ = clientCustomerUpdate(s, created.CustomerID, update)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.KYCPending, updated.KYCStatus)
+
+	history := []customers.KYCTransition{}
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+created.CustomerID+"/kyc-transitions", nil), &history)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Len(history, 4)
+	s.Assert.Equal(customers.KYCVerified, history[3].From)
+	s.Assert.Equal(customers.KYCPending, history[3].To)
+	s.Assert.Equal(customers.KYCIdentityChangedReason, history[3].Reason)
+}
+
+func Test_Customer_KYCAPI_Invalid(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+
+	res := s.MakeCall(s.MakeRequest("POST", "/customers/"+created.CustomerID+"/kyc-transitions", &customers.KYCTransition{To: customers.KYCPending}), nil)
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	res = s.MakeCall(s.MakeRequest("POST", "/customers/"+created.CustomerID+"/kyc-transitions", &customers.KYCTransition{To: "approved", Reason: "Looks fine"}), nil)
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	res = s.MakeCall(s.MakeRequest("GET", "/customers?kycStatus=approved", nil), nil)
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	_, res = clientCustomerTransitionKYC(s, "does-not-exist", customers.KYCPending)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func clientCustomerTransitionKYC(s CustomerTestScope, customerID string, to customers.KYCStatus) (customers.Customer, *http.Response) {
+	cus := customers.Customer{}
+	transition := customers.KYCTransition{To: to, Reason: "Checked by compliance"}
+	res := s.MakeCall(s.MakeRequest("POST", "/customers/"+customerID+"/kyc-transitions", &transition), &cus)
+	return cus, res
+}
@@ -0,0 +1,107 @@
+package customers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+)
+
+func Test_Customer_PhonesAPI(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+
+	// The status is ours, whatever the request says.
+	phone, res := clientCustomerAddPhone(s, created.CustomerID, customers.Phone{
+		Type:    customers.PhoneMobile,
+		Primary: true,
+		Number:  "020 7946 0958",
+		Country: "gb",
+		Status:  customers.PhoneVerified,
+	})
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.NotEmpty(phone.PhoneID)
+	s.Assert.Equal("+442079460958", phone.Number)
+	s.Assert.Equal("GB", phone.Country)
+	s.Assert.Equal(customers.PhoneUnverified, phone.Status)
+	s.Assert.Nil(phone.VerifiedOn)
+
+	s.Env.StaticTime.Add(time.Minute)
+	verified := customers.Phone{}
+	res = s.MakeCall(s.MakeRequest("POST", "/customers/"+created.CustomerID+"/phones/"+phone.PhoneID+"/verification", nil), &verified)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.PhoneVerified, verified.Status)
+	s.Assert.Equal(s.Env.TimeService.Now(), *verified.VerifiedOn)
+
+	// Changing the type keeps the verification, changing the number doesn't.
+	update := verified
+	update.Type = customers.PhoneWork
+	updated, res := clientCustomerUpdatePhone(s, created.CustomerID, phone.PhoneID, update)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.PhoneVerified, updated.Status)
+
+	update.Number = "+1 (319) 555-0100"
+	update.Country = ""
+	updated, res = clientCustomerUpdatePhone(s, created.CustomerID, phone.PhoneID, update)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal("+13195550100", updated.Number)
+	s.Assert.Equal(customers.CountryUS, updated.Country)
+	s.Assert.Equal(customers.PhoneUnverified, updated.Status)
+	s.Assert.Nil(updated.VerifiedOn)
+
+	found := []customers.Phone{}
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/"+created.CustomerID+"/phones", nil), &found)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal([]customers.Phone{updated}, found)
+
+	res = s.MakeCall(s.MakeRequest("DELETE", "/customers/"+created.CustomerID+"/phones/"+phone.PhoneID, nil), nil)
+	s.Assert.Equal(http.StatusNoContent, res.StatusCode)
+	res = s.MakeCall(s.MakeRequest("POST", "/customers/"+created.CustomerID+"/phones/"+phone.PhoneID+"/verification", nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func Test_Customer_PhonesAPI_Invalid(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+
+	cases := []struct {
+		name  string
+		phone customers.Phone
+		field string
+	}{
+		{"Too short", customers.Phone{Type: customers.PhoneHome, Number: "555-0100"}, "number"},
+		{"Wrong country", customers.Phone{Type: customers.PhoneHome, Number: "(319) 555-0100", Country: "FR"}, "number"},
+		{"Unknown country", customers.Phone{Type: customers.PhoneHome, Number: "319 555 0100", Country: "ZZ"}, "country"},
+		{"Letters", customers.Phone{Type: customers.PhoneHome, Number: "1-800-FLOWERS"}, "number"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			details := problem.Details{}
+			res := s.MakeCall(s.MakeRequest("POST", "/customers/"+created.CustomerID+"/phones", &tc.phone), &details)
+			s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+			s.Assert.Contains(details.Errors, tc.field)
+		})
+	}
+
+	_, res := clientCustomerAddPhone(s, created.CustomerID, customers.Phone{Type: "fax", Number: "+13195550100"})
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	_, res = clientCustomerAddPhone(s, "does-not-exist", customers.Phone{Type: customers.PhoneHome, Number: "+13195550100"})
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func clientCustomerAddPhone(s CustomerTestScope, customerID string, create customers.Phone) (customers.Phone, *http.Response) {
+	phone := customers.Phone{}
+	res := s.MakeCall(s.MakeRequest("POST", "/customers/"+customerID+"/phones", &create), &phone)
+	return phone, res
+}
+
+func clientCustomerUpdatePhone(s CustomerTestScope, customerID string, phoneID string, update customers.Phone) (customers.Phone, *http.Response) {
+	phone := customers.Phone{}
+	res := s.MakeCall(s.MakeRequest("PUT", "/customers/"+customerID+"/phones/"+phoneID, &update), &phone)
+	return phone, res
+}
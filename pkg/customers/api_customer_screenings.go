@@ -0,0 +1,47 @@
+package customers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func (c *customerController) listScreenings(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	params := mux.Vars(r)
+	customerID := params["ID"]
+
+	result, err := c.service.ListScreenings(r.Context(), tenantID, customerID)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
+
+func (c *customerController) listScreeningAlerts(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	filter := ScreeningAlertFilter{
+		CustomerID: r.URL.Query().Get("customerID"),
+		RunID:      r.URL.Query().Get("runID"),
+	}
+
+	result, err := c.service.ListScreeningAlerts(r.Context(), tenantID, filter)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	jsonResponse(w, result)
+}
@@ -0,0 +1,152 @@
+package customers_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+	"github.com/moovfinancial/backendhiring/pkg/sanctions"
+	"github.com/moovfinancial/backendhiring/pkg/service"
+)
+
+func Test_Customer_ScreeningsAPI(t *testing.T) {
+	s := CustomerTestSetupWithConfig(t, func(cfg *service.Config) {
+		cfg.Customers.Screening.Lists = []sanctions.ListConfig{
+			{Name: "SDN", Path: "../sanctions/testdata/sdn.csv", AltNamesPath: "../sanctions/testdata/alt.csv"},
+			{Name: "CONS", Path: "../sanctions/testdata/consolidated.xml"},
+		}
+	})
+
+	created, res, _ := clientCustomerCreate(s, newScreenedCustomer(s, "Joe J Doe", "1980/03/31"))
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.ScreeningClear, created.ScreeningStatus)
+
+	screenings := clientCustomerListScreenings(s, created.CustomerID)
+	s.Assert.Len(screenings, 1)
+	s.Assert.Equal(customers.ScreeningClear, screenings[0].Status)
+	s.Assert.Empty(screenings[0].Matches)
+
+	// The alias matches but the birth date doesn't, close enough to review.
+	flagged, res, _ := clientCustomerCreate(s, newScreenedCustomer(s, "Ricky Vega", "1990/01/01"))
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.ScreeningFlagged, flagged.ScreeningStatus)
+
+	screenings = clientCustomerListScreenings(s, flagged.CustomerID)
+	s.Assert.Len(screenings, 1)
+	s.Assert.Equal(customers.ScreeningFlagged, screenings[0].Status)
+	s.Assert.InDelta(sanctions.BirthDateMismatchPenalty, screenings[0].Score, 0.0001)
+	s.Assert.Equal([]customers.ScreeningMatch{{
+		List:     "SDN",
+		EntryID:  "36",
+		Name:     "Ricardo Andres MORALES VEGA",
+		Programs: []string{"SDNTK"},
+		Score:    screenings[0].Score,
+	}}, screenings[0].Matches)
+
+	details := problem.Details{}
+	res = s.MakeCall(s.MakeRequest("POST", "/customers", newScreenedCustomer(s, "Ricardo Andrés Morales Vega", "1968/02/14")), &details)
+	s.Assert.Equal(http.StatusForbidden, res.StatusCode)
+	s.Assert.Equal(customers.ErrSanctionsMatch.Error(), details.Detail)
+
+	// A blocked update leaves the customer as it was.
+	update := newScreenedCustomer(s, "Elena Zhukovskaya", "1971/09/03")
+	res = s.MakeCall(s.MakeRequest("PUT", "/customers/"+created.CustomerID, update), nil)
+	s.Assert.Equal(http.StatusForbidden, res.StatusCode)
+
+	found, _, _ := clientCustomerGet(s, created.CustomerID)
+	s.Assert.Equal("Joe J Doe", found.Name)
+	s.Assert.Equal(customers.ScreeningClear, found.ScreeningStatus)
+
+	screenings = clientCustomerListScreenings(s, created.CustomerID)
+	s.Assert.Len(screenings, 2)
+	s.Assert.Equal(customers.ScreeningBlocked, screenings[1].Status)
+	s.Assert.Equal("17001", screenings[1].Matches[0].EntryID)
+
+	// Changing the name clears a flagged customer, other changes don't screen it again.
+	updated, res, _ := clientCustomerUpdate(s, flagged.CustomerID, newScreenedCustomer(s, "Jane Smith", "1990/01/01"))
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.ScreeningClear, updated.ScreeningStatus)
+
+	update = newScreenedCustomer(s, "Jane Smith", "1990/01/01")
+	update.Email = "jane.smith@moov.io"
+	_, res, _ = clientCustomerUpdate(s, flagged.CustomerID, update)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Len(clientCustomerListScreenings(s, flagged.CustomerID), 2)
+
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/missing/screenings", nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func Test_Customer_ScreeningsAPI_Off(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, res, _ := clientCustomerCreate(s, newScreenedCustomer(s, "Ricardo Andres Morales Vega", "1968/02/14"))
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Empty(created.ScreeningStatus)
+	s.Assert.Empty(clientCustomerListScreenings(s, created.CustomerID))
+}
+
+func Test_Customer_ScreeningAlertsAPI(t *testing.T) {
+	sdn, err := os.ReadFile("../sanctions/testdata/sdn.csv")
+	require.NoError(t, err)
+	sdnPath := filepath.Join(t.TempDir(), "sdn.csv")
+	require.NoError(t, os.WriteFile(sdnPath, sdn, 0o600))
+
+	s := CustomerTestSetupWithConfig(t, func(cfg *service.Config) {
+		cfg.Customers.Screening.Lists = []sanctions.ListConfig{{Name: "SDN", Path: sdnPath}}
+		cfg.Customers.Screening.Rescreening.BatchDelay = -1
+	})
+
+	created, res, _ := clientCustomerCreate(s, newScreenedCustomer(s, "Maria Ines Castillo", "1980/03/31"))
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.ScreeningClear, created.ScreeningStatus)
+
+	// The list's next version adds the customer.
+	entry := `900,"CASTILLO, Maria Ines","individual","SDGT","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- "` + "\n"
+	s.Assert.NoError(os.WriteFile(sdnPath, append(sdn, entry...), 0o600))
+
+	run, err := s.Env.RescreeningJob.Run(context.Background())
+	s.Assert.NoError(err)
+	s.Assert.Equal(1, run.Alerts)
+
+	alerts := clientCustomerListScreeningAlerts(s, "?customerID="+created.CustomerID)
+	s.Assert.Len(alerts, 1)
+	s.Assert.Equal(run.RunID, alerts[0].RunID)
+	s.Assert.Equal(customers.ScreeningBlocked, alerts[0].Status)
+	s.Assert.Equal("900", alerts[0].Match.EntryID)
+	s.Assert.Equal(alerts, clientCustomerListScreeningAlerts(s, "?runID="+run.RunID))
+	s.Assert.Empty(clientCustomerListScreeningAlerts(s, "?runID=other"))
+
+	found, _, _ := clientCustomerGet(s, created.CustomerID)
+	s.Assert.Equal(customers.ScreeningBlocked, found.ScreeningStatus)
+
+	res = s.MakeCall(s.MakeRequest("GET", "/screening-alerts?customerID=missing", nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func newScreenedCustomer(s CustomerTestScope, name string, birthDate string) customers.Customer {
+	c := NewTestCustomer(s.Env.TimeService)
+	c.Name = name
+	c.BirthDate = &birthDate
+	return c
+}
+
+func clientCustomerListScreenings(s CustomerTestScope, customerID string) []customers.Screening {
+	screenings := []customers.Screening{}
+	res := s.MakeCall(s.MakeRequest("GET", "/customers/"+customerID+"/screenings", nil), &screenings)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	return screenings
+}
+
+func clientCustomerListScreeningAlerts(s CustomerTestScope, query string) []customers.ScreeningAlert {
+	alerts := []customers.ScreeningAlert{}
+	res := s.MakeCall(s.MakeRequest("GET", "/screening-alerts"+query, nil), &alerts)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	return alerts
+}
@@ -0,0 +1,215 @@
+package customers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+	"github.com/moovfinancial/backendhiring/pkg/sse"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+const (
+	defaultStreamPollInterval = time.Second
+	defaultStreamHeartbeat    = 15 * time.Second
+	defaultStreamGapTimeout   = 30 * time.Second
+)
+
+// ErrTooManyStreams - The tenant already has as many streams open as it's allowed.
+var ErrTooManyStreams = errors.New("too many open streams for this tenant")
+
+// streamLimiter - Counts the open streams of each tenant.
+type streamLimiter struct {
+	max  int
+	mu   sync.Mutex
+	open map[string]int
+}
+
+func newStreamLimiter(max int) *streamLimiter {
+	return &streamLimiter{max: max, open: map[string]int{}}
+}
+
+// acquire - Reserves a stream for the tenant, the returned func gives it back.
+func (l *streamLimiter) acquire(tenantID string) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.max > 0 && l.open[tenantID] >= l.max {
+		return nil, ErrTooManyStreams
+	}
+	l.open[tenantID]++
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.open[tenantID]--
+		if l.open[tenantID] <= 0 {
+			delete(l.open, tenantID)
+		}
+	}, nil
+}
+
+// stream - Pushes the tenant's customer changes as Server-Sent Events. Each event's ID is a change feed cursor so
+// a client reconnecting with Last-Event-ID picks up where it left off, without one only new changes are sent.
+func (c *customerController) stream(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := c.GetTenantID(r)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	release, err := c.streams.acquire(tenantID)
+	if err != nil {
+		w.Header().Set("Retry-After", strconv.Itoa(int(c.pollInterval().Seconds())+1))
+		problem.Write(w, problem.New(r, http.StatusTooManyRequests, err.Error()))
+		return
+	}
+	defer release()
+
+	ctx := r.Context()
+
+	cursor := r.Header.Get(sse.LastEventIDHeader)
+	if cursor == "" {
+		if cursor, err = c.service.LatestChangesCursor(ctx, tenantID); err != nil {
+			errorResponse(w, r, err, c.logger)
+			return
+		}
+	}
+
+	// Check the cursor before the stream starts so a bad one still gets a proper error response.
+	page, err := c.service.Changes(ctx, tenantID, cursor, MaxChangesLimit)
+	if err != nil {
+		errorResponse(w, r, err, c.logger)
+		return
+	}
+
+	events, err := sse.NewWriter(w)
+	if err != nil {
+		c.logger.LogErrorf("starting stream: %w", err)
+		return
+	}
+
+	logger := c.logger.With(tracing.LogFields(ctx), log.Fields{
+		"tenant_id": log.String(tenantID),
+	})
+
+	if err := events.Retry(c.pollInterval()); err != nil {
+		return
+	}
+
+	poll := time.NewTicker(c.pollInterval())
+	defer poll.Stop()
+
+	heartbeatInterval := c.config.Stream.Heartbeat
+	if heartbeatInterval <= 0 {
+		heartbeatInterval = defaultStreamHeartbeat
+	}
+	heartbeat := time.NewTimer(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	// The missing change the stream is waiting for, if any, and since when.
+	var waitingFor int64
+	var waitingSince time.Time
+
+	for {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			return
+		}
+		changes, gap := contiguousChanges(after, page.Changes)
+		for _, change := range changes {
+			if err := events.Event(encodeCursor(change.Sequence), "customer."+string(change.Type), change.masked()); err != nil {
+				// The client went away.
+				return
+			}
+		}
+		if len(changes) > 0 {
+			heartbeat.Reset(heartbeatInterval)
+			cursor = encodeCursor(changes[len(changes)-1].Sequence)
+		}
+
+		wait := !page.HasMore
+		if gap {
+			missing := after + int64(len(changes)) + 1
+			switch {
+			case missing != waitingFor:
+				waitingFor, waitingSince = missing, time.Now()
+				wait = true
+			case time.Since(waitingSince) < c.gapTimeout():
+				wait = true
+			default:
+				// It's not coming, the client would otherwise get nothing but heartbeats from here on.
+				logger.With(log.Fields{
+					"sequence_number": log.String(encodeCursor(missing)),
+				}).Log("stream moved past a change that never turned up")
+				cursor = encodeCursor(missing)
+				wait = false
+			}
+		}
+
+		if wait {
+			if !c.waitForPoll(r, events, poll, heartbeat, heartbeatInterval) {
+				return
+			}
+		}
+
+		if page, err = c.service.Changes(ctx, tenantID, cursor, MaxChangesLimit); err != nil {
+			if ctx.Err() == nil {
+				logger.LogErrorf("reading changes for stream: %w", err)
+			}
+			// The client reconnects with the last event ID it got and carries on from there.
+			return
+		}
+	}
+}
+
+// contiguousChanges - The changes that follow on from after without a missing sequence number, and whether one was
+// missing. Each tenant's changes are committed in sequence order so there shouldn't be a gap, but if there is the
+// stream waits up to StreamConfig.GapTimeout for it rather than moving Last-Event-ID past a change it never sent.
+func contiguousChanges(after int64, changes []CustomerChange) ([]CustomerChange, bool) {
+	for i, change := range changes {
+		if change.Sequence != after+int64(i)+1 {
+			return changes[:i], true
+		}
+	}
+	return changes, false
+}
+
+// waitForPoll - Sends heartbeats until it's time to check for changes again, false when the stream should end.
+func (c *customerController) waitForPoll(r *http.Request, events *sse.Writer, poll *time.Ticker, heartbeat *time.Timer, heartbeatInterval time.Duration) bool {
+	for {
+		select {
+		case <-r.Context().Done():
+			return false
+		case <-sse.Shutdown(r.Context()):
+			return false
+		case <-heartbeat.C:
+			if err := events.Comment("heartbeat"); err != nil {
+				return false
+			}
+			heartbeat.Reset(heartbeatInterval)
+		case <-poll.C:
+			return true
+		}
+	}
+}
+
+func (c *customerController) gapTimeout() time.Duration {
+	if c.config.Stream.GapTimeout <= 0 {
+		return defaultStreamGapTimeout
+	}
+	return c.config.Stream.GapTimeout
+}
+
+func (c *customerController) pollInterval() time.Duration {
+	if c.config.Stream.PollInterval <= 0 {
+		return defaultStreamPollInterval
+	}
+	return c.config.Stream.PollInterval
+}
@@ -0,0 +1,246 @@
+package customers_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/sse"
+	"github.com/moovfinancial/backendhiring/pkg/test"
+)
+
+type streamScope struct {
+	*require.Assertions
+
+	Env      *test.TestEnvironment
+	Server   *httptest.Server
+	Shutdown chan struct{}
+}
+
+func setupStream(t *testing.T, config customers.StreamConfig) streamScope {
+	router := mux.NewRouter()
+	env := test.NewEnvironment(t, router)
+
+	cfg := env.Config.Customers
+	cfg.Stream = config
+	customers.NewCustomerController(env.Logger, cfg, env.CustomerService).AppendRoutes(router)
+
+	shutdown := make(chan struct{})
+	server := httptest.NewUnstartedServer(router)
+	server.Config.BaseContext = func(net.Listener) context.Context {
+		return sse.WithShutdown(context.Background(), shutdown)
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return streamScope{Assertions: require.New(t), Env: env, Server: server, Shutdown: shutdown}
+}
+
+// open - Starts a stream, the response body is closed when the test ends.
+func (s streamScope) open(t *testing.T, lastEventID string) (*http.Response, *bufio.Reader) {
+	req, err := http.NewRequest("GET", s.Server.URL+"/customers/stream", nil)
+	s.NoError(err)
+	if lastEventID != "" {
+		req.Header.Set(sse.LastEventIDHeader, lastEventID)
+	}
+
+	res, err := s.Server.Client().Do(req)
+	s.NoError(err)
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res, bufio.NewReader(res.Body)
+}
+
+// readEvent - Reads up to the blank line ending the next event or comment and returns its fields.
+func readEvent(a *require.Assertions, r *bufio.Reader) map[string]string {
+	fields := map[string]string{}
+	for {
+		line, err := r.ReadString('\n')
+		a.NoError(err)
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return fields
+		}
+
+		name, value, _ := strings.Cut(line, ":")
+		fields[name] = strings.TrimPrefix(value, " ")
+	}
+}
+
+func (s streamScope) createCustomer() customers.Customer {
+	created, err := s.Env.CustomerService.Create(context.Background(), s.Env.TenantID, NewTestCustomer(s.Env.TimeService))
+	s.NoError(err)
+	return *created
+}
+
+func Test_Customer_StreamAPI(t *testing.T) {
+	s := setupStream(t, customers.StreamConfig{PollInterval: 10 * time.Millisecond, Heartbeat: time.Hour})
+
+	// Only changes after the stream opens are sent without a Last-Event-ID.
+	s.createCustomer()
+
+	res, events := s.open(t, "")
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal(sse.ContentType, res.Header.Get("Content-Type"))
+	s.Equal("10", readEvent(s.Assertions, events)["retry"])
+
+	created := s.createCustomer()
+
+	event := readEvent(s.Assertions, events)
+	s.Equal("customer.created", event["event"])
+	s.Contains(event["data"], created.CustomerID)
+	s.NotEmpty(event["id"])
+
+	s.NoError(s.Env.CustomerService.Delete(context.Background(), s.Env.TenantID, created.CustomerID))
+	disabled := readEvent(s.Assertions, events)
+	s.Equal("customer.disabled", disabled["event"])
+
+	// Reconnecting after the first event replays what was missed.
+	_, events = s.open(t, event["id"])
+	readEvent(s.Assertions, events)
+	s.Equal(disabled, readEvent(s.Assertions, events))
+}
+
+// uncommittedChangesService - Leaves a change out of the feed until it's committed, like a slower transaction would.
+type uncommittedChangesService struct {
+	customers.CustomerService
+	uncommitted int64
+	committed   chan struct{}
+}
+
+func (s *uncommittedChangesService) Changes(ctx context.Context, tenantID string, cursor string, limit int) (*customers.CustomerChanges, error) {
+	page, err := s.CustomerService.Changes(ctx, tenantID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-s.committed:
+		return page, nil
+	default:
+	}
+
+	visible := []customers.CustomerChange{}
+	for _, change := range page.Changes {
+		if change.Sequence != s.uncommitted {
+			visible = append(visible, change)
+		}
+	}
+	page.Changes = visible
+	return page, nil
+}
+
+func Test_Customer_StreamAPI_WaitsForGaps(t *testing.T) {
+	a := require.New(t)
+	router := mux.NewRouter()
+	env := test.NewEnvironment(t, router)
+
+	for i := 0; i < 3; i++ {
+		_, err := env.CustomerService.Create(context.Background(), env.TenantID, NewTestCustomer(env.TimeService))
+		a.NoError(err)
+	}
+
+	service := &uncommittedChangesService{CustomerService: env.CustomerService, uncommitted: 2, committed: make(chan struct{})}
+	cfg := env.Config.Customers
+	cfg.Stream = customers.StreamConfig{PollInterval: 10 * time.Millisecond, Heartbeat: time.Hour}
+	customers.NewCustomerController(env.Logger, cfg, service).AppendRoutes(router)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	s := streamScope{Assertions: a, Env: env, Server: server}
+
+	_, events := s.open(t, "0")
+	readEvent(a, events)
+	a.Equal("1", readEvent(a, events)["id"])
+
+	// The third change isn't sent ahead of the second, which would move Last-Event-ID past it for good.
+	time.Sleep(50 * time.Millisecond)
+	close(service.committed)
+	a.Equal("2", readEvent(a, events)["id"])
+	a.Equal("3", readEvent(a, events)["id"])
+}
+
+func Test_Customer_StreamAPI_MovesPastMissingChanges(t *testing.T) {
+	a := require.New(t)
+	router := mux.NewRouter()
+	env := test.NewEnvironment(t, router)
+
+	for i := 0; i < 3; i++ {
+		_, err := env.CustomerService.Create(context.Background(), env.TenantID, NewTestCustomer(env.TimeService))
+		a.NoError(err)
+	}
+
+	// The second change never turns up.
+	service := &uncommittedChangesService{CustomerService: env.CustomerService, uncommitted: 2, committed: make(chan struct{})}
+	cfg := env.Config.Customers
+	cfg.Stream = customers.StreamConfig{PollInterval: 10 * time.Millisecond, Heartbeat: time.Hour, GapTimeout: 50 * time.Millisecond}
+	customers.NewCustomerController(env.Logger, cfg, service).AppendRoutes(router)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	s := streamScope{Assertions: a, Env: env, Server: server}
+
+	_, events := s.open(t, "0")
+	readEvent(a, events)
+	a.Equal("1", readEvent(a, events)["id"])
+	a.Equal("3", readEvent(a, events)["id"])
+}
+
+func Test_Customer_StreamAPI_Heartbeat(t *testing.T) {
+	s := setupStream(t, customers.StreamConfig{PollInterval: time.Hour, Heartbeat: 10 * time.Millisecond})
+
+	_, events := s.open(t, "")
+	readEvent(s.Assertions, events)
+	s.Equal(map[string]string{"": "heartbeat"}, readEvent(s.Assertions, events))
+}
+
+func Test_Customer_StreamAPI_LimitPerTenant(t *testing.T) {
+	s := setupStream(t, customers.StreamConfig{MaxPerTenant: 1})
+
+	first, events := s.open(t, "")
+	s.Equal(http.StatusOK, first.StatusCode)
+	readEvent(s.Assertions, events)
+
+	second, _ := s.open(t, "")
+	s.Equal(http.StatusTooManyRequests, second.StatusCode)
+	s.NotEmpty(second.Header.Get("Retry-After"))
+}
+
+func Test_Customer_StreamAPI_Shutdown(t *testing.T) {
+	s := setupStream(t, customers.StreamConfig{PollInterval: time.Hour, Heartbeat: time.Hour})
+
+	_, events := s.open(t, "")
+	readEvent(s.Assertions, events)
+
+	close(s.Shutdown)
+
+	done := make(chan error)
+	go func() {
+		_, err := events.ReadString('\n')
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		s.Error(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream didn't end on shutdown")
+	}
+}
+
+func Test_Customer_StreamAPI_BadLastEventID(t *testing.T) {
+	s := setupStream(t, customers.StreamConfig{})
+
+	res, _ := s.open(t, "not a cursor")
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+}
@@ -0,0 +1,124 @@
+package customers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/identity"
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+	"github.com/moovfinancial/backendhiring/pkg/service"
+)
+
+func Test_Customer_TaxIDsAPI(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	c := NewTestCustomer(s.Env.TimeService)
+	c.TaxID = &customers.TaxID{Type: customers.TaxIDForeign, Value: "qq 12 34 56 c", Country: "gb"}
+	created, res, _ := clientCustomerCreate(s, c)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(&customers.TaxID{Type: customers.TaxIDForeign, Country: "GB", Masked: "** ** *4 56 C"}, created.TaxID)
+
+	found, _, _ := clientCustomerGet(s, created.CustomerID)
+	s.Assert.Equal(created.TaxID, found.TaxID)
+
+	// Sent back as it was returned, the tax ID is kept.
+	update := found
+	update.Email = "jane.doe@moov.io"
+	updated, res, _ := clientCustomerUpdate(s, created.CustomerID, update)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(created.TaxID, updated.TaxID)
+
+	// Changing its type needs the new value.
+	update.TaxID = &customers.TaxID{Type: customers.TaxIDITIN}
+	details := problem.Details{}
+	res = s.MakeCall(s.MakeRequest("PUT", "/customers/"+created.CustomerID, &update), &details)
+	s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+	s.Assert.Contains(details.Errors, "taxID")
+
+	update.TaxID.Value = "912701234"
+	updated, res, _ = clientCustomerUpdate(s, created.CustomerID, update)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(&customers.TaxID{Type: customers.TaxIDITIN, Masked: "***-**-1234"}, updated.TaxID)
+
+	// The changes carry it masked too.
+	changes := customers.CustomerChanges{}
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/changes", nil), &changes)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	for _, change := range changes.Changes {
+		s.Assert.Empty(change.Customer.TaxID.Value)
+		s.Assert.Equal("***-**-1234", change.Customer.TaxID.Masked)
+	}
+
+	// The deprecated ssn is taken as a tax ID.
+	legacy := NewTestCustomer(s.Env.TimeService)
+	legacy.TaxID = nil
+	legacy.Ssn = "123456789"
+	created, res, _ = clientCustomerCreate(s, legacy)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Empty(created.Ssn)
+	s.Assert.Equal(&customers.TaxID{Type: customers.TaxIDSSN, Masked: "***-**-6789"}, created.TaxID)
+}
+
+func Test_Customer_TaxIDsAPI_Invalid(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	cases := []struct {
+		name   string
+		modify func(c *customers.Customer)
+		field  string
+	}{
+		{"Missing tax ID", func(c *customers.Customer) { c.TaxID = nil }, "taxID"},
+		{"SSN area 666", func(c *customers.Customer) { c.TaxID.Value = "666-12-3456" }, "taxID"},
+		{"ITIN as an SSN", func(c *customers.Customer) { c.TaxID.Value = "912-70-1234" }, "taxID"},
+		{"Foreign TIN from the US", func(c *customers.Customer) {
+			c.TaxID = &customers.TaxID{Type: customers.TaxIDForeign, Value: "12345678901", Country: "US"}
+		}, "taxID"},
+		{"Both ssn and tax ID", func(c *customers.Customer) { c.Ssn = "123-45-6789" }, "ssn"},
+		{"Business with a tax ID", func(c *customers.Customer) {
+			business := newTestBusiness(s.Env.TimeService)
+			business.TaxID = c.TaxID
+			*c = business
+		}, "taxID"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewTestCustomer(s.Env.TimeService)
+			tc.modify(&c)
+
+			details := problem.Details{}
+			res := s.MakeCall(s.MakeRequest("POST", "/customers", &c), &details)
+			s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+			s.Assert.Contains(details.Errors, tc.field)
+		})
+	}
+
+	invalid := NewTestCustomer(s.Env.TimeService)
+	invalid.TaxID.Type = "passport"
+	_, res, _ := clientCustomerCreate(s, invalid)
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+}
+
+func Test_Customer_TaxIDsAPI_Verification(t *testing.T) {
+	s := CustomerTestSetupWithConfig(t, func(cfg *service.Config) {
+		cfg.Customers.Verification.Identity.Provider = identity.ProviderSimulator
+	})
+
+	// The identity provider checks ITINs like SSNs.
+	itin := NewTestCustomer(s.Env.TimeService)
+	itin.TaxID = &customers.TaxID{Type: customers.TaxIDITIN, Value: "912-70-1234"}
+	created, res, _ := clientCustomerCreate(s, itin)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.KYCPending, created.KYCStatus)
+
+	// Anyone else is left to be reviewed.
+	foreign := NewTestCustomer(s.Env.TimeService)
+	foreign.TaxID = &customers.TaxID{Type: customers.TaxIDForeign, Value: "12345678901", Country: "DE"}
+	created, res, _ = clientCustomerCreate(s, foreign)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.KYCUnverified, created.KYCStatus)
+
+	res = s.MakeCall(s.MakeRequest("POST", "/customers/"+created.CustomerID+"/verifications", nil), nil)
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+	s.Assert.Empty(clientCustomerListVerifications(s, created.CustomerID))
+}
@@ -1,6 +1,7 @@
 package customers_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/service"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,7 +28,7 @@ func Test_Customer_CreateAPI(t *testing.T) {
 	s.Assert.Equal(m.Name, found.Name)
 	s.Assert.Equal(m.Email, found.Email)
 	s.Assert.Equal(m.BirthDate, found.BirthDate)
-	s.Assert.Equal(m.Ssn, found.Ssn)
+	s.Assert.Equal(&customers.TaxID{Type: customers.TaxIDSSN, Masked: "***-**-" + m.TaxID.Value[7:]}, found.TaxID)
 }
 
 func Test_Customer_Validation_Email(t *testing.T) {
@@ -133,7 +135,7 @@ func Test_Customer_UpdateAPI(t *testing.T) {
 		Name:       "Jane Doe",
 		Email:      "jane.doe@moov.io",
 		BirthDate:  &bd,
-		Ssn:        "111-222-3333",
+		Ssn:        "111-22-3333",
 		CreatedOn:  now.Add(time.Hour),
 		UpdatedOn:  now.Add(time.Hour),
 		DisabledOn: &now,
@@ -159,7 +161,8 @@ func Test_Customer_UpdateAPI(t *testing.T) {
 	s.Assert.Equal(updates.Name, updated.Name)
 	s.Assert.Equal(updates.Email, updated.Email)
 	s.Assert.Equal(updates.BirthDate, updated.BirthDate)
-	s.Assert.Equal(updates.Ssn, updated.Ssn)
+	s.Assert.Empty(updated.Ssn)
+	s.Assert.Equal(&customers.TaxID{Type: customers.TaxIDSSN, Masked: "***-**-3333"}, updated.TaxID)
 
 	// Lets fetch it fresh and check that it matches
 	found, resp, err := clientCustomerGet(s, m.CustomerID)
@@ -169,6 +172,32 @@ func Test_Customer_UpdateAPI(t *testing.T) {
 	s.Assert.Equal(updated, found)
 }
 
+func Test_Customer_RiskAPI(t *testing.T) {
+	s := CustomerTestSetupWithConfig(t, func(cfg *service.Config) {
+		cfg.Customers.Risk.RulesPath = "../../configs/risk-rules.yml"
+	})
+
+	m := NewTestCustomer(s.Env.TimeService)
+	bd := "1980/03/31"
+	m.BirthDate = &bd
+	created, res, _ := clientCustomerCreate(s, m)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.NotNil(created.Risk)
+	s.Assert.Equal("low", created.Risk.Tier)
+	s.Assert.Equal([]customers.RiskFactor{{Name: "identity_unverified", Score: 10}, {Name: "missing_address", Score: 5}}, created.Risk.Factors)
+
+	// The score is read only, it's scored again from the update.
+	update := created
+	update.BirthDate = nil
+	update.Risk.Score = 0
+	updated, res, _ := clientCustomerUpdate(s, created.CustomerID, update)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(30, updated.Risk.Score)
+
+	found, _, _ := clientCustomerGet(s, created.CustomerID)
+	s.Assert.Equal(updated.Risk, found.Risk)
+}
+
 func Test_Customer_UpdateAPI_NotFound(t *testing.T) {
 	s := CustomerTestSetup(t)
 
@@ -187,16 +216,17 @@ func addFuzzedCustomer(s CustomerTestScope) customers.Customer {
 	m.TenantID = s.Env.TenantID
 	m.CreatedOn = s.Env.TimeService.Now()
 	m.UpdatedOn = s.Env.TimeService.Now()
-	_, err := s.Repository.Add(m)
+	_, err := s.Repository.Add(context.Background(), m)
 	s.Assert.Nil(err)
 
 	return customers.Customer{
 		TenantID:   m.TenantID,
 		CustomerID: m.CustomerID,
+		Type:       m.Type,
 		Name:       m.Name,
 		BirthDate:  m.BirthDate,
 		Email:      m.Email,
-		Ssn:        m.Ssn,
+		TaxID:      &customers.TaxID{Type: customers.TaxIDSSN, Masked: "***-**-" + m.TaxID.Value[7:]},
 		CreatedOn:  m.CreatedOn,
 		UpdatedOn:  m.UpdatedOn,
 		DisabledOn: m.DisabledOn,
@@ -233,3 +263,33 @@ func clientCustomerDelete(s CustomerTestScope, customerID string) (*http.Respons
 	res := s.MakeCall(s.MakeRequest("DELETE", "/customers/"+customerID, nil), nil)
 	return res, nil
 }
+
+func Test_Customer_DeprecatedRoutes(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created := customers.Customer{}
+	res := s.MakeCall(s.MakeRequest("PUT", "/customers", NewTestCustomer(s.Env.TimeService)), &created)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal("true", res.Header.Get("Deprecation"))
+	s.Assert.Equal(`</customers>; rel="successor-version"`, res.Header.Get("Link"))
+	s.Assert.NotEmpty(created.CustomerID)
+
+	update := created
+	update.Email = "jane.doe@moov.io"
+	updated := customers.Customer{}
+	res = s.MakeCall(s.MakeRequest("PUT", "/customer/"+created.CustomerID, &update), &updated)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal("jane.doe@moov.io", updated.Email)
+
+	// Errors keep their old bodies, validation messages by field and nothing otherwise.
+	update.Email = "not an email"
+	invalid := map[string]string{}
+	res = s.MakeCall(s.MakeRequest("PUT", "/customer/"+created.CustomerID, &update), &invalid)
+	s.Assert.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+	s.Assert.Equal("application/json; charset=UTF-8", res.Header.Get("Content-Type"))
+	s.Assert.Contains(invalid, "email")
+
+	res = s.MakeCall(s.MakeRequest("PUT", "/customer/does-not-exist", &updated), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+	s.Assert.Empty(res.Header.Get("Content-Type"))
+}
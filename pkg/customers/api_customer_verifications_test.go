@@ -0,0 +1,102 @@
+package customers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/identity"
+	"github.com/moovfinancial/backendhiring/pkg/service"
+)
+
+func Test_Customer_VerificationsAPI(t *testing.T) {
+	s := CustomerTestSetupWithConfig(t, func(cfg *service.Config) {
+		cfg.Customers.Verification.Identity.Provider = identity.ProviderSimulator
+	})
+
+	model := NewTestCustomer(s.Env.TimeService)
+	model.TaxID.Value = identity.SimulatorSSNReviewRequired
+	created, res, _ := clientCustomerCreate(s, model)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Equal(customers.KYCPending, created.KYCStatus)
+
+	verifications := clientCustomerListVerifications(s, created.CustomerID)
+	s.Assert.Len(verifications, 1)
+	s.Assert.Equal(customers.VerificationPending, verifications[0].Status)
+	s.Assert.Equal(identity.ProviderSimulator, verifications[0].Provider)
+
+	_, err := s.Env.VerificationWorker.Run(context.Background())
+	s.Assert.NoError(err)
+
+	found, _, _ := clientCustomerGet(s, created.CustomerID)
+	s.Assert.Equal(customers.KYCReviewRequired, found.KYCStatus)
+	verifications = clientCustomerListVerifications(s, created.CustomerID)
+	s.Assert.Len(verifications, 1)
+	s.Assert.Equal(customers.VerificationReviewRequired, verifications[0].Status)
+	s.Assert.Equal([]string{"birth_date_mismatch"}, verifications[0].Reasons)
+
+	// Asking again queues a new check.
+	requested := customers.Verification{}
+	res = s.MakeCall(s.MakeRequest("POST", "/customers/"+created.CustomerID+"/verifications", nil), &requested)
+	s.Assert.Equal(http.StatusAccepted, res.StatusCode)
+	s.Assert.Equal(customers.VerificationPending, requested.Status)
+	s.Assert.Len(clientCustomerListVerifications(s, created.CustomerID), 2)
+
+	res = s.MakeCall(s.MakeRequest("POST", "/customers/does-not-exist/verifications", nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+	res = s.MakeCall(s.MakeRequest("GET", "/customers/does-not-exist/verifications", nil), nil)
+	s.Assert.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func Test_Customer_VerificationsAPI_Audited(t *testing.T) {
+	s := CustomerTestSetupWithConfig(t, func(cfg *service.Config) {
+		cfg.Customers.Verification.Identity.Provider = identity.ProviderSimulator
+	})
+	audit.NewController(s.Env.Logger, s.Env.AuditService).AppendRoutes(s.Router)
+
+	created, res, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	_, err := s.Env.VerificationWorker.Run(context.Background())
+	s.Assert.NoError(err)
+
+	req := s.MakeRequest("POST", "/customers/"+created.CustomerID+"/verifications", nil)
+	req.Header.Set(audit.ActorIDHeader, "jane@moov.io")
+	res = s.MakeCall(req, nil)
+	s.Assert.Equal(http.StatusAccepted, res.StatusCode)
+
+	page := audit.Events{}
+	res = s.MakeCall(s.MakeRequest("GET", "/audit-events?customerID="+created.CustomerID, nil), &page)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Len(page.Events, 4)
+
+	// The worker reads the customer to check it, then its completion moves the customer out of pending.
+	completed := page.Events[2]
+	s.Assert.Equal("VerificationWorker.Run", completed.Route)
+	s.Assert.Equal(audit.ActionUpdate, completed.Action)
+	s.Assert.Equal([]string{"kycStatus"}, completed.Fields)
+
+	requested := page.Events[3]
+	s.Assert.Equal("jane@moov.io", requested.ActorID)
+	s.Assert.Equal(audit.ActionUpdate, requested.Action)
+	s.Assert.Equal([]string{"kycStatus"}, requested.Fields)
+}
+
+func Test_Customer_VerificationsAPI_Off(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	created, _, _ := clientCustomerCreate(s, NewTestCustomer(s.Env.TimeService))
+	s.Assert.Equal(customers.KYCUnverified, created.KYCStatus)
+
+	res := s.MakeCall(s.MakeRequest("POST", "/customers/"+created.CustomerID+"/verifications", nil), nil)
+	s.Assert.Equal(http.StatusConflict, res.StatusCode)
+	s.Assert.Empty(clientCustomerListVerifications(s, created.CustomerID))
+}
+
+func clientCustomerListVerifications(s CustomerTestScope, customerID string) []customers.Verification {
+	verifications := []customers.Verification{}
+	res := s.MakeCall(s.MakeRequest("GET", "/customers/"+customerID+"/verifications", nil), &verifications)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	return verifications
+}
@@ -0,0 +1,106 @@
+package customers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring"
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+	"github.com/moovfinancial/backendhiring/pkg/service"
+)
+
+var pathParam = regexp.MustCompile(`\{[^}]+\}`)
+
+// Every route registered on the router must be in api/openapi.yaml with the route name as its operationId, and
+// the other way around.
+func Test_Customer_OpenAPI_MatchesRoutes(t *testing.T) {
+	a := require.New(t)
+
+	doc, err := service.LoadOpenAPI(backendhiring.OpenAPI)
+	a.NoError(err)
+
+	documented := map[string]string{}
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			documented[method+" "+pathParam.ReplaceAllString(path, "{}")] = op.OperationID
+		}
+	}
+
+	router := mux.NewRouter()
+	customers.NewCustomerController(log.NewNopLogger(), customers.Config{}, nil).AppendRoutes(router)
+	audit.NewController(log.NewNopLogger(), nil).AppendRoutes(router)
+
+	registered := map[string]string{}
+	err = router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+		for _, method := range methods {
+			registered[strings.ToUpper(method)+" "+pathParam.ReplaceAllString(path, "{}")] = route.GetName()
+		}
+		return nil
+	})
+	a.NoError(err)
+
+	a.Equal(documented, registered)
+}
+
+func Test_Customer_OpenAPI_RejectsInvalidRequests(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	create := NewTestCustomer(s.Env.TimeService)
+	create.Name = ""
+	create.Email = "not an email"
+
+	details := problem.Details{}
+	res := s.MakeCall(s.MakeRequest("POST", "/customers", &create), nil)
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+	s.Assert.Equal(problem.ContentType, res.Header.Get("Content-Type"))
+	s.Assert.NoError(json.NewDecoder(res.Body).Decode(&details))
+
+	s.Assert.Contains(details.Errors, "name")
+	s.Assert.Contains(details.Errors, "email")
+}
+
+// Only operations that take uploads skip body validation, whatever Content-Type the request claims.
+func Test_Customer_OpenAPI_ValidatesBodiesClaimingToBeUploads(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	create := NewTestCustomer(s.Env.TimeService)
+	create.Email = "not an email"
+
+	req := s.MakeRequest("POST", "/customers", &create)
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	res := s.MakeCall(req, nil)
+	s.Assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	var found []customers.Customer
+	res = s.MakeCall(s.MakeRequest("GET", "/customers", nil), &found)
+	s.Assert.Equal(http.StatusOK, res.StatusCode)
+	s.Assert.Empty(found)
+}
+
+func Test_Customer_OpenAPI_RejectsLargeBodies(t *testing.T) {
+	s := CustomerTestSetup(t)
+
+	create := NewTestCustomer(s.Env.TimeService)
+	create.Name = strings.Repeat("a", service.MaxRequestBodySize)
+
+	res := s.MakeCall(s.MakeRequest("POST", "/customers", &create), nil)
+	s.Assert.Equal(http.StatusRequestEntityTooLarge, res.StatusCode)
+	s.Assert.Equal(problem.ContentType, res.Header.Get("Content-Type"))
+}
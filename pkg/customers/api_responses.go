@@ -1,15 +1,59 @@
 package customers
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/moov-io/base/log"
+
+	"github.com/moovfinancial/backendhiring/pkg/problem"
 )
 
+// statusClientClosedRequest - Non-standard status (from nginx) recorded when the caller cancels the request.
+const statusClientClosedRequest = 499
+
+// ErrInvalidJSON - The request body couldn't be decoded.
+var ErrInvalidJSON = errors.New("invalid JSON")
+
+func decodeJSON(r *http.Request, into interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(into); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	return nil
+}
+
+type deprecatedRouteKey struct{}
+
+// deprecated - Serves a route kept for clients that haven't moved to its successor yet. Responses point them at it
+// and errors are written the way they were before problem details.
+func deprecated(successor string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+successor+`>; rel="successor-version"`)
+		next(w, r.WithContext(context.WithValue(r.Context(), deprecatedRouteKey{}, true)))
+	}
+}
+
+// writeProblem - Deprecated routes get the old error bodies, validation messages by field as plain JSON and nothing
+// for the rest.
+func writeProblem(w http.ResponseWriter, r *http.Request, details problem.Details) {
+	if legacy, _ := r.Context().Value(deprecatedRouteKey{}).(bool); !legacy {
+		problem.Write(w, details)
+		return
+	}
+
+	if details.Errors != nil {
+		jsonResponseStatus(w, details.Status, details.Errors)
+		return
+	}
+	w.WriteHeader(details.Status)
+}
+
 func jsonResponse(w http.ResponseWriter, value interface{}) {
 	jsonResponseStatus(w, http.StatusOK, value)
 }
@@ -21,18 +65,41 @@ func jsonResponseStatus(w http.ResponseWriter, status int, value interface{}) {
 	e.Encode(value)
 }
 
-func errorResponse(w http.ResponseWriter, err error, logger log.Logger) {
-	validationErr := &validation.Errors{}
+func errorResponse(w http.ResponseWriter, r *http.Request, err error, logger log.Logger) {
+	validationErrs := validation.Errors{}
+	var tooLarge *http.MaxBytesError
 
 	switch true {
-	case errors.Is(err, &json.InvalidUnmarshalError{}):
-		w.WriteHeader(http.StatusBadRequest)
-	case errors.As(err, validationErr):
-		jsonResponseStatus(w, http.StatusUnprocessableEntity, validationErr)
+	case errors.Is(err, ErrMissingTenantID), errors.Is(err, ErrInvalidJSON),
+		errors.Is(err, ErrInvalidCursor), errors.Is(err, ErrInvalidLimit), errors.Is(err, ErrInvalidUpload):
+		writeProblem(w, r, problem.New(r, http.StatusBadRequest, err.Error()))
+	case errors.Is(err, ErrLegalHold), errors.Is(err, ErrRetentionPeriod), errors.Is(err, ErrCustomerErased),
+		errors.Is(err, ErrKYCTransition), errors.Is(err, ErrVerificationOff), errors.Is(err, ErrVerificationIndividualsOnly),
+		errors.Is(err, ErrVerificationTaxIDType), errors.Is(err, ErrNotBusiness), errors.Is(err, ErrBeneficialOwnerExists),
+		errors.Is(err, ErrBankAccountExists), errors.Is(err, ErrBankAccountVerificationFailed),
+		errors.Is(err, ErrMicroDepositsSent), errors.Is(err, ErrMicroDepositsNotPending):
+		writeProblem(w, r, problem.New(r, http.StatusConflict, err.Error()))
+	case errors.Is(err, ErrSanctionsMatch):
+		writeProblem(w, r, problem.New(r, http.StatusForbidden, err.Error()))
+	case errors.Is(err, ErrDocumentTooLarge), errors.As(err, &tooLarge):
+		writeProblem(w, r, problem.New(r, http.StatusRequestEntityTooLarge, ErrDocumentTooLarge.Error()))
+	case errors.As(err, &validationErrs):
+		details := problem.New(r, http.StatusUnprocessableEntity, "request failed validation")
+		details.Errors = map[string]string{}
+		for field, fieldErr := range validationErrs {
+			details.Errors[field] = fieldErr.Error()
+		}
+		writeProblem(w, r, details)
 	case errors.Is(err, sql.ErrNoRows):
-		w.WriteHeader(http.StatusNotFound)
+		writeProblem(w, r, problem.New(r, http.StatusNotFound, ""))
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.Warn().LogErrorf("timed out: %w", err)
+		writeProblem(w, r, problem.New(r, http.StatusGatewayTimeout, ""))
+	case errors.Is(err, context.Canceled):
+		// The client went away or the server is shutting down, nobody is left to read a response.
+		w.WriteHeader(statusClientClosedRequest)
 	default:
 		logger.LogErrorf("unexpected: %w", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeProblem(w, r, problem.New(r, http.StatusInternalServerError, ""))
 	}
 }
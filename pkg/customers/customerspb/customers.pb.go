@@ -0,0 +1,1672 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        v5.28.3
+// source: api/customers/v1/customers.proto
+
+// Mirrors the REST API described in api/openapi.yaml.
+//
+// Every call is scoped to the tenant in the `x-tenant-id` metadata, customers from other tenants are never visible.
+// Failed validation returns INVALID_ARGUMENT with a google.rpc.BadRequest detail listing each invalid field.
+
+package customerspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CustomerType int32
+
+const (
+	CustomerType_CUSTOMER_TYPE_UNSPECIFIED CustomerType = 0
+	CustomerType_CUSTOMER_TYPE_INDIVIDUAL  CustomerType = 1
+	CustomerType_CUSTOMER_TYPE_BUSINESS    CustomerType = 2
+)
+
+// Enum value maps for CustomerType.
+var (
+	CustomerType_name = map[int32]string{
+		0: "CUSTOMER_TYPE_UNSPECIFIED",
+		1: "CUSTOMER_TYPE_INDIVIDUAL",
+		2: "CUSTOMER_TYPE_BUSINESS",
+	}
+	CustomerType_value = map[string]int32{
+		"CUSTOMER_TYPE_UNSPECIFIED": 0,
+		"CUSTOMER_TYPE_INDIVIDUAL":  1,
+		"CUSTOMER_TYPE_BUSINESS":    2,
+	}
+)
+
+func (x CustomerType) Enum() *CustomerType {
+	p := new(CustomerType)
+	*p = x
+	return p
+}
+
+func (x CustomerType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CustomerType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customers_v1_customers_proto_enumTypes[0].Descriptor()
+}
+
+func (CustomerType) Type() protoreflect.EnumType {
+	return &file_api_customers_v1_customers_proto_enumTypes[0]
+}
+
+func (x CustomerType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CustomerType.Descriptor instead.
+func (CustomerType) EnumDescriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{0}
+}
+
+type TaxIDType int32
+
+const (
+	TaxIDType_TAX_ID_TYPE_UNSPECIFIED TaxIDType = 0
+	TaxIDType_TAX_ID_TYPE_SSN         TaxIDType = 1
+	TaxIDType_TAX_ID_TYPE_ITIN        TaxIDType = 2
+	TaxIDType_TAX_ID_TYPE_EIN         TaxIDType = 3
+	TaxIDType_TAX_ID_TYPE_FOREIGN_TIN TaxIDType = 4
+)
+
+// Enum value maps for TaxIDType.
+var (
+	TaxIDType_name = map[int32]string{
+		0: "TAX_ID_TYPE_UNSPECIFIED",
+		1: "TAX_ID_TYPE_SSN",
+		2: "TAX_ID_TYPE_ITIN",
+		3: "TAX_ID_TYPE_EIN",
+		4: "TAX_ID_TYPE_FOREIGN_TIN",
+	}
+	TaxIDType_value = map[string]int32{
+		"TAX_ID_TYPE_UNSPECIFIED": 0,
+		"TAX_ID_TYPE_SSN":         1,
+		"TAX_ID_TYPE_ITIN":        2,
+		"TAX_ID_TYPE_EIN":         3,
+		"TAX_ID_TYPE_FOREIGN_TIN": 4,
+	}
+)
+
+func (x TaxIDType) Enum() *TaxIDType {
+	p := new(TaxIDType)
+	*p = x
+	return p
+}
+
+func (x TaxIDType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaxIDType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customers_v1_customers_proto_enumTypes[1].Descriptor()
+}
+
+func (TaxIDType) Type() protoreflect.EnumType {
+	return &file_api_customers_v1_customers_proto_enumTypes[1]
+}
+
+func (x TaxIDType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaxIDType.Descriptor instead.
+func (TaxIDType) EnumDescriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{1}
+}
+
+type EntityType int32
+
+const (
+	EntityType_ENTITY_TYPE_UNSPECIFIED         EntityType = 0
+	EntityType_ENTITY_TYPE_SOLE_PROPRIETORSHIP EntityType = 1
+	EntityType_ENTITY_TYPE_PARTNERSHIP         EntityType = 2
+	EntityType_ENTITY_TYPE_LLC                 EntityType = 3
+	EntityType_ENTITY_TYPE_CORPORATION         EntityType = 4
+	EntityType_ENTITY_TYPE_NONPROFIT           EntityType = 5
+	EntityType_ENTITY_TYPE_TRUST               EntityType = 6
+)
+
+// Enum value maps for EntityType.
+var (
+	EntityType_name = map[int32]string{
+		0: "ENTITY_TYPE_UNSPECIFIED",
+		1: "ENTITY_TYPE_SOLE_PROPRIETORSHIP",
+		2: "ENTITY_TYPE_PARTNERSHIP",
+		3: "ENTITY_TYPE_LLC",
+		4: "ENTITY_TYPE_CORPORATION",
+		5: "ENTITY_TYPE_NONPROFIT",
+		6: "ENTITY_TYPE_TRUST",
+	}
+	EntityType_value = map[string]int32{
+		"ENTITY_TYPE_UNSPECIFIED":         0,
+		"ENTITY_TYPE_SOLE_PROPRIETORSHIP": 1,
+		"ENTITY_TYPE_PARTNERSHIP":         2,
+		"ENTITY_TYPE_LLC":                 3,
+		"ENTITY_TYPE_CORPORATION":         4,
+		"ENTITY_TYPE_NONPROFIT":           5,
+		"ENTITY_TYPE_TRUST":               6,
+	}
+)
+
+func (x EntityType) Enum() *EntityType {
+	p := new(EntityType)
+	*p = x
+	return p
+}
+
+func (x EntityType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntityType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customers_v1_customers_proto_enumTypes[2].Descriptor()
+}
+
+func (EntityType) Type() protoreflect.EnumType {
+	return &file_api_customers_v1_customers_proto_enumTypes[2]
+}
+
+func (x EntityType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntityType.Descriptor instead.
+func (EntityType) EnumDescriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{2}
+}
+
+// How far the customer has got with identity verification.
+type KYCStatus int32
+
+const (
+	KYCStatus_KYC_STATUS_UNSPECIFIED     KYCStatus = 0
+	KYCStatus_KYC_STATUS_UNVERIFIED      KYCStatus = 1
+	KYCStatus_KYC_STATUS_PENDING         KYCStatus = 2
+	KYCStatus_KYC_STATUS_VERIFIED        KYCStatus = 3
+	KYCStatus_KYC_STATUS_FAILED          KYCStatus = 4
+	KYCStatus_KYC_STATUS_REVIEW_REQUIRED KYCStatus = 5
+)
+
+// Enum value maps for KYCStatus.
+var (
+	KYCStatus_name = map[int32]string{
+		0: "KYC_STATUS_UNSPECIFIED",
+		1: "KYC_STATUS_UNVERIFIED",
+		2: "KYC_STATUS_PENDING",
+		3: "KYC_STATUS_VERIFIED",
+		4: "KYC_STATUS_FAILED",
+		5: "KYC_STATUS_REVIEW_REQUIRED",
+	}
+	KYCStatus_value = map[string]int32{
+		"KYC_STATUS_UNSPECIFIED":     0,
+		"KYC_STATUS_UNVERIFIED":      1,
+		"KYC_STATUS_PENDING":         2,
+		"KYC_STATUS_VERIFIED":        3,
+		"KYC_STATUS_FAILED":          4,
+		"KYC_STATUS_REVIEW_REQUIRED": 5,
+	}
+)
+
+func (x KYCStatus) Enum() *KYCStatus {
+	p := new(KYCStatus)
+	*p = x
+	return p
+}
+
+func (x KYCStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KYCStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customers_v1_customers_proto_enumTypes[3].Descriptor()
+}
+
+func (KYCStatus) Type() protoreflect.EnumType {
+	return &file_api_customers_v1_customers_proto_enumTypes[3]
+}
+
+func (x KYCStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KYCStatus.Descriptor instead.
+func (KYCStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{3}
+}
+
+// The result of the customer's latest sanctions screening.
+type ScreeningStatus int32
+
+const (
+	ScreeningStatus_SCREENING_STATUS_UNSPECIFIED ScreeningStatus = 0
+	ScreeningStatus_SCREENING_STATUS_CLEAR       ScreeningStatus = 1
+	ScreeningStatus_SCREENING_STATUS_FLAGGED     ScreeningStatus = 2
+	// Only set by rescreening, when a new version of a list matches an existing customer.
+	ScreeningStatus_SCREENING_STATUS_BLOCKED ScreeningStatus = 3
+)
+
+// Enum value maps for ScreeningStatus.
+var (
+	ScreeningStatus_name = map[int32]string{
+		0: "SCREENING_STATUS_UNSPECIFIED",
+		1: "SCREENING_STATUS_CLEAR",
+		2: "SCREENING_STATUS_FLAGGED",
+		3: "SCREENING_STATUS_BLOCKED",
+	}
+	ScreeningStatus_value = map[string]int32{
+		"SCREENING_STATUS_UNSPECIFIED": 0,
+		"SCREENING_STATUS_CLEAR":       1,
+		"SCREENING_STATUS_FLAGGED":     2,
+		"SCREENING_STATUS_BLOCKED":     3,
+	}
+)
+
+func (x ScreeningStatus) Enum() *ScreeningStatus {
+	p := new(ScreeningStatus)
+	*p = x
+	return p
+}
+
+func (x ScreeningStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScreeningStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customers_v1_customers_proto_enumTypes[4].Descriptor()
+}
+
+func (ScreeningStatus) Type() protoreflect.EnumType {
+	return &file_api_customers_v1_customers_proto_enumTypes[4]
+}
+
+func (x ScreeningStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScreeningStatus.Descriptor instead.
+func (ScreeningStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{4}
+}
+
+type CustomerChange_Type int32
+
+const (
+	CustomerChange_TYPE_UNSPECIFIED CustomerChange_Type = 0
+	CustomerChange_CREATED          CustomerChange_Type = 1
+	CustomerChange_UPDATED          CustomerChange_Type = 2
+	CustomerChange_DISABLED         CustomerChange_Type = 3
+	// The customer's personal data was erased, copies of it should be erased too.
+	CustomerChange_ERASED CustomerChange_Type = 4
+)
+
+// Enum value maps for CustomerChange_Type.
+var (
+	CustomerChange_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "CREATED",
+		2: "UPDATED",
+		3: "DISABLED",
+		4: "ERASED",
+	}
+	CustomerChange_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"CREATED":          1,
+		"UPDATED":          2,
+		"DISABLED":         3,
+		"ERASED":           4,
+	}
+)
+
+func (x CustomerChange_Type) Enum() *CustomerChange_Type {
+	p := new(CustomerChange_Type)
+	*p = x
+	return p
+}
+
+func (x CustomerChange_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CustomerChange_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customers_v1_customers_proto_enumTypes[5].Descriptor()
+}
+
+func (CustomerChange_Type) Type() protoreflect.EnumType {
+	return &file_api_customers_v1_customers_proto_enumTypes[5]
+}
+
+func (x CustomerChange_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CustomerChange_Type.Descriptor instead.
+func (CustomerChange_Type) EnumDescriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{11, 0}
+}
+
+// Read only fields are ignored when sent in a request.
+type Customer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// UUID v4, read only.
+	TenantId string `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// UUID v4, read only.
+	CustomerId string `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Name       string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Formatted as YYYY/MM/DD.
+	BirthDate *string `protobuf:"bytes,4,opt,name=birth_date,json=birthDate,proto3,oneof" json:"birth_date,omitempty"`
+	Email     string  `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	// Deprecated, an individual's SSN is taken as its tax ID of type SSN and it's never returned.
+	//
+	// Deprecated: Marked as deprecated in api/customers/v1/customers.proto.
+	Ssn string `protobuf:"bytes,6,opt,name=ssn,proto3" json:"ssn,omitempty"`
+	// Read only.
+	CreatedOn *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	// Read only.
+	UpdatedOn *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	// Read only, unset until the customer is disabled.
+	DisabledOn *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=disabled_on,json=disabledOn,proto3" json:"disabled_on,omitempty"`
+	// Read only, changed through the REST API's KYC transitions.
+	KycStatus KYCStatus `protobuf:"varint,10,opt,name=kyc_status,json=kycStatus,proto3,enum=backendhiring.customers.v1.KYCStatus" json:"kyc_status,omitempty"`
+	// Read only, unspecified until the customer has been screened against the sanctions lists.
+	ScreeningStatus ScreeningStatus `protobuf:"varint,11,opt,name=screening_status,json=screeningStatus,proto3,enum=backendhiring.customers.v1.ScreeningStatus" json:"screening_status,omitempty"`
+	// Read only, unset until the customer's risk has been scored.
+	Risk *RiskScore `protobuf:"bytes,12,opt,name=risk,proto3" json:"risk,omitempty"`
+	// Individual when unspecified, it can't be changed once the customer is created.
+	Type CustomerType `protobuf:"varint,13,opt,name=type,proto3,enum=backendhiring.customers.v1.CustomerType" json:"type,omitempty"`
+	// Required for businesses and only allowed for them, they have no birth date or tax ID.
+	Business *Business `protobuf:"bytes,14,opt,name=business,proto3" json:"business,omitempty"`
+	// Required for individuals and only allowed for them, businesses are identified by their EIN.
+	TaxId *TaxID `protobuf:"bytes,15,opt,name=tax_id,json=taxId,proto3" json:"tax_id,omitempty"`
+}
+
+func (x *Customer) Reset() {
+	*x = Customer{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Customer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Customer) ProtoMessage() {}
+
+func (x *Customer) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Customer.ProtoReflect.Descriptor instead.
+func (*Customer) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Customer) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Customer) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Customer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Customer) GetBirthDate() string {
+	if x != nil && x.BirthDate != nil {
+		return *x.BirthDate
+	}
+	return ""
+}
+
+func (x *Customer) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in api/customers/v1/customers.proto.
+func (x *Customer) GetSsn() string {
+	if x != nil {
+		return x.Ssn
+	}
+	return ""
+}
+
+func (x *Customer) GetCreatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return nil
+}
+
+func (x *Customer) GetUpdatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return nil
+}
+
+func (x *Customer) GetDisabledOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisabledOn
+	}
+	return nil
+}
+
+func (x *Customer) GetKycStatus() KYCStatus {
+	if x != nil {
+		return x.KycStatus
+	}
+	return KYCStatus_KYC_STATUS_UNSPECIFIED
+}
+
+func (x *Customer) GetScreeningStatus() ScreeningStatus {
+	if x != nil {
+		return x.ScreeningStatus
+	}
+	return ScreeningStatus_SCREENING_STATUS_UNSPECIFIED
+}
+
+func (x *Customer) GetRisk() *RiskScore {
+	if x != nil {
+		return x.Risk
+	}
+	return nil
+}
+
+func (x *Customer) GetType() CustomerType {
+	if x != nil {
+		return x.Type
+	}
+	return CustomerType_CUSTOMER_TYPE_UNSPECIFIED
+}
+
+func (x *Customer) GetBusiness() *Business {
+	if x != nil {
+		return x.Business
+	}
+	return nil
+}
+
+func (x *Customer) GetTaxId() *TaxID {
+	if x != nil {
+		return x.TaxId
+	}
+	return nil
+}
+
+// How an individual customer is identified for tax purposes.
+type TaxID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type TaxIDType `protobuf:"varint,1,opt,name=type,proto3,enum=backendhiring.customers.v1.TaxIDType" json:"type,omitempty"`
+	// Write only, formatted NNN-NN-NNNN for SSNs and ITINs and NN-NNNNNNN for EINs. Left empty on an update to keep
+	// the current value.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// ISO 3166-1 alpha-2 code of the country that issued a foreign TIN, only for them.
+	Country string `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	// Read only, the value with all but its last four characters hidden.
+	Masked string `protobuf:"bytes,4,opt,name=masked,proto3" json:"masked,omitempty"`
+}
+
+func (x *TaxID) Reset() {
+	*x = TaxID{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxID) ProtoMessage() {}
+
+func (x *TaxID) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxID.ProtoReflect.Descriptor instead.
+func (*TaxID) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TaxID) GetType() TaxIDType {
+	if x != nil {
+		return x.Type
+	}
+	return TaxIDType_TAX_ID_TYPE_UNSPECIFIED
+}
+
+func (x *TaxID) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TaxID) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *TaxID) GetMasked() string {
+	if x != nil {
+		return x.Masked
+	}
+	return ""
+}
+
+// What a business customer is registered as.
+type Business struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LegalName string `protobuf:"bytes,1,opt,name=legal_name,json=legalName,proto3" json:"legal_name,omitempty"`
+	// The name the business trades under, when it isn't its legal name.
+	Dba string `protobuf:"bytes,2,opt,name=dba,proto3" json:"dba,omitempty"`
+	// IRS employer identification number, formatted as NN-NNNNNNN.
+	Ein        string     `protobuf:"bytes,3,opt,name=ein,proto3" json:"ein,omitempty"`
+	EntityType EntityType `protobuf:"varint,4,opt,name=entity_type,json=entityType,proto3,enum=backendhiring.customers.v1.EntityType" json:"entity_type,omitempty"`
+	// Formatted as YYYY/MM/DD.
+	FormationDate *string `protobuf:"bytes,5,opt,name=formation_date,json=formationDate,proto3,oneof" json:"formation_date,omitempty"`
+	// Two letter USPS code, not needed for sole proprietorships.
+	IncorporationState string `protobuf:"bytes,6,opt,name=incorporation_state,json=incorporationState,proto3" json:"incorporation_state,omitempty"`
+}
+
+func (x *Business) Reset() {
+	*x = Business{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Business) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Business) ProtoMessage() {}
+
+func (x *Business) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Business.ProtoReflect.Descriptor instead.
+func (*Business) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Business) GetLegalName() string {
+	if x != nil {
+		return x.LegalName
+	}
+	return ""
+}
+
+func (x *Business) GetDba() string {
+	if x != nil {
+		return x.Dba
+	}
+	return ""
+}
+
+func (x *Business) GetEin() string {
+	if x != nil {
+		return x.Ein
+	}
+	return ""
+}
+
+func (x *Business) GetEntityType() EntityType {
+	if x != nil {
+		return x.EntityType
+	}
+	return EntityType_ENTITY_TYPE_UNSPECIFIED
+}
+
+func (x *Business) GetFormationDate() string {
+	if x != nil && x.FormationDate != nil {
+		return *x.FormationDate
+	}
+	return ""
+}
+
+func (x *Business) GetIncorporationState() string {
+	if x != nil {
+		return x.IncorporationState
+	}
+	return ""
+}
+
+// The customer's risk under a version of the risk rules.
+type RiskScore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Score int32  `protobuf:"varint,1,opt,name=score,proto3" json:"score,omitempty"`
+	Tier  string `protobuf:"bytes,2,opt,name=tier,proto3" json:"tier,omitempty"`
+	// The rules that matched.
+	Factors      []*RiskFactor          `protobuf:"bytes,3,rep,name=factors,proto3" json:"factors,omitempty"`
+	RulesVersion string                 `protobuf:"bytes,4,opt,name=rules_version,json=rulesVersion,proto3" json:"rules_version,omitempty"`
+	ScoredOn     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=scored_on,json=scoredOn,proto3" json:"scored_on,omitempty"`
+}
+
+func (x *RiskScore) Reset() {
+	*x = RiskScore{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RiskScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RiskScore) ProtoMessage() {}
+
+func (x *RiskScore) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RiskScore.ProtoReflect.Descriptor instead.
+func (*RiskScore) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RiskScore) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RiskScore) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *RiskScore) GetFactors() []*RiskFactor {
+	if x != nil {
+		return x.Factors
+	}
+	return nil
+}
+
+func (x *RiskScore) GetRulesVersion() string {
+	if x != nil {
+		return x.RulesVersion
+	}
+	return ""
+}
+
+func (x *RiskScore) GetScoredOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScoredOn
+	}
+	return nil
+}
+
+type RiskFactor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Score int32  `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *RiskFactor) Reset() {
+	*x = RiskFactor{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RiskFactor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RiskFactor) ProtoMessage() {}
+
+func (x *RiskFactor) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RiskFactor.ProtoReflect.Descriptor instead.
+func (*RiskFactor) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RiskFactor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RiskFactor) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type CreateCustomerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Customer *Customer `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+}
+
+func (x *CreateCustomerRequest) Reset() {
+	*x = CreateCustomerRequest{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomerRequest) ProtoMessage() {}
+
+func (x *CreateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*CreateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateCustomerRequest) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type ListCustomersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only customers with this status, unspecified lists them all.
+	KycStatus KYCStatus `protobuf:"varint,1,opt,name=kyc_status,json=kycStatus,proto3,enum=backendhiring.customers.v1.KYCStatus" json:"kyc_status,omitempty"`
+}
+
+func (x *ListCustomersRequest) Reset() {
+	*x = ListCustomersRequest{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomersRequest) ProtoMessage() {}
+
+func (x *ListCustomersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomersRequest.ProtoReflect.Descriptor instead.
+func (*ListCustomersRequest) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListCustomersRequest) GetKycStatus() KYCStatus {
+	if x != nil {
+		return x.KycStatus
+	}
+	return KYCStatus_KYC_STATUS_UNSPECIFIED
+}
+
+type ListCustomersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Customers []*Customer `protobuf:"bytes,1,rep,name=customers,proto3" json:"customers,omitempty"`
+}
+
+func (x *ListCustomersResponse) Reset() {
+	*x = ListCustomersResponse{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomersResponse) ProtoMessage() {}
+
+func (x *ListCustomersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomersResponse.ProtoReflect.Descriptor instead.
+func (*ListCustomersResponse) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListCustomersResponse) GetCustomers() []*Customer {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+type GetCustomerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CustomerId string `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+}
+
+func (x *GetCustomerRequest) Reset() {
+	*x = GetCustomerRequest{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerRequest) ProtoMessage() {}
+
+func (x *GetCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type UpdateCustomerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CustomerId string    `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Customer   *Customer `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty"`
+}
+
+func (x *UpdateCustomerRequest) Reset() {
+	*x = UpdateCustomerRequest{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCustomerRequest) ProtoMessage() {}
+
+func (x *UpdateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *UpdateCustomerRequest) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type DeleteCustomerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CustomerId string `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+}
+
+func (x *DeleteCustomerRequest) Reset() {
+	*x = DeleteCustomerRequest{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomerRequest) ProtoMessage() {}
+
+func (x *DeleteCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type CustomerChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sequence  int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type      CustomerChange_Type    `protobuf:"varint,2,opt,name=type,proto3,enum=backendhiring.customers.v1.CustomerChange_Type" json:"type,omitempty"`
+	ChangedOn *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=changed_on,json=changedOn,proto3" json:"changed_on,omitempty"`
+	// The customer as it is now, not as it was when the change was made.
+	Customer *Customer `protobuf:"bytes,4,opt,name=customer,proto3" json:"customer,omitempty"`
+}
+
+func (x *CustomerChange) Reset() {
+	*x = CustomerChange{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerChange) ProtoMessage() {}
+
+func (x *CustomerChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerChange.ProtoReflect.Descriptor instead.
+func (*CustomerChange) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CustomerChange) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *CustomerChange) GetType() CustomerChange_Type {
+	if x != nil {
+		return x.Type
+	}
+	return CustomerChange_TYPE_UNSPECIFIED
+}
+
+func (x *CustomerChange) GetChangedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedOn
+	}
+	return nil
+}
+
+func (x *CustomerChange) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type ListCustomerChangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Cursor from a previous response, empty to start from the first change.
+	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Between 1 and 1000, defaults to 100.
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListCustomerChangesRequest) Reset() {
+	*x = ListCustomerChangesRequest{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomerChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomerChangesRequest) ProtoMessage() {}
+
+func (x *ListCustomerChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomerChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListCustomerChangesRequest) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListCustomerChangesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListCustomerChangesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListCustomerChangesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*CustomerChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	// Pass back to get the changes after this page, set even when the page is empty.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// More changes are available right away.
+	HasMore bool `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+}
+
+func (x *ListCustomerChangesResponse) Reset() {
+	*x = ListCustomerChangesResponse{}
+	mi := &file_api_customers_v1_customers_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomerChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomerChangesResponse) ProtoMessage() {}
+
+func (x *ListCustomerChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customers_v1_customers_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomerChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListCustomerChangesResponse) Descriptor() ([]byte, []int) {
+	return file_api_customers_v1_customers_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListCustomerChangesResponse) GetChanges() []*CustomerChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ListCustomerChangesResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListCustomerChangesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+var File_api_customers_v1_customers_proto protoreflect.FileDescriptor
+
+var file_api_customers_v1_customers_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2f,
+	0x76, 0x31, 0x2f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x1a, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e,
+	0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1b,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x81, 0x06, 0x0a,
+	0x08, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65,
+	0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0a, 0x62,
+	0x69, 0x72, 0x74, 0x68, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x09, 0x62, 0x69, 0x72, 0x74, 0x68, 0x44, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x14, 0x0a, 0x03, 0x73, 0x73, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x03, 0x73, 0x73, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x4f,
+	0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x5f, 0x6f, 0x6e,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x4f, 0x6e, 0x12, 0x44,
+	0x0a, 0x0a, 0x6b, 0x79, 0x63, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x25, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69,
+	0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4b, 0x59, 0x43, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x09, 0x6b, 0x79, 0x63, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x56, 0x0a, 0x10, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x69, 0x6e,
+	0x67, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b,
+	0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x72, 0x65,
+	0x65, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0f, 0x73, 0x63, 0x72,
+	0x65, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x04,
+	0x72, 0x69, 0x73, 0x6b, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x73, 0x6b, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x52, 0x04, 0x72, 0x69, 0x73, 0x6b, 0x12, 0x3c, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68,
+	0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x40, 0x0a, 0x08, 0x62, 0x75, 0x73, 0x69, 0x6e, 0x65, 0x73,
+	0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x73, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x08, 0x62,
+	0x75, 0x73, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x38, 0x0a, 0x06, 0x74, 0x61, 0x78, 0x5f, 0x69,
+	0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x78, 0x49, 0x44, 0x52, 0x05, 0x74, 0x61, 0x78, 0x49,
+	0x64, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x62, 0x69, 0x72, 0x74, 0x68, 0x5f, 0x64, 0x61, 0x74, 0x65,
+	0x22, 0x8a, 0x01, 0x0a, 0x05, 0x54, 0x61, 0x78, 0x49, 0x44, 0x12, 0x39, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x78, 0x49, 0x44, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x73, 0x6b, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x73, 0x6b, 0x65, 0x64, 0x22, 0x86, 0x02,
+	0x0a, 0x08, 0x42, 0x75, 0x73, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65,
+	0x67, 0x61, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6c, 0x65, 0x67, 0x61, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x62, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x62, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x65,
+	0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x69, 0x6e, 0x12, 0x47, 0x0a,
+	0x0b, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x26, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69,
+	0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x0e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x0d, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x2f, 0x0a, 0x13, 0x69, 0x6e, 0x63, 0x6f, 0x72, 0x70, 0x6f, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x12, 0x69, 0x6e, 0x63, 0x6f, 0x72, 0x70, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x22, 0xd5, 0x01, 0x0a, 0x09, 0x52, 0x69, 0x73, 0x6b, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x69, 0x65, 0x72, 0x12, 0x40,
+	0x0a, 0x07, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x26, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e,
+	0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x73,
+	0x6b, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x07, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x64, 0x5f,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x64, 0x4f, 0x6e, 0x22, 0x36,
+	0x0a, 0x0a, 0x52, 0x69, 0x73, 0x6b, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x59, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x40, 0x0a, 0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e,
+	0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x52, 0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x22, 0x5c, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x0a, 0x6b, 0x79, 0x63,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e,
+	0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x59, 0x43, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x09, 0x6b, 0x79, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
+	0x5b, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x62, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x52, 0x09, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x22, 0x35, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0x7a, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12, 0x40, 0x0a,
+	0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e,
+	0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x52, 0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x22,
+	0x38, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x22, 0xc0, 0x02, 0x0a, 0x0e, 0x43, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2f, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x39, 0x0a,
+	0x0a, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x4f, 0x6e, 0x12, 0x40, 0x0a, 0x08, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x52, 0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x22, 0x50, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52, 0x45, 0x41,
+	0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44,
+	0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x03,
+	0x12, 0x0a, 0x0a, 0x06, 0x45, 0x52, 0x41, 0x53, 0x45, 0x44, 0x10, 0x04, 0x22, 0x4a, 0x0a, 0x1a,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x1b, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x62, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x5f, 0x6d, 0x6f,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4d, 0x6f, 0x72,
+	0x65, 0x2a, 0x67, 0x0a, 0x0c, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x55, 0x53, 0x54, 0x4f, 0x4d, 0x45, 0x52, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x1c, 0x0a, 0x18, 0x43, 0x55, 0x53, 0x54, 0x4f, 0x4d, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x49, 0x4e, 0x44, 0x49, 0x56, 0x49, 0x44, 0x55, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x1a,
+	0x0a, 0x16, 0x43, 0x55, 0x53, 0x54, 0x4f, 0x4d, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x42, 0x55, 0x53, 0x49, 0x4e, 0x45, 0x53, 0x53, 0x10, 0x02, 0x2a, 0x85, 0x01, 0x0a, 0x09, 0x54,
+	0x61, 0x78, 0x49, 0x44, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41, 0x58, 0x5f,
+	0x49, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x41, 0x58, 0x5f, 0x49, 0x44, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x53, 0x4e, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x41,
+	0x58, 0x5f, 0x49, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x54, 0x49, 0x4e, 0x10, 0x02,
+	0x12, 0x13, 0x0a, 0x0f, 0x54, 0x41, 0x58, 0x5f, 0x49, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x45, 0x49, 0x4e, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41, 0x58, 0x5f, 0x49, 0x44, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x4f, 0x52, 0x45, 0x49, 0x47, 0x4e, 0x5f, 0x54, 0x49, 0x4e,
+	0x10, 0x04, 0x2a, 0xcf, 0x01, 0x0a, 0x0a, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x23,
+	0x0a, 0x1f, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4f,
+	0x4c, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x50, 0x52, 0x49, 0x45, 0x54, 0x4f, 0x52, 0x53, 0x48, 0x49,
+	0x50, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x50, 0x41, 0x52, 0x54, 0x4e, 0x45, 0x52, 0x53, 0x48, 0x49, 0x50, 0x10, 0x02,
+	0x12, 0x13, 0x0a, 0x0f, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x4c, 0x4c, 0x43, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x52, 0x50, 0x4f, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x10, 0x04, 0x12, 0x19, 0x0a, 0x15, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x4e, 0x4f, 0x4e, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x54, 0x10, 0x05, 0x12, 0x15, 0x0a,
+	0x11, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x52, 0x55,
+	0x53, 0x54, 0x10, 0x06, 0x2a, 0xaa, 0x01, 0x0a, 0x09, 0x4b, 0x59, 0x43, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1a, 0x0a, 0x16, 0x4b, 0x59, 0x43, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19,
+	0x0a, 0x15, 0x4b, 0x59, 0x43, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x56,
+	0x45, 0x52, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x4b, 0x59, 0x43,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10,
+	0x02, 0x12, 0x17, 0x0a, 0x13, 0x4b, 0x59, 0x43, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x56, 0x45, 0x52, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x4b, 0x59,
+	0x43, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10,
+	0x04, 0x12, 0x1e, 0x0a, 0x1a, 0x4b, 0x59, 0x43, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x52, 0x45, 0x56, 0x49, 0x45, 0x57, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x44, 0x10,
+	0x05, 0x2a, 0x8b, 0x01, 0x0a, 0x0f, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x1c, 0x53, 0x43, 0x52, 0x45, 0x45, 0x4e, 0x49,
+	0x4e, 0x47, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x43, 0x52, 0x45, 0x45,
+	0x4e, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4c, 0x45, 0x41,
+	0x52, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x43, 0x52, 0x45, 0x45, 0x4e, 0x49, 0x4e, 0x47,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x4c, 0x41, 0x47, 0x47, 0x45, 0x44, 0x10,
+	0x02, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x43, 0x52, 0x45, 0x45, 0x4e, 0x49, 0x4e, 0x47, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x03, 0x32,
+	0xa8, 0x05, 0x0a, 0x0f, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x69, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x31, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68,
+	0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x74,
+	0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x12,
+	0x30, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e,
+	0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x31, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e,
+	0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x65, 0x72, 0x12, 0x2e, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72,
+	0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72,
+	0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x69, 0x0a, 0x0e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x31, 0x2e, 0x62, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24,
+	0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x12, 0x5b, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x31, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x12, 0x86, 0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x36, 0x2e, 0x62, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x37, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69, 0x72, 0x69, 0x6e,
+	0x67, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x42, 0x5a, 0x40, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x6f, 0x6f, 0x76, 0x66, 0x69, 0x6e,
+	0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x68, 0x69,
+	0x72, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x73, 0x2f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_customers_v1_customers_proto_rawDescOnce sync.Once
+	file_api_customers_v1_customers_proto_rawDescData = file_api_customers_v1_customers_proto_rawDesc
+)
+
+func file_api_customers_v1_customers_proto_rawDescGZIP() []byte {
+	file_api_customers_v1_customers_proto_rawDescOnce.Do(func() {
+		file_api_customers_v1_customers_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_customers_v1_customers_proto_rawDescData)
+	})
+	return file_api_customers_v1_customers_proto_rawDescData
+}
+
+var file_api_customers_v1_customers_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_api_customers_v1_customers_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_api_customers_v1_customers_proto_goTypes = []any{
+	(CustomerType)(0),                   // 0: backendhiring.customers.v1.CustomerType
+	(TaxIDType)(0),                      // 1: backendhiring.customers.v1.TaxIDType
+	(EntityType)(0),                     // 2: backendhiring.customers.v1.EntityType
+	(KYCStatus)(0),                      // 3: backendhiring.customers.v1.KYCStatus
+	(ScreeningStatus)(0),                // 4: backendhiring.customers.v1.ScreeningStatus
+	(CustomerChange_Type)(0),            // 5: backendhiring.customers.v1.CustomerChange.Type
+	(*Customer)(nil),                    // 6: backendhiring.customers.v1.Customer
+	(*TaxID)(nil),                       // 7: backendhiring.customers.v1.TaxID
+	(*Business)(nil),                    // 8: backendhiring.customers.v1.Business
+	(*RiskScore)(nil),                   // 9: backendhiring.customers.v1.RiskScore
+	(*RiskFactor)(nil),                  // 10: backendhiring.customers.v1.RiskFactor
+	(*CreateCustomerRequest)(nil),       // 11: backendhiring.customers.v1.CreateCustomerRequest
+	(*ListCustomersRequest)(nil),        // 12: backendhiring.customers.v1.ListCustomersRequest
+	(*ListCustomersResponse)(nil),       // 13: backendhiring.customers.v1.ListCustomersResponse
+	(*GetCustomerRequest)(nil),          // 14: backendhiring.customers.v1.GetCustomerRequest
+	(*UpdateCustomerRequest)(nil),       // 15: backendhiring.customers.v1.UpdateCustomerRequest
+	(*DeleteCustomerRequest)(nil),       // 16: backendhiring.customers.v1.DeleteCustomerRequest
+	(*CustomerChange)(nil),              // 17: backendhiring.customers.v1.CustomerChange
+	(*ListCustomerChangesRequest)(nil),  // 18: backendhiring.customers.v1.ListCustomerChangesRequest
+	(*ListCustomerChangesResponse)(nil), // 19: backendhiring.customers.v1.ListCustomerChangesResponse
+	(*timestamppb.Timestamp)(nil),       // 20: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),               // 21: google.protobuf.Empty
+}
+var file_api_customers_v1_customers_proto_depIdxs = []int32{
+	20, // 0: backendhiring.customers.v1.Customer.created_on:type_name -> google.protobuf.Timestamp
+	20, // 1: backendhiring.customers.v1.Customer.updated_on:type_name -> google.protobuf.Timestamp
+	20, // 2: backendhiring.customers.v1.Customer.disabled_on:type_name -> google.protobuf.Timestamp
+	3,  // 3: backendhiring.customers.v1.Customer.kyc_status:type_name -> backendhiring.customers.v1.KYCStatus
+	4,  // 4: backendhiring.customers.v1.Customer.screening_status:type_name -> backendhiring.customers.v1.ScreeningStatus
+	9,  // 5: backendhiring.customers.v1.Customer.risk:type_name -> backendhiring.customers.v1.RiskScore
+	0,  // 6: backendhiring.customers.v1.Customer.type:type_name -> backendhiring.customers.v1.CustomerType
+	8,  // 7: backendhiring.customers.v1.Customer.business:type_name -> backendhiring.customers.v1.Business
+	7,  // 8: backendhiring.customers.v1.Customer.tax_id:type_name -> backendhiring.customers.v1.TaxID
+	1,  // 9: backendhiring.customers.v1.TaxID.type:type_name -> backendhiring.customers.v1.TaxIDType
+	2,  // 10: backendhiring.customers.v1.Business.entity_type:type_name -> backendhiring.customers.v1.EntityType
+	10, // 11: backendhiring.customers.v1.RiskScore.factors:type_name -> backendhiring.customers.v1.RiskFactor
+	20, // 12: backendhiring.customers.v1.RiskScore.scored_on:type_name -> google.protobuf.Timestamp
+	6,  // 13: backendhiring.customers.v1.CreateCustomerRequest.customer:type_name -> backendhiring.customers.v1.Customer
+	3,  // 14: backendhiring.customers.v1.ListCustomersRequest.kyc_status:type_name -> backendhiring.customers.v1.KYCStatus
+	6,  // 15: backendhiring.customers.v1.ListCustomersResponse.customers:type_name -> backendhiring.customers.v1.Customer
+	6,  // 16: backendhiring.customers.v1.UpdateCustomerRequest.customer:type_name -> backendhiring.customers.v1.Customer
+	5,  // 17: backendhiring.customers.v1.CustomerChange.type:type_name -> backendhiring.customers.v1.CustomerChange.Type
+	20, // 18: backendhiring.customers.v1.CustomerChange.changed_on:type_name -> google.protobuf.Timestamp
+	6,  // 19: backendhiring.customers.v1.CustomerChange.customer:type_name -> backendhiring.customers.v1.Customer
+	17, // 20: backendhiring.customers.v1.ListCustomerChangesResponse.changes:type_name -> backendhiring.customers.v1.CustomerChange
+	11, // 21: backendhiring.customers.v1.CustomerService.CreateCustomer:input_type -> backendhiring.customers.v1.CreateCustomerRequest
+	12, // 22: backendhiring.customers.v1.CustomerService.ListCustomers:input_type -> backendhiring.customers.v1.ListCustomersRequest
+	14, // 23: backendhiring.customers.v1.CustomerService.GetCustomer:input_type -> backendhiring.customers.v1.GetCustomerRequest
+	15, // 24: backendhiring.customers.v1.CustomerService.UpdateCustomer:input_type -> backendhiring.customers.v1.UpdateCustomerRequest
+	16, // 25: backendhiring.customers.v1.CustomerService.DeleteCustomer:input_type -> backendhiring.customers.v1.DeleteCustomerRequest
+	18, // 26: backendhiring.customers.v1.CustomerService.ListCustomerChanges:input_type -> backendhiring.customers.v1.ListCustomerChangesRequest
+	6,  // 27: backendhiring.customers.v1.CustomerService.CreateCustomer:output_type -> backendhiring.customers.v1.Customer
+	13, // 28: backendhiring.customers.v1.CustomerService.ListCustomers:output_type -> backendhiring.customers.v1.ListCustomersResponse
+	6,  // 29: backendhiring.customers.v1.CustomerService.GetCustomer:output_type -> backendhiring.customers.v1.Customer
+	6,  // 30: backendhiring.customers.v1.CustomerService.UpdateCustomer:output_type -> backendhiring.customers.v1.Customer
+	21, // 31: backendhiring.customers.v1.CustomerService.DeleteCustomer:output_type -> google.protobuf.Empty
+	19, // 32: backendhiring.customers.v1.CustomerService.ListCustomerChanges:output_type -> backendhiring.customers.v1.ListCustomerChangesResponse
+	27, // [27:33] is the sub-list for method output_type
+	21, // [21:27] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_api_customers_v1_customers_proto_init() }
+func file_api_customers_v1_customers_proto_init() {
+	if File_api_customers_v1_customers_proto != nil {
+		return
+	}
+	file_api_customers_v1_customers_proto_msgTypes[0].OneofWrappers = []any{}
+	file_api_customers_v1_customers_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_customers_v1_customers_proto_rawDesc,
+			NumEnums:      6,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_customers_v1_customers_proto_goTypes,
+		DependencyIndexes: file_api_customers_v1_customers_proto_depIdxs,
+		EnumInfos:         file_api_customers_v1_customers_proto_enumTypes,
+		MessageInfos:      file_api_customers_v1_customers_proto_msgTypes,
+	}.Build()
+	File_api_customers_v1_customers_proto = out.File
+	file_api_customers_v1_customers_proto_rawDesc = nil
+	file_api_customers_v1_customers_proto_goTypes = nil
+	file_api_customers_v1_customers_proto_depIdxs = nil
+}
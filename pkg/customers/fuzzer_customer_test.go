@@ -14,14 +14,16 @@ func NewTestCustomer(times stime.TimeService) customers.Customer {
 		times = stime.NewStaticTimeService()
 	}
 
-	ssn := fmt.Sprintf("%d-%d-%d", ((rand.Int() % 899) + 100), ((rand.Int() % 89) + 10), ((rand.Int() % 8999) + 1000))
+	// Areas from 666 up aren't issued.
+	ssn := fmt.Sprintf("%d-%d-%d", ((rand.Int() % 565) + 100), ((rand.Int() % 89) + 10), ((rand.Int() % 8999) + 1000))
 
 	bd := times.Now().Format("2006/02/01")
 	return customers.Customer{
 		TenantID:   uuid.NewString(),
 		CustomerID: uuid.NewString(),
+		Type:       customers.CustomerIndividual,
 		Name:       "Joe J Doe",
-		Ssn:        ssn,
+		TaxID:      &customers.TaxID{Type: customers.TaxIDSSN, Value: ssn},
 		Email:      "john.doe@moov.io",
 		BirthDate:  &bd,
 	}
@@ -0,0 +1,130 @@
+package customers
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type AddressType string
+
+const (
+	// AddressResidential - Where the customer lives, CIP requires one and it can't be a PO box.
+	AddressResidential AddressType = "residential"
+	AddressMailing     AddressType = "mailing"
+)
+
+// CountryUS - The only country addresses can be in for now.
+const CountryUS = "US"
+
+// Address - One of a customer's addresses. Primary marks the address used for its type, there's at most one.
+type Address struct {
+	TenantID   string      `json:"tenantID,omitempty"`
+	CustomerID string      `json:"customerID,omitempty"`
+	AddressID  string      `json:"addressID,omitempty"`
+	Type       AddressType `json:"type"`
+	Primary    bool        `json:"primary"`
+	Line1      string      `json:"line1"`
+	Line2      string      `json:"line2,omitempty"`
+	City       string      `json:"city"`
+	// State is the two letter USPS code, full names are converted to it.
+	State string `json:"state"`
+	// PostalCode is a ZIP or ZIP+4 code, nine digits without the dash are accepted too.
+	PostalCode string    `json:"postalCode"`
+	Country    string    `json:"country"`
+	CreatedOn  time.Time `json:"createdOn,omitempty"`
+	UpdatedOn  time.Time `json:"updatedOn,omitempty"`
+}
+
+var (
+	zipCode  = regexp.MustCompile(`^\d{5}(-\d{4})?$`)
+	zipPlus4 = regexp.MustCompile(`^(\d{5})-?(\d{4})$`)
+	spaces   = regexp.MustCompile(`\s+`)
+
+	// poBox - "PO Box 12", "P.O. Box", "POB 12", "Post Office Box" and the like.
+	poBox = regexp.MustCompile(`(?i)\b(p\.?\s*o\.?\s*b(ox)?|post\s+office\s+box)\b`)
+)
+
+// ErrPOBox - Residential addresses have to be somewhere a person can live.
+var ErrPOBox = errors.New("must not be a PO box for a residential address")
+
+// Normalize - Tidies the address into its standard form before it's validated: whitespace collapsed, state names
+// converted to USPS codes, ZIP+4 codes dashed and the country defaulted to US.
+func (a *Address) Normalize() {
+	for _, field := range []*string{&a.Line1, &a.Line2, &a.City, &a.State, &a.PostalCode, &a.Country} {
+		*field = spaces.ReplaceAllString(strings.TrimSpace(*field), " ")
+	}
+
+	a.State = strings.ToUpper(a.State)
+	if code, found := stateNames[a.State]; found {
+		a.State = code
+	}
+
+	if match := zipPlus4.FindStringSubmatch(a.PostalCode); match != nil {
+		a.PostalCode = match[1] + "-" + match[2]
+	}
+
+	a.Country = strings.ToUpper(a.Country)
+	if a.Country == "" || a.Country == "USA" {
+		a.Country = CountryUS
+	}
+}
+
+// Validate - Checks a normalized address, keep in sync with the Address schema in api/openapi.yaml.
+func (a Address) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Type, validation.Required, validation.In(AddressResidential, AddressMailing)),
+		validation.Field(&a.Line1, validation.Required, validation.Length(1, 100),
+			validation.When(a.Type == AddressResidential, validation.By(notPOBox))),
+		validation.Field(&a.Line2, validation.Length(0, 100),
+			validation.When(a.Type == AddressResidential, validation.By(notPOBox))),
+		validation.Field(&a.City, validation.Required, validation.Length(1, 100)),
+		validation.Field(&a.State, validation.Required, validation.By(stateCode)),
+		validation.Field(&a.PostalCode, validation.Required, validation.Match(zipCode).Error("must be a ZIP or ZIP+4 code")),
+		validation.Field(&a.Country, validation.Required, validation.In(CountryUS).Error("only US addresses are supported")),
+	)
+}
+
+func notPOBox(value interface{}) error {
+	if line, _ := value.(string); poBox.MatchString(line) {
+		return ErrPOBox
+	}
+	return nil
+}
+
+func stateCode(value interface{}) error {
+	if _, found := states[value.(string)]; !found {
+		return errors.New("must be a two letter USPS state code")
+	}
+	return nil
+}
+
+// states - USPS codes of the states, DC, the territories and the military post offices.
+var states = map[string]string{
+	"AL": "ALABAMA", "AK": "ALASKA", "AZ": "ARIZONA", "AR": "ARKANSAS", "CA": "CALIFORNIA",
+	"CO": "COLORADO", "CT": "CONNECTICUT", "DE": "DELAWARE", "FL": "FLORIDA", "GA": "GEORGIA",
+	"HI": "HAWAII", "ID": "IDAHO", "IL": "ILLINOIS", "IN": "INDIANA", "IA": "IOWA",
+	"KS": "KANSAS", "KY": "KENTUCKY", "LA": "LOUISIANA", "ME": "MAINE", "MD": "MARYLAND",
+	"MA": "MASSACHUSETTS", "MI": "MICHIGAN", "MN": "MINNESOTA", "MS": "MISSISSIPPI", "MO": "MISSOURI",
+	"MT": "MONTANA", "NE": "NEBRASKA", "NV": "NEVADA", "NH": "NEW HAMPSHIRE", "NJ": "NEW JERSEY",
+	"NM": "NEW MEXICO", "NY": "NEW YORK", "NC": "NORTH CAROLINA", "ND": "NORTH DAKOTA", "OH": "OHIO",
+	"OK": "OKLAHOMA", "OR": "OREGON", "PA": "PENNSYLVANIA", "RI": "RHODE ISLAND", "SC": "SOUTH CAROLINA",
+	"SD": "SOUTH DAKOTA", "TN": "TENNESSEE", "TX": "TEXAS", "UT": "UTAH", "VT": "VERMONT",
+	"VA": "VIRGINIA", "WA": "WASHINGTON", "WV": "WEST VIRGINIA", "WI": "WISCONSIN", "WY": "WYOMING",
+	"DC": "DISTRICT OF COLUMBIA",
+	"AS": "AMERICAN SAMOA", "GU": "GUAM", "MP": "NORTHERN MARIANA ISLANDS", "PR": "PUERTO RICO",
+	"VI": "VIRGIN ISLANDS",
+	"AA": "ARMED FORCES AMERICAS", "AE": "ARMED FORCES EUROPE", "AP": "ARMED FORCES PACIFIC",
+}
+
+// stateNames - states the other way around, for converting names to codes.
+var stateNames = func() map[string]string {
+	names := make(map[string]string, len(states))
+	for code, name := range states {
+		names[name] = code
+	}
+	return names
+}()
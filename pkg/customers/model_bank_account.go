@@ -0,0 +1,194 @@
+package customers
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/moovfinancial/backendhiring/pkg/fedach"
+)
+
+type BankAccountType string
+
+const (
+	BankAccountChecking BankAccountType = "checking"
+	BankAccountSavings  BankAccountType = "savings"
+)
+
+type BankAccountStatus string
+
+const (
+	BankAccountUnverified BankAccountStatus = "unverified"
+	// BankAccountPending - Micro-deposits were sent to the account and are waiting to be confirmed.
+	BankAccountPending BankAccountStatus = "pending"
+	// BankAccountVerified - The customer confirmed the micro-deposits, proving they can see the account's statements.
+	BankAccountVerified BankAccountStatus = "verified"
+	// BankAccountVerificationFailed - The micro-deposits were confirmed wrong too many times. The failed attempts are
+	// kept when it's deleted, so the customer can't add it again to get more.
+	BankAccountVerificationFailed BankAccountStatus = "verification_failed"
+)
+
+// MicroDepositCount - How many micro-deposits are sent to an account, each of MinMicroDeposit to MaxMicroDeposit
+// cents.
+const (
+	MicroDepositCount = 2
+	MinMicroDeposit   = 1
+	MaxMicroDeposit   = 99
+)
+
+var (
+	// ErrBankAccountExists - The customer already has an account with the routing and account numbers, or the
+	// in-memory repository already has one with the ID.
+	ErrBankAccountExists = errors.New("bank account already exists")
+	// ErrMicroDepositsSent - Micro-deposits are only sent to accounts that haven't been sent any.
+	ErrMicroDepositsSent = errors.New("micro-deposits can only be sent to an unverified bank account")
+	// ErrMicroDepositsNotPending - Only accounts with micro-deposits waiting to be confirmed can be confirmed.
+	ErrMicroDepositsNotPending = errors.New("bank account has no micro-deposits waiting to be confirmed")
+	// ErrBankAccountVerificationFailed - The customer used up the attempts to verify an account with the routing and
+	// account numbers, deleted ones included.
+	ErrBankAccountVerificationFailed = errors.New("bank account failed verification")
+	// ErrMicroDepositsMismatch - The amounts confirmed aren't the ones sent.
+	ErrMicroDepositsMismatch = errors.New("must match the micro-deposits sent")
+
+	ErrRoutingNumberUnknown        = errors.New("must be in the FedACH directory")
+	ErrRoutingNumberFederalReserve = errors.New("must be a bank's, not a Federal Reserve Bank's")
+)
+
+var (
+	accountNumberFormat = regexp.MustCompile(`^\d{4,17}$`)
+	// bankAccountFormatting - What people put between the digits, stripped before validating.
+	bankAccountFormatting = regexp.MustCompile(`[\s-]`)
+)
+
+// BankAccount - An external bank account of the customer's, for payouts. The account number is only ever accepted,
+// responses carry it in AccountNumberMasked instead.
+type BankAccount struct {
+	TenantID      string          `json:"tenantID,omitempty"`
+	CustomerID    string          `json:"customerID,omitempty"`
+	BankAccountID string          `json:"bankAccountID,omitempty"`
+	Type          BankAccountType `json:"type"`
+	// RoutingNumber is the ABA routing number of the account's bank.
+	RoutingNumber string `json:"routingNumber"`
+	// AccountNumber is stored encrypted, 4 to 17 digits.
+	AccountNumber       string `json:"accountNumber,omitempty"`
+	AccountNumberMasked string `json:"accountNumberMasked,omitempty"`
+	// BankName is the routing number's institution in the FedACH directory, empty when there's no directory.
+	BankName      string            `json:"bankName,omitempty"`
+	Status        BankAccountStatus `json:"status,omitempty"`
+	MicroDeposits *MicroDeposits    `json:"microDeposits,omitempty"`
+	VerifiedOn    *time.Time        `json:"verifiedOn,omitempty"`
+	CreatedOn     time.Time         `json:"createdOn,omitempty"`
+	UpdatedOn     time.Time         `json:"updatedOn,omitempty"`
+}
+
+// MicroDeposits - The small amounts sent to an account to verify it. They're simulated, nothing reaches the bank.
+type MicroDeposits struct {
+	// Amounts are in cents, stored encrypted. They're only returned when MicroDepositsConfig.ShowAmounts is on since
+	// there's no statement to read them from.
+	Amounts []int     `json:"amounts,omitempty"`
+	SentOn  time.Time `json:"sentOn"`
+	// FailedAttempts counts the confirmations with the wrong amounts, including those for the customer's deleted
+	// accounts with the same routing and account numbers.
+	FailedAttempts int `json:"failedAttempts"`
+}
+
+// MicroDepositConfirmation - The amounts the customer found on their statement, in cents and in any order.
+type MicroDepositConfirmation struct {
+	Amounts []int `json:"amounts"`
+}
+
+// Normalize - Strips the spaces and dashes people write routing and account numbers with.
+func (b *BankAccount) Normalize() {
+	b.RoutingNumber = bankAccountFormatting.ReplaceAllString(b.RoutingNumber, "")
+	b.AccountNumber = bankAccountFormatting.ReplaceAllString(b.AccountNumber, "")
+}
+
+// Validate - Checks a normalized bank account, keep in sync with the BankAccount schema in api/openapi.yaml. The
+// routing number is looked up in the FedACH directory by the service.
+func (b BankAccount) Validate() error {
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.Type, validation.Required, validation.In(BankAccountChecking, BankAccountSavings)),
+		validation.Field(&b.RoutingNumber, validation.Required, validation.By(func(value interface{}) error {
+			routingNumber, _ := value.(string)
+			return fedach.Validate(routingNumber)
+		})),
+		validation.Field(&b.AccountNumber, validation.Required,
+			validation.Match(accountNumberFormat).Error("must be 4 to 17 digits")),
+	)
+}
+
+// Validate - Keep in sync with the MicroDepositConfirmation schema in api/openapi.yaml.
+func (c MicroDepositConfirmation) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Amounts, validation.Required, validation.Length(MicroDepositCount, MicroDepositCount),
+			validation.Each(validation.Min(MinMicroDeposit), validation.Max(MaxMicroDeposit))),
+	)
+}
+
+// matches - If the amounts are the ones sent, in any order.
+func (c MicroDepositConfirmation) matches(sent []int) bool {
+	confirmed := append([]int(nil), c.Amounts...)
+	expected := append([]int(nil), sent...)
+	sort.Ints(confirmed)
+	sort.Ints(expected)
+
+	if len(confirmed) != len(expected) {
+		return false
+	}
+	for i := range confirmed {
+		if confirmed[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// masked - The bank account as it's returned, with AccountNumberMasked in place of its number. The micro-deposit
+// amounts are left out unless showAmounts.
+func (b BankAccount) masked(showAmounts bool) BankAccount {
+	b.AccountNumberMasked = maskValue(b.AccountNumber)
+	b.AccountNumber = ""
+	if b.MicroDeposits != nil && !showAmounts {
+		deposits := *b.MicroDeposits
+		deposits.Amounts = nil
+		b.MicroDeposits = &deposits
+	}
+	return b
+}
+
+func maskBankAccounts(accounts []BankAccount, showAmounts bool) []BankAccount {
+	masked := make([]BankAccount, len(accounts))
+	for i, b := range accounts {
+		masked[i] = b.masked(showAmounts)
+	}
+	return masked
+}
+
+// formatMicroDeposits - The amounts as they're stored, e.g. 12,34.
+func formatMicroDeposits(amounts []int) string {
+	formatted := make([]string, len(amounts))
+	for i, amount := range amounts {
+		formatted[i] = strconv.Itoa(amount)
+	}
+	return strings.Join(formatted, ",")
+}
+
+func parseMicroDeposits(value string) ([]int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var amounts []int
+	for _, formatted := range strings.Split(value, ",") {
+		amount, err := strconv.Atoi(formatted)
+		if err != nil {
+			return nil, err
+		}
+		amounts = append(amounts, amount)
+	}
+	return amounts, nil
+}
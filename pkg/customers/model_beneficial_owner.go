@@ -0,0 +1,63 @@
+package customers
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// BeneficialOwnershipThreshold - The share of a business from which an owner has to be listed, under the CDD rule.
+// Owners with less are only listed when they're the control person.
+const BeneficialOwnershipThreshold = 25.0
+
+var (
+	// ErrNotBusiness - Beneficial owners are only listed for business customers.
+	ErrNotBusiness = errors.New("customer isn't a business")
+	// ErrOwnerNotIndividual - Beneficial owners have to be active individual customers of the same tenant.
+	ErrOwnerNotIndividual = errors.New("beneficial owner must be an active individual customer")
+	// ErrBeneficialOwnerExists - The individual is already listed as an owner of the business.
+	ErrBeneficialOwnerExists = errors.New("beneficial owner already exists")
+	// ErrOwnershipExceeded - The business's owners would hold more than all of it.
+	ErrOwnershipExceeded = errors.New("beneficial owners can't own more than 100% of a business")
+)
+
+// BeneficialOwner - Links a business customer to an individual customer that owns at least
+// BeneficialOwnershipThreshold percent of it, or controls it.
+type BeneficialOwner struct {
+	TenantID string `json:"tenantID,omitempty"`
+	// CustomerID is the business.
+	CustomerID string `json:"customerID,omitempty"`
+	// OwnerID is the individual, a customer of the same tenant.
+	OwnerID string `json:"ownerID"`
+	// OwnershipPercentage is the share of the business the owner holds, to two decimal places.
+	OwnershipPercentage float64 `json:"ownershipPercentage"`
+	// ControlPerson marks the individual with significant responsibility for managing the business, like its CEO.
+	ControlPerson bool      `json:"controlPerson"`
+	CreatedOn     time.Time `json:"createdOn,omitempty"`
+	UpdatedOn     time.Time `json:"updatedOn,omitempty"`
+}
+
+// Validate - Keep in sync with the BeneficialOwner schema in api/openapi.yaml.
+func (o BeneficialOwner) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.OwnerID, validation.Required, is.UUID),
+		validation.Field(&o.OwnershipPercentage, validation.By(o.ownership)),
+	)
+}
+
+// ownership - Ozzo's threshold rules skip zero values, which are a valid share for a control person and nobody else.
+func (o BeneficialOwner) ownership(value interface{}) error {
+	percentage, _ := value.(float64)
+	switch {
+	case percentage < 0 || percentage > 100:
+		return errors.New("must be between 0 and 100")
+	case !o.ControlPerson && percentage < BeneficialOwnershipThreshold:
+		return errors.New("must be at least 25 unless the owner is the control person")
+	case math.Abs(percentage*100-math.Round(percentage*100)) > 1e-6:
+		return errors.New("must have at most two decimal places")
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package customers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CustomerType string
+
+const (
+	CustomerIndividual CustomerType = "individual"
+	CustomerBusiness   CustomerType = "business"
+)
+
+// orDefault - Customers created without a type are individuals, like every customer from before there were businesses.
+func (t CustomerType) orDefault() CustomerType {
+	if t == "" {
+		return CustomerIndividual
+	}
+	return t
+}
+
+type EntityType string
+
+const (
+	EntitySoleProprietorship EntityType = "sole_proprietorship"
+	EntityPartnership        EntityType = "partnership"
+	EntityLLC                EntityType = "llc"
+	EntityCorporation        EntityType = "corporation"
+	EntityNonprofit          EntityType = "nonprofit"
+	EntityTrust              EntityType = "trust"
+)
+
+// Business - What a business customer is registered as, set only on customers of type business.
+type Business struct {
+	LegalName string `json:"legalName"`
+	// DBA is the name the business trades under when it isn't its legal name.
+	DBA string `json:"dba,omitempty"`
+	// EIN is the IRS employer identification number, formatted as NN-NNNNNNN.
+	EIN        string     `json:"ein"`
+	EntityType EntityType `json:"entityType"`
+	// FormationDate is formatted as YYYY/MM/DD like a birth date.
+	FormationDate *string `json:"formationDate,omitempty"`
+	// IncorporationState is the two letter USPS code of the state the business is registered in, sole
+	// proprietorships aren't registered so they don't need one.
+	IncorporationState string `json:"incorporationState,omitempty"`
+}
+
+var (
+	einFormat = regexp.MustCompile(`^\d{2}-\d{7}$`)
+	einDigits = regexp.MustCompile(`^(\d{2})-?(\d{7})$`)
+)
+
+// einPrefixes - The campus prefixes the IRS assigns EINs from, an EIN starting with any other two digits was never
+// issued.
+var einPrefixes = func() map[string]bool {
+	prefixes := map[string]bool{}
+	for _, r := range [][2]int{{1, 6}, {10, 16}, {20, 27}, {30, 48}, {50, 68}, {71, 77}, {80, 88}, {90, 95}, {98, 99}} {
+		for p := r[0]; p <= r[1]; p++ {
+			prefixes[fmt.Sprintf("%02d", p)] = true
+		}
+	}
+	return prefixes
+}()
+
+// ErrCustomerTypeChanged - A customer stays the type it was created as.
+var ErrCustomerTypeChanged = errors.New("can't be changed once the customer is created")
+
+// ErrEINPrefix - The EIN is formatted correctly but its prefix was never issued by the IRS.
+var ErrEINPrefix = errors.New("must start with a prefix issued by the IRS")
+
+// Normalize - Tidies the business into its standard form before it's validated: whitespace trimmed, EINs written
+// without the dash dashed and the state uppercased.
+func (b *Business) Normalize() {
+	for _, field := range []*string{&b.LegalName, &b.DBA, &b.EIN, &b.IncorporationState} {
+		*field = spaces.ReplaceAllString(strings.TrimSpace(*field), " ")
+	}
+
+	if match := einDigits.FindStringSubmatch(b.EIN); match != nil {
+		b.EIN = match[1] + "-" + match[2]
+	}
+
+	b.IncorporationState = strings.ToUpper(b.IncorporationState)
+	if code, found := stateNames[b.IncorporationState]; found {
+		b.IncorporationState = code
+	}
+}
+
+// Validate - Checks a normalized business, keep in sync with the Business schema in api/openapi.yaml.
+func (b Business) Validate() error {
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.LegalName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&b.DBA, validation.Length(0, 255)),
+		validation.Field(&b.EIN, validation.Required,
+			validation.Match(einFormat).Error("must be formatted as NN-NNNNNNN"), validation.By(einPrefix)),
+		validation.Field(&b.EntityType, validation.Required, validation.In(EntitySoleProprietorship, EntityPartnership,
+			EntityLLC, EntityCorporation, EntityNonprofit, EntityTrust)),
+		validation.Field(&b.FormationDate, validation.NilOrNotEmpty, validation.Match(birthDateFormat)),
+		validation.Field(&b.IncorporationState,
+			validation.When(b.EntityType != EntitySoleProprietorship, validation.Required),
+			validation.When(b.IncorporationState != "", validation.By(stateCode))),
+	)
+}
+
+func einPrefix(value interface{}) error {
+	if ein, _ := value.(string); einFormat.MatchString(ein) && !einPrefixes[ein[:2]] {
+		return ErrEINPrefix
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package customers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultChangesLimit - How many changes are returned when the caller doesn't ask for a number.
+	DefaultChangesLimit = 100
+	// MaxChangesLimit - The most changes returned by a single call.
+	MaxChangesLimit = 1000
+)
+
+// ErrInvalidCursor - The cursor wasn't one handed out by a previous call.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrInvalidLimit - The limit was outside 1 to MaxChangesLimit.
+var ErrInvalidLimit = errors.New("limit must be between 1 and 1000")
+
+type ChangeType string
+
+const (
+	ChangeCreated  ChangeType = "created"
+	ChangeUpdated  ChangeType = "updated"
+	ChangeDisabled ChangeType = "disabled"
+	// ChangeErased - The customer's personal data was erased, copies of it should be erased too.
+	ChangeErased ChangeType = "erased"
+)
+
+// CustomerChange - Records that a customer was written, each tenant's changes are numbered from 1 in the order they
+// were committed.
+type CustomerChange struct {
+	Sequence  int64      `json:"sequence"`
+	Type      ChangeType `json:"type"`
+	ChangedOn time.Time  `json:"changedOn"`
+	// Customer is the customer as it is now, not as it was when the change was made.
+	Customer Customer `json:"customer"`
+}
+
+// masked - The change as it's returned by the API, with the customer's tax ID masked.
+func (c CustomerChange) masked() CustomerChange {
+	c.Customer = c.Customer.masked()
+	return c
+}
+
+// CustomerChanges - A page of the change feed.
+type CustomerChanges struct {
+	Changes []CustomerChange `json:"changes"`
+	// Cursor is passed back to get the changes after this page, it's returned even when the page is empty.
+	Cursor string `json:"cursor"`
+	// HasMore is set when there are more changes available right away.
+	HasMore bool `json:"hasMore"`
+}
+
+func (p CustomerChanges) masked() CustomerChanges {
+	changes := make([]CustomerChange, len(p.Changes))
+	for i := range p.Changes {
+		changes[i] = p.Changes[i].masked()
+	}
+	p.Changes = changes
+	return p
+}
+
+// encodeCursor - Documented as opaque so the feed is free to change how it tracks its position.
+func encodeCursor(sequence int64) string {
+	return strconv.FormatInt(sequence, 10)
+}
+
+func decodeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	sequence, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil || sequence < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return sequence, nil
+}
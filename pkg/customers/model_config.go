@@ -0,0 +1,221 @@
+package customers
+
+import (
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/blob"
+	"github.com/moovfinancial/backendhiring/pkg/identity"
+	"github.com/moovfinancial/backendhiring/pkg/sanctions"
+)
+
+// DefaultTimeout - Used for any repository operation without a configured timeout.
+const DefaultTimeout = 5 * time.Second
+
+const (
+	// RepositorySQL - Stores customers in the configured database, the default.
+	RepositorySQL = "sql"
+	// RepositoryMemory - Keeps customers in memory only, for tests and local demos.
+	RepositoryMemory = "memory"
+)
+
+// Config - Settings for the customers package, loaded as part of the service config.
+type Config struct {
+	// Repository is one of RepositorySQL or RepositoryMemory
+	Repository   string
+	Timeouts     TimeoutsConfig
+	Cache        CacheConfig
+	Stream       StreamConfig
+	Erasure      ErasureConfig
+	Encryption   EncryptionConfig
+	Retention    RetentionConfig
+	Documents    DocumentsConfig
+	Screening    ScreeningConfig
+	Verification VerificationConfig
+	Risk         RiskConfig
+	BankAccounts BankAccountsConfig
+}
+
+// BankAccountsConfig - Checks the routing numbers of customers' bank accounts and verifies the accounts with
+// micro-deposits.
+type BankAccountsConfig struct {
+	// RoutingDirectoryPath is a local copy of the Fed's FedACH directory, in its text or JSON format going by the
+	// extension, loaded when the service starts. Routing numbers are only checked for a valid check digit without one.
+	RoutingDirectoryPath string
+	MicroDeposits        MicroDepositsConfig
+}
+
+// MicroDepositsConfig - Micro-deposits are simulated, the amounts are generated and stored but nothing is sent.
+type MicroDepositsConfig struct {
+	// MaxAttempts is how many times the amounts can be confirmed wrong before the account fails verification,
+	// DefaultMicroDepositMaxAttempts when unset. Deleting the account and adding it again doesn't reset them.
+	MaxAttempts int
+	// ShowAmounts returns the amounts with the bank account so they can be confirmed without a statement to read
+	// them from, for tests and local demos only.
+	ShowAmounts bool
+}
+
+// DefaultMicroDepositMaxAttempts - Enough for a typo or two.
+const DefaultMicroDepositMaxAttempts = 3
+
+func (c MicroDepositsConfig) maxAttempts() int {
+	if c.MaxAttempts > 0 {
+		return c.MaxAttempts
+	}
+	return DefaultMicroDepositMaxAttempts
+}
+
+// RiskConfig - Scores customers' risk with the rules in a file, when they're created and whenever something the rules
+// look at changes.
+type RiskConfig struct {
+	// RulesPath is loaded when the service starts, customers aren't scored without one.
+	RulesPath string
+}
+
+// VerificationConfig - Verifies new customers' identities with an identity provider, and customers whose identity
+// fields change. The checks are made in the background by the verification worker.
+type VerificationConfig struct {
+	// Identity picks the provider, customers aren't verified when there's none.
+	Identity identity.Config
+	// PollInterval is how often the worker looks for checks due an attempt, taking up to BatchSize at a time.
+	PollInterval time.Duration
+	BatchSize    int
+	// Timeout limits each call to the provider.
+	Timeout time.Duration
+	// MaxAttempts is how many times the provider is tried before the customer is sent for review instead, waiting
+	// RetryDelay after the first failure and twice as long after each one after that.
+	MaxAttempts int
+	RetryDelay  time.Duration
+}
+
+// ScreeningConfig - Screens customers against sanctions lists when they're created and when their name or birth date
+// changes.
+type ScreeningConfig struct {
+	// Lists are loaded when the service starts, customers aren't screened when there are none.
+	Lists []sanctions.ListConfig
+	// FlagThreshold is the score, from 0 to 1, a match needs for the customer to be flagged for review,
+	// DefaultFlagThreshold when unset.
+	FlagThreshold float64
+	// BlockThreshold is the score at which the customer isn't created or updated, DefaultBlockThreshold when unset.
+	BlockThreshold float64
+	Rescreening    RescreeningConfig
+}
+
+// RescreeningConfig - Schedules the job that screens every active customer again when a list changes.
+type RescreeningConfig struct {
+	// Enabled checks the lists for a new version every Interval while the service is up.
+	Enabled  bool
+	Interval time.Duration
+	// BatchSize is how many customers are screened at a time, pausing for BatchDelay between batches so the
+	// database isn't swamped. A negative BatchDelay doesn't pause at all.
+	BatchSize  int
+	BatchDelay time.Duration
+}
+
+const (
+	DefaultFlagThreshold  = 0.85
+	DefaultBlockThreshold = 0.97
+)
+
+func (c ScreeningConfig) flagThreshold() float64 {
+	if c.FlagThreshold > 0 {
+		return c.FlagThreshold
+	}
+	return DefaultFlagThreshold
+}
+
+func (c ScreeningConfig) blockThreshold() float64 {
+	if c.BlockThreshold > 0 {
+		return c.BlockThreshold
+	}
+	return DefaultBlockThreshold
+}
+
+// DocumentsConfig - Limits on the identity documents uploaded for customers and where they're kept.
+type DocumentsConfig struct {
+	// MaxSize is the largest file accepted in bytes, DefaultDocumentMaxSize when unset.
+	MaxSize int64
+	// ContentTypes are the file types accepted, DefaultDocumentContentTypes when unset.
+	ContentTypes []string
+	Storage      blob.Config
+}
+
+// DefaultDocumentMaxSize - Enough for a photo of a document taken on a phone.
+const DefaultDocumentMaxSize = 10 << 20
+
+// DefaultDocumentContentTypes - Photos and scans of documents.
+var DefaultDocumentContentTypes = []string{"image/jpeg", "image/png", "application/pdf"}
+
+func (c DocumentsConfig) maxSize() int64 {
+	if c.MaxSize > 0 {
+		return c.MaxSize
+	}
+	return DefaultDocumentMaxSize
+}
+
+func (c DocumentsConfig) contentTypes() []string {
+	if len(c.ContentTypes) > 0 {
+		return c.ContentTypes
+	}
+	return DefaultDocumentContentTypes
+}
+
+// ErasureConfig - Rules for erasing customers' personal data.
+type ErasureConfig struct {
+	// RetentionPeriod is how long records are kept after a customer is disabled before they can be erased.
+	RetentionPeriod time.Duration
+}
+
+// RetentionConfig - Schedules the job that purges the personal data of customers disabled long ago.
+type RetentionConfig struct {
+	// Enabled runs the job every Interval while the service is up.
+	Enabled  bool
+	Interval time.Duration
+	// BatchSize is how many customers are looked up at a time, a run stops after MaxBatches of them and the rest
+	// wait for the next run.
+	BatchSize  int
+	MaxBatches int
+	// DryRun reports the customers that would be purged without purging them.
+	DryRun bool
+	Rules  []RetentionRule
+}
+
+// RetentionRule - Purges a tenant's customers PurgeAfter they were disabled. The rule without a TenantID covers every
+// tenant without a rule of its own, tenants aren't purged when there's neither.
+type RetentionRule struct {
+	TenantID   string
+	PurgeAfter time.Duration
+}
+
+// EncryptionConfig - Keys for the personal data stored by the SQL repository.
+type EncryptionConfig struct {
+	// KeyEncryptionKey wraps every customer's data key, 32 base64 encoded bytes.
+	KeyEncryptionKey string
+}
+
+// StreamConfig - Settings for the GET /customers/stream Server-Sent Events endpoint.
+type StreamConfig struct {
+	// PollInterval is how often the change feed is checked for new changes.
+	PollInterval time.Duration
+	// Heartbeat is how long a stream can go without an event before a comment is sent to keep it open.
+	Heartbeat time.Duration
+	// MaxPerTenant limits how many streams a tenant can have open at once, 0 means no limit.
+	MaxPerTenant int
+	// GapTimeout is how long a stream waits for a missing change before moving past it, 30s when unset.
+	GapTimeout time.Duration
+}
+
+// CacheConfig - Bounds the customer lookup cache, a Size of 0 disables it.
+type CacheConfig struct {
+	Size int
+	// TTL is how long a cached customer is served before it's looked up again, 0 keeps it until evicted.
+	TTL time.Duration
+}
+
+// TimeoutsConfig - How long each CustomerRepository operation may run before it's cancelled.
+type TimeoutsConfig struct {
+	Add    time.Duration
+	List   time.Duration
+	Get    time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
@@ -1,6 +1,7 @@
 package customers
 
 import (
+	"regexp"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -11,21 +12,97 @@ type Customer struct {
 	// UUID v4
 	TenantID string `json:"tenantID,omitempty"`
 	// UUID v4
-	CustomerID string  `json:"customerID,omitempty"`
-	Name       string  `json:"name,omitempty"`
-	BirthDate  *string `json:"birthDate,omitempty"`
+	CustomerID string `json:"customerID,omitempty"`
+	// Type is individual when it isn't given, it can't be changed once the customer is created.
+	Type CustomerType `json:"type,omitempty"`
+	// Name is what a business is known as, its legal name is in its business details.
+	Name      string  `json:"name,omitempty"`
+	BirthDate *string `json:"birthDate,omitempty"`
 	// Email Address
-	Email      string     `json:"email,omitempty"`
-	Ssn        string     `json:"ssn,omitempty"`
+	Email string `json:"email,omitempty"`
+	// Ssn is deprecated, an individual's SSN is taken as its tax ID of type ssn and it's never returned.
+	Ssn string `json:"ssn,omitempty"`
+	// TaxID is only for individuals, it's required for them. Businesses are identified by their EIN.
+	TaxID *TaxID `json:"taxID,omitempty"`
+	// Business is only for businesses, it's required for them.
+	Business   *Business  `json:"business,omitempty"`
 	CreatedOn  time.Time  `json:"createdOn,omitempty"`
 	UpdatedOn  time.Time  `json:"updatedOn,omitempty"`
 	DisabledOn *time.Time `json:"disabledOn,omitempty"`
+	// ErasedOn is set once the customer's personal data has been erased, only the IDs and timestamps remain.
+	ErasedOn  *time.Time `json:"erasedOn,omitempty"`
+	LegalHold *LegalHold `json:"legalHold,omitempty"`
+	// KYCStatus only changes through KYC transitions, it's ignored on create and update.
+	KYCStatus KYCStatus `json:"kycStatus,omitempty"`
+	// ScreeningStatus is set by sanctions screening, it's empty for customers that haven't been screened.
+	ScreeningStatus ScreeningStatus `json:"screeningStatus,omitempty"`
+	// Risk is scored by the risk rules, it's nil for customers that haven't been scored.
+	Risk *RiskScore `json:"risk,omitempty"`
 }
 
+// ListFilter - Narrows a customer listing, zero values match everything.
+type ListFilter struct {
+	KYCStatus KYCStatus
+}
+
+var birthDateFormat = regexp.MustCompile(`^\d{4}/\d{2}/\d{2}$`)
+
+// Normalize - Defaults the type, moves an individual's SSN into its tax ID and tidies the tax ID and business
+// details before the customer is validated.
+func (a *Customer) Normalize() {
+	a.Type = a.Type.orDefault()
+	if a.Type == CustomerIndividual && a.Ssn != "" && a.TaxID == nil {
+		a.TaxID = &TaxID{Type: TaxIDSSN, Value: a.Ssn}
+		a.Ssn = ""
+	}
+	if a.TaxID != nil {
+		// Detached so the caller's tax ID isn't changed.
+		taxID := *a.TaxID
+		taxID.Normalize()
+		a.TaxID = &taxID
+	}
+	if a.Business != nil {
+		// Detached so the caller's business isn't changed.
+		business := *a.Business
+		business.Normalize()
+		a.Business = &business
+	}
+}
+
+// Validate - Checks the fields a client provides, keep in sync with the Customer schema in api/openapi.yaml.
+// The IDs are assigned by the service so they're only checked when present. Individuals need a tax ID, businesses
+// their business details instead and they don't have a birth date.
 func (a Customer) Validate() error {
+	individual := a.Type.orDefault() == CustomerIndividual
+
 	// Ozzo validation: https://github.com/go-ozzo/ozzo-validation#validating-a-simple-value
 	return validation.ValidateStruct(&a,
-		validation.Field(&a.CustomerID, validation.Required, is.UUID),
-		validation.Field(&a.TenantID, validation.Required, is.UUID),
+		validation.Field(&a.CustomerID, is.UUID),
+		validation.Field(&a.TenantID, is.UUID),
+		validation.Field(&a.Type, validation.In(CustomerIndividual, CustomerBusiness)),
+		validation.Field(&a.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&a.BirthDate, validation.When(individual, validation.NilOrNotEmpty, validation.Match(birthDateFormat)).
+			Else(validation.Nil.Error("must be blank for a business"))),
+		validation.Field(&a.Email, validation.Required, validation.Length(1, 255), is.EmailFormat),
+		validation.Field(&a.Ssn, validation.When(individual, validation.Empty.Error("must be blank when there's a taxID")).
+			Else(validation.Empty.Error("must be blank for a business, use the EIN"))),
+		validation.Field(&a.TaxID, validation.When(individual, validation.Required).
+			Else(validation.Nil.Error("must be blank for a business, use the EIN"))),
+		validation.Field(&a.Business, validation.When(individual, validation.Nil.Error("must be blank for an individual")).
+			Else(validation.Required)),
 	)
 }
+
+// masked - The customer as it's returned by the API, with its tax ID masked.
+func (a Customer) masked() Customer {
+	a.TaxID = a.TaxID.masked()
+	return a
+}
+
+func maskCustomers(items []Customer) []Customer {
+	masked := make([]Customer, len(items))
+	for i := range items {
+		masked[i] = items[i].masked()
+	}
+	return masked
+}
@@ -0,0 +1,59 @@
+package customers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type DocumentType string
+
+const (
+	DocumentDriversLicense DocumentType = "drivers_license"
+	DocumentPassport       DocumentType = "passport"
+)
+
+// Document - An identity document uploaded for KYC review. The file itself is kept encrypted in the blob store,
+// this is what's known about it.
+type Document struct {
+	TenantID   string       `json:"tenantID,omitempty"`
+	CustomerID string       `json:"customerID,omitempty"`
+	DocumentID string       `json:"documentID,omitempty"`
+	Type       DocumentType `json:"type"`
+	// ContentType is worked out from the file's contents, not taken from the upload.
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	// SHA256 is the hex encoded checksum of the file as uploaded.
+	SHA256    string    `json:"sha256,omitempty"`
+	CreatedOn time.Time `json:"createdOn,omitempty"`
+}
+
+var (
+	// ErrDocumentTooLarge - The file is bigger than DocumentsConfig.MaxSize.
+	ErrDocumentTooLarge = errors.New("document too large")
+	ErrDocumentEmpty    = errors.New("must not be empty")
+)
+
+// Validate - Checks the fields a client provides, keep in sync with the createDocument request in api/openapi.yaml.
+func (d Document) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Type, validation.Required, validation.In(DocumentDriversLicense, DocumentPassport)),
+	)
+}
+
+// documentBlobKey - Where the document's file is kept in the blob store.
+func documentBlobKey(d Document) string {
+	return d.TenantID + "/" + d.CustomerID + "/" + d.DocumentID
+}
+
+// documentContentType - Checks the detected content type of an upload against the allowed ones.
+func documentContentType(contentType string, allowed []string) error {
+	for _, a := range allowed {
+		if contentType == a {
+			return nil
+		}
+	}
+	return validation.Errors{"file": fmt.Errorf("content type %s is not allowed", contentType)}
+}
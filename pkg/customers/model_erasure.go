@@ -0,0 +1,53 @@
+package customers
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// ErrLegalHold - The customer is under legal hold so it can't be erased.
+var ErrLegalHold = errors.New("customer is under legal hold")
+
+// ErrRetentionPeriod - Records have to be kept until the retention period after the customer was disabled is over.
+var ErrRetentionPeriod = errors.New("customer is within the regulatory retention period")
+
+// ErrCustomerErased - The customer has already been erased.
+var ErrCustomerErased = errors.New("customer has already been erased")
+
+// ErasedFields - The personal data removed by an erasure.
+var ErasedFields = []string{"name", "birthDate", "email", "taxID", "business", "beneficialOwners", "addresses", "phones", "bankAccounts", "documents"}
+
+// LegalHold - Stops a customer from being erased, for litigation or an investigation.
+type LegalHold struct {
+	Reason   string    `json:"reason"`
+	PlacedOn time.Time `json:"placedOn,omitempty"`
+}
+
+func (h LegalHold) Validate() error {
+	return validation.ValidateStruct(&h,
+		validation.Field(&h.Reason, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// ErasureRequest - Why the customer is being erased, e.g. the ticket for the data subject's request.
+type ErasureRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (r ErasureRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Reason, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// CustomerErasure - Certifies that a customer's personal data was erased. It holds no personal data itself.
+type CustomerErasure struct {
+	ErasureID    string    `json:"erasureID"`
+	TenantID     string    `json:"tenantID"`
+	CustomerID   string    `json:"customerID"`
+	Reason       string    `json:"reason"`
+	ErasedFields []string  `json:"erasedFields"`
+	ErasedOn     time.Time `json:"erasedOn"`
+}
@@ -0,0 +1,66 @@
+package customers
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// KYCStatus - How far the customer has got with identity verification.
+type KYCStatus string
+
+const (
+	// KYCUnverified - Signed up but nothing has been checked yet, every customer starts here.
+	KYCUnverified     KYCStatus = "unverified"
+	KYCPending        KYCStatus = "pending"
+	KYCVerified       KYCStatus = "verified"
+	KYCFailed         KYCStatus = "failed"
+	KYCReviewRequired KYCStatus = "review_required"
+)
+
+// kycTransitions - The statuses a customer can move to from each status. Anything past unverified can go back to
+// pending when there's something new to check.
+var kycTransitions = map[KYCStatus][]KYCStatus{
+	KYCUnverified:     {KYCPending},
+	KYCPending:        {KYCVerified, KYCFailed, KYCReviewRequired},
+	KYCReviewRequired: {KYCPending, KYCVerified, KYCFailed},
+	KYCFailed:         {KYCPending, KYCReviewRequired},
+	KYCVerified:       {KYCPending, KYCReviewRequired},
+}
+
+// KYCIdentityChangedReason - Recorded when an update to the customer's identity fields resets its status to pending.
+const KYCIdentityChangedReason = "identity fields changed"
+
+// ErrKYCTransition - The customer's current status can't move to the one requested.
+var ErrKYCTransition = errors.New("kyc status transition not allowed")
+
+// CanTransitionTo - If the transition table allows moving from s to next.
+func (s KYCStatus) CanTransitionTo(next KYCStatus) bool {
+	for _, allowed := range kycTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// KYCTransition - A change of a customer's KYC status and why it was made, kept as the customer's KYC history.
+type KYCTransition struct {
+	TenantID       string    `json:"tenantID,omitempty"`
+	CustomerID     string    `json:"customerID,omitempty"`
+	TransitionID   string    `json:"transitionID,omitempty"`
+	From           KYCStatus `json:"from,omitempty"`
+	To             KYCStatus `json:"to"`
+	Reason         string    `json:"reason"`
+	TransitionedOn time.Time `json:"transitionedOn,omitempty"`
+}
+
+// Validate - Checks the fields a client provides, keep in sync with the KYCTransition schema in api/openapi.yaml.
+func (t KYCTransition) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.To, validation.Required,
+			validation.In(KYCUnverified, KYCPending, KYCVerified, KYCFailed, KYCReviewRequired)),
+		validation.Field(&t.Reason, validation.Required, validation.Length(1, 255)),
+	)
+}
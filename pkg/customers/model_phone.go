@@ -0,0 +1,189 @@
+package customers
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type PhoneType string
+
+const (
+	PhoneMobile PhoneType = "mobile"
+	PhoneHome   PhoneType = "home"
+	PhoneWork   PhoneType = "work"
+)
+
+type PhoneStatus string
+
+const (
+	PhoneUnverified PhoneStatus = "unverified"
+	// PhoneVerified - The customer proved they control the number, changing the number makes it unverified again.
+	PhoneVerified PhoneStatus = "verified"
+)
+
+// Phone - One of a customer's phone numbers. Primary marks the number used for its type, there's at most one.
+type Phone struct {
+	TenantID   string    `json:"tenantID,omitempty"`
+	CustomerID string    `json:"customerID,omitempty"`
+	PhoneID    string    `json:"phoneID,omitempty"`
+	Type       PhoneType `json:"type"`
+	Primary    bool      `json:"primary"`
+	// Number is stored in E.164 form, national numbers are read using Country.
+	Number string `json:"number"`
+	// Country is the ISO 3166 code the number belongs to, it defaults to US for national numbers.
+	Country    string      `json:"country"`
+	Status     PhoneStatus `json:"status,omitempty"`
+	VerifiedOn *time.Time  `json:"verifiedOn,omitempty"`
+	CreatedOn  time.Time   `json:"createdOn,omitempty"`
+	UpdatedOn  time.Time   `json:"updatedOn,omitempty"`
+}
+
+var (
+	e164 = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+	// phoneFormatting - What people put between the digits, stripped before parsing.
+	phoneFormatting = regexp.MustCompile(`[\s().\-/]`)
+	phoneDigits     = regexp.MustCompile(`^\+?\d+$`)
+)
+
+var (
+	ErrPhoneNumber  = errors.New("must be a valid phone number for its country")
+	ErrPhoneCountry = errors.New("must be a supported country code")
+)
+
+// Normalize - Puts the number into E.164 form using Country for numbers written without a country calling code.
+// Numbers that can't be parsed are left alone for Validate to report.
+func (p *Phone) Normalize() {
+	p.Country = strings.ToUpper(strings.TrimSpace(p.Country))
+
+	number, country, err := ParsePhoneNumber(p.Number, p.Country)
+	if err != nil {
+		p.Number = strings.TrimSpace(p.Number)
+		return
+	}
+	p.Number = number
+	p.Country = country
+}
+
+// Validate - Checks a normalized phone, keep in sync with the Phone schema in api/openapi.yaml.
+func (p Phone) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Type, validation.Required, validation.In(PhoneMobile, PhoneHome, PhoneWork)),
+		validation.Field(&p.Country, validation.By(phoneCountry)),
+		validation.Field(&p.Number, validation.Required,
+			validation.Match(e164).Error(ErrPhoneNumber.Error()),
+			validation.By(func(value interface{}) error {
+				_, _, err := ParsePhoneNumber(value.(string), p.Country)
+				return err
+			})),
+	)
+}
+
+func phoneCountry(value interface{}) error {
+	if country, _ := value.(string); country != "" {
+		if _, found := phoneCountries[country]; !found {
+			return ErrPhoneCountry
+		}
+	}
+	return nil
+}
+
+// ParsePhoneNumber - Reads a number as someone would type it, either international starting with + or national for
+// country, and returns it in E.164 form along with the country it belongs to.
+func ParsePhoneNumber(input string, country string) (string, string, error) {
+	number := phoneFormatting.ReplaceAllString(strings.TrimSpace(input), "")
+	if !phoneDigits.MatchString(number) {
+		return "", "", ErrPhoneNumber
+	}
+
+	if country == "" {
+		country = CountryUS
+	}
+
+	if !strings.HasPrefix(number, "+") {
+		plan, found := phoneCountries[country]
+		if !found {
+			return "", "", ErrPhoneCountry
+		}
+		national := plan.national(number)
+		if !plan.valid(national) {
+			return "", "", ErrPhoneNumber
+		}
+		return "+" + plan.callingCode + national, country, nil
+	}
+
+	// The calling code decides the country, the hint picks between countries sharing one.
+	digits := number[1:]
+	for callingCode, region := range phoneRegions {
+		if !strings.HasPrefix(digits, callingCode) || !phoneCountries[region].valid(digits[len(callingCode):]) {
+			continue
+		}
+		if phoneCountries[country].callingCode == callingCode {
+			region = country
+		}
+		return number, region, nil
+	}
+
+	return "", "", ErrPhoneNumber
+}
+
+// phonePlan - Enough of a country's numbering plan to tell a plausible number from a typo.
+type phonePlan struct {
+	callingCode string
+	// trunkPrefix is dialed before national numbers inside the country but isn't part of the E.164 number.
+	trunkPrefix string
+	pattern     *regexp.Regexp
+}
+
+func (p phonePlan) national(number string) string {
+	if p.trunkPrefix != "" && strings.HasPrefix(number, p.trunkPrefix) {
+		return number[len(p.trunkPrefix):]
+	}
+	// Dialing the calling code without the + is common in North America, e.g. 1 319 555 0100.
+	if p.trunkPrefix == "" && strings.HasPrefix(number, p.callingCode) && !p.valid(number) {
+		return number[len(p.callingCode):]
+	}
+	return number
+}
+
+func (p phonePlan) valid(national string) bool {
+	return p.pattern.MatchString(national)
+}
+
+// nanp - The North American Numbering Plan, area codes and exchanges can't start with 0 or 1.
+var nanp = regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`)
+
+// phoneCountries - The countries numbers can be parsed for, keyed by ISO 3166 code.
+var phoneCountries = map[string]phonePlan{
+	"US": {callingCode: "1", pattern: nanp},
+	"CA": {callingCode: "1", pattern: nanp},
+	"PR": {callingCode: "1", pattern: nanp},
+	"MX": {callingCode: "52", pattern: regexp.MustCompile(`^\d{10}$`)},
+	"GB": {callingCode: "44", trunkPrefix: "0", pattern: regexp.MustCompile(`^[1-9]\d{8,9}$`)},
+	"IE": {callingCode: "353", trunkPrefix: "0", pattern: regexp.MustCompile(`^[1-9]\d{6,8}$`)},
+	"FR": {callingCode: "33", trunkPrefix: "0", pattern: regexp.MustCompile(`^[1-9]\d{8}$`)},
+	"DE": {callingCode: "49", trunkPrefix: "0", pattern: regexp.MustCompile(`^[1-9]\d{5,12}$`)},
+	"ES": {callingCode: "34", pattern: regexp.MustCompile(`^[6-9]\d{8}$`)},
+	"IT": {callingCode: "39", pattern: regexp.MustCompile(`^[03]\d{5,10}$`)},
+	"NL": {callingCode: "31", trunkPrefix: "0", pattern: regexp.MustCompile(`^[1-9]\d{8}$`)},
+	"IN": {callingCode: "91", trunkPrefix: "0", pattern: regexp.MustCompile(`^[1-9]\d{9}$`)},
+	"AU": {callingCode: "61", trunkPrefix: "0", pattern: regexp.MustCompile(`^[1-9]\d{8}$`)},
+	"JP": {callingCode: "81", trunkPrefix: "0", pattern: regexp.MustCompile(`^[1-9]\d{8,9}$`)},
+	"BR": {callingCode: "55", trunkPrefix: "0", pattern: regexp.MustCompile(`^[1-9]\d{9,10}$`)},
+}
+
+// phoneRegions - The country an international number is taken to be in by its calling code, unless the caller said
+// it's another country sharing the code.
+var phoneRegions = func() map[string]string {
+	regions := map[string]string{"1": CountryUS}
+	for code, plan := range phoneCountries {
+		if _, found := regions[plan.callingCode]; !found {
+			regions[plan.callingCode] = code
+		}
+	}
+	return regions
+}()
@@ -0,0 +1,51 @@
+package customers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+func Test_Customer_ParsePhoneNumber(t *testing.T) {
+	cases := []struct {
+		input   string
+		country string
+		number  string
+		region  string
+	}{
+		{"(319) 555-0100", "", "+13195550100", "US"},
+		{"319.555.0100", "US", "+13195550100", "US"},
+		{"1 319 555 0100", "US", "+13195550100", "US"},
+		{"+1 319 555 0100", "", "+13195550100", "US"},
+		{"+1 416 555 0100", "CA", "+14165550100", "CA"},
+		{"+1 416 555 0100", "GB", "+14165550100", "US"},
+		{"020 7946 0958", "GB", "+442079460958", "GB"},
+		{"+44 20 7946 0958", "", "+442079460958", "GB"},
+		{"030 123456", "DE", "+4930123456", "DE"},
+		{"+353 1 234 5678", "", "+35312345678", "IE"},
+	}
+	for _, tc := range cases {
+		number, region, err := customers.ParsePhoneNumber(tc.input, tc.country)
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.number, number, tc.input)
+		require.Equal(t, tc.region, region, tc.input)
+	}
+
+	invalid := []struct {
+		input   string
+		country string
+		err     error
+	}{
+		{"555-0100", "US", customers.ErrPhoneNumber},
+		{"(119) 555-0100", "US", customers.ErrPhoneNumber},
+		{"call me", "US", customers.ErrPhoneNumber},
+		{"+999 1234 5678", "", customers.ErrPhoneNumber},
+		{"020 7946 0958", "ZZ", customers.ErrPhoneCountry},
+	}
+	for _, tc := range invalid {
+		_, _, err := customers.ParsePhoneNumber(tc.input, tc.country)
+		require.ErrorIs(t, err, tc.err, tc.input)
+	}
+}
@@ -0,0 +1,46 @@
+package customers
+
+import (
+	"time"
+)
+
+// RescreeningActorID - The actor the rescreening job's changes to customers are attributed to in the audit log.
+const RescreeningActorID = "rescreening-job"
+
+// RescreeningStatus - Where a rescreening run is up to.
+type RescreeningStatus string
+
+const (
+	RescreeningRunning   RescreeningStatus = "running"
+	RescreeningCompleted RescreeningStatus = "completed"
+	// RescreeningSuperseded - Another version of the lists came out before the run finished, a new run took over.
+	RescreeningSuperseded RescreeningStatus = "superseded"
+)
+
+// RescreeningRun - Screening every active customer, across tenants, against one version of the sanctions lists.
+type RescreeningRun struct {
+	RunID       string            `json:"runID"`
+	ListVersion string            `json:"listVersion"`
+	Status      RescreeningStatus `json:"status"`
+	// Screened and Failed count customers so far, Alerts the alerts raised for them.
+	Screened   int        `json:"screened"`
+	Alerts     int        `json:"alerts"`
+	Failed     int        `json:"failed"`
+	StartedOn  time.Time  `json:"startedOn"`
+	UpdatedOn  time.Time  `json:"updatedOn"`
+	FinishedOn *time.Time `json:"finishedOn,omitempty"`
+	// After is the last customer the run got to, an interrupted run carries on from the one after it.
+	After ScreeningCandidate `json:"-"`
+}
+
+// ScreeningCandidate - An active customer to rescreen, without any of its personal data.
+type ScreeningCandidate struct {
+	TenantID   string `json:"tenantID"`
+	CustomerID string `json:"customerID"`
+}
+
+// ScreenableFilter - Pages through every tenant's active customers in tenant then customer ID order.
+type ScreenableFilter struct {
+	After ScreeningCandidate
+	Limit int
+}
@@ -0,0 +1,38 @@
+package customers
+
+import (
+	"time"
+)
+
+const (
+	// PurgeReason - Recorded on the erasures made by the retention job.
+	PurgeReason = "retention policy"
+	// PurgeActorID - The actor the retention job's erasures are attributed to in the audit log.
+	PurgeActorID = "retention-job"
+)
+
+// PurgeFilter - Selects customers for the retention job. Customers already erased or under legal hold never match.
+type PurgeFilter struct {
+	// TenantID limits the customers to one tenant, when empty every tenant but ExcludeTenantIDs is included.
+	TenantID         string
+	ExcludeTenantIDs []string
+	DisabledBefore   time.Time
+	Limit            int
+}
+
+// PurgeCandidate - A customer the retention job purges, without any of its personal data.
+type PurgeCandidate struct {
+	TenantID   string    `json:"tenantID"`
+	CustomerID string    `json:"customerID"`
+	DisabledOn time.Time `json:"disabledOn"`
+}
+
+// PurgeReport - What a run of the retention job purged, or would have purged in a dry run.
+type PurgeReport struct {
+	DryRun     bool             `json:"dryRun"`
+	StartedOn  time.Time        `json:"startedOn"`
+	FinishedOn time.Time        `json:"finishedOn"`
+	Purged     []PurgeCandidate `json:"purged"`
+	// Failed counts the customers that couldn't be purged, they're tried again on the next run.
+	Failed int `json:"failed"`
+}
@@ -0,0 +1,66 @@
+package customers
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/risk"
+)
+
+// Attributes of a customer the risk rules can match, see configs/risk-rules.yml.
+const (
+	RiskCustomerType = "customer_type"
+	// RiskEntityType - A business's entity type, missing for individuals.
+	RiskEntityType = "entity_type"
+	// RiskAge - Whole years since the customer's birth date.
+	RiskAge                = "age"
+	RiskScreeningStatus    = "screening_status"
+	RiskKYCStatus          = "kyc_status"
+	RiskVerificationStatus = "verification_status"
+	// RiskCountry - The country of the customer's primary residential address.
+	RiskCountry = "country"
+)
+
+// ErrRiskScoringOff - There are no risk rules to score the customer with.
+var ErrRiskScoringOff = errors.New("risk scoring is off")
+
+// RiskScore - The customer's risk under the rules version it was scored with. It's scored again whenever one of the
+// attributes the rules look at changes.
+type RiskScore struct {
+	Score int    `json:"score"`
+	Tier  string `json:"tier"`
+	// Factors are the rules that matched and added to the score.
+	Factors      []RiskFactor `json:"factors"`
+	RulesVersion string       `json:"rulesVersion"`
+	ScoredOn     time.Time    `json:"scoredOn"`
+}
+
+// RiskFactor - A rule that matched.
+type RiskFactor struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+func newRiskScore(assessment risk.Assessment, scoredOn time.Time) RiskScore {
+	score := RiskScore{
+		Score:        assessment.Score,
+		Tier:         assessment.Tier,
+		Factors:      make([]RiskFactor, len(assessment.Factors)),
+		RulesVersion: assessment.RulesVersion,
+		ScoredOn:     scoredOn,
+	}
+	for i, f := range assessment.Factors {
+		score.Factors[i] = RiskFactor{Name: f.Name, Score: f.Score}
+	}
+	return score
+}
+
+// sameAs - If the scores only differ in when they were made.
+func (r RiskScore) sameAs(other *RiskScore) bool {
+	return other != nil &&
+		r.Score == other.Score &&
+		r.Tier == other.Tier &&
+		r.RulesVersion == other.RulesVersion &&
+		slices.Equal(r.Factors, other.Factors)
+}
@@ -0,0 +1,66 @@
+package customers
+
+import (
+	"errors"
+	"time"
+)
+
+// ScreeningStatus - The outcome of screening a customer against the sanctions lists.
+type ScreeningStatus string
+
+const (
+	ScreeningClear ScreeningStatus = "clear"
+	// ScreeningFlagged - A possible match the compliance team has to review, the customer is stored as usual.
+	ScreeningFlagged ScreeningStatus = "flagged"
+	// ScreeningBlocked - A match close enough that the customer isn't created or updated. Existing customers are only
+	// blocked by rescreening, when a new version of a list matches them.
+	ScreeningBlocked ScreeningStatus = "blocked"
+)
+
+// MaxScreeningMatches - How many of the best matches are kept with a screening, a common name can match dozens of
+// entries and the first few are the ones reviewed.
+const MaxScreeningMatches = 10
+
+// ErrSanctionsMatch - The customer's name and birth date match an entry on a sanctions list.
+var ErrSanctionsMatch = errors.New("customer matches a sanctions list")
+
+// Screening - A check of the customer's name and birth date against the sanctions lists. Screenings are compliance
+// records so they're kept when the customer is erased, and for blocked customers that were never stored.
+type Screening struct {
+	TenantID    string          `json:"tenantID"`
+	CustomerID  string          `json:"customerID"`
+	ScreeningID string          `json:"screeningID"`
+	Status      ScreeningStatus `json:"status"`
+	// Score is the best of the matches, 0 when there weren't any.
+	Score      float64          `json:"score"`
+	Matches    []ScreeningMatch `json:"matches"`
+	ScreenedOn time.Time        `json:"screenedOn"`
+}
+
+// ScreeningMatch - A list entry scoring at least the flag threshold.
+type ScreeningMatch struct {
+	List     string   `json:"list"`
+	EntryID  string   `json:"entryID"`
+	Name     string   `json:"name"`
+	Programs []string `json:"programs,omitempty"`
+	Score    float64  `json:"score"`
+}
+
+// ScreeningAlert - A match found by rescreening that none of the customer's earlier screenings had, for the compliance
+// team to review.
+type ScreeningAlert struct {
+	TenantID    string          `json:"tenantID"`
+	CustomerID  string          `json:"customerID"`
+	AlertID     string          `json:"alertID"`
+	ScreeningID string          `json:"screeningID"`
+	RunID       string          `json:"runID"`
+	Status      ScreeningStatus `json:"status"`
+	Match       ScreeningMatch  `json:"match"`
+	CreatedOn   time.Time       `json:"createdOn"`
+}
+
+// ScreeningAlertFilter - Narrows down the tenant's alerts, every alert matches the zero value.
+type ScreeningAlertFilter struct {
+	CustomerID string
+	RunID      string
+}
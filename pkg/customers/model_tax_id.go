@@ -0,0 +1,191 @@
+package customers
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+type TaxIDType string
+
+const (
+	TaxIDSSN TaxIDType = "ssn"
+	// TaxIDITIN - Issued by the IRS to people who can't get an SSN.
+	TaxIDITIN TaxIDType = "itin"
+	TaxIDEIN  TaxIDType = "ein"
+	// TaxIDForeign - A tax ID issued by another country, it's kept with the country that issued it.
+	TaxIDForeign TaxIDType = "foreign_tin"
+)
+
+// TaxID - How an individual customer is identified for tax purposes. Value is only ever accepted, responses
+// carry it in Masked instead.
+type TaxID struct {
+	Type TaxIDType `json:"type"`
+	// Value is NNN-NN-NNNN for SSNs and ITINs and NN-NNNNNNN for EINs, the digits without dashes are accepted too.
+	Value string `json:"value,omitempty"`
+	// Country is the ISO 3166-1 alpha-2 code of the country that issued a foreign TIN, it's only for them.
+	Country string `json:"country,omitempty"`
+	// Masked is the value with all but its last four characters hidden, e.g. ***-**-6789.
+	Masked string `json:"masked,omitempty"`
+}
+
+var (
+	taxIDFormat  = regexp.MustCompile(`^\d{3}-\d{2}-\d{4}$`)
+	taxIDDigits  = regexp.MustCompile(`^(\d{3})-?(\d{2})-?(\d{4})$`)
+	foreignTaxID = regexp.MustCompile(`^[A-Z0-9][A-Z0-9 ./-]{1,29}$`)
+)
+
+var (
+	// ErrSSNNotIssued - The SSN is formatted correctly but falls in a range the SSA never issues.
+	ErrSSNNotIssued = errors.New("must be an SSN the SSA issues")
+	// ErrITINNotIssued - The ITIN is formatted correctly but falls outside the ranges the IRS issues.
+	ErrITINNotIssued = errors.New("must be an ITIN the IRS issues")
+)
+
+// Normalize - Tidies the tax ID into its standard form before it's validated: whitespace trimmed, SSNs, ITINs and
+// EINs written without dashes dashed, foreign TINs and countries uppercased.
+func (t *TaxID) Normalize() {
+	for _, field := range []*string{&t.Value, &t.Country} {
+		*field = spaces.ReplaceAllString(strings.TrimSpace(*field), " ")
+	}
+	t.Country = strings.ToUpper(t.Country)
+
+	switch t.Type {
+	case TaxIDSSN, TaxIDITIN:
+		if match := taxIDDigits.FindStringSubmatch(t.Value); match != nil {
+			t.Value = match[1] + "-" + match[2] + "-" + match[3]
+		}
+	case TaxIDEIN:
+		if match := einDigits.FindStringSubmatch(t.Value); match != nil {
+			t.Value = match[1] + "-" + match[2]
+		}
+	case TaxIDForeign:
+		t.Value = strings.ToUpper(t.Value)
+	}
+}
+
+// Validate - Checks a normalized tax ID, keep in sync with the TaxID schema in api/openapi.yaml.
+func (t TaxID) Validate() error {
+	foreign := t.Type == TaxIDForeign
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Type, validation.Required, validation.In(TaxIDSSN, TaxIDITIN, TaxIDEIN, TaxIDForeign)),
+		validation.Field(&t.Value, validation.Required,
+			validation.When(t.Type == TaxIDSSN || t.Type == TaxIDITIN,
+				validation.Match(taxIDFormat).Error("must be formatted as NNN-NN-NNNN")),
+			validation.When(t.Type == TaxIDSSN, validation.By(ssnIssued)),
+			validation.When(t.Type == TaxIDITIN, validation.By(itinIssued)),
+			validation.When(t.Type == TaxIDEIN,
+				validation.Match(einFormat).Error("must be formatted as NN-NNNNNNN"), validation.By(einPrefix)),
+			validation.When(foreign, validation.Match(foreignTaxID).
+				Error("must be 2 to 30 letters, digits, spaces, dots, slashes or dashes"))),
+		validation.Field(&t.Country, validation.When(foreign, validation.Required, is.CountryCode2,
+			validation.NotIn(CountryUS).Error("must be another country, US tax IDs are SSNs, ITINs or EINs")).
+			Else(validation.Empty.Error("must be blank unless it's a foreign TIN"))),
+	)
+}
+
+// ssnIssued - The SSA never issues area 000, 666 or 900 and up, group 00 or serial 0000. Those from 900 up are
+// ITINs.
+func ssnIssued(value interface{}) error {
+	area, group, serial, ok := taxIDParts(value)
+	if ok && (area == 0 || area == 666 || area >= 900 || group == 0 || serial == 0) {
+		return ErrSSNNotIssued
+	}
+	return nil
+}
+
+// itinIssued - ITINs start with a 9 and have a group, the fourth and fifth digits, of 50-65, 70-88, 90-92 or 94-99.
+func itinIssued(value interface{}) error {
+	area, group, _, ok := taxIDParts(value)
+	if !ok {
+		return nil
+	}
+	validGroup := (group >= 50 && group <= 65) || (group >= 70 && group <= 88) || (group >= 90 && group <= 92) || group >= 94
+	if area < 900 || !validGroup {
+		return ErrITINNotIssued
+	}
+	return nil
+}
+
+// taxIDParts - The area, group and serial of an SSN or ITIN, not ok when it isn't formatted as one so the format
+// rule reports it.
+func taxIDParts(value interface{}) (area int, group int, serial int, ok bool) {
+	s, _ := value.(string)
+	if !taxIDFormat.MatchString(s) {
+		return 0, 0, 0, false
+	}
+	area, _ = strconv.Atoi(s[0:3])
+	group, _ = strconv.Atoi(s[4:6])
+	serial, _ = strconv.Atoi(s[7:11])
+	return area, group, serial, true
+}
+
+// keepValue - A tax ID sent back as it was returned has no value, it keeps the current one if it's the same type
+// from the same country.
+func (t *TaxID) keepValue(cur *TaxID) {
+	if t == nil || cur == nil || t.Value != "" {
+		return
+	}
+	if t.Type == cur.Type && t.Country == cur.Country {
+		t.Value = cur.Value
+	}
+}
+
+// masked - The tax ID as it's returned, with Masked in place of its value.
+func (t *TaxID) masked() *TaxID {
+	if t == nil {
+		return nil
+	}
+	m := *t
+	m.Masked = maskValue(t.Value)
+	m.Value = ""
+	return &m
+}
+
+// maskValue - Hides every letter and digit but the last four, keeping the separators. Values too short to hide at
+// least as many as they show are hidden completely.
+func maskValue(value string) string {
+	visible := 4
+	alphanumerics := 0
+	for _, r := range value {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			alphanumerics++
+		}
+	}
+	if alphanumerics < 2*visible {
+		visible = 0
+	}
+
+	masked := []rune(value)
+	hide := alphanumerics - visible
+	for i, r := range masked {
+		if hide == 0 {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			masked[i] = '*'
+			hide--
+		}
+	}
+	return string(masked)
+}
+
+// value - The tax ID's value, empty for customers without one.
+func (t *TaxID) value() string {
+	if t == nil {
+		return ""
+	}
+	return t.Value
+}
+
+func sameTaxID(a *TaxID, b *TaxID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Type == b.Type && a.Value == b.Value && a.Country == b.Country
+}
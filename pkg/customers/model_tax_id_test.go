@@ -0,0 +1,63 @@
+package customers_test
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+func Test_Customer_TaxID(t *testing.T) {
+	valid := []struct {
+		input customers.TaxID
+		value string
+	}{
+		{customers.TaxID{Type: customers.TaxIDSSN, Value: " 123456789 "}, "123-45-6789"},
+		{customers.TaxID{Type: customers.TaxIDSSN, Value: "665-01-0001"}, "665-01-0001"},
+		{customers.TaxID{Type: customers.TaxIDITIN, Value: "912-70-1234"}, "912-70-1234"},
+		{customers.TaxID{Type: customers.TaxIDITIN, Value: "999991234"}, "999-99-1234"},
+		{customers.TaxID{Type: customers.TaxIDEIN, Value: "123456789"}, "12-3456789"},
+		{customers.TaxID{Type: customers.TaxIDForeign, Value: "qq  12 34 56 c", Country: "gb"}, "QQ 12 34 56 C"},
+	}
+	for _, tc := range valid {
+		taxID := tc.input
+		taxID.Normalize()
+		require.NoError(t, taxID.Validate(), tc.input.Value)
+		require.Equal(t, tc.value, taxID.Value, tc.input.Value)
+	}
+
+	invalid := []struct {
+		name  string
+		input customers.TaxID
+		field string
+	}{
+		{"Missing type", customers.TaxID{Value: "123-45-6789"}, "type"},
+		{"Missing value", customers.TaxID{Type: customers.TaxIDSSN}, "value"},
+		{"Short SSN", customers.TaxID{Type: customers.TaxIDSSN, Value: "123-45-678"}, "value"},
+		{"SSN area 000", customers.TaxID{Type: customers.TaxIDSSN, Value: "000-12-3456"}, "value"},
+		{"SSN area 666", customers.TaxID{Type: customers.TaxIDSSN, Value: "666-12-3456"}, "value"},
+		{"SSN area 9xx", customers.TaxID{Type: customers.TaxIDSSN, Value: "912-70-1234"}, "value"},
+		{"SSN group 00", customers.TaxID{Type: customers.TaxIDSSN, Value: "123-00-4567"}, "value"},
+		{"SSN serial 0000", customers.TaxID{Type: customers.TaxIDSSN, Value: "123-45-0000"}, "value"},
+		{"ITIN not starting with 9", customers.TaxID{Type: customers.TaxIDITIN, Value: "812-70-1234"}, "value"},
+		{"ITIN group 93", customers.TaxID{Type: customers.TaxIDITIN, Value: "912-93-1234"}, "value"},
+		{"ITIN group 66", customers.TaxID{Type: customers.TaxIDITIN, Value: "912-66-1234"}, "value"},
+		{"EIN prefix", customers.TaxID{Type: customers.TaxIDEIN, Value: "07-1234567"}, "value"},
+		{"Foreign TIN symbols", customers.TaxID{Type: customers.TaxIDForeign, Value: "12#34", Country: "DE"}, "value"},
+		{"Foreign TIN without a country", customers.TaxID{Type: customers.TaxIDForeign, Value: "12345678901"}, "country"},
+		{"Foreign TIN from the US", customers.TaxID{Type: customers.TaxIDForeign, Value: "12345678901", Country: "US"}, "country"},
+		{"Foreign TIN from nowhere", customers.TaxID{Type: customers.TaxIDForeign, Value: "12345678901", Country: "XX"}, "country"},
+		{"Country on an SSN", customers.TaxID{Type: customers.TaxIDSSN, Value: "123-45-6789", Country: "GB"}, "country"},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			taxID := tc.input
+			taxID.Normalize()
+			errs, ok := taxID.Validate().(validation.Errors)
+			require.True(t, ok)
+			require.Contains(t, errs, tc.field)
+		})
+	}
+}
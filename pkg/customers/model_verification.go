@@ -0,0 +1,87 @@
+package customers
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// VerificationStatus - Where a check of the customer's identity with the identity provider is up to.
+type VerificationStatus string
+
+const (
+	// VerificationPending - Waiting for the provider, including between retries.
+	VerificationPending        VerificationStatus = "pending"
+	VerificationVerified       VerificationStatus = "verified"
+	VerificationFailed         VerificationStatus = "failed"
+	VerificationReviewRequired VerificationStatus = "review_required"
+	// VerificationErrored - The provider couldn't be reached in MaxAttempts, the customer needs reviewing instead.
+	VerificationErrored VerificationStatus = "errored"
+	// VerificationSuperseded - The customer's identity changed before the provider answered, a new check took over.
+	VerificationSuperseded VerificationStatus = "superseded"
+)
+
+const (
+	// KYCVerificationStartedReason - Recorded when a new customer is sent for identity verification.
+	KYCVerificationStartedReason = "identity verification started"
+	// KYCVerificationErroredReason - Recorded when the identity provider couldn't be reached.
+	KYCVerificationErroredReason = "identity provider unavailable"
+)
+
+// VerificationActorID - The actor the verification worker's reads of customers are attributed to in the audit log.
+const VerificationActorID = "verification-worker"
+
+// ErrVerificationOff - There's no identity provider configured.
+var ErrVerificationOff = errors.New("identity verification is not configured")
+
+// ErrVerificationIndividualsOnly - The identity provider only checks people, businesses are reviewed through KYC
+// transitions.
+var ErrVerificationIndividualsOnly = errors.New("identity verification is only for individuals")
+
+// ErrVerificationTaxIDType - The identity provider checks SSNs and ITINs, individuals with another tax ID are
+// reviewed through KYC transitions.
+var ErrVerificationTaxIDType = errors.New("identity verification needs an SSN or ITIN")
+
+// Verification - A check of the customer's name, SSN or ITIN, birth date and address with the identity provider.
+// Only the outcome is kept, not the details sent.
+type Verification struct {
+	TenantID       string             `json:"tenantID"`
+	CustomerID     string             `json:"customerID"`
+	VerificationID string             `json:"verificationID"`
+	Provider       string             `json:"provider"`
+	Status         VerificationStatus `json:"status"`
+	// Reference is the provider's ID for the check, Reasons its codes for the details that didn't match.
+	Reference string   `json:"reference,omitempty"`
+	Reasons   []string `json:"reasons,omitempty"`
+	// Attempts counts the calls made to the provider, LastError is why the last of them failed.
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"lastError,omitempty"`
+	// NextAttemptOn is when a pending check is next sent to the provider.
+	NextAttemptOn time.Time  `json:"nextAttemptOn"`
+	CreatedOn     time.Time  `json:"createdOn"`
+	CompletedOn   *time.Time `json:"completedOn,omitempty"`
+}
+
+// kycStatus - The KYC status a completed verification moves a pending customer to.
+func (v Verification) kycStatus() KYCStatus {
+	switch v.Status {
+	case VerificationVerified:
+		return KYCVerified
+	case VerificationFailed:
+		return KYCFailed
+	default:
+		return KYCReviewRequired
+	}
+}
+
+// kycReason - Why a completed verification moved the customer's KYC status.
+func (v Verification) kycReason() string {
+	if v.Status == VerificationErrored {
+		return KYCVerificationErroredReason
+	}
+	reason := "identity verification " + string(v.Status)
+	if len(v.Reasons) > 0 {
+		reason += ": " + strings.Join(v.Reasons, ", ")
+	}
+	return reason
+}
@@ -1,64 +1,221 @@
 package customers
 
 import (
+	"context"
 	"database/sql"
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
 )
 
 // Repository - Used for interacting identities on the data store
 type CustomerRepository interface {
-	Add(create Customer) (*Customer, error)
-	List(tenantID string) ([]Customer, error)
-	Get(tenantID string, customerID string) (*Customer, error)
-	Update(update Customer) (*Customer, error)
-	Delete(update Customer) (*Customer, error)
+	Add(ctx context.Context, create Customer) (*Customer, error)
+	// List - The tenant's active customers matching the filter.
+	List(ctx context.Context, tenantID string, filter ListFilter) ([]Customer, error)
+	Get(ctx context.Context, tenantID string, customerID string) (*Customer, error)
+	Update(ctx context.Context, update Customer) (*Customer, error)
+	Delete(ctx context.Context, update Customer) (*Customer, error)
+
+	// ListChanges - Changes to the tenant's customers after the sequence number, oldest first.
+	ListChanges(ctx context.Context, tenantID string, after int64, limit int) ([]CustomerChange, error)
+	// LatestChange - Sequence number of the tenant's most recent change, 0 when there hasn't been one.
+	LatestChange(ctx context.Context, tenantID string) (int64, error)
+
+	// SetLegalHold - Places the hold on the customer, or releases it when hold is nil.
+	SetLegalHold(ctx context.Context, tenantID string, customerID string, hold *LegalHold, updatedOn time.Time) error
+	// Erase - Irreversibly removes the customer's personal data and records the erasure, leaving a tombstone.
+	// Customers under legal hold or already erased aren't found.
+	Erase(ctx context.Context, erasure CustomerErasure) error
+	GetErasure(ctx context.Context, tenantID string, customerID string) (*CustomerErasure, error)
+
+	// ListPurgeable - Customers matching the filter, the longest disabled first.
+	ListPurgeable(ctx context.Context, filter PurgeFilter) ([]PurgeCandidate, error)
+
+	// AddAddress - A primary address takes over from the customer's other primary address of the same type.
+	AddAddress(ctx context.Context, create Address) (*Address, error)
+	// ListAddresses - The customer's addresses, oldest first.
+	ListAddresses(ctx context.Context, tenantID string, customerID string) ([]Address, error)
+	UpdateAddress(ctx context.Context, update Address) (*Address, error)
+	DeleteAddress(ctx context.Context, tenantID string, customerID string, addressID string, deletedOn time.Time) error
+
+	AddBeneficialOwner(ctx context.Context, create BeneficialOwner) (*BeneficialOwner, error)
+	// ListBeneficialOwners - The business's owners, oldest first.
+	ListBeneficialOwners(ctx context.Context, tenantID string, customerID string) ([]BeneficialOwner, error)
+	UpdateBeneficialOwner(ctx context.Context, update BeneficialOwner) (*BeneficialOwner, error)
+	DeleteBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string, deletedOn time.Time) error
+
+	// AddPhone - A primary phone takes over from the customer's other primary phone of the same type.
+	AddPhone(ctx context.Context, create Phone) (*Phone, error)
+	// ListPhones - The customer's phones, oldest first.
+	ListPhones(ctx context.Context, tenantID string, customerID string) ([]Phone, error)
+	UpdatePhone(ctx context.Context, update Phone) (*Phone, error)
+	DeletePhone(ctx context.Context, tenantID string, customerID string, phoneID string, deletedOn time.Time) error
+
+	AddBankAccount(ctx context.Context, create BankAccount) (*BankAccount, error)
+	// ListBankAccounts - The customer's bank accounts, oldest first.
+	ListBankAccounts(ctx context.Context, tenantID string, customerID string) ([]BankAccount, error)
+	// UpdateBankAccount - Saves the account's status and micro-deposits. It's only found while its status and failed
+	// attempts are still from's, so concurrent confirmations can't use the same attempt. More failed attempts are
+	// also kept for BankAccountFailedAttempts.
+	UpdateBankAccount(ctx context.Context, from BankAccount, update BankAccount) (*BankAccount, error)
+	DeleteBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string, deletedOn time.Time) error
+	// BankAccountFailedAttempts - The most failed attempts of any account the customer had with the routing and
+	// account numbers, deleted ones included. Erasing the customer forgets them.
+	BankAccountFailedAttempts(ctx context.Context, tenantID string, customerID string, routingNumber string, accountNumber string) (int, error)
+
+	// TransitionKYC - Moves the customer from the transition's From status to its To status and records it. Customers
+	// no longer in the From status, disabled or erased aren't found.
+	TransitionKYC(ctx context.Context, transition KYCTransition) error
+	// ListKYCTransitions - The customer's KYC history, oldest first.
+	ListKYCTransitions(ctx context.Context, tenantID string, customerID string) ([]KYCTransition, error)
+
+	// DataKey - The customer's data key for encrypting what's stored outside the repository, like its documents.
+	// Customers without one are given one, erasing the customer deletes it.
+	DataKey(ctx context.Context, tenantID string, customerID string, createdOn time.Time) (*envelope.DataKey, error)
+	AddDocument(ctx context.Context, create Document) (*Document, error)
+	// ListDocuments - The customer's documents, oldest first.
+	ListDocuments(ctx context.Context, tenantID string, customerID string) ([]Document, error)
+	DeleteDocument(ctx context.Context, tenantID string, customerID string, documentID string, deletedOn time.Time) error
+
+	// AddScreening - Records the screening and its matches, the customer's status is saved with the customer.
+	AddScreening(ctx context.Context, create Screening) error
+	// ListScreenings - The customer's screenings, oldest first. They're kept when the customer is erased.
+	ListScreenings(ctx context.Context, tenantID string, customerID string) ([]Screening, error)
+	// SetScreeningStatus - Only active customers are found, rescreening leaves the others alone.
+	SetScreeningStatus(ctx context.Context, tenantID string, customerID string, status ScreeningStatus, updatedOn time.Time) error
+	// ListScreenable - Active customers of every tenant after the filter's, in tenant then customer ID order.
+	ListScreenable(ctx context.Context, filter ScreenableFilter) ([]ScreeningCandidate, error)
+	AddScreeningAlerts(ctx context.Context, alerts []ScreeningAlert) error
+	// ListScreeningAlerts - The tenant's alerts matching the filter, oldest first.
+	ListScreeningAlerts(ctx context.Context, tenantID string, filter ScreeningAlertFilter) ([]ScreeningAlert, error)
+
+	AddRescreeningRun(ctx context.Context, run RescreeningRun) error
+	UpdateRescreeningRun(ctx context.Context, run RescreeningRun) error
+	// LatestRescreeningRun - The run started most recently, sql.ErrNoRows when there hasn't been one.
+	LatestRescreeningRun(ctx context.Context) (*RescreeningRun, error)
+
+	// AddVerification - Supersedes the customer's verifications that are still pending, only the latest is completed.
+	AddVerification(ctx context.Context, create Verification) error
+	// UpdateVerification - Saves the verification's attempt or answer. It's only found while it's pending with from's
+	// attempts and next attempt, so a superseded one can't be completed and two workers can't claim the same attempt.
+	UpdateVerification(ctx context.Context, from Verification, update Verification) error
+	// ListVerifications - The customer's verifications, oldest first.
+	ListVerifications(ctx context.Context, tenantID string, customerID string) ([]Verification, error)
+	// ListDueVerifications - Pending verifications of every tenant whose next attempt is due by dueBy, longest due
+	// first.
+	ListDueVerifications(ctx context.Context, dueBy time.Time, limit int) ([]Verification, error)
+
+	// SetRiskScore - Replaces the customer's risk score. Only active customers are found.
+	SetRiskScore(ctx context.Context, tenantID string, customerID string, score RiskScore) error
 }
 
 type customerRepo struct {
-	db *sql.DB
+	db       *sql.DB
+	dialect  sqldb.Dialect
+	timeouts TimeoutsConfig
+	keys     *envelope.KeyEncryptionKey
 }
 
-func NewCustomerRepository(db *sql.DB) CustomerRepository {
-	return &customerRepo{db: db}
+// NewCustomerRepository - Personal data is encrypted with a key per customer, wrapped by keys, so erasing a
+// customer only has to delete its key.
+func NewCustomerRepository(db *sql.DB, dialect sqldb.Dialect, timeouts TimeoutsConfig, keys *envelope.KeyEncryptionKey) CustomerRepository {
+	return &tracedCustomerRepository{next: &customerRepo{db: db, dialect: dialect, timeouts: timeouts, keys: keys}}
 }
 
-func (r *customerRepo) List(tenantID string) ([]Customer, error) {
-	qry := `
-		SELECT 
+// customerColumns - Selected by every query that returns customers, in the order scanCustomer reads them.
+const customerColumns = `
 			customers.tenant_id,
 			customers.customer_id,
+			customers.customer_type,
 			customers.name,
 			customers.birth_date,
 			customers.email,
 			customers.ssn,
 			customers.created_on,
 			customers.updated_on,
-			customers.disabled_on
-		FROM customers
-		WHERE customers.tenant_id = "` + tenantID + `"
+			customers.disabled_on,
+			customers.erased_on,
+			customers.legal_hold_on,
+			customers.legal_hold_reason,
+			customers.kyc_status,
+			customers.screening_status,
+			customer_keys.wrapped_key,
+			customer_tax_ids.tax_id_type,
+			customer_tax_ids.tax_id,
+			customer_tax_ids.country,
+			customer_businesses.legal_name,
+			customer_businesses.dba,
+			customer_businesses.ein,
+			customer_businesses.entity_type,
+			customer_businesses.formation_date,
+			customer_businesses.incorporation_state,
+			customer_risk_scores.score,
+			customer_risk_scores.tier,
+			customer_risk_scores.factors,
+			customer_risk_scores.rules_version,
+			customer_risk_scores.scored_on`
+
+// customerJoin - Brings in the customer's data key, missing for customers erased or stored before encryption, its
+// tax ID, missing for businesses, its business details, missing for individuals, and its risk score, missing until
+// the customer is scored.
+const customerJoin = `
+		LEFT JOIN customer_keys
+		  ON customer_keys.tenant_id = customers.tenant_id
+		 AND customer_keys.customer_id = customers.customer_id
+		LEFT JOIN customer_tax_ids
+		  ON customer_tax_ids.tenant_id = customers.tenant_id
+		 AND customer_tax_ids.customer_id = customers.customer_id
+		LEFT JOIN customer_businesses
+		  ON customer_businesses.tenant_id = customers.tenant_id
+		 AND customer_businesses.customer_id = customers.customer_id
+		LEFT JOIN customer_risk_scores
+		  ON customer_risk_scores.tenant_id = customers.tenant_id
+		 AND customer_risk_scores.customer_id = customers.customer_id`
+
+// withTimeout - Bounds a single repository operation, the caller's deadline still applies if it's sooner.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (r *customerRepo) List(ctx context.Context, tenantID string, filter ListFilter) ([]Customer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT ` + customerColumns + `
+		FROM customers` + customerJoin + `
+		WHERE customers.tenant_id = ?
+		  AND customers.disabled_on IS NULL
 	`
+	args := []interface{}{tenantID}
+
+	if filter.KYCStatus != "" {
+		qry += ` AND customers.kyc_status = ?`
+		args = append(args, filter.KYCStatus)
+	}
 
-	return r.queryScanCustomer(qry)
+	return r.queryScanCustomer(ctx, qry, args...)
 }
 
-func (r *customerRepo) Get(tenantID string, customerID string) (*Customer, error) {
+func (r *customerRepo) Get(ctx context.Context, tenantID string, customerID string) (*Customer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
 	qry := `
-		SELECT 
-			customers.tenant_id,
-			customers.customer_id,
-			customers.name,
-			customers.birth_date,
-			customers.email,
-			customers.ssn,
-			customers.created_on,
-			customers.updated_on,
-			customers.disabled_on
-		FROM customers
-		WHERE customers.tenant_id = ? 
+		SELECT ` + customerColumns + `
+		FROM customers` + customerJoin + `
+		WHERE customers.tenant_id = ?
 		  AND customers.customer_id = ?
 		LIMIT 1
 	`
 
-	rows, err := r.queryScanCustomer(qry, tenantID, customerID)
+	rows, err := r.queryScanCustomer(ctx, qry, tenantID, customerID)
 	if err != nil {
 		return nil, err
 	}
@@ -70,13 +227,28 @@ func (r *customerRepo) Get(tenantID string, customerID string) (*Customer, error
 	return &rows[0], nil
 }
 
-func (r *customerRepo) Update(update Customer) (*Customer, error) {
-	tx, err := r.db.Begin()
+func (r *customerRepo) Update(ctx context.Context, update Customer) (*Customer, error) {
+	pendingEventFrom(ctx).saving(update)
+
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
+	dataKey, err := r.dataKeyForUpdate(ctx, tx, update.TenantID, update.CustomerID, update.UpdatedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := encryptCustomer(dataKey, update)
+	if err != nil {
+		return nil, err
+	}
+
 	qry := `
 		UPDATE customers
 		SET
@@ -85,19 +257,21 @@ func (r *customerRepo) Update(update Customer) (*Customer, error) {
 			email = ?,
 			ssn = ?,
 			updated_on = ?,
-			disabled_on = ?
+			disabled_on = ?,
+			screening_status = ?
 		WHERE
 			customer_id = ?
 			AND tenant_id = ? 
 			AND disabled_on IS NULL 
 	`
-	res, err := tx.Exec(qry,
-		update.Name,
-		update.BirthDate,
-		update.Email,
-		update.Ssn,
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		encrypted.Name,
+		encrypted.BirthDate,
+		encrypted.Email,
+		legacySSN(encrypted.TaxID),
 		update.UpdatedOn,
 		update.DisabledOn,
+		update.ScreeningStatus,
 
 		update.CustomerID,
 		update.TenantID)
@@ -113,13 +287,30 @@ func (r *customerRepo) Update(update Customer) (*Customer, error) {
 		return nil, sql.ErrNoRows
 	}
 
-	tx.Commit()
+	if err := r.saveTaxID(ctx, tx, encrypted); err != nil {
+		return nil, err
+	}
+
+	if err := r.saveBusiness(ctx, tx, encrypted); err != nil {
+		return nil, err
+	}
+
+	if err := r.recordChange(ctx, tx, update.TenantID, update.CustomerID, ChangeUpdated, update.UpdatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
 	return &update, nil
 }
 
-func (r *customerRepo) Delete(update Customer) (*Customer, error) {
-	tx, err := r.db.Begin()
+func (r *customerRepo) Delete(ctx context.Context, update Customer) (*Customer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -135,7 +326,7 @@ func (r *customerRepo) Delete(update Customer) (*Customer, error) {
 			tenant_id = ? AND
 			disabled_on IS NULL
 	`
-	res, err := tx.Exec(qry,
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
 		update.UpdatedOn,
 		update.DisabledOn,
 		update.CustomerID,
@@ -152,42 +343,69 @@ func (r *customerRepo) Delete(update Customer) (*Customer, error) {
 		return nil, sql.ErrNoRows
 	}
 
-	tx.Commit()
+	if err := r.recordChange(ctx, tx, update.TenantID, update.CustomerID, ChangeDisabled, update.UpdatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
 	return &update, nil
 }
 
-func (r *customerRepo) Add(create Customer) (*Customer, error) {
-	tx, err := r.db.Begin()
+func (r *customerRepo) Add(ctx context.Context, create Customer) (*Customer, error) {
+	pendingEventFrom(ctx).saving(create)
+
+	ctx, cancel := withTimeout(ctx, r.timeouts.Add)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
+	dataKey, err := r.newDataKey(ctx, tx, create.TenantID, create.CustomerID, create.CreatedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := encryptCustomer(dataKey, create)
+	if err != nil {
+		return nil, err
+	}
+
 	qry := `
 		INSERT INTO customers(
 			tenant_id, 
 			customer_id, 
+			customer_type,
 			name,
 			birth_date, 
 			email, 
 			ssn, 
 			created_on, 
 			updated_on, 
-			disabled_on 
-		) VALUES (?,?,?,?,?,?,?,?,?)
+			disabled_on,
+			kyc_status,
+			screening_status
+		) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)
 	`
 
-	res, err := tx.Exec(qry,
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
 		create.TenantID,
 		create.CustomerID,
-		create.Name,
-		create.BirthDate,
-		create.Email,
-		create.Ssn,
+		create.Type.orDefault(),
+		encrypted.Name,
+		encrypted.BirthDate,
+		encrypted.Email,
+		legacySSN(encrypted.TaxID),
 		create.CreatedOn,
 		create.UpdatedOn,
 		create.DisabledOn,
+		create.KYCStatus,
+		create.ScreeningStatus,
 	)
 	if err != nil {
 		return nil, err
@@ -201,35 +419,209 @@ func (r *customerRepo) Add(create Customer) (*Customer, error) {
 		return nil, sql.ErrNoRows
 	}
 
-	tx.Commit()
+	if err := r.saveTaxID(ctx, tx, encrypted); err != nil {
+		return nil, err
+	}
+
+	if err := r.saveBusiness(ctx, tx, encrypted); err != nil {
+		return nil, err
+	}
+
+	if err := r.recordChange(ctx, tx, create.TenantID, create.CustomerID, ChangeCreated, create.CreatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
 	return &create, nil
 }
 
-func (r *customerRepo) queryScanCustomer(query string, args ...interface{}) ([]Customer, error) {
-	rows, err := r.db.Query(query, args...)
+// legacySSN - What's kept in customers.ssn alongside an encrypted tax ID, the SSN when it's one.
+func legacySSN(encrypted *TaxID) string {
+	if encrypted == nil || encrypted.Type != TaxIDSSN {
+		return ""
+	}
+	return encrypted.Value
+}
+
+// saveTaxID - Replaces the tax ID of the encrypted customer, businesses don't have one.
+func (r *customerRepo) saveTaxID(ctx context.Context, tx *sql.Tx, encrypted Customer) error {
+	qry := `
+		DELETE FROM customer_tax_ids
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+	`
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), encrypted.TenantID, encrypted.CustomerID); err != nil {
+		return err
+	}
+
+	t := encrypted.TaxID
+	if t == nil {
+		return nil
+	}
+
+	qry = `
+		INSERT INTO customer_tax_ids(
+			tenant_id,
+			customer_id,
+			tax_id_type,
+			tax_id,
+			country
+		) VALUES (?,?,?,?,?)
+	`
+	_, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		encrypted.TenantID,
+		encrypted.CustomerID,
+		t.Type,
+		t.Value,
+		t.Country,
+	)
+	return err
+}
+
+// saveBusiness - Replaces the business details of the encrypted customer, individuals don't have any.
+func (r *customerRepo) saveBusiness(ctx context.Context, tx *sql.Tx, encrypted Customer) error {
+	qry := `
+		DELETE FROM customer_businesses
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+	`
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), encrypted.TenantID, encrypted.CustomerID); err != nil {
+		return err
+	}
+
+	b := encrypted.Business
+	if b == nil {
+		return nil
+	}
+
+	qry = `
+		INSERT INTO customer_businesses(
+			tenant_id,
+			customer_id,
+			legal_name,
+			dba,
+			ein,
+			entity_type,
+			formation_date,
+			incorporation_state
+		) VALUES (?,?,?,?,?,?,?,?)
+	`
+	_, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		encrypted.TenantID,
+		encrypted.CustomerID,
+		b.LegalName,
+		b.DBA,
+		b.EIN,
+		b.EntityType,
+		b.FormationDate,
+		b.IncorporationState,
+	)
+	return err
+}
+
+// recordChange - Appends to the change feed in the same transaction as the write so the feed can't miss one. The
+// change is numbered from the tenant's counter in customer_change_sequences. Moving the counter forward locks the
+// tenant's row until tx commits, so the tenant's changes are committed in sequence order and a reader's cursor can't
+// pass one still being written.
+func (r *customerRepo) recordChange(ctx context.Context, tx *sql.Tx, tenantID string, customerID string, changeType ChangeType, changedOn time.Time) error {
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(r.startChangeSequence()), tenantID); err != nil {
+		return err
+	}
+
+	qry := `
+		UPDATE customer_change_sequences
+		SET sequence_number = sequence_number + 1
+		WHERE tenant_id = ?
+	`
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), tenantID); err != nil {
+		return err
+	}
+
+	qry = `
+		SELECT sequence_number
+		FROM customer_change_sequences
+		WHERE tenant_id = ?
+	`
+	var sequence int64
+	if err := tx.QueryRowContext(ctx, r.dialect.Rebind(qry), tenantID).Scan(&sequence); err != nil {
+		return err
+	}
+
+	qry = `
+		INSERT INTO customer_changes(
+			tenant_id,
+			sequence_number,
+			customer_id,
+			change_type,
+			changed_on
+		) VALUES (?,?,?,?,?)
+	`
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), tenantID, sequence, customerID, changeType, changedOn); err != nil {
+		return err
+	}
+
+	// The audit event of the call making the change is staged by its first transaction, later ones are part of it.
+	if pending := pendingEventFrom(ctx); pending.ready() {
+		event := audit.Event{CustomerID: customerID, Action: pending.action, Fields: pending.fields, OccurredOn: changedOn}
+		if err := audit.Stage(ctx, tx, r.dialect, tenantID, pending.outboxID, event); err != nil {
+			return err
+		}
+		pending.staged = true
+	}
+	return nil
+}
+
+// startChangeSequence - Adds the tenant's counter unless it already has one.
+func (r *customerRepo) startChangeSequence() string {
+	switch r.dialect {
+	case sqldb.MySQL:
+		return `INSERT IGNORE INTO customer_change_sequences(tenant_id, sequence_number) VALUES (?, 0)`
+	case sqldb.Postgres:
+		return `INSERT INTO customer_change_sequences(tenant_id, sequence_number) VALUES (?, 0) ON CONFLICT DO NOTHING`
+	default:
+		return `INSERT OR IGNORE INTO customer_change_sequences(tenant_id, sequence_number) VALUES (?, 0)`
+	}
+}
+
+func (r *customerRepo) ListChanges(ctx context.Context, tenantID string, after int64, limit int) ([]CustomerChange, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT
+			customer_changes.sequence_number,
+			customer_changes.change_type,
+			customer_changes.changed_on,` + customerColumns + `
+		FROM customer_changes
+		JOIN customers
+		  ON customers.tenant_id = customer_changes.tenant_id
+		 AND customers.customer_id = customer_changes.customer_id` + customerJoin + `
+		WHERE customer_changes.tenant_id = ?
+		  AND customer_changes.sequence_number > ?
+		ORDER BY customer_changes.sequence_number
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), tenantID, after, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	items := []Customer{}
+	items := []CustomerChange{}
 	for rows.Next() {
-		item := Customer{}
-		if err := rows.Scan(
-			&item.TenantID,
-			&item.CustomerID,
-			&item.Name,
-			&item.BirthDate,
-			&item.Email,
-			&item.Ssn,
-			&item.CreatedOn,
-			&item.UpdatedOn,
-			&item.DisabledOn,
-		); err != nil {
+		item := CustomerChange{}
+		customer, err := r.scanCustomer(rows, &item.Sequence, &item.Type, &item.ChangedOn)
+		if err != nil {
 			return nil, err
 		}
 
+		item.ChangedOn = item.ChangedOn.UTC()
+		item.Customer = *customer
+
 		items = append(items, item)
 	}
 
@@ -239,3 +631,152 @@ func (r *customerRepo) queryScanCustomer(query string, args ...interface{}) ([]C
 
 	return items, nil
 }
+
+func (r *customerRepo) LatestChange(ctx context.Context, tenantID string) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	qry := `
+		SELECT MAX(customer_changes.sequence_number)
+		FROM customer_changes
+		WHERE customer_changes.tenant_id = ?
+	`
+
+	var latest sql.NullInt64
+	if err := r.db.QueryRowContext(ctx, r.dialect.Rebind(qry), tenantID).Scan(&latest); err != nil {
+		return 0, err
+	}
+
+	return latest.Int64, nil
+}
+
+func (r *customerRepo) queryScanCustomer(ctx context.Context, query string, args ...interface{}) ([]Customer, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Customer{}
+	for rows.Next() {
+		item, err := r.scanCustomer(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, *item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// scanCustomer - Reads the customerColumns after any leading columns and decrypts the customer's personal data.
+func (r *customerRepo) scanCustomer(rows *sql.Rows, leading ...interface{}) (*Customer, error) {
+	item := Customer{}
+	var legalHoldOn *time.Time
+	var legalHoldReason, wrappedKey sql.NullString
+	var riskScore sql.NullInt64
+	var riskTier, riskFactors, riskRulesVersion sql.NullString
+	var riskScoredOn *time.Time
+	var ssn string
+	var taxIDType, taxID, taxIDCountry sql.NullString
+	var businessLegalName, businessDBA, businessEIN, businessEntityType, businessState sql.NullString
+	var businessFormationDate *string
+
+	dest := append(leading,
+		&item.TenantID,
+		&item.CustomerID,
+		&item.Type,
+		&item.Name,
+		&item.BirthDate,
+		&item.Email,
+		&ssn,
+		&item.CreatedOn,
+		&item.UpdatedOn,
+		&item.DisabledOn,
+		&item.ErasedOn,
+		&legalHoldOn,
+		&legalHoldReason,
+		&item.KYCStatus,
+		&item.ScreeningStatus,
+		&wrappedKey,
+		&taxIDType,
+		&taxID,
+		&taxIDCountry,
+		&businessLegalName,
+		&businessDBA,
+		&businessEIN,
+		&businessEntityType,
+		&businessFormationDate,
+		&businessState,
+		&riskScore,
+		&riskTier,
+		&riskFactors,
+		&riskRulesVersion,
+		&riskScoredOn,
+	)
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	// Drivers hand back timestamps in the session's zone, keep everything in UTC.
+	item.CreatedOn = item.CreatedOn.UTC()
+	item.UpdatedOn = item.UpdatedOn.UTC()
+	item.DisabledOn = utcOrNil(item.DisabledOn)
+	item.ErasedOn = utcOrNil(item.ErasedOn)
+	if legalHoldOn != nil {
+		item.LegalHold = &LegalHold{Reason: legalHoldReason.String, PlacedOn: legalHoldOn.UTC()}
+	}
+	if taxIDType.Valid {
+		item.TaxID = &TaxID{Type: TaxIDType(taxIDType.String), Value: taxID.String, Country: taxIDCountry.String}
+	}
+	if ssn != legacySSN(item.TaxID) {
+		// Instances from before tax IDs only write customers.ssn, so when it isn't what we'd have written alongside
+		// the tax ID one of them saved the customer last, or saved it before the SSNs were copied over.
+		item.TaxID = nil
+		if ssn != "" {
+			item.TaxID = &TaxID{Type: TaxIDSSN, Value: ssn}
+		}
+	}
+	if businessEIN.Valid {
+		item.Business = &Business{
+			LegalName:          businessLegalName.String,
+			DBA:                businessDBA.String,
+			EIN:                businessEIN.String,
+			EntityType:         EntityType(businessEntityType.String),
+			FormationDate:      businessFormationDate,
+			IncorporationState: businessState.String,
+		}
+	}
+	if riskScoredOn != nil {
+		factors, err := decodeRiskFactors(riskFactors.String)
+		if err != nil {
+			return nil, err
+		}
+		item.Risk = &RiskScore{
+			Score:        int(riskScore.Int64),
+			Tier:         riskTier.String,
+			Factors:      factors,
+			RulesVersion: riskRulesVersion.String,
+			ScoredOn:     riskScoredOn.UTC(),
+		}
+	}
+
+	if err := r.decryptCustomer(&item, wrappedKey); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func utcOrNil(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,278 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+func (r *customerRepo) AddAddress(ctx context.Context, create Address) (*Address, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Add)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	encrypted, err := r.encryptAddress(ctx, tx, create, create.CreatedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	if create.Primary {
+		if err := r.demotePrimary(ctx, tx, create); err != nil {
+			return nil, err
+		}
+	}
+
+	qry := `
+		INSERT INTO customer_addresses(
+			tenant_id,
+			customer_id,
+			address_id,
+			address_type,
+			is_primary,
+			line1,
+			line2,
+			city,
+			postal_code,
+			state,
+			country,
+			created_on,
+			updated_on
+		) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)
+	`
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		encrypted.TenantID,
+		encrypted.CustomerID,
+		encrypted.AddressID,
+		encrypted.Type,
+		encrypted.Primary,
+		encrypted.Line1,
+		encrypted.Line2,
+		encrypted.City,
+		encrypted.PostalCode,
+		encrypted.State,
+		encrypted.Country,
+		encrypted.CreatedOn,
+		encrypted.UpdatedOn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.recordChange(ctx, tx, create.TenantID, create.CustomerID, ChangeUpdated, create.CreatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &create, nil
+}
+
+func (r *customerRepo) ListAddresses(ctx context.Context, tenantID string, customerID string) ([]Address, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT
+			customer_addresses.tenant_id,
+			customer_addresses.customer_id,
+			customer_addresses.address_id,
+			customer_addresses.address_type,
+			customer_addresses.is_primary,
+			customer_addresses.line1,
+			customer_addresses.line2,
+			customer_addresses.city,
+			customer_addresses.postal_code,
+			customer_addresses.state,
+			customer_addresses.country,
+			customer_addresses.created_on,
+			customer_addresses.updated_on,
+			customer_keys.wrapped_key
+		FROM customer_addresses
+		LEFT JOIN customer_keys
+		  ON customer_keys.tenant_id = customer_addresses.tenant_id
+		 AND customer_keys.customer_id = customer_addresses.customer_id
+		WHERE customer_addresses.tenant_id = ?
+		  AND customer_addresses.customer_id = ?
+		ORDER BY customer_addresses.created_on, customer_addresses.address_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Address{}
+	for rows.Next() {
+		item := Address{}
+		var wrappedKey sql.NullString
+		err := rows.Scan(
+			&item.TenantID,
+			&item.CustomerID,
+			&item.AddressID,
+			&item.Type,
+			&item.Primary,
+			&item.Line1,
+			&item.Line2,
+			&item.City,
+			&item.PostalCode,
+			&item.State,
+			&item.Country,
+			&item.CreatedOn,
+			&item.UpdatedOn,
+			&wrappedKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := r.decryptFields(item.TenantID, item.CustomerID, wrappedKey, addressPersonalData(&item)); err != nil {
+			return nil, err
+		}
+		item.CreatedOn = item.CreatedOn.UTC()
+		item.UpdatedOn = item.UpdatedOn.UTC()
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *customerRepo) UpdateAddress(ctx context.Context, update Address) (*Address, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	encrypted, err := r.encryptAddress(ctx, tx, update, update.UpdatedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Primary {
+		if err := r.demotePrimary(ctx, tx, update); err != nil {
+			return nil, err
+		}
+	}
+
+	qry := `
+		UPDATE customer_addresses
+		SET
+			address_type = ?,
+			is_primary = ?,
+			line1 = ?,
+			line2 = ?,
+			city = ?,
+			postal_code = ?,
+			state = ?,
+			country = ?,
+			updated_on = ?
+		WHERE
+			tenant_id = ?
+			AND customer_id = ?
+			AND address_id = ?
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		encrypted.Type,
+		encrypted.Primary,
+		encrypted.Line1,
+		encrypted.Line2,
+		encrypted.City,
+		encrypted.PostalCode,
+		encrypted.State,
+		encrypted.Country,
+		encrypted.UpdatedOn,
+		encrypted.TenantID,
+		encrypted.CustomerID,
+		encrypted.AddressID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if cnt != 1 {
+		return nil, sql.ErrNoRows
+	}
+
+	if err := r.recordChange(ctx, tx, update.TenantID, update.CustomerID, ChangeUpdated, update.UpdatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &update, nil
+}
+
+func (r *customerRepo) DeleteAddress(ctx context.Context, tenantID string, customerID string, addressID string, deletedOn time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		DELETE FROM customer_addresses
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND address_id = ?
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), tenantID, customerID, addressID)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+
+	if err := r.recordChange(ctx, tx, tenantID, customerID, ChangeUpdated, deletedOn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// encryptAddress - Returns a copy of the address with its personal data encrypted by the customer's data key.
+func (r *customerRepo) encryptAddress(ctx context.Context, tx *sql.Tx, a Address, on time.Time) (Address, error) {
+	dataKey, err := r.dataKeyForUpdate(ctx, tx, a.TenantID, a.CustomerID, on)
+	if err != nil {
+		return a, err
+	}
+	return a, encryptFields(dataKey, addressPersonalData(&a))
+}
+
+// demotePrimary - Clears the primary flag from the customer's other addresses of the same type.
+func (r *customerRepo) demotePrimary(ctx context.Context, tx *sql.Tx, a Address) error {
+	qry := `
+		UPDATE customer_addresses
+		SET is_primary = ?, updated_on = ?
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND address_type = ?
+		  AND address_id <> ?
+		  AND is_primary = ?
+	`
+	_, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), false, a.UpdatedOn, a.TenantID, a.CustomerID, a.Type, a.AddressID, true)
+	return err
+}
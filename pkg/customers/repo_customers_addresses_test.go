@@ -0,0 +1,130 @@
+package customers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+func Test_Customer_Addresses(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+
+		home := newAddress(*added, customers.AddressResidential)
+		_, err = repository.AddAddress(ctx, home)
+		a.Nil(err)
+
+		moved := newAddress(*added, customers.AddressResidential)
+		moved.Line1 = "456 Oak Ave"
+		moved.CreatedOn = moved.CreatedOn.Add(time.Second)
+		moved.UpdatedOn = moved.CreatedOn
+		_, err = repository.AddAddress(ctx, moved)
+		a.Nil(err)
+
+		mailing := newAddress(*added, customers.AddressMailing)
+		mailing.CreatedOn = mailing.CreatedOn.Add(2 * time.Second)
+		mailing.UpdatedOn = mailing.CreatedOn
+		_, err = repository.AddAddress(ctx, mailing)
+		a.Nil(err)
+
+		// Only the newest residential address is still primary, the mailing one has its own.
+		found, err := repository.ListAddresses(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Len(found, 3)
+		a.Equal(home.AddressID, found[0].AddressID)
+		a.False(found[0].Primary)
+		a.Equal(moved.UpdatedOn, found[0].UpdatedOn)
+		a.Equal(moved, found[1])
+		a.Equal(mailing, found[2])
+
+		home.Primary = true
+		home.UpdatedOn = home.UpdatedOn.Add(time.Minute)
+		_, err = repository.UpdateAddress(ctx, home)
+		a.Nil(err)
+
+		found, err = repository.ListAddresses(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(home, found[0])
+		a.False(found[1].Primary)
+
+		a.Nil(repository.DeleteAddress(ctx, added.TenantID, added.CustomerID, mailing.AddressID, home.UpdatedOn))
+		a.Equal(sql.ErrNoRows, repository.DeleteAddress(ctx, added.TenantID, added.CustomerID, mailing.AddressID, home.UpdatedOn))
+
+		found, err = repository.ListAddresses(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Len(found, 2)
+
+		// Every change to an address is a change to the customer.
+		changes, err := repository.ListChanges(ctx, added.TenantID, 0, 10)
+		a.Nil(err)
+		a.Len(changes, 6)
+		a.Equal(customers.ChangeUpdated, changes[5].Type)
+
+		// Other tenants can't see or change them.
+		found, err = repository.ListAddresses(ctx, uuid.NewString(), added.CustomerID)
+		a.Nil(err)
+		a.Empty(found)
+		home.TenantID = uuid.NewString()
+		_, err = repository.UpdateAddress(ctx, home)
+		a.Equal(sql.ErrNoRows, err)
+
+		// Erasing the customer removes its addresses.
+		a.Nil(repository.Erase(ctx, newErasure(*added)))
+		found, err = repository.ListAddresses(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Empty(found)
+	})
+}
+
+func Test_Customer_AddressesEncryptedAtRest(t *testing.T) {
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			a := require.New(t)
+			ctx := context.Background()
+			repository := customers.NewCustomerRepository(db.DB, db.Dialect, customers.TimeoutsConfig{}, testKeyEncryptionKey(t))
+
+			added, err := repository.Add(ctx, NewCustomer())
+			a.Nil(err)
+			address, err := repository.AddAddress(ctx, newAddress(*added, customers.AddressResidential))
+			a.Nil(err)
+
+			var line1, city, postalCode string
+			qry := db.Dialect.Rebind(`SELECT line1, city, postal_code FROM customer_addresses WHERE tenant_id = ? AND address_id = ?`)
+			a.Nil(db.DB.QueryRowContext(ctx, qry, added.TenantID, address.AddressID).Scan(&line1, &city, &postalCode))
+			a.NotContains(line1, address.Line1)
+			a.NotContains(city, address.City)
+			a.NotContains(postalCode, address.PostalCode)
+		})
+	}
+}
+
+func newAddress(c customers.Customer, addressType customers.AddressType) customers.Address {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return customers.Address{
+		TenantID:   c.TenantID,
+		CustomerID: c.CustomerID,
+		AddressID:  uuid.NewString(),
+		Type:       addressType,
+		Primary:    true,
+		Line1:      "123 Main St",
+		Line2:      "Apt 4",
+		City:       "Cedar Rapids",
+		State:      "IA",
+		PostalCode: "52401-1234",
+		Country:    customers.CountryUS,
+		CreatedOn:  now,
+		UpdatedOn:  now,
+	}
+}
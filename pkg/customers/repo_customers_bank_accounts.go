@@ -0,0 +1,363 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+func (r *customerRepo) AddBankAccount(ctx context.Context, create BankAccount) (*BankAccount, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Add)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	encrypted, err := r.encryptBankAccount(ctx, tx, create, create.CreatedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	qry := `
+		INSERT INTO customer_bank_accounts(
+			tenant_id,
+			customer_id,
+			bank_account_id,
+			account_type,
+			routing_number,
+			account_number,
+			bank_name,
+			status,
+			micro_deposits,
+			micro_deposits_sent_on,
+			micro_deposit_attempts,
+			verified_on,
+			created_on,
+			updated_on
+		) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)
+	`
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		encrypted.TenantID,
+		encrypted.CustomerID,
+		encrypted.BankAccountID,
+		encrypted.Type,
+		encrypted.RoutingNumber,
+		encrypted.AccountNumber,
+		encrypted.BankName,
+		encrypted.Status,
+		encrypted.microDeposits,
+		encrypted.sentOn,
+		encrypted.attempts,
+		encrypted.VerifiedOn,
+		encrypted.CreatedOn,
+		encrypted.UpdatedOn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.recordChange(ctx, tx, create.TenantID, create.CustomerID, ChangeUpdated, create.CreatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &create, nil
+}
+
+func (r *customerRepo) ListBankAccounts(ctx context.Context, tenantID string, customerID string) ([]BankAccount, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT
+			customer_bank_accounts.tenant_id,
+			customer_bank_accounts.customer_id,
+			customer_bank_accounts.bank_account_id,
+			customer_bank_accounts.account_type,
+			customer_bank_accounts.routing_number,
+			customer_bank_accounts.account_number,
+			customer_bank_accounts.bank_name,
+			customer_bank_accounts.status,
+			customer_bank_accounts.micro_deposits,
+			customer_bank_accounts.micro_deposits_sent_on,
+			customer_bank_accounts.micro_deposit_attempts,
+			customer_bank_accounts.verified_on,
+			customer_bank_accounts.created_on,
+			customer_bank_accounts.updated_on,
+			customer_keys.wrapped_key
+		FROM customer_bank_accounts
+		LEFT JOIN customer_keys
+		  ON customer_keys.tenant_id = customer_bank_accounts.tenant_id
+		 AND customer_keys.customer_id = customer_bank_accounts.customer_id
+		WHERE customer_bank_accounts.tenant_id = ?
+		  AND customer_bank_accounts.customer_id = ?
+		ORDER BY customer_bank_accounts.created_on, customer_bank_accounts.bank_account_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []BankAccount{}
+	for rows.Next() {
+		item := BankAccount{}
+		var microDeposits sql.NullString
+		var sentOn *time.Time
+		var attempts int
+		var wrappedKey sql.NullString
+		err := rows.Scan(
+			&item.TenantID,
+			&item.CustomerID,
+			&item.BankAccountID,
+			&item.Type,
+			&item.RoutingNumber,
+			&item.AccountNumber,
+			&item.BankName,
+			&item.Status,
+			&microDeposits,
+			&sentOn,
+			&attempts,
+			&item.VerifiedOn,
+			&item.CreatedOn,
+			&item.UpdatedOn,
+			&wrappedKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		amounts := microDeposits.String
+		if err := r.decryptFields(item.TenantID, item.CustomerID, wrappedKey, bankAccountPersonalData(&item, &amounts)); err != nil {
+			return nil, err
+		}
+		if sentOn != nil {
+			item.MicroDeposits = &MicroDeposits{SentOn: sentOn.UTC(), FailedAttempts: attempts}
+			if item.MicroDeposits.Amounts, err = parseMicroDeposits(amounts); err != nil {
+				return nil, err
+			}
+		}
+		item.VerifiedOn = utcOrNil(item.VerifiedOn)
+		item.CreatedOn = item.CreatedOn.UTC()
+		item.UpdatedOn = item.UpdatedOn.UTC()
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *customerRepo) UpdateBankAccount(ctx context.Context, from BankAccount, update BankAccount) (*BankAccount, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	encrypted, err := r.encryptBankAccount(ctx, tx, update, update.UpdatedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	qry := `
+		UPDATE customer_bank_accounts
+		SET
+			status = ?,
+			micro_deposits = ?,
+			micro_deposits_sent_on = ?,
+			micro_deposit_attempts = ?,
+			verified_on = ?,
+			updated_on = ?
+		WHERE
+			tenant_id = ?
+			AND customer_id = ?
+			AND bank_account_id = ?
+			AND status = ?
+			AND micro_deposit_attempts = ?
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		encrypted.Status,
+		encrypted.microDeposits,
+		encrypted.sentOn,
+		encrypted.attempts,
+		encrypted.VerifiedOn,
+		encrypted.UpdatedOn,
+		encrypted.TenantID,
+		encrypted.CustomerID,
+		encrypted.BankAccountID,
+		from.Status,
+		failedAttempts(from),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if cnt != 1 {
+		return nil, sql.ErrNoRows
+	}
+
+	if failedAttempts(update) > failedAttempts(from) {
+		if err := r.saveBankAccountAttempts(ctx, tx, update); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.recordChange(ctx, tx, update.TenantID, update.CustomerID, ChangeUpdated, update.UpdatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &update, nil
+}
+
+// saveBankAccountAttempts - Keeps the account's failed attempts for BankAccountFailedAttempts, unless an account
+// with the same numbers already had more.
+func (r *customerRepo) saveBankAccountAttempts(ctx context.Context, tx *sql.Tx, b BankAccount) error {
+	fingerprint := r.keys.Fingerprint(b.AccountNumber)
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(r.startBankAccountAttempts()),
+		b.TenantID, b.CustomerID, b.RoutingNumber, fingerprint, b.UpdatedOn); err != nil {
+		return err
+	}
+
+	qry := `
+		UPDATE customer_bank_account_attempts
+		SET
+			failed_attempts = ?,
+			updated_on = ?
+		WHERE
+			tenant_id = ?
+			AND customer_id = ?
+			AND routing_number = ?
+			AND account_fingerprint = ?
+			AND failed_attempts < ?
+	`
+	_, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		failedAttempts(b),
+		b.UpdatedOn,
+		b.TenantID,
+		b.CustomerID,
+		b.RoutingNumber,
+		fingerprint,
+		failedAttempts(b),
+	)
+	return err
+}
+
+// startBankAccountAttempts - Adds the account's row without any failed attempts when it has none yet.
+func (r *customerRepo) startBankAccountAttempts() string {
+	const columns = `customer_bank_account_attempts(tenant_id, customer_id, routing_number, account_fingerprint, failed_attempts, updated_on) VALUES (?, ?, ?, ?, 0, ?)`
+	switch r.dialect {
+	case sqldb.MySQL:
+		return `INSERT IGNORE INTO ` + columns
+	case sqldb.Postgres:
+		return `INSERT INTO ` + columns + ` ON CONFLICT DO NOTHING`
+	default:
+		return `INSERT OR IGNORE INTO ` + columns
+	}
+}
+
+func (r *customerRepo) BankAccountFailedAttempts(ctx context.Context, tenantID string, customerID string, routingNumber string, accountNumber string) (int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	qry := `
+		SELECT failed_attempts
+		FROM customer_bank_account_attempts
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND routing_number = ?
+		  AND account_fingerprint = ?
+	`
+	var attempts int
+	err := r.db.QueryRowContext(ctx, r.dialect.Rebind(qry), tenantID, customerID, routingNumber, r.keys.Fingerprint(accountNumber)).Scan(&attempts)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return attempts, err
+}
+
+func (r *customerRepo) DeleteBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string, deletedOn time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		DELETE FROM customer_bank_accounts
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND bank_account_id = ?
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), tenantID, customerID, bankAccountID)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+
+	if err := r.recordChange(ctx, tx, tenantID, customerID, ChangeUpdated, deletedOn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// storedBankAccount - A bank account as it's written, encrypted and with its micro-deposits in their own columns.
+type storedBankAccount struct {
+	BankAccount
+	microDeposits *string
+	sentOn        *time.Time
+	attempts      int
+}
+
+// encryptBankAccount - Returns a copy of the bank account with its number and micro-deposits encrypted by the
+// customer's data key.
+func (r *customerRepo) encryptBankAccount(ctx context.Context, tx *sql.Tx, b BankAccount, on time.Time) (storedBankAccount, error) {
+	stored := storedBankAccount{BankAccount: b, attempts: failedAttempts(b)}
+	dataKey, err := r.dataKeyForUpdate(ctx, tx, b.TenantID, b.CustomerID, on)
+	if err != nil {
+		return stored, err
+	}
+
+	var amounts string
+	if b.MicroDeposits != nil {
+		amounts = formatMicroDeposits(b.MicroDeposits.Amounts)
+		sentOn := b.MicroDeposits.SentOn
+		stored.microDeposits = &amounts
+		stored.sentOn = &sentOn
+	}
+	return stored, encryptFields(dataKey, bankAccountPersonalData(&stored.BankAccount, stored.microDeposits))
+}
+
+func failedAttempts(b BankAccount) int {
+	if b.MicroDeposits == nil {
+		return 0
+	}
+	return b.MicroDeposits.FailedAttempts
+}
@@ -0,0 +1,189 @@
+package customers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+func Test_Customer_BankAccounts(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+
+		checking := newBankAccount(*added, customers.BankAccountChecking)
+		_, err = repository.AddBankAccount(ctx, checking)
+		a.Nil(err)
+
+		savings := newBankAccount(*added, customers.BankAccountSavings)
+		savings.AccountNumber = "987654321"
+		savings.CreatedOn = savings.CreatedOn.Add(time.Second)
+		savings.UpdatedOn = savings.CreatedOn
+		_, err = repository.AddBankAccount(ctx, savings)
+		a.Nil(err)
+
+		found, err := repository.ListBankAccounts(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal([]customers.BankAccount{checking, savings}, found)
+
+		sent := checking
+		sent.Status = customers.BankAccountPending
+		sent.MicroDeposits = &customers.MicroDeposits{Amounts: []int{12, 34}, SentOn: checking.UpdatedOn.Add(time.Minute)}
+		sent.UpdatedOn = sent.MicroDeposits.SentOn
+		_, err = repository.UpdateBankAccount(ctx, checking, sent)
+		a.Nil(err)
+
+		found, err = repository.ListBankAccounts(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(sent, found[0])
+
+		failed := sent
+		failed.MicroDeposits = &customers.MicroDeposits{Amounts: []int{12, 34}, SentOn: sent.MicroDeposits.SentOn, FailedAttempts: 1}
+		failed.UpdatedOn = sent.UpdatedOn.Add(time.Minute)
+		_, err = repository.UpdateBankAccount(ctx, sent, failed)
+		a.Nil(err)
+
+		// Updates from a stale copy are refused, so concurrent confirmations can't both count.
+		_, err = repository.UpdateBankAccount(ctx, sent, failed)
+		a.Equal(sql.ErrNoRows, err)
+
+		verifiedOn := failed.UpdatedOn.Add(time.Minute)
+		verified := failed
+		verified.Status = customers.BankAccountVerified
+		verified.VerifiedOn = &verifiedOn
+		verified.UpdatedOn = verifiedOn
+		_, err = repository.UpdateBankAccount(ctx, failed, verified)
+		a.Nil(err)
+
+		found, err = repository.ListBankAccounts(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(verified, found[0])
+
+		a.Nil(repository.DeleteBankAccount(ctx, added.TenantID, added.CustomerID, savings.BankAccountID, verifiedOn))
+		a.Equal(sql.ErrNoRows, repository.DeleteBankAccount(ctx, added.TenantID, added.CustomerID, savings.BankAccountID, verifiedOn))
+
+		// Other tenants can't see or change them.
+		found, err = repository.ListBankAccounts(ctx, uuid.NewString(), added.CustomerID)
+		a.Nil(err)
+		a.Empty(found)
+		a.Equal(sql.ErrNoRows, repository.DeleteBankAccount(ctx, uuid.NewString(), added.CustomerID, checking.BankAccountID, verifiedOn))
+
+		// Erasing the customer removes its bank accounts.
+		a.Nil(repository.Erase(ctx, newErasure(*added)))
+		found, err = repository.ListBankAccounts(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Empty(found)
+	})
+}
+
+func Test_Customer_BankAccountFailedAttempts(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+
+		account := newBankAccount(*added, customers.BankAccountChecking)
+		account.Status = customers.BankAccountPending
+		account.MicroDeposits = &customers.MicroDeposits{Amounts: []int{12, 34}, SentOn: account.CreatedOn}
+		_, err = repository.AddBankAccount(ctx, account)
+		a.Nil(err)
+
+		failed := account
+		failed.MicroDeposits = &customers.MicroDeposits{Amounts: []int{12, 34}, SentOn: account.CreatedOn, FailedAttempts: 2}
+		failed.UpdatedOn = account.UpdatedOn.Add(time.Minute)
+		_, err = repository.UpdateBankAccount(ctx, account, failed)
+		a.Nil(err)
+
+		// They're kept when the account is deleted.
+		a.Nil(repository.DeleteBankAccount(ctx, added.TenantID, added.CustomerID, account.BankAccountID, failed.UpdatedOn))
+		attempts, err := repository.BankAccountFailedAttempts(ctx, added.TenantID, added.CustomerID, account.RoutingNumber, account.AccountNumber)
+		a.Nil(err)
+		a.Equal(2, attempts)
+
+		// Only for the same numbers and customer.
+		attempts, err = repository.BankAccountFailedAttempts(ctx, added.TenantID, added.CustomerID, account.RoutingNumber, "987654321")
+		a.Nil(err)
+		a.Zero(attempts)
+		attempts, err = repository.BankAccountFailedAttempts(ctx, added.TenantID, added.CustomerID, "121000248", account.AccountNumber)
+		a.Nil(err)
+		a.Zero(attempts)
+		attempts, err = repository.BankAccountFailedAttempts(ctx, uuid.NewString(), added.CustomerID, account.RoutingNumber, account.AccountNumber)
+		a.Nil(err)
+		a.Zero(attempts)
+
+		// An account added again with fewer doesn't lower them.
+		again := account
+		again.BankAccountID = uuid.NewString()
+		_, err = repository.AddBankAccount(ctx, again)
+		a.Nil(err)
+		fewer := again
+		fewer.MicroDeposits = &customers.MicroDeposits{Amounts: []int{12, 34}, SentOn: account.CreatedOn, FailedAttempts: 1}
+		_, err = repository.UpdateBankAccount(ctx, again, fewer)
+		a.Nil(err)
+		attempts, err = repository.BankAccountFailedAttempts(ctx, added.TenantID, added.CustomerID, account.RoutingNumber, account.AccountNumber)
+		a.Nil(err)
+		a.Equal(2, attempts)
+
+		// Erasing the customer forgets them.
+		a.Nil(repository.Erase(ctx, newErasure(*added)))
+		attempts, err = repository.BankAccountFailedAttempts(ctx, added.TenantID, added.CustomerID, account.RoutingNumber, account.AccountNumber)
+		a.Nil(err)
+		a.Zero(attempts)
+	})
+}
+
+func Test_Customer_BankAccountsEncryptedAtRest(t *testing.T) {
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			a := require.New(t)
+			ctx := context.Background()
+			repository := customers.NewCustomerRepository(db.DB, db.Dialect, customers.TimeoutsConfig{}, testKeyEncryptionKey(t))
+
+			added, err := repository.Add(ctx, NewCustomer())
+			a.Nil(err)
+			account, err := repository.AddBankAccount(ctx, newBankAccount(*added, customers.BankAccountChecking))
+			a.Nil(err)
+
+			sent := *account
+			sent.Status = customers.BankAccountPending
+			sent.MicroDeposits = &customers.MicroDeposits{Amounts: []int{12, 34}, SentOn: account.UpdatedOn}
+			_, err = repository.UpdateBankAccount(ctx, *account, sent)
+			a.Nil(err)
+
+			var accountNumber, microDeposits string
+			qry := db.Dialect.Rebind(`SELECT account_number, micro_deposits FROM customer_bank_accounts WHERE tenant_id = ? AND bank_account_id = ?`)
+			a.Nil(db.DB.QueryRowContext(ctx, qry, added.TenantID, account.BankAccountID).Scan(&accountNumber, &microDeposits))
+			a.NotContains(accountNumber, account.AccountNumber)
+			a.NotContains(microDeposits, "12,34")
+		})
+	}
+}
+
+func newBankAccount(c customers.Customer, accountType customers.BankAccountType) customers.BankAccount {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return customers.BankAccount{
+		TenantID:      c.TenantID,
+		CustomerID:    c.CustomerID,
+		BankAccountID: uuid.NewString(),
+		Type:          accountType,
+		RoutingNumber: "021000021",
+		AccountNumber: "000123456789",
+		BankName:      "JPMORGAN CHASE BANK, NA",
+		Status:        customers.BankAccountUnverified,
+		CreatedOn:     now,
+		UpdatedOn:     now,
+	}
+}
@@ -0,0 +1,187 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+func (r *customerRepo) AddBeneficialOwner(ctx context.Context, create BeneficialOwner) (*BeneficialOwner, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Add)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		INSERT INTO customer_beneficial_owners(
+			tenant_id,
+			customer_id,
+			owner_id,
+			ownership_percentage,
+			control_person,
+			created_on,
+			updated_on
+		) VALUES (?,?,?,?,?,?,?)
+	`
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		create.TenantID,
+		create.CustomerID,
+		create.OwnerID,
+		create.OwnershipPercentage,
+		create.ControlPerson,
+		create.CreatedOn,
+		create.UpdatedOn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.recordChange(ctx, tx, create.TenantID, create.CustomerID, ChangeUpdated, create.CreatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &create, nil
+}
+
+func (r *customerRepo) ListBeneficialOwners(ctx context.Context, tenantID string, customerID string) ([]BeneficialOwner, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT
+			customer_beneficial_owners.tenant_id,
+			customer_beneficial_owners.customer_id,
+			customer_beneficial_owners.owner_id,
+			customer_beneficial_owners.ownership_percentage,
+			customer_beneficial_owners.control_person,
+			customer_beneficial_owners.created_on,
+			customer_beneficial_owners.updated_on
+		FROM customer_beneficial_owners
+		WHERE customer_beneficial_owners.tenant_id = ?
+		  AND customer_beneficial_owners.customer_id = ?
+		ORDER BY customer_beneficial_owners.created_on, customer_beneficial_owners.owner_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []BeneficialOwner{}
+	for rows.Next() {
+		item := BeneficialOwner{}
+		err := rows.Scan(
+			&item.TenantID,
+			&item.CustomerID,
+			&item.OwnerID,
+			&item.OwnershipPercentage,
+			&item.ControlPerson,
+			&item.CreatedOn,
+			&item.UpdatedOn,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		item.CreatedOn = item.CreatedOn.UTC()
+		item.UpdatedOn = item.UpdatedOn.UTC()
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *customerRepo) UpdateBeneficialOwner(ctx context.Context, update BeneficialOwner) (*BeneficialOwner, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		UPDATE customer_beneficial_owners
+		SET
+			ownership_percentage = ?,
+			control_person = ?,
+			updated_on = ?
+		WHERE
+			tenant_id = ?
+			AND customer_id = ?
+			AND owner_id = ?
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		update.OwnershipPercentage,
+		update.ControlPerson,
+		update.UpdatedOn,
+		update.TenantID,
+		update.CustomerID,
+		update.OwnerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if cnt != 1 {
+		return nil, sql.ErrNoRows
+	}
+
+	if err := r.recordChange(ctx, tx, update.TenantID, update.CustomerID, ChangeUpdated, update.UpdatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &update, nil
+}
+
+func (r *customerRepo) DeleteBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string, deletedOn time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		DELETE FROM customer_beneficial_owners
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND owner_id = ?
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), tenantID, customerID, ownerID)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+
+	if err := r.recordChange(ctx, tx, tenantID, customerID, ChangeUpdated, deletedOn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,162 @@
+package customers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+// NewBusinessCustomer - A business of the tenant as the service would store it.
+func NewBusinessCustomer(tenantID string) customers.Customer {
+	c := NewCustomer()
+	c.TenantID = tenantID
+	c.Type = customers.CustomerBusiness
+	c.Name = "Acme"
+	c.BirthDate = nil
+	c.TaxID = nil
+	formationDate := "2015/06/01"
+	c.Business = &customers.Business{
+		LegalName:          "Acme Widgets LLC",
+		DBA:                "Acme",
+		EIN:                "12-3456789",
+		EntityType:         customers.EntityLLC,
+		FormationDate:      &formationDate,
+		IncorporationState: "DE",
+	}
+	return c
+}
+
+func Test_Customer_Business(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		model := NewBusinessCustomer(NewCustomer().TenantID)
+		added, err := repository.Add(ctx, model)
+		a.Nil(err)
+
+		found, err := repository.Get(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(model, *found)
+
+		// The business details are replaced with the customer's.
+		found.Business.DBA = ""
+		found.Business.FormationDate = nil
+		found.UpdatedOn = found.UpdatedOn.Add(time.Minute)
+		_, err = repository.Update(ctx, *found)
+		a.Nil(err)
+
+		listed, err := repository.List(ctx, added.TenantID, customers.ListFilter{})
+		a.Nil(err)
+		a.Len(listed, 1)
+		a.Equal(*found, listed[0])
+
+		// Individuals have none.
+		individual := NewCustomer()
+		individual.TenantID = added.TenantID
+		_, err = repository.Add(ctx, individual)
+		a.Nil(err)
+		foundIndividual, err := repository.Get(ctx, individual.TenantID, individual.CustomerID)
+		a.Nil(err)
+		a.Equal(customers.CustomerIndividual, foundIndividual.Type)
+		a.Nil(foundIndividual.Business)
+
+		a.Nil(repository.Erase(ctx, newErasure(*added)))
+		found, err = repository.Get(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(customers.CustomerBusiness, found.Type)
+		a.Nil(found.Business)
+	})
+}
+
+func Test_Customer_BusinessEncryptedAtRest(t *testing.T) {
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			a := require.New(t)
+			ctx := context.Background()
+			repository := customers.NewCustomerRepository(db.DB, db.Dialect, customers.TimeoutsConfig{}, testKeyEncryptionKey(t))
+
+			added, err := repository.Add(ctx, NewBusinessCustomer(NewCustomer().TenantID))
+			a.Nil(err)
+
+			var legalName, ein, state string
+			qry := db.Dialect.Rebind(`SELECT legal_name, ein, incorporation_state FROM customer_businesses WHERE tenant_id = ? AND customer_id = ?`)
+			a.Nil(db.DB.QueryRowContext(ctx, qry, added.TenantID, added.CustomerID).Scan(&legalName, &ein, &state))
+			a.NotContains(legalName, added.Business.LegalName)
+			a.NotContains(ein, added.Business.EIN)
+			a.Equal("DE", state)
+		})
+	}
+}
+
+func Test_Customer_BeneficialOwners(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		business, err := repository.Add(ctx, NewBusinessCustomer(NewCustomer().TenantID))
+		a.Nil(err)
+
+		found, err := repository.ListBeneficialOwners(ctx, business.TenantID, business.CustomerID)
+		a.Nil(err)
+		a.Empty(found)
+
+		first := newBeneficialOwner(*business, 50, true)
+		second := newBeneficialOwner(*business, 25.5, false)
+		second.CreatedOn = second.CreatedOn.Add(time.Second)
+		second.UpdatedOn = second.CreatedOn
+		for _, o := range []customers.BeneficialOwner{second, first} {
+			_, err := repository.AddBeneficialOwner(ctx, o)
+			a.Nil(err)
+		}
+
+		// An individual is only listed once for each business.
+		_, err = repository.AddBeneficialOwner(ctx, first)
+		a.Error(err)
+
+		found, err = repository.ListBeneficialOwners(ctx, business.TenantID, business.CustomerID)
+		a.Nil(err)
+		a.Equal([]customers.BeneficialOwner{first, second}, found)
+
+		second.OwnershipPercentage = 10
+		second.ControlPerson = true
+		second.UpdatedOn = second.UpdatedOn.Add(time.Minute)
+		_, err = repository.UpdateBeneficialOwner(ctx, second)
+		a.Nil(err)
+
+		a.Nil(repository.DeleteBeneficialOwner(ctx, business.TenantID, business.CustomerID, first.OwnerID, second.UpdatedOn))
+		a.Equal(sql.ErrNoRows, repository.DeleteBeneficialOwner(ctx, business.TenantID, business.CustomerID, first.OwnerID, second.UpdatedOn))
+		_, err = repository.UpdateBeneficialOwner(ctx, first)
+		a.Equal(sql.ErrNoRows, err)
+
+		found, err = repository.ListBeneficialOwners(ctx, business.TenantID, business.CustomerID)
+		a.Nil(err)
+		a.Equal([]customers.BeneficialOwner{second}, found)
+
+		// Owners are part of the business's personal data.
+		a.Nil(repository.Erase(ctx, newErasure(*business)))
+		found, err = repository.ListBeneficialOwners(ctx, business.TenantID, business.CustomerID)
+		a.Nil(err)
+		a.Empty(found)
+	})
+}
+
+func newBeneficialOwner(business customers.Customer, percentage float64, controlPerson bool) customers.BeneficialOwner {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return customers.BeneficialOwner{
+		TenantID:            business.TenantID,
+		CustomerID:          business.CustomerID,
+		OwnerID:             NewCustomer().CustomerID,
+		OwnershipPercentage: percentage,
+		ControlPerson:       controlPerson,
+		CreatedOn:           now,
+		UpdatedOn:           now,
+	}
+}
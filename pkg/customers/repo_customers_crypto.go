@@ -0,0 +1,176 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
+)
+
+// keyAssociatedData - Binds a wrapped data key to its customer so it can't be swapped onto another row.
+func keyAssociatedData(tenantID string, customerID string) string {
+	return tenantID + "/" + customerID
+}
+
+// newDataKey - Generates the customer's data key and stores it wrapped, in the transaction adding the customer.
+func (r *customerRepo) newDataKey(ctx context.Context, tx *sql.Tx, tenantID string, customerID string, createdOn time.Time) (*envelope.DataKey, error) {
+	dataKey, wrapped, err := r.keys.NewDataKey(keyAssociatedData(tenantID, customerID))
+	if err != nil {
+		return nil, err
+	}
+
+	qry := `
+		INSERT INTO customer_keys(
+			tenant_id,
+			customer_id,
+			wrapped_key,
+			created_on
+		) VALUES (?,?,?,?)
+	`
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), tenantID, customerID, wrapped, createdOn); err != nil {
+		return nil, err
+	}
+
+	return dataKey, nil
+}
+
+// dataKeyForUpdate - The customer's data key, customers stored before encryption are given one so the update
+// encrypts them.
+func (r *customerRepo) dataKeyForUpdate(ctx context.Context, tx *sql.Tx, tenantID string, customerID string, updatedOn time.Time) (*envelope.DataKey, error) {
+	qry := `
+		SELECT customer_keys.wrapped_key
+		FROM customer_keys
+		WHERE customer_keys.tenant_id = ?
+		  AND customer_keys.customer_id = ?
+	`
+
+	var wrapped string
+	err := tx.QueryRowContext(ctx, r.dialect.Rebind(qry), tenantID, customerID).Scan(&wrapped)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r.newDataKey(ctx, tx, tenantID, customerID, updatedOn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.keys.Unwrap(wrapped, keyAssociatedData(tenantID, customerID))
+}
+
+// encryptCustomer - Returns a copy of the customer with its personal data encrypted for storage.
+func encryptCustomer(dataKey *envelope.DataKey, c Customer) (Customer, error) {
+	// Detach the birth date, tax ID and business so the caller's copy isn't overwritten.
+	c = copyCustomer(c)
+	return c, encryptFields(dataKey, personalData(&c))
+}
+
+// decryptCustomer - Decrypts the customer's personal data in place. Erased customers have no key and nothing left
+// to decrypt, customers stored before encryption was added are returned as they are.
+func (r *customerRepo) decryptCustomer(c *Customer, wrappedKey sql.NullString) error {
+	return r.decryptFields(c.TenantID, c.CustomerID, wrappedKey, personalData(c))
+}
+
+func encryptFields(dataKey *envelope.DataKey, fields []personalDataField) error {
+	var err error
+	for _, field := range fields {
+		if field.value == nil {
+			continue
+		}
+		if *field.value, err = dataKey.Encrypt(*field.value, field.associatedData()); err != nil {
+			return fmt.Errorf("encrypting %s: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+// decryptFields - Decrypts the customer's fields in place with its wrapped data key, if it has one.
+func (r *customerRepo) decryptFields(tenantID string, customerID string, wrappedKey sql.NullString, fields []personalDataField) error {
+	if !wrappedKey.Valid {
+		return nil
+	}
+
+	dataKey, err := r.keys.Unwrap(wrappedKey.String, keyAssociatedData(tenantID, customerID))
+	if err != nil {
+		return fmt.Errorf("unwrapping key for customer %s: %w", customerID, err)
+	}
+
+	for _, field := range fields {
+		if field.value == nil || !envelope.IsEncrypted(*field.value) {
+			continue
+		}
+		if *field.value, err = dataKey.Decrypt(*field.value, field.associatedData()); err != nil {
+			return fmt.Errorf("decrypting %s of customer %s: %w", field.name, customerID, err)
+		}
+	}
+	return nil
+}
+
+type personalDataField struct {
+	name  string
+	value *string
+	// encryptedAs is what the value is encrypted under when it isn't its name.
+	encryptedAs string
+}
+
+func (f personalDataField) associatedData() string {
+	if f.encryptedAs != "" {
+		return f.encryptedAs
+	}
+	return f.name
+}
+
+// personalData - The customer's fields that are encrypted at rest and removed when it's erased, see ErasedFields.
+// A business's names and EIN are included since a sole proprietorship's are the owner's own. SSNs are encrypted
+// as ssn like they were before tax IDs, so the values copied over from customers.ssn decrypt as they are.
+func personalData(c *Customer) []personalDataField {
+	taxID := personalDataField{name: "taxID"}
+	if c.TaxID != nil {
+		taxID.value = &c.TaxID.Value
+		if c.TaxID.Type == TaxIDSSN {
+			taxID.encryptedAs = "ssn"
+		}
+	}
+
+	fields := []personalDataField{
+		{name: "name", value: &c.Name},
+		{name: "birthDate", value: c.BirthDate},
+		{name: "email", value: &c.Email},
+		taxID,
+	}
+	if c.Business != nil {
+		fields = append(fields,
+			personalDataField{name: "business.legalName", value: &c.Business.LegalName},
+			personalDataField{name: "business.dba", value: &c.Business.DBA},
+			personalDataField{name: "business.ein", value: &c.Business.EIN},
+		)
+	}
+	return fields
+}
+
+// addressPersonalData - The address fields that are encrypted at rest, the state and country are left readable.
+func addressPersonalData(a *Address) []personalDataField {
+	return []personalDataField{
+		{name: "address.line1", value: &a.Line1},
+		{name: "address.line2", value: &a.Line2},
+		{name: "address.city", value: &a.City},
+		{name: "address.postalCode", value: &a.PostalCode},
+	}
+}
+
+// phonePersonalData - The phone fields that are encrypted at rest, the country is left readable.
+func phonePersonalData(p *Phone) []personalDataField {
+	return []personalDataField{
+		{name: "phone.number", value: &p.Number},
+	}
+}
+
+// bankAccountPersonalData - The bank account fields that are encrypted at rest along with its micro-deposit amounts,
+// the routing number and bank are left readable.
+func bankAccountPersonalData(b *BankAccount, microDeposits *string) []personalDataField {
+	return []personalDataField{
+		{name: "bankAccount.accountNumber", value: &b.AccountNumber},
+		{name: "bankAccount.microDeposits", value: microDeposits},
+	}
+}
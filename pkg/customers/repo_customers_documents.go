@@ -0,0 +1,159 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
+)
+
+func (r *customerRepo) DataKey(ctx context.Context, tenantID string, customerID string, createdOn time.Time) (*envelope.DataKey, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	dataKey, err := r.dataKeyForUpdate(ctx, tx, tenantID, customerID, createdOn)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataKey, tx.Commit()
+}
+
+func (r *customerRepo) AddDocument(ctx context.Context, create Document) (*Document, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Add)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		INSERT INTO customer_documents(
+			tenant_id,
+			customer_id,
+			document_id,
+			document_type,
+			content_type,
+			size,
+			sha256,
+			created_on
+		) VALUES (?,?,?,?,?,?,?,?)
+	`
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		create.TenantID,
+		create.CustomerID,
+		create.DocumentID,
+		create.Type,
+		create.ContentType,
+		create.Size,
+		create.SHA256,
+		create.CreatedOn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.recordChange(ctx, tx, create.TenantID, create.CustomerID, ChangeUpdated, create.CreatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &create, nil
+}
+
+func (r *customerRepo) ListDocuments(ctx context.Context, tenantID string, customerID string) ([]Document, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT
+			customer_documents.tenant_id,
+			customer_documents.customer_id,
+			customer_documents.document_id,
+			customer_documents.document_type,
+			customer_documents.content_type,
+			customer_documents.size,
+			customer_documents.sha256,
+			customer_documents.created_on
+		FROM customer_documents
+		WHERE customer_documents.tenant_id = ?
+		  AND customer_documents.customer_id = ?
+		ORDER BY customer_documents.created_on, customer_documents.document_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Document{}
+	for rows.Next() {
+		item := Document{}
+		err := rows.Scan(
+			&item.TenantID,
+			&item.CustomerID,
+			&item.DocumentID,
+			&item.Type,
+			&item.ContentType,
+			&item.Size,
+			&item.SHA256,
+			&item.CreatedOn,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		item.CreatedOn = item.CreatedOn.UTC()
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *customerRepo) DeleteDocument(ctx context.Context, tenantID string, customerID string, documentID string, deletedOn time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		DELETE FROM customer_documents
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND document_id = ?
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), tenantID, customerID, documentID)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+
+	if err := r.recordChange(ctx, tx, tenantID, customerID, ChangeUpdated, deletedOn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,105 @@
+package customers_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
+)
+
+func Test_Customer_Documents(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+
+		license := newDocument(*added, customers.DocumentDriversLicense)
+		_, err = repository.AddDocument(ctx, license)
+		a.Nil(err)
+
+		passport := newDocument(*added, customers.DocumentPassport)
+		passport.CreatedOn = passport.CreatedOn.Add(time.Second)
+		_, err = repository.AddDocument(ctx, passport)
+		a.Nil(err)
+
+		found, err := repository.ListDocuments(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal([]customers.Document{license, passport}, found)
+
+		a.Nil(repository.DeleteDocument(ctx, added.TenantID, added.CustomerID, license.DocumentID, passport.CreatedOn))
+		a.Equal(sql.ErrNoRows, repository.DeleteDocument(ctx, added.TenantID, added.CustomerID, license.DocumentID, passport.CreatedOn))
+
+		// Other tenants can't see or delete them.
+		found, err = repository.ListDocuments(ctx, uuid.NewString(), added.CustomerID)
+		a.Nil(err)
+		a.Empty(found)
+		a.Equal(sql.ErrNoRows, repository.DeleteDocument(ctx, uuid.NewString(), added.CustomerID, passport.DocumentID, passport.CreatedOn))
+
+		// Erasing the customer removes its documents.
+		a.Nil(repository.Erase(ctx, newErasure(*added)))
+		found, err = repository.ListDocuments(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Empty(found)
+	})
+}
+
+func Test_Customer_DataKey(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+
+		dataKey, err := repository.DataKey(ctx, added.TenantID, added.CustomerID, added.CreatedOn)
+		a.Nil(err)
+		ciphertext := encryptDocument(t, dataKey, "passport scan")
+
+		// The same key comes back every time.
+		dataKey, err = repository.DataKey(ctx, added.TenantID, added.CustomerID, added.CreatedOn)
+		a.Nil(err)
+		plaintext, err := io.ReadAll(dataKey.DecryptStream(bytes.NewReader(ciphertext), "document"))
+		a.Nil(err)
+		a.Equal("passport scan", string(plaintext))
+
+		// Erasing the customer deletes it, so whatever it encrypted can't be read.
+		a.Nil(repository.Erase(ctx, newErasure(*added)))
+		dataKey, err = repository.DataKey(ctx, added.TenantID, added.CustomerID, added.CreatedOn)
+		a.Nil(err)
+		_, err = io.ReadAll(dataKey.DecryptStream(bytes.NewReader(ciphertext), "document"))
+		a.ErrorIs(err, envelope.ErrDecrypt)
+	})
+}
+
+func encryptDocument(t *testing.T, dataKey *envelope.DataKey, plaintext string) []byte {
+	var buf bytes.Buffer
+	w, err := dataKey.EncryptStream(&buf, "document")
+	require.NoError(t, err)
+	_, err = io.WriteString(w, plaintext)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func newDocument(c customers.Customer, documentType customers.DocumentType) customers.Document {
+	return customers.Document{
+		TenantID:    c.TenantID,
+		CustomerID:  c.CustomerID,
+		DocumentID:  uuid.NewString(),
+		Type:        documentType,
+		ContentType: "image/jpeg",
+		Size:        1024,
+		SHA256:      "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		CreatedOn:   time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
@@ -0,0 +1,238 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+func (r *customerRepo) SetLegalHold(ctx context.Context, tenantID string, customerID string, hold *LegalHold, updatedOn time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var placedOn *time.Time
+	var reason *string
+	if hold != nil {
+		placedOn, reason = &hold.PlacedOn, &hold.Reason
+	}
+
+	qry := `
+		UPDATE customers
+		SET
+			legal_hold_on = ?,
+			legal_hold_reason = ?,
+			updated_on = ?
+		WHERE
+			customer_id = ?
+			AND tenant_id = ?
+			AND erased_on IS NULL
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), placedOn, reason, updatedOn, customerID, tenantID)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+
+	if err := r.recordChange(ctx, tx, tenantID, customerID, ChangeUpdated, updatedOn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Erase - Deletes the customer's data key, which leaves any copy of its personal data (backups, replicas)
+// unreadable, and blanks the personal data in the row as well.
+func (r *customerRepo) Erase(ctx context.Context, erasure CustomerErasure) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		UPDATE customers
+		SET
+			name = '',
+			birth_date = NULL,
+			email = '',
+			ssn = '',
+			updated_on = ?,
+			erased_on = ?
+		WHERE
+			customer_id = ?
+			AND tenant_id = ?
+			AND erased_on IS NULL
+			AND legal_hold_on IS NULL
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		erasure.ErasedOn,
+		erasure.ErasedOn,
+		erasure.CustomerID,
+		erasure.TenantID)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+
+	for _, table := range []string{"customer_tax_ids", "customer_businesses", "customer_beneficial_owners",
+		"customer_addresses", "customer_phones", "customer_bank_accounts", "customer_bank_account_attempts",
+		"customer_documents", "customer_risk_scores"} {
+		qry = `
+			DELETE FROM ` + table + `
+			WHERE customer_id = ?
+			  AND tenant_id = ?
+		`
+		if _, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), erasure.CustomerID, erasure.TenantID); err != nil {
+			return err
+		}
+	}
+
+	qry = `
+		DELETE FROM customer_keys
+		WHERE customer_id = ?
+		  AND tenant_id = ?
+	`
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), erasure.CustomerID, erasure.TenantID); err != nil {
+		return err
+	}
+
+	qry = `
+		INSERT INTO customer_erasures(
+			erasure_id,
+			tenant_id,
+			customer_id,
+			reason,
+			erased_fields,
+			erased_on
+		) VALUES (?,?,?,?,?,?)
+	`
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		erasure.ErasureID,
+		erasure.TenantID,
+		erasure.CustomerID,
+		erasure.Reason,
+		strings.Join(erasure.ErasedFields, ","),
+		erasure.ErasedOn,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := r.recordChange(ctx, tx, erasure.TenantID, erasure.CustomerID, ChangeErased, erasure.ErasedOn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *customerRepo) GetErasure(ctx context.Context, tenantID string, customerID string) (*CustomerErasure, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	qry := `
+		SELECT
+			customer_erasures.erasure_id,
+			customer_erasures.tenant_id,
+			customer_erasures.customer_id,
+			customer_erasures.reason,
+			customer_erasures.erased_fields,
+			customer_erasures.erased_on
+		FROM customer_erasures
+		WHERE customer_erasures.tenant_id = ?
+		  AND customer_erasures.customer_id = ?
+	`
+
+	erasure := CustomerErasure{}
+	var fields string
+	err := r.db.QueryRowContext(ctx, r.dialect.Rebind(qry), tenantID, customerID).Scan(
+		&erasure.ErasureID,
+		&erasure.TenantID,
+		&erasure.CustomerID,
+		&erasure.Reason,
+		&fields,
+		&erasure.ErasedOn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	erasure.ErasedFields = strings.Split(fields, ",")
+	erasure.ErasedOn = erasure.ErasedOn.UTC()
+	return &erasure, nil
+}
+
+func (r *customerRepo) ListPurgeable(ctx context.Context, filter PurgeFilter) ([]PurgeCandidate, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT
+			customers.tenant_id,
+			customers.customer_id,
+			customers.disabled_on
+		FROM customers
+		WHERE customers.disabled_on < ?
+		  AND customers.erased_on IS NULL
+		  AND customers.legal_hold_on IS NULL
+	`
+	args := []interface{}{filter.DisabledBefore}
+
+	if filter.TenantID != "" {
+		qry += ` AND customers.tenant_id = ?`
+		args = append(args, filter.TenantID)
+	}
+	if len(filter.ExcludeTenantIDs) > 0 {
+		qry += ` AND customers.tenant_id NOT IN (?` + strings.Repeat(",?", len(filter.ExcludeTenantIDs)-1) + `)`
+		for _, tenantID := range filter.ExcludeTenantIDs {
+			args = append(args, tenantID)
+		}
+	}
+
+	qry += `
+		ORDER BY customers.disabled_on, customers.customer_id
+		LIMIT ?
+	`
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []PurgeCandidate{}
+	for rows.Next() {
+		item := PurgeCandidate{}
+		if err := rows.Scan(&item.TenantID, &item.CustomerID, &item.DisabledOn); err != nil {
+			return nil, err
+		}
+		item.DisabledOn = item.DisabledOn.UTC()
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
@@ -0,0 +1,178 @@
+package customers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+func Test_Customer_LegalHold(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+
+		hold := customers.LegalHold{Reason: "Litigation", PlacedOn: time.Now().UTC().Truncate(time.Microsecond)}
+		a.Nil(repository.SetLegalHold(ctx, added.TenantID, added.CustomerID, &hold, hold.PlacedOn))
+
+		found, err := repository.Get(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(&hold, found.LegalHold)
+
+		// Held customers can't be erased.
+		err = repository.Erase(ctx, newErasure(*added))
+		a.Equal(sql.ErrNoRows, err)
+
+		a.Nil(repository.SetLegalHold(ctx, added.TenantID, added.CustomerID, nil, hold.PlacedOn))
+		found, err = repository.Get(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Nil(found.LegalHold)
+
+		err = repository.SetLegalHold(ctx, uuid.NewString(), added.CustomerID, &hold, hold.PlacedOn)
+		a.Equal(sql.ErrNoRows, err)
+	})
+}
+
+func Test_Customer_Erase(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+
+		erasure := newErasure(*added)
+		a.Nil(repository.Erase(ctx, erasure))
+
+		// Only the IDs and timestamps are left.
+		found, err := repository.Get(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(added.CustomerID, found.CustomerID)
+		a.Equal(added.CreatedOn, found.CreatedOn)
+		a.Equal(&erasure.ErasedOn, found.ErasedOn)
+		a.Empty(found.Name)
+		a.Empty(found.Email)
+		a.Nil(found.TaxID)
+		a.Nil(found.BirthDate)
+
+		certificate, err := repository.GetErasure(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(erasure, *certificate)
+
+		changes, err := repository.ListChanges(ctx, added.TenantID, 0, 10)
+		a.Nil(err)
+		a.Len(changes, 2)
+		a.Equal(customers.ChangeErased, changes[1].Type)
+		a.Nil(changes[1].Customer.TaxID)
+
+		// Erased customers can't be erased again, or held.
+		a.Equal(sql.ErrNoRows, repository.Erase(ctx, newErasure(*added)))
+		a.Equal(sql.ErrNoRows, repository.SetLegalHold(ctx, added.TenantID, added.CustomerID, &customers.LegalHold{Reason: "Late"}, erasure.ErasedOn))
+
+		_, err = repository.GetErasure(ctx, uuid.NewString(), added.CustomerID)
+		a.Equal(sql.ErrNoRows, err)
+	})
+}
+
+func Test_Customer_EncryptedAtRest(t *testing.T) {
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			a := require.New(t)
+			ctx := context.Background()
+			repository := customers.NewCustomerRepository(db.DB, db.Dialect, customers.TimeoutsConfig{}, testKeyEncryptionKey(t))
+
+			added, err := repository.Add(ctx, NewCustomer())
+			a.Nil(err)
+
+			var name, email, ssn, taxID string
+			qry := db.Dialect.Rebind(`SELECT name, email, ssn FROM customers WHERE tenant_id = ? AND customer_id = ?`)
+			a.Nil(db.DB.QueryRowContext(ctx, qry, added.TenantID, added.CustomerID).Scan(&name, &email, &ssn))
+			a.NotContains(name, added.Name)
+			a.NotContains(email, added.Email)
+			a.NotContains(ssn, added.TaxID.Value)
+
+			qry = db.Dialect.Rebind(`SELECT tax_id FROM customer_tax_ids WHERE tenant_id = ? AND customer_id = ?`)
+			a.Nil(db.DB.QueryRowContext(ctx, qry, added.TenantID, added.CustomerID).Scan(&taxID))
+			a.NotContains(taxID, added.TaxID.Value)
+
+			a.Nil(repository.Erase(ctx, newErasure(*added)))
+
+			var keys int
+			qry = db.Dialect.Rebind(`SELECT COUNT(*) FROM customer_keys WHERE tenant_id = ? AND customer_id = ?`)
+			a.Nil(db.DB.QueryRowContext(ctx, qry, added.TenantID, added.CustomerID).Scan(&keys))
+			a.Zero(keys)
+		})
+	}
+}
+
+func Test_Customer_ListPurgeable(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		// Long enough ago that customers added by other tests don't match.
+		longAgo := time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+		disable := func(c customers.Customer, disabledOn time.Time) customers.PurgeCandidate {
+			added, err := repository.Add(ctx, c)
+			a.Nil(err)
+			added.UpdatedOn = disabledOn
+			added.DisabledOn = &disabledOn
+			_, err = repository.Delete(ctx, *added)
+			a.Nil(err)
+			return customers.PurgeCandidate{TenantID: c.TenantID, CustomerID: c.CustomerID, DisabledOn: disabledOn}
+		}
+
+		first := disable(NewCustomer(), longAgo)
+		second := NewCustomer()
+		second.TenantID = first.TenantID
+		oldest := disable(second, longAgo.Add(-time.Hour))
+		other := disable(NewCustomer(), longAgo.Add(time.Hour))
+
+		// Disabled too recently.
+		disable(NewCustomer(), longAgo.Add(48*time.Hour))
+
+		held := disable(NewCustomer(), longAgo)
+		a.Nil(repository.SetLegalHold(ctx, held.TenantID, held.CustomerID, &customers.LegalHold{Reason: "Audit"}, longAgo))
+
+		erased := disable(NewCustomer(), longAgo)
+		a.Nil(repository.Erase(ctx, newErasure(customers.Customer{TenantID: erased.TenantID, CustomerID: erased.CustomerID})))
+
+		cutoff := longAgo.Add(24 * time.Hour)
+		found, err := repository.ListPurgeable(ctx, customers.PurgeFilter{DisabledBefore: cutoff, Limit: 10})
+		a.Nil(err)
+		a.Equal([]customers.PurgeCandidate{oldest, first, other}, found)
+
+		found, err = repository.ListPurgeable(ctx, customers.PurgeFilter{DisabledBefore: cutoff, Limit: 1})
+		a.Nil(err)
+		a.Equal([]customers.PurgeCandidate{oldest}, found)
+
+		found, err = repository.ListPurgeable(ctx, customers.PurgeFilter{TenantID: other.TenantID, DisabledBefore: cutoff, Limit: 10})
+		a.Nil(err)
+		a.Equal([]customers.PurgeCandidate{other}, found)
+
+		found, err = repository.ListPurgeable(ctx, customers.PurgeFilter{ExcludeTenantIDs: []string{first.TenantID}, DisabledBefore: cutoff, Limit: 10})
+		a.Nil(err)
+		a.Equal([]customers.PurgeCandidate{other}, found)
+	})
+}
+
+func newErasure(c customers.Customer) customers.CustomerErasure {
+	return customers.CustomerErasure{
+		ErasureID:    uuid.NewString(),
+		TenantID:     c.TenantID,
+		CustomerID:   c.CustomerID,
+		Reason:       "Data subject request",
+		ErasedFields: customers.ErasedFields,
+		ErasedOn:     time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
@@ -0,0 +1,115 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+)
+
+func (r *customerRepo) TransitionKYC(ctx context.Context, transition KYCTransition) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Matching the From status keeps two concurrent transitions from both applying.
+	qry := `
+		UPDATE customers
+		SET kyc_status = ?, updated_on = ?
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND kyc_status = ?
+		  AND disabled_on IS NULL
+		  AND erased_on IS NULL
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		transition.To,
+		transition.TransitionedOn,
+		transition.TenantID,
+		transition.CustomerID,
+		transition.From,
+	)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+
+	qry = `
+		INSERT INTO customer_kyc_transitions(
+			tenant_id,
+			customer_id,
+			transition_id,
+			from_status,
+			to_status,
+			reason,
+			transitioned_on
+		) VALUES (?,?,?,?,?,?,?)
+	`
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		transition.TenantID,
+		transition.CustomerID,
+		transition.TransitionID,
+		transition.From,
+		transition.To,
+		transition.Reason,
+		transition.TransitionedOn,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := r.recordChange(ctx, tx, transition.TenantID, transition.CustomerID, ChangeUpdated, transition.TransitionedOn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *customerRepo) ListKYCTransitions(ctx context.Context, tenantID string, customerID string) ([]KYCTransition, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT tenant_id, customer_id, transition_id, from_status, to_status, reason, transitioned_on
+		FROM customer_kyc_transitions
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		ORDER BY transitioned_on, transition_id
+	`
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []KYCTransition{}
+	for rows.Next() {
+		item := KYCTransition{}
+		err := rows.Scan(
+			&item.TenantID,
+			&item.CustomerID,
+			&item.TransitionID,
+			&item.From,
+			&item.To,
+			&item.Reason,
+			&item.TransitionedOn,
+		)
+		if err != nil {
+			return nil, err
+		}
+		item.TransitionedOn = item.TransitionedOn.UTC()
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
@@ -0,0 +1,67 @@
+package customers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+func Test_Customer_TransitionKYC(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+		other := NewCustomer()
+		other.TenantID = added.TenantID
+		_, err = repository.Add(ctx, other)
+		a.Nil(err)
+
+		transition := newKYCTransition(*added, customers.KYCUnverified, customers.KYCPending)
+		a.Nil(repository.TransitionKYC(ctx, transition))
+
+		found, err := repository.Get(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(customers.KYCPending, found.KYCStatus)
+		a.Equal(transition.TransitionedOn, found.UpdatedOn)
+
+		// Someone else got there first.
+		a.Equal(sql.ErrNoRows, repository.TransitionKYC(ctx, newKYCTransition(*added, customers.KYCUnverified, customers.KYCPending)))
+
+		history, err := repository.ListKYCTransitions(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal([]customers.KYCTransition{transition}, history)
+
+		listed, err := repository.List(ctx, added.TenantID, customers.ListFilter{KYCStatus: customers.KYCPending})
+		a.Nil(err)
+		a.Len(listed, 1)
+		a.Equal(added.CustomerID, listed[0].CustomerID)
+
+		listed, err = repository.List(ctx, added.TenantID, customers.ListFilter{})
+		a.Nil(err)
+		a.Len(listed, 2)
+
+		history, err = repository.ListKYCTransitions(ctx, uuid.NewString(), added.CustomerID)
+		a.Nil(err)
+		a.Empty(history)
+	})
+}
+
+func newKYCTransition(c customers.Customer, from customers.KYCStatus, to customers.KYCStatus) customers.KYCTransition {
+	return customers.KYCTransition{
+		TenantID:       c.TenantID,
+		CustomerID:     c.CustomerID,
+		TransitionID:   uuid.NewString(),
+		From:           from,
+		To:             to,
+		Reason:         "Checked",
+		TransitionedOn: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
@@ -0,0 +1,1205 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
+)
+
+// ErrCustomerExists - Returned by the in-memory repository when adding a customer ID already used by the tenant.
+var ErrCustomerExists = errors.New("customer already exists")
+
+// ErrAddressExists - Returned by the in-memory repository when adding an address ID the customer already has.
+var ErrAddressExists = errors.New("address already exists")
+
+// ErrPhoneExists - Returned by the in-memory repository when adding a phone ID the customer already has.
+var ErrPhoneExists = errors.New("phone already exists")
+
+// ErrDocumentExists - Returned by the in-memory repository when adding a document ID the customer already has.
+var ErrDocumentExists = errors.New("document already exists")
+
+type customerKey struct {
+	tenantID   string
+	customerID string
+}
+
+// memoryCustomerRepo - Keeps customers in a map, it follows the same rules as customerRepo so it can stand in for it
+// in tests and local demos. Nothing is persisted.
+type memoryCustomerRepo struct {
+	mu        sync.RWMutex
+	customers map[customerKey]Customer
+	// changes are kept by tenant, numbered from 1 like the SQL repository's.
+	changes   map[string][]memoryChange
+	erasures  map[customerKey]CustomerErasure
+	owners    map[customerKey][]BeneficialOwner
+	addresses map[customerKey][]Address
+	phones    map[customerKey][]Phone
+	// bankAccounts are kept in the order they were added, which is oldest first.
+	bankAccounts map[customerKey][]BankAccount
+	// bankAccountAttempts are the most failed attempts by routing and account number, kept through deletes.
+	bankAccountAttempts map[customerKey]map[string]int
+	kyc                 map[customerKey][]KYCTransition
+	documents           map[customerKey][]Document
+	// screenings are kept through erasure like the SQL repository's.
+	screenings map[customerKey][]Screening
+	alerts     []ScreeningAlert
+	runs       []RescreeningRun
+	// verifications are kept in the order they were added.
+	verifications []Verification
+	// keys are never wrapped since they don't outlive the process.
+	keys map[customerKey]*envelope.DataKey
+}
+
+// memoryChange - A change feed entry, the customer is looked up when the feed is read like the SQL join.
+type memoryChange struct {
+	key       customerKey
+	sequence  int64
+	typ       ChangeType
+	changedOn time.Time
+}
+
+func NewInMemoryCustomerRepository() CustomerRepository {
+	return &tracedCustomerRepository{next: &memoryCustomerRepo{
+		customers:           map[customerKey]Customer{},
+		changes:             map[string][]memoryChange{},
+		erasures:            map[customerKey]CustomerErasure{},
+		owners:              map[customerKey][]BeneficialOwner{},
+		addresses:           map[customerKey][]Address{},
+		phones:              map[customerKey][]Phone{},
+		bankAccounts:        map[customerKey][]BankAccount{},
+		bankAccountAttempts: map[customerKey]map[string]int{},
+		kyc:                 map[customerKey][]KYCTransition{},
+		documents:           map[customerKey][]Document{},
+		screenings:          map[customerKey][]Screening{},
+		keys:                map[customerKey]*envelope.DataKey{},
+	}}
+}
+
+func (r *memoryCustomerRepo) List(ctx context.Context, tenantID string, filter ListFilter) ([]Customer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []Customer{}
+	for key, item := range r.customers {
+		if key.tenantID == tenantID && item.DisabledOn == nil && (filter.KYCStatus == "" || item.KYCStatus == filter.KYCStatus) {
+			items = append(items, copyCustomer(item))
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].CreatedOn.Equal(items[j].CreatedOn) {
+			return items[i].CreatedOn.Before(items[j].CreatedOn)
+		}
+		return items[i].CustomerID < items[j].CustomerID
+	})
+
+	return items, nil
+}
+
+func (r *memoryCustomerRepo) Get(ctx context.Context, tenantID string, customerID string) (*Customer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, found := r.customers[customerKey{tenantID: tenantID, customerID: customerID}]
+	if !found {
+		return nil, sql.ErrNoRows
+	}
+
+	result := copyCustomer(item)
+	return &result, nil
+}
+
+func (r *memoryCustomerRepo) Update(ctx context.Context, update Customer) (*Customer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: update.TenantID, customerID: update.CustomerID}
+	cur, found := r.customers[key]
+	if !found || cur.DisabledOn != nil {
+		return nil, sql.ErrNoRows
+	}
+
+	cur.Name = update.Name
+	cur.BirthDate = update.BirthDate
+	cur.Email = update.Email
+	cur.TaxID = update.TaxID
+	cur.Business = update.Business
+	cur.UpdatedOn = update.UpdatedOn
+	cur.DisabledOn = update.DisabledOn
+	cur.ScreeningStatus = update.ScreeningStatus
+	r.customers[key] = copyCustomer(cur)
+	r.recordChange(key, ChangeUpdated, update.UpdatedOn)
+
+	return &update, nil
+}
+
+func (r *memoryCustomerRepo) Delete(ctx context.Context, update Customer) (*Customer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: update.TenantID, customerID: update.CustomerID}
+	cur, found := r.customers[key]
+	if !found || cur.DisabledOn != nil {
+		return nil, sql.ErrNoRows
+	}
+
+	cur.UpdatedOn = update.UpdatedOn
+	cur.DisabledOn = update.DisabledOn
+	r.customers[key] = copyCustomer(cur)
+	r.recordChange(key, ChangeDisabled, update.UpdatedOn)
+
+	return &update, nil
+}
+
+func (r *memoryCustomerRepo) Add(ctx context.Context, create Customer) (*Customer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: create.TenantID, customerID: create.CustomerID}
+	if _, found := r.customers[key]; found {
+		return nil, ErrCustomerExists
+	}
+
+	// Risk scores are only set by SetRiskScore and SSNs are kept as tax IDs, like the SQL repository's.
+	stored := copyCustomer(create)
+	stored.Type = create.Type.orDefault()
+	stored.Ssn = ""
+	stored.Risk = nil
+	r.customers[key] = stored
+	r.recordChange(key, ChangeCreated, create.CreatedOn)
+
+	return &create, nil
+}
+
+// recordChange - Callers must hold the write lock.
+func (r *memoryCustomerRepo) recordChange(key customerKey, changeType ChangeType, changedOn time.Time) {
+	r.changes[key.tenantID] = append(r.changes[key.tenantID], memoryChange{
+		key:       key,
+		sequence:  int64(len(r.changes[key.tenantID]) + 1),
+		typ:       changeType,
+		changedOn: changedOn,
+	})
+}
+
+func (r *memoryCustomerRepo) ListChanges(ctx context.Context, tenantID string, after int64, limit int) ([]CustomerChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []CustomerChange{}
+	// Sequences start at 1 and are never removed so the slice index is one less than the sequence.
+	changes := r.changes[tenantID]
+	for i := int(after); i >= 0 && i < len(changes) && len(items) < limit; i++ {
+		change := changes[i]
+		items = append(items, CustomerChange{
+			Sequence:  change.sequence,
+			Type:      change.typ,
+			ChangedOn: change.changedOn,
+			Customer:  copyCustomer(r.customers[change.key]),
+		})
+	}
+
+	return items, nil
+}
+
+func (r *memoryCustomerRepo) LatestChange(ctx context.Context, tenantID string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return int64(len(r.changes[tenantID])), nil
+}
+
+func (r *memoryCustomerRepo) SetLegalHold(ctx context.Context, tenantID string, customerID string, hold *LegalHold, updatedOn time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: tenantID, customerID: customerID}
+	cur, found := r.customers[key]
+	if !found || cur.ErasedOn != nil {
+		return sql.ErrNoRows
+	}
+
+	cur.LegalHold = hold
+	cur.UpdatedOn = updatedOn
+	r.customers[key] = copyCustomer(cur)
+	r.recordChange(key, ChangeUpdated, updatedOn)
+
+	return nil
+}
+
+func (r *memoryCustomerRepo) Erase(ctx context.Context, erasure CustomerErasure) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: erasure.TenantID, customerID: erasure.CustomerID}
+	cur, found := r.customers[key]
+	if !found || cur.ErasedOn != nil || cur.LegalHold != nil {
+		return sql.ErrNoRows
+	}
+
+	cur.Name, cur.BirthDate, cur.Email, cur.TaxID = "", nil, "", nil
+	cur.Business = nil
+	cur.Risk = nil
+	cur.UpdatedOn = erasure.ErasedOn
+	cur.ErasedOn = &erasure.ErasedOn
+	r.customers[key] = copyCustomer(cur)
+
+	delete(r.owners, key)
+	delete(r.addresses, key)
+	delete(r.phones, key)
+	delete(r.bankAccounts, key)
+	delete(r.bankAccountAttempts, key)
+	delete(r.documents, key)
+	delete(r.keys, key)
+
+	erasure.ErasedFields = append([]string(nil), erasure.ErasedFields...)
+	r.erasures[key] = erasure
+	r.recordChange(key, ChangeErased, erasure.ErasedOn)
+
+	return nil
+}
+
+func (r *memoryCustomerRepo) GetErasure(ctx context.Context, tenantID string, customerID string) (*CustomerErasure, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	erasure, found := r.erasures[customerKey{tenantID: tenantID, customerID: customerID}]
+	if !found {
+		return nil, sql.ErrNoRows
+	}
+
+	erasure.ErasedFields = append([]string(nil), erasure.ErasedFields...)
+	return &erasure, nil
+}
+
+func (r *memoryCustomerRepo) ListPurgeable(ctx context.Context, filter PurgeFilter) ([]PurgeCandidate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	excluded := map[string]bool{}
+	for _, tenantID := range filter.ExcludeTenantIDs {
+		excluded[tenantID] = true
+	}
+
+	items := []PurgeCandidate{}
+	for key, item := range r.customers {
+		switch {
+		case filter.TenantID != "" && key.tenantID != filter.TenantID, excluded[key.tenantID]:
+			continue
+		case item.DisabledOn == nil || !item.DisabledOn.Before(filter.DisabledBefore):
+			continue
+		case item.ErasedOn != nil || item.LegalHold != nil:
+			continue
+		}
+		items = append(items, PurgeCandidate{TenantID: key.tenantID, CustomerID: key.customerID, DisabledOn: *item.DisabledOn})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].DisabledOn.Equal(items[j].DisabledOn) {
+			return items[i].DisabledOn.Before(items[j].DisabledOn)
+		}
+		return items[i].CustomerID < items[j].CustomerID
+	})
+
+	if len(items) > filter.Limit {
+		items = items[:filter.Limit]
+	}
+	return items, nil
+}
+
+func (r *memoryCustomerRepo) AddAddress(ctx context.Context, create Address) (*Address, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: create.TenantID, customerID: create.CustomerID}
+	for _, item := range r.addresses[key] {
+		if item.AddressID == create.AddressID {
+			return nil, ErrAddressExists
+		}
+	}
+
+	if create.Primary {
+		r.demotePrimary(key, create)
+	}
+	r.addresses[key] = append(r.addresses[key], create)
+	r.recordChange(key, ChangeUpdated, create.CreatedOn)
+
+	return &create, nil
+}
+
+func (r *memoryCustomerRepo) ListAddresses(ctx context.Context, tenantID string, customerID string) ([]Address, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Kept in the order they were added.
+	return append([]Address{}, r.addresses[customerKey{tenantID: tenantID, customerID: customerID}]...), nil
+}
+
+func (r *memoryCustomerRepo) UpdateAddress(ctx context.Context, update Address) (*Address, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: update.TenantID, customerID: update.CustomerID}
+	for i, item := range r.addresses[key] {
+		if item.AddressID != update.AddressID {
+			continue
+		}
+
+		if update.Primary {
+			r.demotePrimary(key, update)
+		}
+		r.addresses[key][i] = update
+		r.recordChange(key, ChangeUpdated, update.UpdatedOn)
+		return &update, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *memoryCustomerRepo) DeleteAddress(ctx context.Context, tenantID string, customerID string, addressID string, deletedOn time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: tenantID, customerID: customerID}
+	for i, item := range r.addresses[key] {
+		if item.AddressID == addressID {
+			r.addresses[key] = append(r.addresses[key][:i:i], r.addresses[key][i+1:]...)
+			r.recordChange(key, ChangeUpdated, deletedOn)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// demotePrimary - Clears the primary flag from the customer's other addresses of the same type, the lock must be held.
+func (r *memoryCustomerRepo) demotePrimary(key customerKey, a Address) {
+	for i, item := range r.addresses[key] {
+		if item.AddressID != a.AddressID && item.Type == a.Type && item.Primary {
+			r.addresses[key][i].Primary = false
+			r.addresses[key][i].UpdatedOn = a.UpdatedOn
+		}
+	}
+}
+
+func (r *memoryCustomerRepo) AddBeneficialOwner(ctx context.Context, create BeneficialOwner) (*BeneficialOwner, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: create.TenantID, customerID: create.CustomerID}
+	for _, item := range r.owners[key] {
+		if item.OwnerID == create.OwnerID {
+			return nil, ErrBeneficialOwnerExists
+		}
+	}
+
+	r.owners[key] = append(r.owners[key], create)
+	r.recordChange(key, ChangeUpdated, create.CreatedOn)
+
+	return &create, nil
+}
+
+func (r *memoryCustomerRepo) ListBeneficialOwners(ctx context.Context, tenantID string, customerID string) ([]BeneficialOwner, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := append([]BeneficialOwner{}, r.owners[customerKey{tenantID: tenantID, customerID: customerID}]...)
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].CreatedOn.Equal(items[j].CreatedOn) {
+			return items[i].CreatedOn.Before(items[j].CreatedOn)
+		}
+		return items[i].OwnerID < items[j].OwnerID
+	})
+	return items, nil
+}
+
+func (r *memoryCustomerRepo) UpdateBeneficialOwner(ctx context.Context, update BeneficialOwner) (*BeneficialOwner, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: update.TenantID, customerID: update.CustomerID}
+	for i, item := range r.owners[key] {
+		if item.OwnerID == update.OwnerID {
+			r.owners[key][i] = update
+			r.recordChange(key, ChangeUpdated, update.UpdatedOn)
+			return &update, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *memoryCustomerRepo) DeleteBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string, deletedOn time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: tenantID, customerID: customerID}
+	for i, item := range r.owners[key] {
+		if item.OwnerID == ownerID {
+			r.owners[key] = append(r.owners[key][:i:i], r.owners[key][i+1:]...)
+			r.recordChange(key, ChangeUpdated, deletedOn)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (r *memoryCustomerRepo) AddPhone(ctx context.Context, create Phone) (*Phone, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: create.TenantID, customerID: create.CustomerID}
+	for _, item := range r.phones[key] {
+		if item.PhoneID == create.PhoneID {
+			return nil, ErrPhoneExists
+		}
+	}
+
+	if create.Primary {
+		r.demotePrimaryPhone(key, create)
+	}
+	r.phones[key] = append(r.phones[key], copyPhone(create))
+	r.recordChange(key, ChangeUpdated, create.CreatedOn)
+
+	return &create, nil
+}
+
+func (r *memoryCustomerRepo) ListPhones(ctx context.Context, tenantID string, customerID string) ([]Phone, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []Phone{}
+	for _, item := range r.phones[customerKey{tenantID: tenantID, customerID: customerID}] {
+		items = append(items, copyPhone(item))
+	}
+	return items, nil
+}
+
+func (r *memoryCustomerRepo) UpdatePhone(ctx context.Context, update Phone) (*Phone, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: update.TenantID, customerID: update.CustomerID}
+	for i, item := range r.phones[key] {
+		if item.PhoneID != update.PhoneID {
+			continue
+		}
+
+		if update.Primary {
+			r.demotePrimaryPhone(key, update)
+		}
+		r.phones[key][i] = copyPhone(update)
+		r.recordChange(key, ChangeUpdated, update.UpdatedOn)
+		return &update, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *memoryCustomerRepo) DeletePhone(ctx context.Context, tenantID string, customerID string, phoneID string, deletedOn time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: tenantID, customerID: customerID}
+	for i, item := range r.phones[key] {
+		if item.PhoneID == phoneID {
+			r.phones[key] = append(r.phones[key][:i:i], r.phones[key][i+1:]...)
+			r.recordChange(key, ChangeUpdated, deletedOn)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// demotePrimaryPhone - Clears the primary flag from the customer's other phones of the same type, the lock must be held.
+func (r *memoryCustomerRepo) demotePrimaryPhone(key customerKey, p Phone) {
+	for i, item := range r.phones[key] {
+		if item.PhoneID != p.PhoneID && item.Type == p.Type && item.Primary {
+			r.phones[key][i].Primary = false
+			r.phones[key][i].UpdatedOn = p.UpdatedOn
+		}
+	}
+}
+
+func (r *memoryCustomerRepo) AddBankAccount(ctx context.Context, create BankAccount) (*BankAccount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: create.TenantID, customerID: create.CustomerID}
+	for _, item := range r.bankAccounts[key] {
+		if item.BankAccountID == create.BankAccountID {
+			return nil, ErrBankAccountExists
+		}
+	}
+
+	r.bankAccounts[key] = append(r.bankAccounts[key], copyBankAccount(create))
+	r.recordChange(key, ChangeUpdated, create.CreatedOn)
+
+	return &create, nil
+}
+
+func (r *memoryCustomerRepo) ListBankAccounts(ctx context.Context, tenantID string, customerID string) ([]BankAccount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []BankAccount{}
+	for _, item := range r.bankAccounts[customerKey{tenantID: tenantID, customerID: customerID}] {
+		items = append(items, copyBankAccount(item))
+	}
+	return items, nil
+}
+
+func (r *memoryCustomerRepo) UpdateBankAccount(ctx context.Context, from BankAccount, update BankAccount) (*BankAccount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: update.TenantID, customerID: update.CustomerID}
+	for i, item := range r.bankAccounts[key] {
+		if item.BankAccountID != update.BankAccountID || item.Status != from.Status || failedAttempts(item) != failedAttempts(from) {
+			continue
+		}
+
+		// Only the status and micro-deposits are saved, like the SQL update.
+		saved, updated := copyBankAccount(item), copyBankAccount(update)
+		saved.Status = updated.Status
+		saved.MicroDeposits = updated.MicroDeposits
+		saved.VerifiedOn = updated.VerifiedOn
+		saved.UpdatedOn = updated.UpdatedOn
+		r.bankAccounts[key][i] = saved
+		if attempts := r.bankAccountAttempts[key]; failedAttempts(saved) > attempts[bankAccountNumbers(saved)] {
+			if attempts == nil {
+				attempts = map[string]int{}
+				r.bankAccountAttempts[key] = attempts
+			}
+			attempts[bankAccountNumbers(saved)] = failedAttempts(saved)
+		}
+		r.recordChange(key, ChangeUpdated, update.UpdatedOn)
+		return &update, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *memoryCustomerRepo) BankAccountFailedAttempts(ctx context.Context, tenantID string, customerID string, routingNumber string, accountNumber string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key := customerKey{tenantID: tenantID, customerID: customerID}
+	return r.bankAccountAttempts[key][bankAccountNumbers(BankAccount{RoutingNumber: routingNumber, AccountNumber: accountNumber})], nil
+}
+
+// bankAccountNumbers - What bankAccountAttempts are kept by, the process holds the account numbers anyway.
+func bankAccountNumbers(b BankAccount) string {
+	return b.RoutingNumber + "/" + b.AccountNumber
+}
+
+func (r *memoryCustomerRepo) DeleteBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string, deletedOn time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: tenantID, customerID: customerID}
+	for i, item := range r.bankAccounts[key] {
+		if item.BankAccountID == bankAccountID {
+			r.bankAccounts[key] = append(r.bankAccounts[key][:i:i], r.bankAccounts[key][i+1:]...)
+			r.recordChange(key, ChangeUpdated, deletedOn)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (r *memoryCustomerRepo) TransitionKYC(ctx context.Context, transition KYCTransition) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: transition.TenantID, customerID: transition.CustomerID}
+	cur, found := r.customers[key]
+	if !found || cur.DisabledOn != nil || cur.ErasedOn != nil || cur.KYCStatus != transition.From {
+		return sql.ErrNoRows
+	}
+
+	cur.KYCStatus = transition.To
+	cur.UpdatedOn = transition.TransitionedOn
+	r.customers[key] = cur
+	r.kyc[key] = append(r.kyc[key], transition)
+	r.recordChange(key, ChangeUpdated, transition.TransitionedOn)
+
+	return nil
+}
+
+func (r *memoryCustomerRepo) ListKYCTransitions(ctx context.Context, tenantID string, customerID string) ([]KYCTransition, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]KYCTransition{}, r.kyc[customerKey{tenantID: tenantID, customerID: customerID}]...), nil
+}
+
+func (r *memoryCustomerRepo) DataKey(ctx context.Context, tenantID string, customerID string, createdOn time.Time) (*envelope.DataKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: tenantID, customerID: customerID}
+	if dataKey, found := r.keys[key]; found {
+		return dataKey, nil
+	}
+
+	dataKey, err := envelope.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+	r.keys[key] = dataKey
+	return dataKey, nil
+}
+
+func (r *memoryCustomerRepo) AddDocument(ctx context.Context, create Document) (*Document, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: create.TenantID, customerID: create.CustomerID}
+	for _, item := range r.documents[key] {
+		if item.DocumentID == create.DocumentID {
+			return nil, ErrDocumentExists
+		}
+	}
+
+	r.documents[key] = append(r.documents[key], create)
+	r.recordChange(key, ChangeUpdated, create.CreatedOn)
+
+	return &create, nil
+}
+
+func (r *memoryCustomerRepo) ListDocuments(ctx context.Context, tenantID string, customerID string) ([]Document, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Document{}, r.documents[customerKey{tenantID: tenantID, customerID: customerID}]...), nil
+}
+
+func (r *memoryCustomerRepo) DeleteDocument(ctx context.Context, tenantID string, customerID string, documentID string, deletedOn time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: tenantID, customerID: customerID}
+	for i, item := range r.documents[key] {
+		if item.DocumentID == documentID {
+			r.documents[key] = append(r.documents[key][:i:i], r.documents[key][i+1:]...)
+			r.recordChange(key, ChangeUpdated, deletedOn)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// copyPhone - Detaches the phone from the caller's VerifiedOn pointer.
+func copyPhone(p Phone) Phone {
+	if p.VerifiedOn != nil {
+		verifiedOn := *p.VerifiedOn
+		p.VerifiedOn = &verifiedOn
+	}
+	return p
+}
+
+// copyBankAccount - Detaches the bank account from the caller's micro-deposits and VerifiedOn pointer.
+func copyBankAccount(b BankAccount) BankAccount {
+	if b.MicroDeposits != nil {
+		deposits := *b.MicroDeposits
+		deposits.Amounts = append([]int(nil), deposits.Amounts...)
+		b.MicroDeposits = &deposits
+	}
+	if b.VerifiedOn != nil {
+		verifiedOn := *b.VerifiedOn
+		b.VerifiedOn = &verifiedOn
+	}
+	return b
+}
+
+// copyCustomer - Detaches the pointer fields so callers can't modify what's stored.
+func copyCustomer(c Customer) Customer {
+	if c.BirthDate != nil {
+		birthDate := *c.BirthDate
+		c.BirthDate = &birthDate
+	}
+	if c.DisabledOn != nil {
+		disabledOn := *c.DisabledOn
+		c.DisabledOn = &disabledOn
+	}
+	if c.ErasedOn != nil {
+		erasedOn := *c.ErasedOn
+		c.ErasedOn = &erasedOn
+	}
+	if c.LegalHold != nil {
+		hold := *c.LegalHold
+		c.LegalHold = &hold
+	}
+	if c.TaxID != nil {
+		taxID := *c.TaxID
+		c.TaxID = &taxID
+	}
+	if c.Business != nil {
+		business := *c.Business
+		if business.FormationDate != nil {
+			formationDate := *business.FormationDate
+			business.FormationDate = &formationDate
+		}
+		c.Business = &business
+	}
+	if c.Risk != nil {
+		risk := *c.Risk
+		risk.Factors = append([]RiskFactor{}, risk.Factors...)
+		c.Risk = &risk
+	}
+	return c
+}
+
+func (r *memoryCustomerRepo) AddScreening(ctx context.Context, create Screening) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: create.TenantID, customerID: create.CustomerID}
+	create.Matches = append([]ScreeningMatch{}, create.Matches...)
+	r.screenings[key] = append(r.screenings[key], create)
+
+	return nil
+}
+
+func (r *memoryCustomerRepo) ListScreenings(ctx context.Context, tenantID string, customerID string) ([]Screening, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []Screening{}
+	for _, item := range r.screenings[customerKey{tenantID: tenantID, customerID: customerID}] {
+		item.Matches = append([]ScreeningMatch{}, item.Matches...)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *memoryCustomerRepo) SetScreeningStatus(ctx context.Context, tenantID string, customerID string, status ScreeningStatus, updatedOn time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: tenantID, customerID: customerID}
+	cur, found := r.customers[key]
+	if !found || cur.DisabledOn != nil || cur.ErasedOn != nil {
+		return sql.ErrNoRows
+	}
+
+	cur.ScreeningStatus = status
+	cur.UpdatedOn = updatedOn
+	r.customers[key] = copyCustomer(cur)
+	r.recordChange(key, ChangeUpdated, updatedOn)
+
+	return nil
+}
+
+func (r *memoryCustomerRepo) SetRiskScore(ctx context.Context, tenantID string, customerID string, score RiskScore) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{tenantID: tenantID, customerID: customerID}
+	cur, found := r.customers[key]
+	if !found || cur.DisabledOn != nil || cur.ErasedOn != nil {
+		return sql.ErrNoRows
+	}
+
+	cur.Risk = &score
+	cur.UpdatedOn = score.ScoredOn
+	r.customers[key] = copyCustomer(cur)
+	r.recordChange(key, ChangeUpdated, score.ScoredOn)
+
+	return nil
+}
+
+func (r *memoryCustomerRepo) ListScreenable(ctx context.Context, filter ScreenableFilter) ([]ScreeningCandidate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	after := filter.After
+	items := []ScreeningCandidate{}
+	for key, item := range r.customers {
+		if item.DisabledOn != nil || item.ErasedOn != nil {
+			continue
+		}
+		if key.tenantID < after.TenantID || (key.tenantID == after.TenantID && key.customerID <= after.CustomerID) {
+			continue
+		}
+		items = append(items, ScreeningCandidate{TenantID: key.tenantID, CustomerID: key.customerID})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].TenantID != items[j].TenantID {
+			return items[i].TenantID < items[j].TenantID
+		}
+		return items[i].CustomerID < items[j].CustomerID
+	})
+
+	if len(items) > filter.Limit {
+		items = items[:filter.Limit]
+	}
+	return items, nil
+}
+
+func (r *memoryCustomerRepo) AddScreeningAlerts(ctx context.Context, alerts []ScreeningAlert) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.alerts = append(r.alerts, alerts...)
+	return nil
+}
+
+func (r *memoryCustomerRepo) ListScreeningAlerts(ctx context.Context, tenantID string, filter ScreeningAlertFilter) ([]ScreeningAlert, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []ScreeningAlert{}
+	for _, item := range r.alerts {
+		switch {
+		case item.TenantID != tenantID:
+			continue
+		case filter.CustomerID != "" && item.CustomerID != filter.CustomerID:
+			continue
+		case filter.RunID != "" && item.RunID != filter.RunID:
+			continue
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].CreatedOn.Equal(items[j].CreatedOn) {
+			return items[i].CreatedOn.Before(items[j].CreatedOn)
+		}
+		return items[i].AlertID < items[j].AlertID
+	})
+	return items, nil
+}
+
+func (r *memoryCustomerRepo) AddRescreeningRun(ctx context.Context, run RescreeningRun) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.runs = append(r.runs, run)
+	return nil
+}
+
+func (r *memoryCustomerRepo) UpdateRescreeningRun(ctx context.Context, run RescreeningRun) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, item := range r.runs {
+		if item.RunID == run.RunID {
+			r.runs[i] = run
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (r *memoryCustomerRepo) LatestRescreeningRun(ctx context.Context) (*RescreeningRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *RescreeningRun
+	for i, item := range r.runs {
+		if latest == nil || item.StartedOn.After(latest.StartedOn) ||
+			(item.StartedOn.Equal(latest.StartedOn) && item.RunID > latest.RunID) {
+			latest = &r.runs[i]
+		}
+	}
+	if latest == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	run := *latest
+	return &run, nil
+}
+
+func (r *memoryCustomerRepo) AddVerification(ctx context.Context, create Verification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, item := range r.verifications {
+		if item.TenantID == create.TenantID && item.CustomerID == create.CustomerID && item.Status == VerificationPending {
+			completedOn := create.CreatedOn
+			r.verifications[i].Status = VerificationSuperseded
+			r.verifications[i].CompletedOn = &completedOn
+		}
+	}
+	r.verifications = append(r.verifications, copyVerification(create))
+	return nil
+}
+
+func (r *memoryCustomerRepo) UpdateVerification(ctx context.Context, from Verification, update Verification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, item := range r.verifications {
+		if item.TenantID == update.TenantID && item.CustomerID == update.CustomerID &&
+			item.VerificationID == update.VerificationID && item.Status == VerificationPending &&
+			item.Attempts == from.Attempts && item.NextAttemptOn.Equal(from.NextAttemptOn) {
+			// Only what the SQL repository updates.
+			item.Status = update.Status
+			item.Reference = update.Reference
+			item.Reasons = update.Reasons
+			item.Attempts = update.Attempts
+			item.LastError = update.LastError
+			item.NextAttemptOn = update.NextAttemptOn
+			item.CompletedOn = update.CompletedOn
+			r.verifications[i] = copyVerification(item)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (r *memoryCustomerRepo) ListVerifications(ctx context.Context, tenantID string, customerID string) ([]Verification, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []Verification{}
+	for _, item := range r.verifications {
+		if item.TenantID == tenantID && item.CustomerID == customerID {
+			items = append(items, copyVerification(item))
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].CreatedOn.Equal(items[j].CreatedOn) {
+			return items[i].CreatedOn.Before(items[j].CreatedOn)
+		}
+		return items[i].VerificationID < items[j].VerificationID
+	})
+	return items, nil
+}
+
+func (r *memoryCustomerRepo) ListDueVerifications(ctx context.Context, dueBy time.Time, limit int) ([]Verification, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []Verification{}
+	for _, item := range r.verifications {
+		if item.Status == VerificationPending && !item.NextAttemptOn.After(dueBy) {
+			items = append(items, copyVerification(item))
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if !a.NextAttemptOn.Equal(b.NextAttemptOn) {
+			return a.NextAttemptOn.Before(b.NextAttemptOn)
+		}
+		if a.TenantID != b.TenantID {
+			return a.TenantID < b.TenantID
+		}
+		if a.CustomerID != b.CustomerID {
+			return a.CustomerID < b.CustomerID
+		}
+		return a.VerificationID < b.VerificationID
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// copyVerification - Copies the reasons and completion time so callers can't change what's stored.
+func copyVerification(v Verification) Verification {
+	if v.Reasons != nil {
+		v.Reasons = append([]string{}, v.Reasons...)
+	}
+	if v.CompletedOn != nil {
+		completedOn := *v.CompletedOn
+		v.CompletedOn = &completedOn
+	}
+	return v
+}
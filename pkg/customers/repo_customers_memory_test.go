@@ -0,0 +1,68 @@
+package customers_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+func Test_Customer_InMemory_Concurrent(t *testing.T) {
+	a := require.New(t)
+	repository := customers.NewInMemoryCustomerRepository()
+
+	tenant := NewCustomer()
+	// Failing from the goroutines isn't allowed, their errors are checked once they're done.
+	errs := make(chan error, 50*3)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			m := NewCustomer()
+			m.TenantID = tenant.TenantID
+			_, err := repository.Add(context.Background(), m)
+			errs <- err
+
+			_, err = repository.Get(context.Background(), m.TenantID, m.CustomerID)
+			errs <- err
+
+			_, err = repository.List(context.Background(), m.TenantID, customers.ListFilter{})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		a.NoError(err)
+	}
+
+	found, err := repository.List(context.Background(), tenant.TenantID, customers.ListFilter{})
+	a.NoError(err)
+	a.Len(found, 50)
+}
+
+func Test_Customer_InMemory_Copies(t *testing.T) {
+	a := require.New(t)
+	repository := customers.NewInMemoryCustomerRepository()
+
+	added, err := repository.Add(context.Background(), NewCustomer())
+	a.NoError(err)
+
+	// Changing what we got back mustn't change what's stored.
+	found, err := repository.Get(context.Background(), added.TenantID, added.CustomerID)
+	a.NoError(err)
+	*found.BirthDate = "1900/01/01"
+
+	again, err := repository.Get(context.Background(), added.TenantID, added.CustomerID)
+	a.NoError(err)
+	a.Equal(*added.BirthDate, *again.BirthDate)
+
+	_, err = repository.Add(context.Background(), *added)
+	a.Equal(customers.ErrCustomerExists, err)
+}
@@ -0,0 +1,267 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+func (r *customerRepo) AddPhone(ctx context.Context, create Phone) (*Phone, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Add)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	encrypted, err := r.encryptPhone(ctx, tx, create, create.CreatedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	if create.Primary {
+		if err := r.demotePrimaryPhone(ctx, tx, create); err != nil {
+			return nil, err
+		}
+	}
+
+	qry := `
+		INSERT INTO customer_phones(
+			tenant_id,
+			customer_id,
+			phone_id,
+			phone_type,
+			is_primary,
+			phone_number,
+			country,
+			status,
+			verified_on,
+			created_on,
+			updated_on
+		) VALUES (?,?,?,?,?,?,?,?,?,?,?)
+	`
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		encrypted.TenantID,
+		encrypted.CustomerID,
+		encrypted.PhoneID,
+		encrypted.Type,
+		encrypted.Primary,
+		encrypted.Number,
+		encrypted.Country,
+		encrypted.Status,
+		encrypted.VerifiedOn,
+		encrypted.CreatedOn,
+		encrypted.UpdatedOn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.recordChange(ctx, tx, create.TenantID, create.CustomerID, ChangeUpdated, create.CreatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &create, nil
+}
+
+func (r *customerRepo) ListPhones(ctx context.Context, tenantID string, customerID string) ([]Phone, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT
+			customer_phones.tenant_id,
+			customer_phones.customer_id,
+			customer_phones.phone_id,
+			customer_phones.phone_type,
+			customer_phones.is_primary,
+			customer_phones.phone_number,
+			customer_phones.country,
+			customer_phones.status,
+			customer_phones.verified_on,
+			customer_phones.created_on,
+			customer_phones.updated_on,
+			customer_keys.wrapped_key
+		FROM customer_phones
+		LEFT JOIN customer_keys
+		  ON customer_keys.tenant_id = customer_phones.tenant_id
+		 AND customer_keys.customer_id = customer_phones.customer_id
+		WHERE customer_phones.tenant_id = ?
+		  AND customer_phones.customer_id = ?
+		ORDER BY customer_phones.created_on, customer_phones.phone_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Phone{}
+	for rows.Next() {
+		item := Phone{}
+		var wrappedKey sql.NullString
+		err := rows.Scan(
+			&item.TenantID,
+			&item.CustomerID,
+			&item.PhoneID,
+			&item.Type,
+			&item.Primary,
+			&item.Number,
+			&item.Country,
+			&item.Status,
+			&item.VerifiedOn,
+			&item.CreatedOn,
+			&item.UpdatedOn,
+			&wrappedKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := r.decryptFields(item.TenantID, item.CustomerID, wrappedKey, phonePersonalData(&item)); err != nil {
+			return nil, err
+		}
+		item.VerifiedOn = utcOrNil(item.VerifiedOn)
+		item.CreatedOn = item.CreatedOn.UTC()
+		item.UpdatedOn = item.UpdatedOn.UTC()
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *customerRepo) UpdatePhone(ctx context.Context, update Phone) (*Phone, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	encrypted, err := r.encryptPhone(ctx, tx, update, update.UpdatedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Primary {
+		if err := r.demotePrimaryPhone(ctx, tx, update); err != nil {
+			return nil, err
+		}
+	}
+
+	qry := `
+		UPDATE customer_phones
+		SET
+			phone_type = ?,
+			is_primary = ?,
+			phone_number = ?,
+			country = ?,
+			status = ?,
+			verified_on = ?,
+			updated_on = ?
+		WHERE
+			tenant_id = ?
+			AND customer_id = ?
+			AND phone_id = ?
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		encrypted.Type,
+		encrypted.Primary,
+		encrypted.Number,
+		encrypted.Country,
+		encrypted.Status,
+		encrypted.VerifiedOn,
+		encrypted.UpdatedOn,
+		encrypted.TenantID,
+		encrypted.CustomerID,
+		encrypted.PhoneID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if cnt != 1 {
+		return nil, sql.ErrNoRows
+	}
+
+	if err := r.recordChange(ctx, tx, update.TenantID, update.CustomerID, ChangeUpdated, update.UpdatedOn); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &update, nil
+}
+
+func (r *customerRepo) DeletePhone(ctx context.Context, tenantID string, customerID string, phoneID string, deletedOn time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		DELETE FROM customer_phones
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND phone_id = ?
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), tenantID, customerID, phoneID)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+
+	if err := r.recordChange(ctx, tx, tenantID, customerID, ChangeUpdated, deletedOn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// encryptPhone - Returns a copy of the phone with its number encrypted by the customer's data key.
+func (r *customerRepo) encryptPhone(ctx context.Context, tx *sql.Tx, p Phone, on time.Time) (Phone, error) {
+	dataKey, err := r.dataKeyForUpdate(ctx, tx, p.TenantID, p.CustomerID, on)
+	if err != nil {
+		return p, err
+	}
+	return p, encryptFields(dataKey, phonePersonalData(&p))
+}
+
+// demotePrimaryPhone - Clears the primary flag from the customer's other phones of the same type.
+func (r *customerRepo) demotePrimaryPhone(ctx context.Context, tx *sql.Tx, p Phone) error {
+	qry := `
+		UPDATE customer_phones
+		SET is_primary = ?, updated_on = ?
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND phone_type = ?
+		  AND phone_id <> ?
+		  AND is_primary = ?
+	`
+	_, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), false, p.UpdatedOn, p.TenantID, p.CustomerID, p.Type, p.PhoneID, true)
+	return err
+}
@@ -0,0 +1,109 @@
+package customers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+func Test_Customer_Phones(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+
+		mobile := newPhone(*added, customers.PhoneMobile)
+		_, err = repository.AddPhone(ctx, mobile)
+		a.Nil(err)
+
+		replaced := newPhone(*added, customers.PhoneMobile)
+		replaced.Number = "+13195550101"
+		replaced.CreatedOn = replaced.CreatedOn.Add(time.Second)
+		replaced.UpdatedOn = replaced.CreatedOn
+		_, err = repository.AddPhone(ctx, replaced)
+		a.Nil(err)
+
+		// Only the newest mobile is still primary.
+		found, err := repository.ListPhones(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Len(found, 2)
+		a.Equal(mobile.PhoneID, found[0].PhoneID)
+		a.False(found[0].Primary)
+		a.Equal(replaced, found[1])
+
+		verifiedOn := mobile.UpdatedOn.Add(time.Minute)
+		mobile.Status = customers.PhoneVerified
+		mobile.VerifiedOn = &verifiedOn
+		mobile.UpdatedOn = verifiedOn
+		_, err = repository.UpdatePhone(ctx, mobile)
+		a.Nil(err)
+
+		found, err = repository.ListPhones(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(mobile, found[0])
+		a.False(found[1].Primary)
+
+		a.Nil(repository.DeletePhone(ctx, added.TenantID, added.CustomerID, replaced.PhoneID, verifiedOn))
+		a.Equal(sql.ErrNoRows, repository.DeletePhone(ctx, added.TenantID, added.CustomerID, replaced.PhoneID, verifiedOn))
+
+		// Other tenants can't see or change them.
+		found, err = repository.ListPhones(ctx, uuid.NewString(), added.CustomerID)
+		a.Nil(err)
+		a.Empty(found)
+		mobile.TenantID = uuid.NewString()
+		_, err = repository.UpdatePhone(ctx, mobile)
+		a.Equal(sql.ErrNoRows, err)
+
+		// Erasing the customer removes its phones.
+		a.Nil(repository.Erase(ctx, newErasure(*added)))
+		found, err = repository.ListPhones(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Empty(found)
+	})
+}
+
+func Test_Customer_PhonesEncryptedAtRest(t *testing.T) {
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			a := require.New(t)
+			ctx := context.Background()
+			repository := customers.NewCustomerRepository(db.DB, db.Dialect, customers.TimeoutsConfig{}, testKeyEncryptionKey(t))
+
+			added, err := repository.Add(ctx, NewCustomer())
+			a.Nil(err)
+			phone, err := repository.AddPhone(ctx, newPhone(*added, customers.PhoneHome))
+			a.Nil(err)
+
+			var number string
+			qry := db.Dialect.Rebind(`SELECT phone_number FROM customer_phones WHERE tenant_id = ? AND phone_id = ?`)
+			a.Nil(db.DB.QueryRowContext(ctx, qry, added.TenantID, phone.PhoneID).Scan(&number))
+			a.NotContains(number, phone.Number)
+		})
+	}
+}
+
+func newPhone(c customers.Customer, phoneType customers.PhoneType) customers.Phone {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return customers.Phone{
+		TenantID:   c.TenantID,
+		CustomerID: c.CustomerID,
+		PhoneID:    uuid.NewString(),
+		Type:       phoneType,
+		Primary:    true,
+		Number:     "+13195550100",
+		Country:    customers.CountryUS,
+		Status:     customers.PhoneUnverified,
+		CreatedOn:  now,
+		UpdatedOn:  now,
+	}
+}
@@ -0,0 +1,323 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+func (r *customerRepo) SetScreeningStatus(ctx context.Context, tenantID string, customerID string, status ScreeningStatus, updatedOn time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		UPDATE customers
+		SET screening_status = ?, updated_on = ?
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND disabled_on IS NULL
+		  AND erased_on IS NULL
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), status, updatedOn, tenantID, customerID)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+
+	if err := r.recordChange(ctx, tx, tenantID, customerID, ChangeUpdated, updatedOn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *customerRepo) ListScreenable(ctx context.Context, filter ScreenableFilter) ([]ScreeningCandidate, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT tenant_id, customer_id
+		FROM customers
+		WHERE disabled_on IS NULL
+		  AND erased_on IS NULL
+		  AND (tenant_id > ? OR (tenant_id = ? AND customer_id > ?))
+		ORDER BY tenant_id, customer_id
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry),
+		filter.After.TenantID,
+		filter.After.TenantID,
+		filter.After.CustomerID,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []ScreeningCandidate{}
+	for rows.Next() {
+		item := ScreeningCandidate{}
+		if err := rows.Scan(&item.TenantID, &item.CustomerID); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *customerRepo) AddScreeningAlerts(ctx context.Context, alerts []ScreeningAlert) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Add)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		INSERT INTO customer_screening_alerts(
+			tenant_id,
+			customer_id,
+			alert_id,
+			screening_id,
+			run_id,
+			status,
+			list_name,
+			entry_id,
+			matched_name,
+			programs,
+			score,
+			created_on
+		) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)
+	`
+	for _, a := range alerts {
+		_, err := tx.ExecContext(ctx, r.dialect.Rebind(qry),
+			a.TenantID,
+			a.CustomerID,
+			a.AlertID,
+			a.ScreeningID,
+			a.RunID,
+			a.Status,
+			a.Match.List,
+			a.Match.EntryID,
+			a.Match.Name,
+			strings.Join(a.Match.Programs, " "),
+			a.Match.Score,
+			a.CreatedOn,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *customerRepo) ListScreeningAlerts(ctx context.Context, tenantID string, filter ScreeningAlertFilter) ([]ScreeningAlert, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT
+			tenant_id,
+			customer_id,
+			alert_id,
+			screening_id,
+			run_id,
+			status,
+			list_name,
+			entry_id,
+			matched_name,
+			programs,
+			score,
+			created_on
+		FROM customer_screening_alerts
+		WHERE tenant_id = ?
+	`
+	args := []interface{}{tenantID}
+
+	if filter.CustomerID != "" {
+		qry += ` AND customer_id = ?`
+		args = append(args, filter.CustomerID)
+	}
+	if filter.RunID != "" {
+		qry += ` AND run_id = ?`
+		args = append(args, filter.RunID)
+	}
+	qry += ` ORDER BY created_on, alert_id`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []ScreeningAlert{}
+	for rows.Next() {
+		item := ScreeningAlert{}
+		var programs string
+		err := rows.Scan(
+			&item.TenantID,
+			&item.CustomerID,
+			&item.AlertID,
+			&item.ScreeningID,
+			&item.RunID,
+			&item.Status,
+			&item.Match.List,
+			&item.Match.EntryID,
+			&item.Match.Name,
+			&programs,
+			&item.Match.Score,
+			&item.CreatedOn,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if programs != "" {
+			item.Match.Programs = strings.Fields(programs)
+		}
+		item.CreatedOn = item.CreatedOn.UTC()
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *customerRepo) AddRescreeningRun(ctx context.Context, run RescreeningRun) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Add)
+	defer cancel()
+
+	qry := `
+		INSERT INTO rescreening_runs(
+			run_id,
+			list_version,
+			status,
+			screened,
+			alerts,
+			failed,
+			after_tenant_id,
+			after_customer_id,
+			started_on,
+			updated_on,
+			finished_on
+		) VALUES (?,?,?,?,?,?,?,?,?,?,?)
+	`
+	_, err := r.db.ExecContext(ctx, r.dialect.Rebind(qry),
+		run.RunID,
+		run.ListVersion,
+		run.Status,
+		run.Screened,
+		run.Alerts,
+		run.Failed,
+		run.After.TenantID,
+		run.After.CustomerID,
+		run.StartedOn,
+		run.UpdatedOn,
+		run.FinishedOn,
+	)
+	return err
+}
+
+func (r *customerRepo) UpdateRescreeningRun(ctx context.Context, run RescreeningRun) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	qry := `
+		UPDATE rescreening_runs
+		SET
+			status = ?,
+			screened = ?,
+			alerts = ?,
+			failed = ?,
+			after_tenant_id = ?,
+			after_customer_id = ?,
+			updated_on = ?,
+			finished_on = ?
+		WHERE run_id = ?
+	`
+	res, err := r.db.ExecContext(ctx, r.dialect.Rebind(qry),
+		run.Status,
+		run.Screened,
+		run.Alerts,
+		run.Failed,
+		run.After.TenantID,
+		run.After.CustomerID,
+		run.UpdatedOn,
+		run.FinishedOn,
+		run.RunID,
+	)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *customerRepo) LatestRescreeningRun(ctx context.Context) (*RescreeningRun, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	qry := `
+		SELECT
+			run_id,
+			list_version,
+			status,
+			screened,
+			alerts,
+			failed,
+			after_tenant_id,
+			after_customer_id,
+			started_on,
+			updated_on,
+			finished_on
+		FROM rescreening_runs
+		ORDER BY started_on DESC, run_id DESC
+		LIMIT 1
+	`
+	item := RescreeningRun{}
+	err := r.db.QueryRowContext(ctx, r.dialect.Rebind(qry)).Scan(
+		&item.RunID,
+		&item.ListVersion,
+		&item.Status,
+		&item.Screened,
+		&item.Alerts,
+		&item.Failed,
+		&item.After.TenantID,
+		&item.After.CustomerID,
+		&item.StartedOn,
+		&item.UpdatedOn,
+		&item.FinishedOn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	item.StartedOn = item.StartedOn.UTC()
+	item.UpdatedOn = item.UpdatedOn.UTC()
+	item.FinishedOn = utcOrNil(item.FinishedOn)
+	return &item, nil
+}
@@ -0,0 +1,143 @@
+package customers_test
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+func Test_Customer_Rescreening(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		tenantID := uuid.NewString()
+		var candidates []customers.ScreeningCandidate
+		for i := 0; i < 3; i++ {
+			model := NewCustomer()
+			model.TenantID = tenantID
+			added, err := repository.Add(ctx, model)
+			a.Nil(err)
+			candidates = append(candidates, customers.ScreeningCandidate{TenantID: added.TenantID, CustomerID: added.CustomerID})
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].CustomerID < candidates[j].CustomerID })
+
+		// Disabled customers aren't rescreened, or blocked.
+		disabled := NewCustomer()
+		disabled.TenantID = tenantID
+		added, err := repository.Add(ctx, disabled)
+		a.Nil(err)
+		disabledOn := time.Now().UTC()
+		added.UpdatedOn = disabledOn
+		added.DisabledOn = &disabledOn
+		_, err = repository.Delete(ctx, *added)
+		a.Nil(err)
+		a.Equal(sql.ErrNoRows, repository.SetScreeningStatus(ctx, disabled.TenantID, disabled.CustomerID, customers.ScreeningBlocked, time.Now()))
+
+		// Paging starts at the first of the tenant's customers and carries on from the last one listed.
+		page, err := repository.ListScreenable(ctx, customers.ScreenableFilter{After: customers.ScreeningCandidate{TenantID: tenantID}, Limit: 2})
+		a.Nil(err)
+		a.Equal(candidates[:2], page)
+		page, err = repository.ListScreenable(ctx, customers.ScreenableFilter{After: page[1], Limit: 2})
+		a.Nil(err)
+		a.NotEmpty(page)
+		a.Equal(candidates[2], page[0])
+
+		blocked := candidates[0]
+		a.Nil(repository.SetScreeningStatus(ctx, blocked.TenantID, blocked.CustomerID, customers.ScreeningBlocked, time.Now()))
+		found, err := repository.Get(ctx, blocked.TenantID, blocked.CustomerID)
+		a.Nil(err)
+		a.Equal(customers.ScreeningBlocked, found.ScreeningStatus)
+		a.Equal(sql.ErrNoRows, repository.SetScreeningStatus(ctx, tenantID, uuid.NewString(), customers.ScreeningBlocked, time.Now()))
+
+		runID := uuid.NewString()
+		createdOn := time.Now().UTC().Truncate(time.Microsecond)
+		alerts := []customers.ScreeningAlert{
+			{
+				TenantID:    tenantID,
+				CustomerID:  candidates[0].CustomerID,
+				AlertID:     uuid.NewString(),
+				ScreeningID: uuid.NewString(),
+				RunID:       runID,
+				Status:      customers.ScreeningBlocked,
+				Match:       customers.ScreeningMatch{List: "SDN", EntryID: "36", Name: "Ricardo Andres MORALES VEGA", Programs: []string{"SDNTK"}, Score: 0.98},
+				CreatedOn:   createdOn,
+			},
+			{
+				TenantID:    tenantID,
+				CustomerID:  candidates[1].CustomerID,
+				AlertID:     uuid.NewString(),
+				ScreeningID: uuid.NewString(),
+				RunID:       uuid.NewString(),
+				Status:      customers.ScreeningFlagged,
+				Match:       customers.ScreeningMatch{List: "CONS", EntryID: "17001", Name: "Elena Zhukovskaya", Score: 0.86},
+				CreatedOn:   createdOn.Add(time.Second),
+			},
+		}
+		a.Nil(repository.AddScreeningAlerts(ctx, alerts))
+
+		listed, err := repository.ListScreeningAlerts(ctx, tenantID, customers.ScreeningAlertFilter{})
+		a.Nil(err)
+		a.Equal(alerts, listed)
+
+		listed, err = repository.ListScreeningAlerts(ctx, tenantID, customers.ScreeningAlertFilter{RunID: runID})
+		a.Nil(err)
+		a.Equal(alerts[:1], listed)
+
+		listed, err = repository.ListScreeningAlerts(ctx, tenantID, customers.ScreeningAlertFilter{CustomerID: candidates[1].CustomerID})
+		a.Nil(err)
+		a.Equal(alerts[1:], listed)
+
+		listed, err = repository.ListScreeningAlerts(ctx, uuid.NewString(), customers.ScreeningAlertFilter{})
+		a.Nil(err)
+		a.Empty(listed)
+
+		// Runs
+		startedOn := time.Now().UTC().Truncate(time.Microsecond)
+		run := customers.RescreeningRun{
+			RunID:       uuid.NewString(),
+			ListVersion: "v1",
+			Status:      customers.RescreeningRunning,
+			StartedOn:   startedOn,
+			UpdatedOn:   startedOn,
+		}
+		a.Nil(repository.AddRescreeningRun(ctx, run))
+
+		latest, err := repository.LatestRescreeningRun(ctx)
+		a.Nil(err)
+		a.Equal(run, *latest)
+
+		finishedOn := startedOn.Add(time.Minute)
+		run.Status = customers.RescreeningCompleted
+		run.Screened, run.Alerts, run.Failed = 3, 2, 1
+		run.After = candidates[2]
+		run.UpdatedOn = finishedOn
+		run.FinishedOn = &finishedOn
+		a.Nil(repository.UpdateRescreeningRun(ctx, run))
+
+		latest, err = repository.LatestRescreeningRun(ctx)
+		a.Nil(err)
+		a.Equal(run, *latest)
+
+		next := customers.RescreeningRun{
+			RunID:       uuid.NewString(),
+			ListVersion: "v2",
+			Status:      customers.RescreeningRunning,
+			StartedOn:   startedOn.Add(time.Hour),
+			UpdatedOn:   startedOn.Add(time.Hour),
+		}
+		a.Nil(repository.AddRescreeningRun(ctx, next))
+		latest, err = repository.LatestRescreeningRun(ctx)
+		a.Nil(err)
+		a.Equal(next.RunID, latest.RunID)
+
+		a.Equal(sql.ErrNoRows, repository.UpdateRescreeningRun(ctx, customers.RescreeningRun{RunID: uuid.NewString()}))
+	})
+}
@@ -0,0 +1,103 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func (r *customerRepo) SetRiskScore(ctx context.Context, tenantID string, customerID string, score RiskScore) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		UPDATE customers
+		SET updated_on = ?
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND disabled_on IS NULL
+		  AND erased_on IS NULL
+	`
+	res, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), score.ScoredOn, tenantID, customerID)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+
+	// Replaced rather than upserted since the dialects disagree on how, the customer's row above is locked anyway.
+	qry = `
+		DELETE FROM customer_risk_scores
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+	`
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(qry), tenantID, customerID); err != nil {
+		return err
+	}
+
+	qry = `
+		INSERT INTO customer_risk_scores(
+			tenant_id,
+			customer_id,
+			score,
+			tier,
+			factors,
+			rules_version,
+			scored_on
+		) VALUES (?,?,?,?,?,?,?)
+	`
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		tenantID,
+		customerID,
+		score.Score,
+		score.Tier,
+		encodeRiskFactors(score.Factors),
+		score.RulesVersion,
+		score.ScoredOn,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := r.recordChange(ctx, tx, tenantID, customerID, ChangeUpdated, score.ScoredOn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// encodeRiskFactors - Factor names are lowercase letters, digits and underscores so they can't contain the separators.
+func encodeRiskFactors(factors []RiskFactor) string {
+	encoded := make([]string, len(factors))
+	for i, f := range factors {
+		encoded[i] = f.Name + ":" + strconv.Itoa(f.Score)
+	}
+	return strings.Join(encoded, " ")
+}
+
+func decodeRiskFactors(encoded string) ([]RiskFactor, error) {
+	factors := []RiskFactor{}
+	for _, field := range strings.Fields(encoded) {
+		name, score, found := strings.Cut(field, ":")
+		n, err := strconv.Atoi(score)
+		if !found || err != nil {
+			return nil, fmt.Errorf("invalid risk factor %q", field)
+		}
+		factors = append(factors, RiskFactor{Name: name, Score: n})
+	}
+	return factors, nil
+}
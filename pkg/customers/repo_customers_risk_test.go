@@ -0,0 +1,65 @@
+package customers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+func Test_Customer_RiskScore(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+		a.Nil(added.Risk)
+
+		scoredOn := time.Now().UTC().Truncate(time.Microsecond)
+		score := customers.RiskScore{
+			Score:        55,
+			Tier:         "medium",
+			Factors:      []customers.RiskFactor{{Name: "sanctions_flagged", Score: 40}, {Name: "missing_birth_date", Score: 15}},
+			RulesVersion: "v1",
+			ScoredOn:     scoredOn,
+		}
+		a.Nil(repository.SetRiskScore(ctx, added.TenantID, added.CustomerID, score))
+
+		found, err := repository.Get(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(score, *found.Risk)
+		a.Equal(scoredOn, found.UpdatedOn)
+
+		// The score is replaced, and kept when the customer's details are updated.
+		score = customers.RiskScore{Score: -5, Tier: "low", Factors: []customers.RiskFactor{{Name: "verified", Score: -5}}, RulesVersion: "v2", ScoredOn: scoredOn.Add(time.Minute)}
+		a.Nil(repository.SetRiskScore(ctx, added.TenantID, added.CustomerID, score))
+		found.Email = "jane.doe@moov.io"
+		found.UpdatedOn = scoredOn.Add(time.Hour)
+		_, err = repository.Update(ctx, *found)
+		a.Nil(err)
+
+		listed, err := repository.List(ctx, added.TenantID, customers.ListFilter{})
+		a.Nil(err)
+		a.Len(listed, 1)
+		a.Equal(score, *listed[0].Risk)
+
+		// Nothing matched.
+		score.Factors = []customers.RiskFactor{}
+		a.Nil(repository.SetRiskScore(ctx, added.TenantID, added.CustomerID, score))
+		found, err = repository.Get(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(score, *found.Risk)
+
+		disabledOn := scoredOn.Add(2 * time.Hour)
+		found.UpdatedOn = disabledOn
+		found.DisabledOn = &disabledOn
+		_, err = repository.Delete(ctx, *found)
+		a.Nil(err)
+		a.Equal(sql.ErrNoRows, repository.SetRiskScore(ctx, added.TenantID, added.CustomerID, score))
+	})
+}
@@ -0,0 +1,140 @@
+package customers
+
+import (
+	"context"
+	"strings"
+)
+
+func (r *customerRepo) AddScreening(ctx context.Context, create Screening) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Add)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qry := `
+		INSERT INTO customer_screenings(
+			tenant_id,
+			customer_id,
+			screening_id,
+			status,
+			score,
+			screened_on
+		) VALUES (?,?,?,?,?,?)
+	`
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		create.TenantID,
+		create.CustomerID,
+		create.ScreeningID,
+		create.Status,
+		create.Score,
+		create.ScreenedOn,
+	)
+	if err != nil {
+		return err
+	}
+
+	qry = `
+		INSERT INTO customer_screening_matches(
+			tenant_id,
+			customer_id,
+			screening_id,
+			list_name,
+			entry_id,
+			matched_name,
+			programs,
+			score
+		) VALUES (?,?,?,?,?,?,?,?)
+	`
+	for _, m := range create.Matches {
+		_, err = tx.ExecContext(ctx, r.dialect.Rebind(qry),
+			create.TenantID,
+			create.CustomerID,
+			create.ScreeningID,
+			m.List,
+			m.EntryID,
+			m.Name,
+			strings.Join(m.Programs, " "),
+			m.Score,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *customerRepo) ListScreenings(ctx context.Context, tenantID string, customerID string) ([]Screening, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT tenant_id, customer_id, screening_id, status, score, screened_on
+		FROM customer_screenings
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		ORDER BY screened_on, screening_id
+	`
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Screening{}
+	byID := map[string]int{}
+	for rows.Next() {
+		item := Screening{Matches: []ScreeningMatch{}}
+		err := rows.Scan(
+			&item.TenantID,
+			&item.CustomerID,
+			&item.ScreeningID,
+			&item.Status,
+			&item.Score,
+			&item.ScreenedOn,
+		)
+		if err != nil {
+			return nil, err
+		}
+		item.ScreenedOn = item.ScreenedOn.UTC()
+		byID[item.ScreeningID] = len(items)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// The customer's matches are read in one go rather than a query per screening.
+	qry = `
+		SELECT screening_id, list_name, entry_id, matched_name, programs, score
+		FROM customer_screening_matches
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		ORDER BY screening_id, score DESC, list_name, entry_id
+	`
+	matches, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer matches.Close()
+
+	for matches.Next() {
+		var screeningID, programs string
+		m := ScreeningMatch{}
+		if err := matches.Scan(&screeningID, &m.List, &m.EntryID, &m.Name, &programs, &m.Score); err != nil {
+			return nil, err
+		}
+		if programs != "" {
+			m.Programs = strings.Fields(programs)
+		}
+		if i, found := byID[screeningID]; found {
+			items[i].Matches = append(items[i].Matches, m)
+		}
+	}
+
+	return items, matches.Err()
+}
@@ -0,0 +1,67 @@
+package customers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+func Test_Customer_Screenings(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		model := NewCustomer()
+		model.ScreeningStatus = customers.ScreeningFlagged
+		added, err := repository.Add(ctx, model)
+		a.Nil(err)
+
+		found, err := repository.Get(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(customers.ScreeningFlagged, found.ScreeningStatus)
+
+		found.ScreeningStatus = customers.ScreeningClear
+		_, err = repository.Update(ctx, *found)
+		a.Nil(err)
+		found, err = repository.Get(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(customers.ScreeningClear, found.ScreeningStatus)
+
+		flagged := newScreening(*added, customers.ScreeningFlagged,
+			customers.ScreeningMatch{List: "SDN", EntryID: "36", Name: "Ricardo Andres MORALES VEGA", Programs: []string{"SDNTK", "SDGT"}, Score: 0.91},
+			customers.ScreeningMatch{List: "CONS", EntryID: "17001", Name: "Elena Zhukovskaya", Score: 0.86},
+		)
+		a.Nil(repository.AddScreening(ctx, flagged))
+		clear := newScreening(*added, customers.ScreeningClear)
+		clear.ScreenedOn = flagged.ScreenedOn.Add(time.Second)
+		a.Nil(repository.AddScreening(ctx, clear))
+
+		screenings, err := repository.ListScreenings(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal([]customers.Screening{flagged, clear}, screenings)
+
+		screenings, err = repository.ListScreenings(ctx, added.TenantID, uuid.NewString())
+		a.Nil(err)
+		a.Empty(screenings)
+	})
+}
+
+func newScreening(c customers.Customer, status customers.ScreeningStatus, matches ...customers.ScreeningMatch) customers.Screening {
+	screening := customers.Screening{
+		TenantID:    c.TenantID,
+		CustomerID:  c.CustomerID,
+		ScreeningID: uuid.NewString(),
+		Status:      status,
+		Matches:     append([]customers.ScreeningMatch{}, matches...),
+		ScreenedOn:  time.Now().UTC().Truncate(time.Microsecond),
+	}
+	if len(matches) > 0 {
+		screening.Score = matches[0].Score
+	}
+	return screening
+}
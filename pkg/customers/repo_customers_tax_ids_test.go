@@ -0,0 +1,129 @@
+package customers_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+func Test_Customer_TaxIDs(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		model := NewCustomer()
+		model.TaxID = &customers.TaxID{Type: customers.TaxIDForeign, Value: "QQ 12 34 56 C", Country: "GB"}
+		_, err := repository.Add(ctx, model)
+		a.Nil(err)
+
+		found, err := repository.Get(ctx, model.TenantID, model.CustomerID)
+		a.Nil(err)
+		a.Equal(model, *found)
+
+		// The tax ID is replaced with the customer's.
+		found.TaxID = &customers.TaxID{Type: customers.TaxIDITIN, Value: "912-70-1234"}
+		found.UpdatedOn = found.UpdatedOn.Add(time.Minute)
+		_, err = repository.Update(ctx, *found)
+		a.Nil(err)
+
+		listed, err := repository.List(ctx, model.TenantID, customers.ListFilter{})
+		a.Nil(err)
+		a.Len(listed, 1)
+		a.Equal(*found, listed[0])
+
+		a.Nil(repository.Erase(ctx, newErasure(*found)))
+		found, err = repository.Get(ctx, model.TenantID, model.CustomerID)
+		a.Nil(err)
+		a.Nil(found.TaxID)
+	})
+}
+
+func Test_Customer_TaxIDsFromSSNs(t *testing.T) {
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			a := require.New(t)
+			ctx := context.Background()
+			repository := customers.NewCustomerRepository(db.DB, db.Dialect, customers.TimeoutsConfig{}, testKeyEncryptionKey(t))
+
+			// SSNs are still written to customers.ssn for instances from before tax IDs.
+			added, err := repository.Add(ctx, NewCustomer())
+			a.Nil(err)
+			var ssn string
+			qry := db.Dialect.Rebind(`SELECT ssn FROM customers WHERE tenant_id = ? AND customer_id = ?`)
+			a.Nil(db.DB.QueryRowContext(ctx, qry, added.TenantID, added.CustomerID).Scan(&ssn))
+			a.NotEmpty(ssn)
+
+			// Like a customer from before the SSNs were copied over.
+			qry = db.Dialect.Rebind(`DELETE FROM customer_tax_ids WHERE tenant_id = ? AND customer_id = ?`)
+			_, err = db.DB.ExecContext(ctx, qry, added.TenantID, added.CustomerID)
+			a.Nil(err)
+			found, err := repository.Get(ctx, added.TenantID, added.CustomerID)
+			a.Nil(err)
+			a.Equal(added.TaxID, found.TaxID)
+
+			// Other tax IDs aren't kept there.
+			foreign := NewCustomer()
+			foreign.TaxID = &customers.TaxID{Type: customers.TaxIDForeign, Value: "12345678901", Country: "DE"}
+			_, err = repository.Add(ctx, foreign)
+			a.Nil(err)
+			qry = db.Dialect.Rebind(`SELECT ssn FROM customers WHERE tenant_id = ? AND customer_id = ?`)
+			a.Nil(db.DB.QueryRowContext(ctx, qry, foreign.TenantID, foreign.CustomerID).Scan(&ssn))
+			a.Empty(ssn)
+
+			// Copying the SSNs over again leaves the tax IDs already there alone.
+			backfill, err := os.ReadFile("../../migrations/027_customer_tax_ids_backfill.up." + string(db.Dialect) + ".sql")
+			a.Nil(err)
+			for i := 0; i < 2; i++ {
+				_, err = db.DB.ExecContext(ctx, string(backfill))
+				a.Nil(err)
+			}
+
+			var taxIDs int
+			qry = db.Dialect.Rebind(`SELECT COUNT(*) FROM customer_tax_ids WHERE tenant_id IN (?, ?)`)
+			a.Nil(db.DB.QueryRowContext(ctx, qry, added.TenantID, foreign.TenantID).Scan(&taxIDs))
+			a.Equal(2, taxIDs)
+
+			for _, c := range []customers.Customer{*added, foreign} {
+				found, err = repository.Get(ctx, c.TenantID, c.CustomerID)
+				a.Nil(err)
+				a.Equal(c.TaxID, found.TaxID)
+			}
+
+			// An instance from before tax IDs changing the SSN back after it was copied over only writes
+			// customers.ssn, which wins over the stale tax ID.
+			var copied string
+			qry = db.Dialect.Rebind(`SELECT ssn FROM customers WHERE tenant_id = ? AND customer_id = ?`)
+			a.Nil(db.DB.QueryRowContext(ctx, qry, added.TenantID, added.CustomerID).Scan(&copied))
+
+			changed := *added
+			changed.TaxID = &customers.TaxID{Type: customers.TaxIDSSN, Value: "665-01-0001"}
+			changed.UpdatedOn = changed.UpdatedOn.Add(time.Minute)
+			_, err = repository.Update(ctx, changed)
+			a.Nil(err)
+			found, err = repository.Get(ctx, added.TenantID, added.CustomerID)
+			a.Nil(err)
+			a.Equal(changed.TaxID, found.TaxID)
+
+			qry = db.Dialect.Rebind(`UPDATE customers SET ssn = ? WHERE tenant_id = ? AND customer_id = ?`)
+			_, err = db.DB.ExecContext(ctx, qry, copied, added.TenantID, added.CustomerID)
+			a.Nil(err)
+			found, err = repository.Get(ctx, added.TenantID, added.CustomerID)
+			a.Nil(err)
+			a.Equal(added.TaxID, found.TaxID)
+
+			// Or removing it.
+			_, err = db.DB.ExecContext(ctx, qry, "", added.TenantID, added.CustomerID)
+			a.Nil(err)
+			found, err = repository.Get(ctx, added.TenantID, added.CustomerID)
+			a.Nil(err)
+			a.Nil(found.TaxID)
+		})
+	}
+}
@@ -1,34 +1,40 @@
 package customers_test
 
 import (
+	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/base64"
+	"sync"
 	"testing"
 
 	"time"
 
 	"github.com/google/uuid"
 	_ "github.com/mattn/go-sqlite3"
-	"github.com/moov-io/base/database"
 	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
 	"github.com/stretchr/testify/require"
 )
 
 func NewCustomer() customers.Customer {
 	m := NewTestCustomer(nil)
+	m.KYCStatus = customers.KYCUnverified
 	return m
 }
 
 func Test_Customer_AddAndGet(t *testing.T) {
-	CustomerTestEachDatabase(t, func(t *testing.T, repository customers.CustomerRepository) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
 		a := require.New(t)
 
 		model := NewCustomer()
 
-		added, err := repository.Add(model)
+		added, err := repository.Add(context.Background(), model)
 		a.Nil(err)
 		a.Equal(model, *added)
 
-		found, err := repository.Get(added.TenantID, added.CustomerID)
+		found, err := repository.Get(context.Background(), added.TenantID, added.CustomerID)
 		a.Nil(err)
 
 		a.Nil(added.DisabledOn)
@@ -36,7 +42,7 @@ func Test_Customer_AddAndGet(t *testing.T) {
 		a.Equal(*added, *found)
 
 		badTenantID := uuid.New().String()
-		_, err = repository.Get(badTenantID, added.CustomerID)
+		_, err = repository.Get(context.Background(), badTenantID, added.CustomerID)
 		if err != sql.ErrNoRows {
 			t.Fatal(err)
 		}
@@ -44,104 +50,307 @@ func Test_Customer_AddAndGet(t *testing.T) {
 }
 
 func Test_Customer_List(t *testing.T) {
-	CustomerTestEachDatabase(t, func(t *testing.T, repository customers.CustomerRepository) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
 		a := require.New(t)
 
-		added, err := repository.Add(NewCustomer())
+		added, err := repository.Add(context.Background(), NewCustomer())
 		a.Nil(err)
 
 		tenantID := added.TenantID
 
 		// Add noise and other invites on other tenants
-		_, _ = repository.Add(NewCustomer())
-		_, _ = repository.Add(NewCustomer())
-		_, _ = repository.Add(NewCustomer())
-		_, _ = repository.Add(NewCustomer())
+		_, _ = repository.Add(context.Background(), NewCustomer())
+		_, _ = repository.Add(context.Background(), NewCustomer())
+		_, _ = repository.Add(context.Background(), NewCustomer())
+		_, _ = repository.Add(context.Background(), NewCustomer())
 
-		found, err := repository.List(tenantID)
+		found, err := repository.List(context.Background(), tenantID, customers.ListFilter{})
 		a.Nil(err)
 		a.Len(found, 1)
 		a.Equal(*added, found[0])
 
 		badTenantID := uuid.New().String()
-		found, err = repository.List(badTenantID)
+		found, err = repository.List(context.Background(), badTenantID, customers.ListFilter{})
 		a.Nil(err)
 		a.Empty(found)
 	})
 }
 
 func Test_Customer_Update(t *testing.T) {
-	CustomerTestEachDatabase(t, func(t *testing.T, repository customers.CustomerRepository) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
 		a := require.New(t)
 
-		added, err := repository.Add(NewCustomer())
+		added, err := repository.Add(context.Background(), NewCustomer())
 		a.Nil(err)
 
 		tenantID := added.TenantID
 		updated := *added
-		updated.UpdatedOn = time.Now().UTC()
+		// MySQL and PostgreSQL only keep microseconds
+		updated.UpdatedOn = time.Now().UTC().Truncate(time.Microsecond)
 
 		// @TODO add some valid changes here
 
-		saved, err := repository.Update(updated)
+		saved, err := repository.Update(context.Background(), updated)
 		a.Nil(err)
 		a.Equal(updated, *saved)
 
-		found, err := repository.Get(tenantID, updated.CustomerID)
+		found, err := repository.Get(context.Background(), tenantID, updated.CustomerID)
 		a.Nil(err)
 		a.Equal(updated, *found)
 
 		badUpdate := updated
 		badUpdate.TenantID = uuid.New().String()
-		_, err = repository.Update(badUpdate)
+		_, err = repository.Update(context.Background(), badUpdate)
 		a.Equal(err, sql.ErrNoRows)
 	})
 }
 
 func Test_Customer_Delete(t *testing.T) {
-	CustomerTestEachDatabase(t, func(t *testing.T, repository customers.CustomerRepository) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
 		a := require.New(t)
 
-		added, err := repository.Add(NewCustomer())
+		added, err := repository.Add(context.Background(), NewCustomer())
 		a.Nil(err)
 
 		tenantID := added.TenantID
 		updated := *added
-		updated.UpdatedOn = time.Now().UTC()
+		// MySQL and PostgreSQL only keep microseconds
+		updated.UpdatedOn = time.Now().UTC().Truncate(time.Microsecond)
 		updated.DisabledOn = &updated.UpdatedOn
 
 		// @TODO add some valid changes here
 
-		saved, err := repository.Delete(updated)
+		saved, err := repository.Delete(context.Background(), updated)
 		a.Nil(err)
 		a.Equal(updated, *saved)
 
 		// We can retrieve deleted items by specifically asking for them.
-		got, err := repository.Get(tenantID, updated.CustomerID)
+		got, err := repository.Get(context.Background(), tenantID, updated.CustomerID)
 		a.Nil(err)
 		a.Equal(updated, *got)
 
 		// Don't list anything thats been deleted
-		listed, err := repository.List(tenantID)
+		listed, err := repository.List(context.Background(), tenantID, customers.ListFilter{})
 		a.Nil(err)
 		a.Empty(listed)
 
 		badUpdate := updated
 		badUpdate.TenantID = uuid.New().String()
-		_, err = repository.Update(badUpdate)
+		_, err = repository.Update(context.Background(), badUpdate)
 		a.Equal(err, sql.ErrNoRows)
 	})
 }
 
-func CustomerTestEachDatabase(t *testing.T, run func(t *testing.T, repository customers.CustomerRepository)) {
-	cases := map[string]*sql.DB{
-		"sqlite": database.CreateTestSQLiteDB(t).DB,
-	}
+func Test_Customer_UpdateDisabled(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+
+		added, err := repository.Add(context.Background(), NewCustomer())
+		a.Nil(err)
+
+		disabled := *added
+		disabled.UpdatedOn = time.Now().UTC().Truncate(time.Microsecond)
+		disabled.DisabledOn = &disabled.UpdatedOn
+		_, err = repository.Delete(context.Background(), disabled)
+		a.Nil(err)
+
+		// Disabled customers can't be changed or disabled again.
+		update := disabled
+		update.Name = "Jane Doe"
+		update.DisabledOn = nil
+		_, err = repository.Update(context.Background(), update)
+		a.Equal(sql.ErrNoRows, err)
+
+		_, err = repository.Delete(context.Background(), disabled)
+		a.Equal(sql.ErrNoRows, err)
+
+		found, err := repository.Get(context.Background(), added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Equal(disabled, *found)
+	})
+}
+
+func Test_Customer_Cancelled(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := repository.Add(ctx, NewCustomer())
+		a.ErrorIs(err, context.Canceled)
 
-	for k, db := range cases {
-		t.Run(k, func(t *testing.T) {
-			repo := customers.NewCustomerRepository(db)
+		_, err = repository.List(ctx, uuid.New().String(), customers.ListFilter{})
+		a.ErrorIs(err, context.Canceled)
+	})
+}
+
+func Test_Customer_ListChanges(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+		tenantID := added.TenantID
+
+		// Noise on another tenant
+		_, err = repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+
+		updated := *added
+		updated.Name = "Jane Doe"
+		updated.UpdatedOn = time.Now().UTC().Truncate(time.Microsecond)
+		_, err = repository.Update(ctx, updated)
+		a.Nil(err)
+
+		disabled := updated
+		disabled.UpdatedOn = updated.UpdatedOn.Add(time.Second)
+		disabled.DisabledOn = &disabled.UpdatedOn
+		_, err = repository.Delete(ctx, disabled)
+		a.Nil(err)
+
+		changes, err := repository.ListChanges(ctx, tenantID, 0, 10)
+		a.Nil(err)
+		a.Len(changes, 3)
+
+		a.Equal(customers.ChangeCreated, changes[0].Type)
+		a.Equal(customers.ChangeUpdated, changes[1].Type)
+		a.Equal(customers.ChangeDisabled, changes[2].Type)
+		a.Equal(disabled.UpdatedOn, changes[2].ChangedOn)
+		for i, change := range changes {
+			// Every change carries the customer as it is now.
+			a.Equal(disabled, change.Customer)
+			if i > 0 {
+				a.Greater(change.Sequence, changes[i-1].Sequence)
+			}
+		}
+
+		// Resuming from a change only returns the ones after it.
+		rest, err := repository.ListChanges(ctx, tenantID, changes[0].Sequence, 1)
+		a.Nil(err)
+		a.Len(rest, 1)
+		a.Equal(changes[1], rest[0])
+
+		rest, err = repository.ListChanges(ctx, tenantID, changes[2].Sequence, 10)
+		a.Nil(err)
+		a.Empty(rest)
+
+		// Failed writes don't show up in the feed.
+		_, err = repository.Update(ctx, updated)
+		a.Equal(sql.ErrNoRows, err)
+		rest, err = repository.ListChanges(ctx, tenantID, changes[2].Sequence, 10)
+		a.Nil(err)
+		a.Empty(rest)
+	})
+}
+
+func Test_Customer_ListChanges_ConcurrentWriters(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		// Start the tenant's sequence first, SQLite can't have two transactions create it at once.
+		first, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+		tenantID := first.TenantID
+
+		// A reader follows the feed while the writers commit, it must see every change once and in order.
+		done := make(chan struct{})
+		followed := make(chan []int64)
+		go func() {
+			var seen []int64
+			after := int64(0)
+			follow := func() {
+				changes, err := repository.ListChanges(ctx, tenantID, after, 5)
+				if err != nil {
+					// SQLite reports the database as busy now and then, the final read after the writers checks it.
+					return
+				}
+				for _, change := range changes {
+					seen = append(seen, change.Sequence)
+					after = change.Sequence
+				}
+			}
+			for {
+				select {
+				case <-done:
+					followed <- seen
+					return
+				default:
+					follow()
+				}
+			}
+		}()
+
+		errs := make(chan error, 20)
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m := NewCustomer()
+				m.TenantID = tenantID
+				_, err := repository.Add(ctx, m)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		close(done)
+		seen := <-followed
+
+		written := int64(1)
+		for err := range errs {
+			if err == nil {
+				written++
+			}
+		}
+
+		latest, err := repository.LatestChange(ctx, tenantID)
+		a.Nil(err)
+		a.Equal(written, latest)
+
+		after := int64(0)
+		if len(seen) > 0 {
+			after = seen[len(seen)-1]
+		}
+		rest, err := repository.ListChanges(ctx, tenantID, after, customers.MaxChangesLimit)
+		a.Nil(err)
+		for _, change := range rest {
+			seen = append(seen, change.Sequence)
+		}
+
+		// Numbered 1 to the latest without gaps, so nothing the reader skipped past can turn up later.
+		a.Len(seen, int(written))
+		for i, sequence := range seen {
+			a.EqualValues(i+1, sequence)
+		}
+	})
+}
+
+// CustomerTestEachRepository - Runs the repository contract against the in-memory repository and SQLite, plus
+// MySQL or PostgreSQL when configured. See sqldb.CreateTestDatabases for the environment variables.
+func CustomerTestEachRepository(t *testing.T, run func(t *testing.T, repository customers.CustomerRepository)) {
+	t.Run("memory", func(t *testing.T) {
+		run(t, customers.NewInMemoryCustomerRepository())
+	})
+
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			repo := customers.NewCustomerRepository(db.DB, db.Dialect, customers.TimeoutsConfig{}, testKeyEncryptionKey(t))
 			run(t, repo)
 		})
 	}
 }
+
+func testKeyEncryptionKey(t *testing.T) *envelope.KeyEncryptionKey {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	kek, err := envelope.NewKeyEncryptionKey(base64.StdEncoding.EncodeToString(key))
+	require.NoError(t, err)
+	return kek
+}
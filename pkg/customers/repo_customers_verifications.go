@@ -0,0 +1,195 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// verificationColumns - Selected by every query that returns verifications, in the order scanVerification reads them.
+const verificationColumns = `
+			tenant_id,
+			customer_id,
+			verification_id,
+			provider,
+			status,
+			reference,
+			reasons,
+			attempts,
+			last_error,
+			next_attempt_on,
+			created_on,
+			completed_on
+`
+
+func (r *customerRepo) AddVerification(ctx context.Context, create Verification) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Add)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	superseded := `
+		UPDATE customer_verifications
+		SET status = ?, completed_on = ?
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND status = ?
+	`
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(superseded),
+		VerificationSuperseded,
+		create.CreatedOn,
+		create.TenantID,
+		create.CustomerID,
+		VerificationPending,
+	)
+	if err != nil {
+		return err
+	}
+
+	qry := `
+		INSERT INTO customer_verifications(` + verificationColumns + `) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)
+	`
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(qry),
+		create.TenantID,
+		create.CustomerID,
+		create.VerificationID,
+		create.Provider,
+		create.Status,
+		create.Reference,
+		strings.Join(create.Reasons, " "),
+		create.Attempts,
+		create.LastError,
+		create.NextAttemptOn,
+		create.CreatedOn,
+		create.CompletedOn,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *customerRepo) UpdateVerification(ctx context.Context, from Verification, update Verification) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	qry := `
+		UPDATE customer_verifications
+		SET
+			status = ?,
+			reference = ?,
+			reasons = ?,
+			attempts = ?,
+			last_error = ?,
+			next_attempt_on = ?,
+			completed_on = ?
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		  AND verification_id = ?
+		  AND status = ?
+		  AND attempts = ?
+		  AND next_attempt_on = ?
+	`
+	res, err := r.db.ExecContext(ctx, r.dialect.Rebind(qry),
+		update.Status,
+		update.Reference,
+		strings.Join(update.Reasons, " "),
+		update.Attempts,
+		update.LastError,
+		update.NextAttemptOn,
+		update.CompletedOn,
+		update.TenantID,
+		update.CustomerID,
+		update.VerificationID,
+		VerificationPending,
+		from.Attempts,
+		from.NextAttemptOn,
+	)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *customerRepo) ListVerifications(ctx context.Context, tenantID string, customerID string) ([]Verification, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT` + verificationColumns + `
+		FROM customer_verifications
+		WHERE tenant_id = ?
+		  AND customer_id = ?
+		ORDER BY created_on, verification_id
+	`
+	return r.queryVerifications(ctx, qry, tenantID, customerID)
+}
+
+func (r *customerRepo) ListDueVerifications(ctx context.Context, dueBy time.Time, limit int) ([]Verification, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	qry := `
+		SELECT` + verificationColumns + `
+		FROM customer_verifications
+		WHERE status = ?
+		  AND next_attempt_on <= ?
+		ORDER BY next_attempt_on, tenant_id, customer_id, verification_id
+		LIMIT ?
+	`
+	return r.queryVerifications(ctx, qry, VerificationPending, dueBy, limit)
+}
+
+func (r *customerRepo) queryVerifications(ctx context.Context, qry string, args ...interface{}) ([]Verification, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(qry), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Verification{}
+	for rows.Next() {
+		item := Verification{}
+		var reasons string
+		err := rows.Scan(
+			&item.TenantID,
+			&item.CustomerID,
+			&item.VerificationID,
+			&item.Provider,
+			&item.Status,
+			&item.Reference,
+			&reasons,
+			&item.Attempts,
+			&item.LastError,
+			&item.NextAttemptOn,
+			&item.CreatedOn,
+			&item.CompletedOn,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if reasons != "" {
+			item.Reasons = strings.Fields(reasons)
+		}
+		item.NextAttemptOn = item.NextAttemptOn.UTC()
+		item.CreatedOn = item.CreatedOn.UTC()
+		item.CompletedOn = utcOrNil(item.CompletedOn)
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
@@ -0,0 +1,94 @@
+package customers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+func Test_Customer_Verifications(t *testing.T) {
+	CustomerTestEachRepository(t, func(t *testing.T, repository customers.CustomerRepository) {
+		a := require.New(t)
+		ctx := context.Background()
+
+		added, err := repository.Add(ctx, NewCustomer())
+		a.Nil(err)
+
+		// Long enough ago that checks added by other tests aren't due first.
+		createdOn := time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+		first := newVerification(*added, createdOn)
+		a.Nil(repository.AddVerification(ctx, first))
+
+		due, err := repository.ListDueVerifications(ctx, createdOn, 1)
+		a.Nil(err)
+		a.Equal([]customers.Verification{first}, due)
+
+		// A retry isn't due until its next attempt.
+		listed := first
+		first.Attempts = 1
+		first.LastError = "identity provider unavailable"
+		first.NextAttemptOn = createdOn.Add(time.Minute)
+		a.Nil(repository.UpdateVerification(ctx, listed, first))
+		due, err = repository.ListDueVerifications(ctx, createdOn, 10)
+		a.Nil(err)
+		a.NotContains(due, first)
+
+		// Only one worker claims the attempt it listed, the others don't find it.
+		claimed := listed
+		claimed.Attempts = 1
+		claimed.NextAttemptOn = createdOn.Add(2 * time.Minute)
+		a.Equal(sql.ErrNoRows, repository.UpdateVerification(ctx, listed, claimed))
+
+		// A new check supersedes the pending one, which can't be completed after that.
+		second := newVerification(*added, createdOn.Add(time.Second))
+		a.Nil(repository.AddVerification(ctx, second))
+
+		completed := first
+		completed.Status = customers.VerificationVerified
+		a.Equal(sql.ErrNoRows, repository.UpdateVerification(ctx, first, completed))
+
+		completedOn := createdOn.Add(time.Hour)
+		queued := second
+		second.Status = customers.VerificationFailed
+		second.Reference = "sim_0123456789abcdef"
+		second.Reasons = []string{"ssn_name_mismatch", "birth_date_mismatch"}
+		second.Attempts = 1
+		second.CompletedOn = &completedOn
+		a.Nil(repository.UpdateVerification(ctx, queued, second))
+
+		verifications, err := repository.ListVerifications(ctx, added.TenantID, added.CustomerID)
+		a.Nil(err)
+		a.Len(verifications, 2)
+		a.Equal(customers.VerificationSuperseded, verifications[0].Status)
+		a.Equal(second.CreatedOn, *verifications[0].CompletedOn)
+		a.Equal(second, verifications[1])
+
+		due, err = repository.ListDueVerifications(ctx, completedOn, 10)
+		a.Nil(err)
+		for _, v := range due {
+			a.NotEqual(added.CustomerID, v.CustomerID)
+		}
+
+		verifications, err = repository.ListVerifications(ctx, added.TenantID, uuid.NewString())
+		a.Nil(err)
+		a.Empty(verifications)
+	})
+}
+
+func newVerification(c customers.Customer, createdOn time.Time) customers.Verification {
+	return customers.Verification{
+		TenantID:       c.TenantID,
+		CustomerID:     c.CustomerID,
+		VerificationID: uuid.NewString(),
+		Provider:       "simulator",
+		Status:         customers.VerificationPending,
+		NextAttemptOn:  createdOn,
+		CreatedOn:      createdOn,
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	fuzz "github.com/google/gofuzz"
 	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/service"
 	"github.com/moovfinancial/backendhiring/pkg/test"
 	"github.com/stretchr/testify/require"
 )
@@ -28,15 +29,18 @@ type CustomerTestScope struct {
 }
 
 func CustomerTestSetup(t *testing.T) CustomerTestScope {
+	return CustomerTestSetupWithConfig(t, nil)
+}
+
+func CustomerTestSetupWithConfig(t *testing.T, configure func(cfg *service.Config)) CustomerTestScope {
 	a := require.New(t)
 
 	router := mux.NewRouter()
-	testEnv := test.NewEnvironment(t, router)
+	testEnv := test.NewEnvironmentWithConfig(t, router, configure)
 
-	// These can be replaced with whats in the `testEnv` created above.
-	repository := customers.NewCustomerRepository(testEnv.DB)
-	service, _ := customers.NewCustomerService(testEnv.TimeService, testEnv.Logger, repository)
-	controller := customers.NewCustomerController(testEnv.Logger, service)
+	repository := testEnv.CustomerRepository
+	service := testEnv.CustomerService
+	controller := customers.NewCustomerController(testEnv.Logger, testEnv.Config.Customers, service)
 
 	controller.AppendRoutes(router)
 
@@ -56,7 +60,11 @@ func (s CustomerTestScope) MakeRequest(method string, target string, body interf
 		json.NewEncoder(&jsonBody).Encode(body)
 	}
 
-	return httptest.NewRequest(method, target, &jsonBody)
+	req := httptest.NewRequest(method, target, &jsonBody)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
 }
 
 func (s CustomerTestScope) MakeCall(req *http.Request, body interface{}) *http.Response {
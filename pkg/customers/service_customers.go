@@ -1,87 +1,304 @@
 package customers
 
 import (
+	"context"
+	"database/sql"
+	"io"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 	"github.com/moov-io/base/log"
 	"github.com/moov-io/base/stime"
+
+	"github.com/moovfinancial/backendhiring/pkg/blob"
+	"github.com/moovfinancial/backendhiring/pkg/fedach"
+	"github.com/moovfinancial/backendhiring/pkg/risk"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
 )
 
 type CustomerService interface {
-	Create(tenantID string, create Customer) (*Customer, error)
-	List(tenantID string) ([]Customer, error)
-	Get(tenantID string, customerID string) (*Customer, error)
-	Update(tenantID string, customerID string, update Customer) (*Customer, error)
-	Delete(tenantID string, customerID string) error
-}
-
-func NewCustomerService(time stime.TimeService, logger log.Logger, repository CustomerRepository) (CustomerService, error) {
-	return &customerService{
-		time:       time,
-		logger:     logger,
-		repository: repository,
+	Create(ctx context.Context, tenantID string, create Customer) (*Customer, error)
+	// List - The tenant's active customers matching the filter.
+	List(ctx context.Context, tenantID string, filter ListFilter) ([]Customer, error)
+	Get(ctx context.Context, tenantID string, customerID string) (*Customer, error)
+	Update(ctx context.Context, tenantID string, customerID string, update Customer) (*Customer, error)
+	Delete(ctx context.Context, tenantID string, customerID string) error
+
+	// Changes - The tenant's customer changes after cursor, an empty cursor starts from the beginning.
+	Changes(ctx context.Context, tenantID string, cursor string, limit int) (*CustomerChanges, error)
+	// LatestChangesCursor - Cursor for the head of the change feed, to follow only the changes from now on.
+	LatestChangesCursor(ctx context.Context, tenantID string) (string, error)
+
+	// PlaceLegalHold - Stops the customer being erased until the hold is released.
+	PlaceLegalHold(ctx context.Context, tenantID string, customerID string, hold LegalHold) (*Customer, error)
+	ReleaseLegalHold(ctx context.Context, tenantID string, customerID string) error
+	// Erase - Honors a right to erasure request, the customer must be disabled and past the retention period.
+	Erase(ctx context.Context, tenantID string, customerID string, request ErasureRequest) (*CustomerErasure, error)
+	GetErasure(ctx context.Context, tenantID string, customerID string) (*CustomerErasure, error)
+
+	// AddAddress - Normalizes and adds an address, marking it primary demotes the customer's other one of its type.
+	AddAddress(ctx context.Context, tenantID string, customerID string, create Address) (*Address, error)
+	ListAddresses(ctx context.Context, tenantID string, customerID string) ([]Address, error)
+	UpdateAddress(ctx context.Context, tenantID string, customerID string, addressID string, update Address) (*Address, error)
+	DeleteAddress(ctx context.Context, tenantID string, customerID string, addressID string) error
+
+	// AddBeneficialOwner - Lists an active individual customer of the tenant as an owner of the business customer.
+	AddBeneficialOwner(ctx context.Context, tenantID string, customerID string, create BeneficialOwner) (*BeneficialOwner, error)
+	ListBeneficialOwners(ctx context.Context, tenantID string, customerID string) ([]BeneficialOwner, error)
+	UpdateBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string, update BeneficialOwner) (*BeneficialOwner, error)
+	DeleteBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string) error
+
+	// AddPhone - Normalizes the number to E.164 and adds it unverified, marking it primary demotes the customer's
+	// other phone of its type.
+	AddPhone(ctx context.Context, tenantID string, customerID string, create Phone) (*Phone, error)
+	ListPhones(ctx context.Context, tenantID string, customerID string) ([]Phone, error)
+	// UpdatePhone - Changing the number makes the phone unverified again.
+	UpdatePhone(ctx context.Context, tenantID string, customerID string, phoneID string, update Phone) (*Phone, error)
+	DeletePhone(ctx context.Context, tenantID string, customerID string, phoneID string) error
+	// VerifyPhone - Records that the customer proved they control the number, e.g. by entering a code sent to it.
+	VerifyPhone(ctx context.Context, tenantID string, customerID string, phoneID string) (*Phone, error)
+
+	// AddBankAccount - Adds an unverified bank account once its routing number is found in the FedACH directory.
+	AddBankAccount(ctx context.Context, tenantID string, customerID string, create BankAccount) (*BankAccount, error)
+	ListBankAccounts(ctx context.Context, tenantID string, customerID string) ([]BankAccount, error)
+	GetBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string) (*BankAccount, error)
+	DeleteBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string) error
+	// SendMicroDeposits - Sends two simulated micro-deposits to an unverified bank account, moving it to pending.
+	SendMicroDeposits(ctx context.Context, tenantID string, customerID string, bankAccountID string) (*BankAccount, error)
+	// ConfirmMicroDeposits - Verifies the bank account when the amounts match the micro-deposits. Each mismatch uses
+	// up an attempt, the account fails verification when there are none left.
+	ConfirmMicroDeposits(ctx context.Context, tenantID string, customerID string, bankAccountID string, confirmation MicroDepositConfirmation) (*BankAccount, error)
+
+	// TransitionKYC - Moves the customer to the transition's To status if the transition table allows it.
+	TransitionKYC(ctx context.Context, tenantID string, customerID string, transition KYCTransition) (*Customer, error)
+	ListKYCTransitions(ctx context.Context, tenantID string, customerID string) ([]KYCTransition, error)
+
+	// AddDocument - Stores the file encrypted with the customer's data key, checking its size and content type on the
+	// way through.
+	AddDocument(ctx context.Context, tenantID string, customerID string, create Document, content io.Reader) (*Document, error)
+	ListDocuments(ctx context.Context, tenantID string, customerID string) ([]Document, error)
+	GetDocument(ctx context.Context, tenantID string, customerID string, documentID string) (*Document, error)
+	// OpenDocument - The document and its file decrypted as it's read, the caller must close it.
+	OpenDocument(ctx context.Context, tenantID string, customerID string, documentID string) (*Document, io.ReadCloser, error)
+	DeleteDocument(ctx context.Context, tenantID string, customerID string, documentID string) error
+
+	// ListScreenings - The customer's sanctions screenings, oldest first.
+	ListScreenings(ctx context.Context, tenantID string, customerID string) ([]Screening, error)
+	// ReloadSanctionsLists - Loads the lists again if their files have changed, returning the version now in use. The
+	// version is empty when screening is off.
+	ReloadSanctionsLists(ctx context.Context) (string, error)
+	// Rescreen - Screens an active customer against the lists as they are now, updating its status and raising alerts
+	// for the matches it hadn't had before. Blocking matches block the customer rather than failing.
+	Rescreen(ctx context.Context, tenantID string, customerID string, runID string) ([]ScreeningAlert, error)
+	ListScreeningAlerts(ctx context.Context, tenantID string, filter ScreeningAlertFilter) ([]ScreeningAlert, error)
+
+	// VerifyIdentity - Queues a check of the customer's identity with the identity provider, moving it to pending.
+	// New customers and customers whose identity fields change are queued without asking.
+	VerifyIdentity(ctx context.Context, tenantID string, customerID string) (*Verification, error)
+	// ListVerifications - The customer's identity checks, oldest first.
+	ListVerifications(ctx context.Context, tenantID string, customerID string) ([]Verification, error)
+	// CompleteVerification - Records the provider's answer, or that it couldn't be reached, and moves a pending
+	// customer to the KYC status for it. Like UpdateVerification, it isn't found once it's been superseded or
+	// changed since from.
+	CompleteVerification(ctx context.Context, from Verification, verification Verification) (*Verification, error)
+
+	// ScoreRisk - Scores an active customer with the risk rules as they are now, saving the score when it's changed.
+	// Customers are scored without asking when they're created and whenever an attribute the rules look at changes.
+	ScoreRisk(ctx context.Context, tenantID string, customerID string) (*RiskScore, error)
+}
+
+func NewCustomerService(time stime.TimeService, logger log.Logger, config Config, repository CustomerRepository) (CustomerService, error) {
+	documents, err := blob.New(config.Documents.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := newScreeningLists(config.Screening)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := newRiskRules(config.Risk)
+	if err != nil {
+		return nil, err
+	}
+
+	routing, err := newRoutingDirectory(config.BankAccounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracedCustomerService{
+		next: &customerService{
+			time:       time,
+			logger:     logger,
+			config:     config,
+			repository: repository,
+			documents:  documents,
+			lists:      lists,
+			rules:      rules,
+			routing:    routing,
+		},
 	}, nil
 }
 
 type customerService struct {
 	time       stime.TimeService
 	logger     log.Logger
+	config     Config
 	repository CustomerRepository
+	documents  blob.Store
+	lists      *screeningLists
+	rules      *risk.Rules
+	// routing is nil when there's no FedACH directory.
+	routing *fedach.Directory
 }
 
-func (s *customerService) Create(tenantID string, create Customer) (*Customer, error) {
-	if err := create.Validate(); err != nil {
+func (s *customerService) Create(ctx context.Context, tenantID string, create Customer) (*Customer, error) {
+	create.Normalize()
+	if err := validate(ctx, tenantID, create); err != nil {
 		return nil, err
 	}
 
-	s.logger.Info().With(log.Fields{
-		"Name":      log.String(create.Name),
-		"BirthDate": log.StringOrNil(create.BirthDate),
-		"SSN":       log.String(create.Ssn),
-	}).Log("Created a new customer")
-
 	created := Customer{
 		CustomerID: uuid.New().String(),
 		TenantID:   tenantID,
+		Type:       create.Type,
 		CreatedOn:  s.time.Now(),
 		UpdatedOn:  s.time.Now(),
+		Name:       create.Name,
 		BirthDate:  create.BirthDate,
 		Email:      create.Email,
-		Ssn:        create.Ssn,
+		TaxID:      create.TaxID,
+		Business:   create.Business,
+		KYCStatus:  KYCUnverified,
+	}
+
+	// Blocked customers aren't created, their screening is kept under the ID they would have had.
+	screening, err := s.screen(ctx, created)
+	if err != nil {
+		return nil, err
+	}
+	if screening != nil {
+		created.ScreeningStatus = screening.Status
 	}
 
-	saved, err := s.repository.Add(created)
+	saved, err := s.repository.Add(ctx, created)
 	if err != nil {
 		return nil, err
 	}
 
+	// Only IDs are logged, personal data in the logs couldn't be erased.
+	s.logger.Info().With(tracing.LogFields(ctx), log.Fields{
+		"tenant_id":   log.String(saved.TenantID),
+		"customer_id": log.String(saved.CustomerID),
+	}).Log("Created a new customer")
+
+	// The customer is kept even when its check can't be queued, it's left unverified to be sent again.
+	if s.verifying() && verifiable(*saved) == nil {
+		if _, err := s.requestVerification(ctx, *saved); err != nil {
+			s.logger.Warn().With(tracing.LogFields(ctx), log.Fields{
+				"tenant_id":   log.String(saved.TenantID),
+				"customer_id": log.String(saved.CustomerID),
+			}).LogErrorf("queueing identity verification: %v", err)
+		} else {
+			saved.KYCStatus = KYCPending
+		}
+	}
+
+	if score := s.rescore(ctx, saved.TenantID, saved.CustomerID); score != nil {
+		saved.Risk = score
+		saved.UpdatedOn = score.ScoredOn
+	}
+
 	return saved, nil
 }
 
-func (s *customerService) List(tenantID string) ([]Customer, error) {
-	return s.repository.List(tenantID)
+func (s *customerService) List(ctx context.Context, tenantID string, filter ListFilter) ([]Customer, error) {
+	return s.repository.List(ctx, tenantID, filter)
 }
 
-func (s *customerService) Get(tenantID string, customerID string) (*Customer, error) {
-	return s.repository.Get(tenantID, customerID)
+func (s *customerService) Get(ctx context.Context, tenantID string, customerID string) (*Customer, error) {
+	return s.repository.Get(ctx, tenantID, customerID)
 }
 
-func (s *customerService) Update(tenantID string, customerID string, update Customer) (*Customer, error) {
-	if err := update.Validate(); err != nil {
+func (s *customerService) Update(ctx context.Context, tenantID string, customerID string, update Customer) (*Customer, error) {
+	update.Normalize()
+	cur, err := s.Get(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	update.TaxID.keepValue(cur.TaxID)
+	if err := validate(ctx, tenantID, update); err != nil {
 		return nil, err
 	}
+	if update.Type != cur.Type {
+		return nil, validation.Errors{"type": ErrCustomerTypeChanged}
+	}
 
-	update.UpdatedOn = s.time.Now()
+	// Screened again when what's screened changes, and the first time a customer from before screening is updated.
+	// Blocked updates aren't applied at all.
+	candidate := *cur
+	candidate.Name = update.Name
+	candidate.BirthDate = update.BirthDate
+	candidate.Business = update.Business
+	if screeningQuery(*cur) != screeningQuery(candidate) || cur.ScreeningStatus == "" {
+		screening, err := s.screen(ctx, candidate)
+		if err != nil {
+			return nil, err
+		}
+		if screening != nil {
+			cur.ScreeningStatus = screening.Status
+		}
+	}
 
-	_, err := s.repository.Update(update)
-	if err != nil {
+	// Whatever was verified no longer matches the customer so it has to be checked again.
+	verify := s.verifying() && verifiable(update) == nil && identityChanged(*cur, update)
+	reset := identityChanged(*cur, update) && cur.KYCStatus.CanTransitionTo(KYCPending) && cur.KYCStatus != KYCUnverified
+
+	// Only the customer's details come from the request, the IDs and timestamps are ours.
+	cur.Name = update.Name
+	cur.BirthDate = update.BirthDate
+	cur.Email = update.Email
+	cur.TaxID = update.TaxID
+	cur.Business = update.Business
+	cur.UpdatedOn = s.time.Now()
+
+	// The update's audit event is staged by its first write, so it's given the customer as the update leaves it,
+	// pending when it's checked again, before the reset.
+	saving := *cur
+	if reset || verify {
+		saving.KYCStatus = KYCPending
+	}
+	pendingEventFrom(ctx).saving(saving)
+
+	// Reset first so a failed update leaves the customer pending rather than verified with new details.
+	if reset {
+		if _, err := s.transitionKYC(ctx, *cur, KYCTransition{To: KYCPending, Reason: KYCIdentityChangedReason}); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.repository.Update(ctx, *cur); err != nil {
 		return nil, err
 	}
 
-	return s.Get(tenantID, customerID)
+	if verify {
+		updated, err := s.Get(ctx, tenantID, customerID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.requestVerification(ctx, *updated); err != nil {
+			return nil, err
+		}
+	}
+
+	s.rescore(ctx, tenantID, customerID)
+	return s.Get(ctx, tenantID, customerID)
 }
 
-func (s *customerService) Delete(tenantID string, customerID string) error {
-	cur, err := s.Get(tenantID, customerID)
+func (s *customerService) Delete(ctx context.Context, tenantID string, customerID string) error {
+	cur, err := s.Get(ctx, tenantID, customerID)
 	if err != nil {
 		return err
 	}
@@ -89,10 +306,391 @@ func (s *customerService) Delete(tenantID string, customerID string) error {
 	cur.UpdatedOn = s.time.Now()
 	cur.DisabledOn = &cur.UpdatedOn
 
-	_, err = s.repository.Delete(*cur)
+	_, err = s.repository.Delete(ctx, *cur)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func (s *customerService) Changes(ctx context.Context, tenantID string, cursor string, limit int) (*CustomerChanges, error) {
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit == 0 {
+		limit = DefaultChangesLimit
+	}
+	if limit < 0 || limit > MaxChangesLimit {
+		return nil, ErrInvalidLimit
+	}
+
+	// One extra tells us if there's another page without a second query.
+	changes, err := s.repository.ListChanges(ctx, tenantID, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CustomerChanges{Changes: changes, Cursor: encodeCursor(after)}
+	if len(changes) > limit {
+		result.Changes = changes[:limit]
+		result.HasMore = true
+	}
+	if len(result.Changes) > 0 {
+		result.Cursor = encodeCursor(result.Changes[len(result.Changes)-1].Sequence)
+	}
+
+	return result, nil
+}
+
+func (s *customerService) LatestChangesCursor(ctx context.Context, tenantID string) (string, error) {
+	latest, err := s.repository.LatestChange(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	return encodeCursor(latest), nil
+}
+
+func (s *customerService) PlaceLegalHold(ctx context.Context, tenantID string, customerID string, hold LegalHold) (*Customer, error) {
+	if err := hold.Validate(); err != nil {
+		return nil, err
+	}
+
+	hold.PlacedOn = s.time.Now()
+	if err := s.repository.SetLegalHold(ctx, tenantID, customerID, &hold, hold.PlacedOn); err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, tenantID, customerID)
+}
+
+func (s *customerService) ReleaseLegalHold(ctx context.Context, tenantID string, customerID string) error {
+	return s.repository.SetLegalHold(ctx, tenantID, customerID, nil, s.time.Now())
+}
+
+func (s *customerService) Erase(ctx context.Context, tenantID string, customerID string, request ErasureRequest) (*CustomerErasure, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	cur, err := s.Get(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.time.Now()
+	switch {
+	case cur.ErasedOn != nil:
+		return nil, ErrCustomerErased
+	case cur.LegalHold != nil:
+		return nil, ErrLegalHold
+	// Retention runs from the end of the relationship so active customers are always within it.
+	case cur.DisabledOn == nil || now.Before(cur.DisabledOn.Add(s.config.Erasure.RetentionPeriod)):
+		return nil, ErrRetentionPeriod
+	}
+
+	erasure := CustomerErasure{
+		ErasureID:    uuid.NewString(),
+		TenantID:     tenantID,
+		CustomerID:   customerID,
+		Reason:       request.Reason,
+		ErasedFields: ErasedFields,
+		ErasedOn:     now,
+	}
+
+	// Looked up first since erasing removes the records of where the files are.
+	documents, err := s.repository.ListDocuments(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The repository checks the hold again in case one was placed since we looked.
+	if err := s.repository.Erase(ctx, erasure); err != nil {
+		return nil, err
+	}
+
+	for _, d := range documents {
+		s.deleteDocumentBlob(ctx, d)
+	}
+
+	s.logger.Info().With(tracing.LogFields(ctx), log.Fields{
+		"tenant_id":   log.String(tenantID),
+		"customer_id": log.String(customerID),
+		"erasure_id":  log.String(erasure.ErasureID),
+	}).Log("Erased customer")
+
+	return &erasure, nil
+}
+
+func (s *customerService) GetErasure(ctx context.Context, tenantID string, customerID string) (*CustomerErasure, error) {
+	return s.repository.GetErasure(ctx, tenantID, customerID)
+}
+
+func (s *customerService) AddAddress(ctx context.Context, tenantID string, customerID string, create Address) (*Address, error) {
+	create.Normalize()
+	if err := create.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.addressable(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	// Only the address itself comes from the request, the IDs and timestamps are ours.
+	create.TenantID = tenantID
+	create.CustomerID = customerID
+	create.AddressID = uuid.NewString()
+	create.CreatedOn = s.time.Now()
+	create.UpdatedOn = create.CreatedOn
+
+	created, err := s.repository.AddAddress(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rescore(ctx, tenantID, customerID)
+	return created, nil
+}
+
+func (s *customerService) ListAddresses(ctx context.Context, tenantID string, customerID string) ([]Address, error) {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListAddresses(ctx, tenantID, customerID)
+}
+
+func (s *customerService) UpdateAddress(ctx context.Context, tenantID string, customerID string, addressID string, update Address) (*Address, error) {
+	update.Normalize()
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.addressable(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	cur, err := s.getAddress(ctx, tenantID, customerID, addressID)
+	if err != nil {
+		return nil, err
+	}
+
+	update.TenantID = tenantID
+	update.CustomerID = customerID
+	update.AddressID = addressID
+	update.CreatedOn = cur.CreatedOn
+	update.UpdatedOn = s.time.Now()
+
+	updated, err := s.repository.UpdateAddress(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rescore(ctx, tenantID, customerID)
+	return updated, nil
+}
+
+func (s *customerService) DeleteAddress(ctx context.Context, tenantID string, customerID string, addressID string) error {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return err
+	}
+	if err := s.repository.DeleteAddress(ctx, tenantID, customerID, addressID, s.time.Now()); err != nil {
+		return err
+	}
+
+	s.rescore(ctx, tenantID, customerID)
+	return nil
+}
+
+// addressable - Contact details can only be saved for customers that still have their personal data.
+func (s *customerService) addressable(ctx context.Context, tenantID string, customerID string) error {
+	cur, err := s.Get(ctx, tenantID, customerID)
+	if err != nil {
+		return err
+	}
+	if cur.ErasedOn != nil {
+		return ErrCustomerErased
+	}
+	return nil
+}
+
+func (s *customerService) getAddress(ctx context.Context, tenantID string, customerID string, addressID string) (*Address, error) {
+	addresses, err := s.repository.ListAddresses(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range addresses {
+		if a.AddressID == addressID {
+			return &a, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s *customerService) AddPhone(ctx context.Context, tenantID string, customerID string, create Phone) (*Phone, error) {
+	create.Normalize()
+	if err := create.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.addressable(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	// Only the phone itself comes from the request, the IDs, status and timestamps are ours.
+	create.TenantID = tenantID
+	create.CustomerID = customerID
+	create.PhoneID = uuid.NewString()
+	create.Status = PhoneUnverified
+	create.VerifiedOn = nil
+	create.CreatedOn = s.time.Now()
+	create.UpdatedOn = create.CreatedOn
+
+	return s.repository.AddPhone(ctx, create)
+}
+
+func (s *customerService) ListPhones(ctx context.Context, tenantID string, customerID string) ([]Phone, error) {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListPhones(ctx, tenantID, customerID)
+}
+
+func (s *customerService) UpdatePhone(ctx context.Context, tenantID string, customerID string, phoneID string, update Phone) (*Phone, error) {
+	update.Normalize()
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.addressable(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	cur, err := s.getPhone(ctx, tenantID, customerID, phoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	update.TenantID = tenantID
+	update.CustomerID = customerID
+	update.PhoneID = phoneID
+	update.Status = cur.Status
+	update.VerifiedOn = cur.VerifiedOn
+	if update.Number != cur.Number {
+		update.Status = PhoneUnverified
+		update.VerifiedOn = nil
+	}
+	update.CreatedOn = cur.CreatedOn
+	update.UpdatedOn = s.time.Now()
+
+	return s.repository.UpdatePhone(ctx, update)
+}
+
+func (s *customerService) DeletePhone(ctx context.Context, tenantID string, customerID string, phoneID string) error {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return err
+	}
+	return s.repository.DeletePhone(ctx, tenantID, customerID, phoneID, s.time.Now())
+}
+
+func (s *customerService) VerifyPhone(ctx context.Context, tenantID string, customerID string, phoneID string) (*Phone, error) {
+	if err := s.addressable(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	cur, err := s.getPhone(ctx, tenantID, customerID, phoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.time.Now()
+	cur.Status = PhoneVerified
+	cur.VerifiedOn = &now
+	cur.UpdatedOn = now
+
+	return s.repository.UpdatePhone(ctx, *cur)
+}
+
+func (s *customerService) getPhone(ctx context.Context, tenantID string, customerID string, phoneID string) (*Phone, error) {
+	phones, err := s.repository.ListPhones(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range phones {
+		if p.PhoneID == phoneID {
+			return &p, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s *customerService) TransitionKYC(ctx context.Context, tenantID string, customerID string, transition KYCTransition) (*Customer, error) {
+	if err := transition.Validate(); err != nil {
+		return nil, err
+	}
+
+	cur, err := s.Get(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if cur.ErasedOn != nil {
+		return nil, ErrCustomerErased
+	}
+	if !cur.KYCStatus.CanTransitionTo(transition.To) {
+		return nil, ErrKYCTransition
+	}
+
+	if _, err := s.transitionKYC(ctx, *cur, transition); err != nil {
+		return nil, err
+	}
+
+	s.rescore(ctx, tenantID, customerID)
+	return s.Get(ctx, tenantID, customerID)
+}
+
+func (s *customerService) ListKYCTransitions(ctx context.Context, tenantID string, customerID string) ([]KYCTransition, error) {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListKYCTransitions(ctx, tenantID, customerID)
+}
+
+// transitionKYC - Records the move from the customer's current status, already checked against the table.
+func (s *customerService) transitionKYC(ctx context.Context, cur Customer, transition KYCTransition) (*KYCTransition, error) {
+	transition.TenantID = cur.TenantID
+	transition.CustomerID = cur.CustomerID
+	transition.TransitionID = uuid.NewString()
+	transition.From = cur.KYCStatus
+	transition.TransitionedOn = s.time.Now()
+
+	if err := s.repository.TransitionKYC(ctx, transition); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().With(tracing.LogFields(ctx), log.Fields{
+		"tenant_id":   log.String(cur.TenantID),
+		"customer_id": log.String(cur.CustomerID),
+		"kyc_from":    log.String(string(transition.From)),
+		"kyc_to":      log.String(string(transition.To)),
+	}).Log("Customer KYC status changed")
+
+	return &transition, nil
+}
+
+// identityChanged - If the update changes any of the fields identity verification checks, or that identify a
+// business.
+func identityChanged(cur Customer, update Customer) bool {
+	if cur.Business != nil && update.Business != nil &&
+		(cur.Business.LegalName != update.Business.LegalName || cur.Business.EIN != update.Business.EIN) {
+		return true
+	}
+	return cur.Name != update.Name || !sameTaxID(cur.TaxID, update.TaxID) || valueOf(cur.BirthDate) != valueOf(update.BirthDate)
+}
+
+func validate(ctx context.Context, tenantID string, customer Customer) error {
+	_, span := tracing.Start(ctx, "Customer.Validate", tenantID)
+	err := customer.Validate()
+	tracing.End(span, err)
+	return err
+}
@@ -0,0 +1,452 @@
+package customers
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/moov-io/base/log"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+// NewAuditedCustomerService - Records every change to a customer, and every time its personal data is returned, in
+// the audit log. A change's event is staged in the transaction that saves it, so neither is saved without the other,
+// and chained onto the log once the call succeeds. The auditor has to keep its log in the repository's database for
+// that, repositories that can't stage events have them recorded after the change instead. A read fails if its event
+// can't be recorded so nobody sees personal data unaudited.
+func NewAuditedCustomerService(logger log.Logger, auditor audit.Service, next CustomerService) CustomerService {
+	return &auditedCustomerService{CustomerService: next, logger: logger, auditor: auditor}
+}
+
+// auditedCustomerService - Everything not overridden here doesn't touch personal data.
+type auditedCustomerService struct {
+	CustomerService
+
+	logger  log.Logger
+	auditor audit.Service
+}
+
+func (s *auditedCustomerService) Create(ctx context.Context, tenantID string, create Customer) (*Customer, error) {
+	var result *Customer
+	pending := &pendingEvent{outboxID: uuid.NewString(), action: audit.ActionCreate, resolve: presentFields}
+	err := s.change(ctx, tenantID, pending, func(ctx context.Context) (err error) {
+		if result, err = s.CustomerService.Create(ctx, tenantID, create); err == nil {
+			pending.saving(*result)
+		}
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) List(ctx context.Context, tenantID string, filter ListFilter) ([]Customer, error) {
+	result, err := s.CustomerService.List(ctx, tenantID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]audit.Event, len(result))
+	for i, c := range result {
+		events[i] = audit.Event{CustomerID: c.CustomerID, Action: audit.ActionRead, Fields: presentFields(c)}
+	}
+	return result, s.auditor.Record(ctx, tenantID, events...)
+}
+
+func (s *auditedCustomerService) Get(ctx context.Context, tenantID string, customerID string) (*Customer, error) {
+	result, err := s.CustomerService.Get(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, s.record(ctx, tenantID, customerID, audit.ActionRead, presentFields(*result))
+}
+
+func (s *auditedCustomerService) Update(ctx context.Context, tenantID string, customerID string, update Customer) (*Customer, error) {
+	// Looked up without an event of its own, it's only needed to tell what changed.
+	before, err := s.CustomerService.Get(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *Customer
+	pending := &pendingEvent{outboxID: uuid.NewString(), customerID: customerID, action: audit.ActionUpdate, resolve: func(after Customer) []string {
+		fields := changedFields(*before, after)
+		// Changing who the customer is sends it back through KYC.
+		if after.KYCStatus != before.KYCStatus {
+			fields = append(fields, "kycStatus")
+		}
+		return fields
+	}}
+	err = s.change(ctx, tenantID, pending, func(ctx context.Context) (err error) {
+		if result, err = s.CustomerService.Update(ctx, tenantID, customerID, update); err == nil {
+			pending.saving(*result)
+		}
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) Delete(ctx context.Context, tenantID string, customerID string) error {
+	return s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionDelete, []string{"disabledOn"}), func(ctx context.Context) error {
+		return s.CustomerService.Delete(ctx, tenantID, customerID)
+	})
+}
+
+// Changes - The feed carries each customer's personal data so every change on the page is a read.
+func (s *auditedCustomerService) Changes(ctx context.Context, tenantID string, cursor string, limit int) (*CustomerChanges, error) {
+	result, err := s.CustomerService.Changes(ctx, tenantID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]audit.Event, len(result.Changes))
+	for i, change := range result.Changes {
+		events[i] = audit.Event{CustomerID: change.Customer.CustomerID, Action: audit.ActionRead, Fields: presentFields(change.Customer)}
+	}
+	return result, s.auditor.Record(ctx, tenantID, events...)
+}
+
+func (s *auditedCustomerService) PlaceLegalHold(ctx context.Context, tenantID string, customerID string, hold LegalHold) (*Customer, error) {
+	var result *Customer
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"legalHold"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.PlaceLegalHold(ctx, tenantID, customerID, hold)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) ReleaseLegalHold(ctx context.Context, tenantID string, customerID string) error {
+	return s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"legalHold"}), func(ctx context.Context) error {
+		return s.CustomerService.ReleaseLegalHold(ctx, tenantID, customerID)
+	})
+}
+
+func (s *auditedCustomerService) Erase(ctx context.Context, tenantID string, customerID string, request ErasureRequest) (*CustomerErasure, error) {
+	var result *CustomerErasure
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionErase, ErasedFields), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.Erase(ctx, tenantID, customerID, request)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) AddAddress(ctx context.Context, tenantID string, customerID string, create Address) (*Address, error) {
+	var result *Address
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"addresses"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.AddAddress(ctx, tenantID, customerID, create)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) ListAddresses(ctx context.Context, tenantID string, customerID string) ([]Address, error) {
+	result, err := s.CustomerService.ListAddresses(ctx, tenantID, customerID)
+	if err != nil || len(result) == 0 {
+		return result, err
+	}
+
+	return result, s.record(ctx, tenantID, customerID, audit.ActionRead, []string{"addresses"})
+}
+
+func (s *auditedCustomerService) UpdateAddress(ctx context.Context, tenantID string, customerID string, addressID string, update Address) (*Address, error) {
+	var result *Address
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"addresses"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.UpdateAddress(ctx, tenantID, customerID, addressID, update)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) DeleteAddress(ctx context.Context, tenantID string, customerID string, addressID string) error {
+	return s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"addresses"}), func(ctx context.Context) error {
+		return s.CustomerService.DeleteAddress(ctx, tenantID, customerID, addressID)
+	})
+}
+
+func (s *auditedCustomerService) AddBeneficialOwner(ctx context.Context, tenantID string, customerID string, create BeneficialOwner) (*BeneficialOwner, error) {
+	var result *BeneficialOwner
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"beneficialOwners"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.AddBeneficialOwner(ctx, tenantID, customerID, create)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) ListBeneficialOwners(ctx context.Context, tenantID string, customerID string) ([]BeneficialOwner, error) {
+	result, err := s.CustomerService.ListBeneficialOwners(ctx, tenantID, customerID)
+	if err != nil || len(result) == 0 {
+		return result, err
+	}
+
+	return result, s.record(ctx, tenantID, customerID, audit.ActionRead, []string{"beneficialOwners"})
+}
+
+func (s *auditedCustomerService) UpdateBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string, update BeneficialOwner) (*BeneficialOwner, error) {
+	var result *BeneficialOwner
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"beneficialOwners"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.UpdateBeneficialOwner(ctx, tenantID, customerID, ownerID, update)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) DeleteBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string) error {
+	return s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"beneficialOwners"}), func(ctx context.Context) error {
+		return s.CustomerService.DeleteBeneficialOwner(ctx, tenantID, customerID, ownerID)
+	})
+}
+
+func (s *auditedCustomerService) AddPhone(ctx context.Context, tenantID string, customerID string, create Phone) (*Phone, error) {
+	var result *Phone
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"phones"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.AddPhone(ctx, tenantID, customerID, create)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) ListPhones(ctx context.Context, tenantID string, customerID string) ([]Phone, error) {
+	result, err := s.CustomerService.ListPhones(ctx, tenantID, customerID)
+	if err != nil || len(result) == 0 {
+		return result, err
+	}
+
+	return result, s.record(ctx, tenantID, customerID, audit.ActionRead, []string{"phones"})
+}
+
+func (s *auditedCustomerService) UpdatePhone(ctx context.Context, tenantID string, customerID string, phoneID string, update Phone) (*Phone, error) {
+	var result *Phone
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"phones"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.UpdatePhone(ctx, tenantID, customerID, phoneID, update)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) DeletePhone(ctx context.Context, tenantID string, customerID string, phoneID string) error {
+	return s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"phones"}), func(ctx context.Context) error {
+		return s.CustomerService.DeletePhone(ctx, tenantID, customerID, phoneID)
+	})
+}
+
+func (s *auditedCustomerService) VerifyPhone(ctx context.Context, tenantID string, customerID string, phoneID string) (*Phone, error) {
+	var result *Phone
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"phones"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.VerifyPhone(ctx, tenantID, customerID, phoneID)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) AddBankAccount(ctx context.Context, tenantID string, customerID string, create BankAccount) (*BankAccount, error) {
+	var result *BankAccount
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"bankAccounts"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.AddBankAccount(ctx, tenantID, customerID, create)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) ListBankAccounts(ctx context.Context, tenantID string, customerID string) ([]BankAccount, error) {
+	result, err := s.CustomerService.ListBankAccounts(ctx, tenantID, customerID)
+	if err != nil || len(result) == 0 {
+		return result, err
+	}
+
+	return result, s.record(ctx, tenantID, customerID, audit.ActionRead, []string{"bankAccounts"})
+}
+
+func (s *auditedCustomerService) GetBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string) (*BankAccount, error) {
+	result, err := s.CustomerService.GetBankAccount(ctx, tenantID, customerID, bankAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, s.record(ctx, tenantID, customerID, audit.ActionRead, []string{"bankAccounts"})
+}
+
+func (s *auditedCustomerService) DeleteBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string) error {
+	return s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"bankAccounts"}), func(ctx context.Context) error {
+		return s.CustomerService.DeleteBankAccount(ctx, tenantID, customerID, bankAccountID)
+	})
+}
+
+func (s *auditedCustomerService) SendMicroDeposits(ctx context.Context, tenantID string, customerID string, bankAccountID string) (*BankAccount, error) {
+	var result *BankAccount
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"bankAccounts"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.SendMicroDeposits(ctx, tenantID, customerID, bankAccountID)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) ConfirmMicroDeposits(ctx context.Context, tenantID string, customerID string, bankAccountID string, confirmation MicroDepositConfirmation) (*BankAccount, error) {
+	var result *BankAccount
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"bankAccounts"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.ConfirmMicroDeposits(ctx, tenantID, customerID, bankAccountID, confirmation)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) TransitionKYC(ctx context.Context, tenantID string, customerID string, transition KYCTransition) (*Customer, error) {
+	var result *Customer
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"kycStatus"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.TransitionKYC(ctx, tenantID, customerID, transition)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) Rescreen(ctx context.Context, tenantID string, customerID string, runID string) ([]ScreeningAlert, error) {
+	var result []ScreeningAlert
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"screeningStatus"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.Rescreen(ctx, tenantID, customerID, runID)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) VerifyIdentity(ctx context.Context, tenantID string, customerID string) (*Verification, error) {
+	var result *Verification
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"kycStatus"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.VerifyIdentity(ctx, tenantID, customerID)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) CompleteVerification(ctx context.Context, from Verification, verification Verification) (*Verification, error) {
+	var result *Verification
+	err := s.change(ctx, verification.TenantID, newPendingEvent(verification.CustomerID, audit.ActionUpdate, []string{"kycStatus"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.CompleteVerification(ctx, from, verification)
+		return err
+	})
+	return result, err
+}
+
+// change - Runs write with its event pending in ctx for the repository to stage. Events the repository didn't stage
+// are recorded once write succeeds. Staged events are already saved with the change, so when chaining them fails the
+// relay job chains them later and the call still succeeds.
+func (s *auditedCustomerService) change(ctx context.Context, tenantID string, pending *pendingEvent, write func(ctx context.Context) error) error {
+	if err := write(withPendingEvent(ctx, pending)); err != nil {
+		return err
+	}
+
+	if !pending.staged {
+		return s.record(ctx, tenantID, pending.customerID, pending.action, pending.fields)
+	}
+	if err := s.auditor.Relay(ctx, tenantID); err != nil {
+		s.logger.Warn().With(tracing.LogFields(ctx), log.Fields{
+			"tenant_id": log.String(tenantID),
+		}).LogErrorf("relaying audit events: %v", err)
+	}
+	return nil
+}
+
+func (s *auditedCustomerService) record(ctx context.Context, tenantID string, customerID string, action audit.Action, fields []string) error {
+	return s.auditor.Record(ctx, tenantID, audit.Event{CustomerID: customerID, Action: action, Fields: fields})
+}
+
+// presentFields - The customer's personal data fields that have a value.
+func presentFields(c Customer) []string {
+	fields := []string{}
+	for _, field := range personalData(&c) {
+		if field.value != nil && *field.value != "" {
+			fields = append(fields, field.name)
+		}
+	}
+	return fields
+}
+
+// changedFields - The personal data fields with a different value after an update.
+func changedFields(before Customer, after Customer) []string {
+	fields := []string{}
+	afterFields := personalData(&after)
+	for i, field := range personalData(&before) {
+		if valueOf(field.value) != valueOf(afterFields[i].value) {
+			fields = append(fields, field.name)
+		}
+	}
+	return fields
+}
+
+func valueOf(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func (s *auditedCustomerService) AddDocument(ctx context.Context, tenantID string, customerID string, create Document, content io.Reader) (*Document, error) {
+	var result *Document
+	err := s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"documents"}), func(ctx context.Context) (err error) {
+		result, err = s.CustomerService.AddDocument(ctx, tenantID, customerID, create, content)
+		return err
+	})
+	return result, err
+}
+
+func (s *auditedCustomerService) OpenDocument(ctx context.Context, tenantID string, customerID string, documentID string) (*Document, io.ReadCloser, error) {
+	result, content, err := s.CustomerService.OpenDocument(ctx, tenantID, customerID, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Nobody sees the file unless the read was recorded.
+	if err := s.record(ctx, tenantID, customerID, audit.ActionRead, []string{"documents"}); err != nil {
+		content.Close()
+		return nil, nil, err
+	}
+	return result, content, nil
+}
+
+func (s *auditedCustomerService) DeleteDocument(ctx context.Context, tenantID string, customerID string, documentID string) error {
+	return s.change(ctx, tenantID, newPendingEvent(customerID, audit.ActionUpdate, []string{"documents"}), func(ctx context.Context) error {
+		return s.CustomerService.DeleteDocument(ctx, tenantID, customerID, documentID)
+	})
+}
+
+// pendingEvent - The audit event of a change in progress, staged by the repository in the change's transaction.
+type pendingEvent struct {
+	outboxID   string
+	customerID string
+	action     audit.Action
+	fields     []string
+	// resolve - Works the fields out from the customer being saved, for events whose fields depend on it. They
+	// aren't staged until the customer is saved, writes that come before it are left out of the event.
+	resolve func(Customer) []string
+	staged  bool
+}
+
+func newPendingEvent(customerID string, action audit.Action, fields []string) *pendingEvent {
+	return &pendingEvent{outboxID: uuid.NewString(), customerID: customerID, action: action, fields: fields}
+}
+
+// saving - Resolves the event's fields from the customer being saved, the first customer saved for it wins.
+func (p *pendingEvent) saving(c Customer) {
+	if p == nil || p.resolve == nil {
+		return
+	}
+	p.customerID = c.CustomerID
+	p.fields = p.resolve(c)
+	p.resolve = nil
+}
+
+// ready - Whether the event can be staged by the change's next transaction.
+func (p *pendingEvent) ready() bool {
+	return p != nil && p.resolve == nil && !p.staged
+}
+
+type pendingEventKey struct{}
+
+func withPendingEvent(ctx context.Context, pending *pendingEvent) context.Context {
+	return context.WithValue(ctx, pendingEventKey{}, pending)
+}
+
+// pendingEventFrom - The event of the change ctx belongs to, nil outside of one.
+func pendingEventFrom(ctx context.Context) *pendingEvent {
+	pending, _ := ctx.Value(pendingEventKey{}).(*pendingEvent)
+	return pending
+}
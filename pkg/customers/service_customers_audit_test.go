@@ -0,0 +1,164 @@
+package customers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/stime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+// unavailableAuditor - Can't chain anything, as when the audit log can't be written right after a change.
+type unavailableAuditor struct {
+	audit.Service
+}
+
+func (unavailableAuditor) Relay(ctx context.Context, tenantID string) error {
+	return errors.New("audit log unavailable")
+}
+
+func Test_Customer_AuditedChanges(t *testing.T) {
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			a := require.New(t)
+			ctx := context.Background()
+			times := stime.NewStaticTimeService()
+
+			repository := customers.NewCustomerRepository(db.DB, db.Dialect, customers.TimeoutsConfig{}, testKeyEncryptionKey(t))
+			next, err := customers.NewCustomerService(times, log.NewNopLogger(), customers.Config{}, repository)
+			a.NoError(err)
+			chainKey, err := audit.NewRandomChainKey()
+			a.NoError(err)
+			auditor := audit.NewService(times, audit.NewRepository(db.DB, db.Dialect, chainKey), chainKey)
+			service := customers.NewAuditedCustomerService(log.NewNopLogger(), auditor, next)
+
+			create := NewCustomer()
+			created, err := service.Create(ctx, create.TenantID, create)
+			a.NoError(err)
+
+			update := *created
+			update.Email = "jane.doe@moov.io"
+			_, err = service.Update(ctx, created.TenantID, created.CustomerID, update)
+			a.NoError(err)
+
+			// Chained as soon as the change succeeds.
+			page, err := auditor.List(ctx, created.TenantID, created.CustomerID, "", 0)
+			a.NoError(err)
+			a.Len(page.Events, 2)
+			a.Equal(audit.ActionCreate, page.Events[0].Action)
+			a.Contains(page.Events[0].Fields, "email")
+			a.Equal(audit.ActionUpdate, page.Events[1].Action)
+			a.Equal([]string{"email"}, page.Events[1].Fields)
+
+			// A change is kept with its event when the event can't be chained right away.
+			buf, logger := log.NewBufferLogger()
+			unavailable := customers.NewAuditedCustomerService(logger, unavailableAuditor{auditor}, next)
+			a.NoError(unavailable.Delete(ctx, created.TenantID, created.CustomerID))
+			a.Contains(buf.String(), "audit log unavailable")
+			a.Contains(buf.String(), created.TenantID)
+
+			page, err = auditor.List(ctx, created.TenantID, created.CustomerID, "", 0)
+			a.NoError(err)
+			a.Len(page.Events, 2)
+
+			// The relay chains it later.
+			a.NoError(auditor.Relay(ctx, ""))
+			page, err = auditor.List(ctx, created.TenantID, created.CustomerID, "", 0)
+			a.NoError(err)
+			a.Len(page.Events, 3)
+			a.Equal(audit.ActionDelete, page.Events[2].Action)
+
+			verification, err := auditor.Verify(ctx, created.TenantID)
+			a.NoError(err)
+			a.True(verification.Valid, verification.Problem)
+
+			// Changes that fail leave nothing to chain.
+			_, err = service.Update(ctx, created.TenantID, uuid.NewString(), update)
+			a.Error(err)
+			tenants, err := audit.NewRepository(db.DB, db.Dialect, chainKey).OutboxTenants(ctx)
+			a.NoError(err)
+			a.NotContains(tenants, created.TenantID)
+		})
+	}
+}
+
+// failingUpdates - Fails every update, as when the update's transaction can't commit.
+type failingUpdates struct {
+	customers.CustomerRepository
+}
+
+func (failingUpdates) Update(ctx context.Context, update customers.Customer) (*customers.Customer, error) {
+	return nil, errors.New("update failed")
+}
+
+func Test_Customer_AuditedKYCReset(t *testing.T) {
+	for _, db := range sqldb.CreateTestDatabases(t) {
+		db := db
+		t.Run(string(db.Dialect), func(t *testing.T) {
+			a := require.New(t)
+			ctx := context.Background()
+			times := stime.NewStaticTimeService()
+
+			repository := customers.NewCustomerRepository(db.DB, db.Dialect, customers.TimeoutsConfig{}, testKeyEncryptionKey(t))
+			next, err := customers.NewCustomerService(times, log.NewNopLogger(), customers.Config{}, repository)
+			a.NoError(err)
+			failing, err := customers.NewCustomerService(times, log.NewNopLogger(), customers.Config{}, failingUpdates{repository})
+			a.NoError(err)
+			chainKey, err := audit.NewRandomChainKey()
+			a.NoError(err)
+			auditor := audit.NewService(times, audit.NewRepository(db.DB, db.Dialect, chainKey), chainKey)
+			service := customers.NewAuditedCustomerService(log.NewNopLogger(), auditor, next)
+
+			create := NewCustomer()
+			created, err := service.Create(ctx, create.TenantID, create)
+			a.NoError(err)
+			verify := func() {
+				for _, status := range []customers.KYCStatus{customers.KYCPending, customers.KYCVerified} {
+					found, err := next.Get(ctx, created.TenantID, created.CustomerID)
+					a.NoError(err)
+					if found.KYCStatus != status {
+						_, err = service.TransitionKYC(ctx, created.TenantID, created.CustomerID, customers.KYCTransition{To: status, Reason: "documents reviewed"})
+						a.NoError(err)
+					}
+				}
+			}
+			verify()
+
+			// Changing who the customer is resets it to pending, and says so.
+			update := *created
+			update.Name = "Jane Q Doe"
+			updated, err := service.Update(ctx, created.TenantID, created.CustomerID, update)
+			a.NoError(err)
+			a.Equal(customers.KYCPending, updated.KYCStatus)
+
+			page, err := auditor.List(ctx, created.TenantID, created.CustomerID, "", 0)
+			a.NoError(err)
+			a.Len(page.Events, 4)
+			a.Equal([]string{"name", "kycStatus"}, page.Events[3].Fields)
+
+			// The reset is kept when the rest of the update fails, its event is staged along with it.
+			verify()
+			update.Name = "Jane R Doe"
+			_, err = customers.NewAuditedCustomerService(log.NewNopLogger(), auditor, failing).Update(ctx, created.TenantID, created.CustomerID, update)
+			a.Error(err)
+
+			found, err := next.Get(ctx, created.TenantID, created.CustomerID)
+			a.NoError(err)
+			a.Equal(customers.KYCPending, found.KYCStatus)
+
+			a.NoError(auditor.Relay(ctx, created.TenantID))
+			page, err = auditor.List(ctx, created.TenantID, created.CustomerID, "", 0)
+			a.NoError(err)
+			a.Len(page.Events, 6)
+			a.Equal([]string{"name", "kycStatus"}, page.Events[5].Fields)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package customers
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"math/big"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+
+	"github.com/moovfinancial/backendhiring/pkg/fedach"
+)
+
+// newRoutingDirectory - The FedACH directory from the configured file, nil when there isn't one.
+func newRoutingDirectory(config BankAccountsConfig) (*fedach.Directory, error) {
+	if config.RoutingDirectoryPath == "" {
+		return nil, nil
+	}
+	return fedach.Load(config.RoutingDirectoryPath)
+}
+
+func (s *customerService) AddBankAccount(ctx context.Context, tenantID string, customerID string, create BankAccount) (*BankAccount, error) {
+	create.Normalize()
+	if err := create.Validate(); err != nil {
+		return nil, err
+	}
+	bankName, err := s.bankName(create.RoutingNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.addressable(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.repository.ListBankAccounts(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range accounts {
+		if b.RoutingNumber == create.RoutingNumber && b.AccountNumber == create.AccountNumber {
+			return nil, ErrBankAccountExists
+		}
+	}
+	if _, err := s.bankAccountFailedAttempts(ctx, tenantID, customerID, create); err != nil {
+		return nil, err
+	}
+
+	// Only the account itself comes from the request, the IDs, bank, status and timestamps are ours.
+	create.TenantID = tenantID
+	create.CustomerID = customerID
+	create.BankAccountID = uuid.NewString()
+	create.AccountNumberMasked = ""
+	create.BankName = bankName
+	create.Status = BankAccountUnverified
+	create.MicroDeposits = nil
+	create.VerifiedOn = nil
+	create.CreatedOn = s.time.Now()
+	create.UpdatedOn = create.CreatedOn
+
+	return s.repository.AddBankAccount(ctx, create)
+}
+
+func (s *customerService) ListBankAccounts(ctx context.Context, tenantID string, customerID string) ([]BankAccount, error) {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListBankAccounts(ctx, tenantID, customerID)
+}
+
+func (s *customerService) GetBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string) (*BankAccount, error) {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+	return s.getBankAccount(ctx, tenantID, customerID, bankAccountID)
+}
+
+func (s *customerService) DeleteBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string) error {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return err
+	}
+	return s.repository.DeleteBankAccount(ctx, tenantID, customerID, bankAccountID, s.time.Now())
+}
+
+func (s *customerService) SendMicroDeposits(ctx context.Context, tenantID string, customerID string, bankAccountID string) (*BankAccount, error) {
+	if err := s.addressable(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	cur, err := s.getBankAccount(ctx, tenantID, customerID, bankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if cur.Status != BankAccountUnverified {
+		return nil, ErrMicroDepositsSent
+	}
+
+	// Picks up where the customer's deleted accounts with the same numbers left off.
+	failed, err := s.bankAccountFailedAttempts(ctx, tenantID, customerID, *cur)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts, err := newMicroDeposits()
+	if err != nil {
+		return nil, err
+	}
+
+	update := *cur
+	update.Status = BankAccountPending
+	update.MicroDeposits = &MicroDeposits{Amounts: amounts, SentOn: s.time.Now(), FailedAttempts: failed}
+	update.UpdatedOn = update.MicroDeposits.SentOn
+
+	return s.repository.UpdateBankAccount(ctx, *cur, update)
+}
+
+func (s *customerService) ConfirmMicroDeposits(ctx context.Context, tenantID string, customerID string, bankAccountID string, confirmation MicroDepositConfirmation) (*BankAccount, error) {
+	if err := confirmation.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.addressable(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	cur, err := s.getBankAccount(ctx, tenantID, customerID, bankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if cur.Status != BankAccountPending || cur.MicroDeposits == nil {
+		return nil, ErrMicroDepositsNotPending
+	}
+
+	now := s.time.Now()
+	update := *cur
+	deposits := *cur.MicroDeposits
+	update.MicroDeposits = &deposits
+	update.UpdatedOn = now
+
+	matched := confirmation.matches(deposits.Amounts)
+	if matched {
+		update.Status = BankAccountVerified
+		update.VerifiedOn = &now
+	} else {
+		deposits.FailedAttempts++
+		if deposits.FailedAttempts >= s.config.BankAccounts.MicroDeposits.maxAttempts() {
+			update.Status = BankAccountVerificationFailed
+		}
+	}
+
+	saved, err := s.repository.UpdateBankAccount(ctx, *cur, update)
+	if err != nil {
+		return nil, err
+	}
+	if matched {
+		return saved, nil
+	}
+
+	if remaining := s.config.BankAccounts.MicroDeposits.maxAttempts() - deposits.FailedAttempts; remaining > 0 {
+		return nil, validation.Errors{"amounts": fmt.Errorf("%w, %d attempts left", ErrMicroDepositsMismatch, remaining)}
+	}
+	return nil, validation.Errors{"amounts": fmt.Errorf("%w, the bank account failed verification", ErrMicroDepositsMismatch)}
+}
+
+// bankName - The routing number's bank in the FedACH directory. Without a directory any routing number with a valid
+// check digit is taken.
+func (s *customerService) bankName(routingNumber string) (string, error) {
+	if s.routing == nil {
+		return "", nil
+	}
+
+	p, found := s.routing.Lookup(routingNumber)
+	switch {
+	case !found:
+		return "", validation.Errors{"routingNumber": ErrRoutingNumberUnknown}
+	case p.RecordType == fedach.RecordFederalReserveBank:
+		return "", validation.Errors{"routingNumber": ErrRoutingNumberFederalReserve}
+	case p.RecordType == fedach.RecordNewRoutingNumber:
+		return "", validation.Errors{"routingNumber": fmt.Errorf("has been replaced by %s", p.NewRoutingNumber)}
+	}
+	return p.Name, nil
+}
+
+// bankAccountFailedAttempts - The failed attempts of the customer's accounts with the same routing and account
+// numbers, deleted ones included. ErrBankAccountVerificationFailed when there are none left.
+func (s *customerService) bankAccountFailedAttempts(ctx context.Context, tenantID string, customerID string, b BankAccount) (int, error) {
+	failed, err := s.repository.BankAccountFailedAttempts(ctx, tenantID, customerID, b.RoutingNumber, b.AccountNumber)
+	if err != nil {
+		return 0, err
+	}
+	if failed >= s.config.BankAccounts.MicroDeposits.maxAttempts() {
+		return 0, ErrBankAccountVerificationFailed
+	}
+	return failed, nil
+}
+
+func (s *customerService) getBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string) (*BankAccount, error) {
+	accounts, err := s.repository.ListBankAccounts(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range accounts {
+		if b.BankAccountID == bankAccountID {
+			return &b, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// newMicroDeposits - Random amounts so they can't be guessed, each of MinMicroDeposit to MaxMicroDeposit cents.
+func newMicroDeposits() ([]int, error) {
+	amounts := make([]int, MicroDepositCount)
+	for i := range amounts {
+		n, err := rand.Int(rand.Reader, big.NewInt(MaxMicroDeposit-MinMicroDeposit+1))
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = MinMicroDeposit + int(n.Int64())
+	}
+	return amounts, nil
+}
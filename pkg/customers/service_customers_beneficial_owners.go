@@ -0,0 +1,135 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func (s *customerService) AddBeneficialOwner(ctx context.Context, tenantID string, customerID string, create BeneficialOwner) (*BeneficialOwner, error) {
+	if err := create.Validate(); err != nil {
+		return nil, err
+	}
+
+	owners, err := s.ownedBusiness(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range owners {
+		if o.OwnerID == create.OwnerID {
+			return nil, ErrBeneficialOwnerExists
+		}
+	}
+	if err := s.ownerIndividual(ctx, tenantID, create.OwnerID); err != nil {
+		return nil, err
+	}
+	if err := withinOwnership(owners, create); err != nil {
+		return nil, err
+	}
+
+	// Only the share and control come from the request, the business and timestamps are ours.
+	create.TenantID = tenantID
+	create.CustomerID = customerID
+	create.CreatedOn = s.time.Now()
+	create.UpdatedOn = create.CreatedOn
+
+	return s.repository.AddBeneficialOwner(ctx, create)
+}
+
+func (s *customerService) ListBeneficialOwners(ctx context.Context, tenantID string, customerID string) ([]BeneficialOwner, error) {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListBeneficialOwners(ctx, tenantID, customerID)
+}
+
+func (s *customerService) UpdateBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string, update BeneficialOwner) (*BeneficialOwner, error) {
+	update.OwnerID = ownerID
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	owners, err := s.ownedBusiness(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var cur *BeneficialOwner
+	others := []BeneficialOwner{}
+	for i, o := range owners {
+		if o.OwnerID == ownerID {
+			cur = &owners[i]
+			continue
+		}
+		others = append(others, o)
+	}
+	if cur == nil {
+		return nil, sql.ErrNoRows
+	}
+	if err := withinOwnership(others, update); err != nil {
+		return nil, err
+	}
+
+	update.TenantID = tenantID
+	update.CustomerID = customerID
+	update.CreatedOn = cur.CreatedOn
+	update.UpdatedOn = s.time.Now()
+
+	return s.repository.UpdateBeneficialOwner(ctx, update)
+}
+
+func (s *customerService) DeleteBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string) error {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return err
+	}
+	return s.repository.DeleteBeneficialOwner(ctx, tenantID, customerID, ownerID, s.time.Now())
+}
+
+// ownedBusiness - The owners of the business, which has to be an active business customer to list any more.
+func (s *customerService) ownedBusiness(ctx context.Context, tenantID string, customerID string) ([]BeneficialOwner, error) {
+	cur, err := s.Get(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case cur.ErasedOn != nil:
+		return nil, ErrCustomerErased
+	case cur.DisabledOn != nil:
+		return nil, sql.ErrNoRows
+	case cur.Type != CustomerBusiness:
+		return nil, ErrNotBusiness
+	}
+	return s.repository.ListBeneficialOwners(ctx, tenantID, customerID)
+}
+
+// ownerIndividual - Owners are looked up in the business's tenant so one tenant's customers can't be linked to
+// another's.
+func (s *customerService) ownerIndividual(ctx context.Context, tenantID string, ownerID string) error {
+	owner, err := s.Get(ctx, tenantID, ownerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.Errors{"ownerID": ErrOwnerNotIndividual}
+	}
+	if err != nil {
+		return err
+	}
+	if owner.Type != CustomerIndividual || owner.DisabledOn != nil || owner.ErasedOn != nil {
+		return validation.Errors{"ownerID": ErrOwnerNotIndividual}
+	}
+	return nil
+}
+
+// withinOwnership - If the owner's share, with the business's other owners', is no more than all of it.
+func withinOwnership(others []BeneficialOwner, owner BeneficialOwner) error {
+	total := owner.OwnershipPercentage
+	for _, o := range others {
+		total += o.OwnershipPercentage
+	}
+	// Shares are in hundredths, rounded so adding them up in floating point doesn't tip the total over.
+	if math.Round(total*100) > 100*100 {
+		return validation.Errors{"ownershipPercentage": ErrOwnershipExceeded}
+	}
+	return nil
+}
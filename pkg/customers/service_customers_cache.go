@@ -0,0 +1,262 @@
+package customers
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	kitprom "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/moov-io/base/stime"
+	stdprom "github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	customerCacheHits = kitprom.NewCounterFrom(stdprom.CounterOpts{
+		Name: "customer_cache_hits",
+		Help: "Number of customer lookups answered from the cache.",
+	}, nil)
+
+	customerCacheMisses = kitprom.NewCounterFrom(stdprom.CounterOpts{
+		Name: "customer_cache_misses",
+		Help: "Number of customer lookups that had to go to the repository.",
+	}, nil)
+
+	customerCacheEvictions = kitprom.NewCounterFrom(stdprom.CounterOpts{
+		Name: "customer_cache_evictions",
+		Help: "Number of customers dropped from the cache to stay under its size limit.",
+	}, nil)
+)
+
+// NewCachedCustomerService - Answers Get from a bounded LRU cache in front of next. Concurrent misses for the same
+// customer share a single lookup, and any change made through the service drops the cached copy. Shared lookups
+// aren't tied to any one caller's context, they run for up to timeout instead.
+func NewCachedCustomerService(time stime.TimeService, config CacheConfig, timeout time.Duration, next CustomerService) CustomerService {
+	if config.Size <= 0 {
+		return next
+	}
+
+	return &cachedCustomerService{
+		CustomerService: next,
+		time:            time,
+		timeout:         timeout,
+		cache:           newCustomerCache(config.Size, config.TTL),
+	}
+}
+
+// cachedCustomerService - Everything not overridden here goes straight through to the wrapped service.
+type cachedCustomerService struct {
+	CustomerService
+
+	time    stime.TimeService
+	timeout time.Duration
+	cache   *customerCache
+	lookups singleflight.Group
+}
+
+func (s *cachedCustomerService) Get(ctx context.Context, tenantID string, customerID string) (*Customer, error) {
+	key := customerKey{tenantID: tenantID, customerID: customerID}
+
+	if found, ok := s.cache.get(key, s.time.Now()); ok {
+		customerCacheHits.Add(1)
+		return &found, nil
+	}
+	customerCacheMisses.Add(1)
+
+	generation := s.cache.generation()
+	lookup := s.lookups.DoChan(tenantID+"/"+customerID, func() (interface{}, error) {
+		// Detached from whichever caller started it so the others waiting on it don't fail when it goes away.
+		ctx, cancel := withTimeout(context.WithoutCancel(ctx), s.timeout)
+		defer cancel()
+
+		found, err := s.CustomerService.Get(ctx, tenantID, customerID)
+		if err != nil {
+			return nil, err
+		}
+
+		s.cache.add(key, *found, generation, s.time.Now())
+		return *found, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-lookup:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		found := copyCustomer(result.Val.(Customer))
+		return &found, nil
+	}
+}
+
+func (s *cachedCustomerService) Update(ctx context.Context, tenantID string, customerID string, update Customer) (*Customer, error) {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.Update(ctx, tenantID, customerID, update)
+}
+
+func (s *cachedCustomerService) Delete(ctx context.Context, tenantID string, customerID string) error {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.Delete(ctx, tenantID, customerID)
+}
+
+func (s *cachedCustomerService) PlaceLegalHold(ctx context.Context, tenantID string, customerID string, hold LegalHold) (*Customer, error) {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.PlaceLegalHold(ctx, tenantID, customerID, hold)
+}
+
+func (s *cachedCustomerService) ReleaseLegalHold(ctx context.Context, tenantID string, customerID string) error {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.ReleaseLegalHold(ctx, tenantID, customerID)
+}
+
+// Erase - Erased personal data mustn't be served from the cache.
+func (s *cachedCustomerService) Erase(ctx context.Context, tenantID string, customerID string, request ErasureRequest) (*CustomerErasure, error) {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.Erase(ctx, tenantID, customerID, request)
+}
+
+func (s *cachedCustomerService) TransitionKYC(ctx context.Context, tenantID string, customerID string, transition KYCTransition) (*Customer, error) {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.TransitionKYC(ctx, tenantID, customerID, transition)
+}
+
+// Rescreen - Can change the customer's screening status.
+func (s *cachedCustomerService) Rescreen(ctx context.Context, tenantID string, customerID string, runID string) ([]ScreeningAlert, error) {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.Rescreen(ctx, tenantID, customerID, runID)
+}
+
+// VerifyIdentity - Moves the customer to pending.
+func (s *cachedCustomerService) VerifyIdentity(ctx context.Context, tenantID string, customerID string) (*Verification, error) {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.VerifyIdentity(ctx, tenantID, customerID)
+}
+
+// CompleteVerification - Moves a pending customer on to the KYC status for the answer.
+func (s *cachedCustomerService) CompleteVerification(ctx context.Context, from Verification, verification Verification) (*Verification, error) {
+	defer s.invalidate(verification.TenantID, verification.CustomerID)
+	return s.CustomerService.CompleteVerification(ctx, from, verification)
+}
+
+// ScoreRisk - Saves the customer's new risk score.
+func (s *cachedCustomerService) ScoreRisk(ctx context.Context, tenantID string, customerID string) (*RiskScore, error) {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.ScoreRisk(ctx, tenantID, customerID)
+}
+
+// AddAddress - The customer's risk is scored again with its primary residential address.
+func (s *cachedCustomerService) AddAddress(ctx context.Context, tenantID string, customerID string, create Address) (*Address, error) {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.AddAddress(ctx, tenantID, customerID, create)
+}
+
+func (s *cachedCustomerService) UpdateAddress(ctx context.Context, tenantID string, customerID string, addressID string, update Address) (*Address, error) {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.UpdateAddress(ctx, tenantID, customerID, addressID, update)
+}
+
+func (s *cachedCustomerService) DeleteAddress(ctx context.Context, tenantID string, customerID string, addressID string) error {
+	defer s.invalidate(tenantID, customerID)
+	return s.CustomerService.DeleteAddress(ctx, tenantID, customerID, addressID)
+}
+
+func (s *cachedCustomerService) invalidate(tenantID string, customerID string) {
+	s.cache.remove(customerKey{tenantID: tenantID, customerID: customerID})
+	s.lookups.Forget(tenantID + "/" + customerID)
+}
+
+type customerCacheEntry struct {
+	key       customerKey
+	customer  Customer
+	expiresOn time.Time
+}
+
+// customerCache - LRU of customers that also expires entries after the ttl.
+type customerCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[customerKey]*list.Element
+	order   *list.List
+
+	// Bumped on every removal so a lookup that started before a change can't store what it read.
+	gen uint64
+}
+
+func newCustomerCache(size int, ttl time.Duration) *customerCache {
+	return &customerCache{
+		size:    size,
+		ttl:     ttl,
+		entries: map[customerKey]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+func (c *customerCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+func (c *customerCache) get(key customerKey, now time.Time) (Customer, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, found := c.entries[key]
+	if !found {
+		return Customer{}, false
+	}
+
+	entry := elem.Value.(*customerCacheEntry)
+	if c.ttl > 0 && !now.Before(entry.expiresOn) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return Customer{}, false
+	}
+
+	c.order.MoveToFront(elem)
+	return copyCustomer(entry.customer), true
+}
+
+func (c *customerCache) add(key customerKey, customer Customer, generation uint64, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.gen {
+		return
+	}
+
+	entry := &customerCacheEntry{
+		key:       key,
+		customer:  copyCustomer(customer),
+		expiresOn: now.Add(c.ttl),
+	}
+
+	if elem, found := c.entries[key]; found {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*customerCacheEntry).key)
+		customerCacheEvictions.Add(1)
+	}
+}
+
+func (c *customerCache) remove(key customerKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	if elem, found := c.entries[key]; found {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
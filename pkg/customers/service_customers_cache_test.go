@@ -0,0 +1,176 @@
+package customers_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/stime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+// countingCustomerService - Counts how many lookups reach it, Get blocks until release is closed when it's set. Like
+// a database it fails lookups whose context is done by then.
+type countingCustomerService struct {
+	customers.CustomerService
+	gets    int32
+	release chan struct{}
+}
+
+func (s *countingCustomerService) Get(ctx context.Context, tenantID string, customerID string) (*customers.Customer, error) {
+	atomic.AddInt32(&s.gets, 1)
+	if s.release != nil {
+		<-s.release
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.CustomerService.Get(ctx, tenantID, customerID)
+}
+
+func setupCachedService(t *testing.T, config customers.CacheConfig) (customers.CustomerService, *countingCustomerService, stime.StaticTimeService, customers.CustomerRepository) {
+	times := stime.NewStaticTimeService()
+	repository := customers.NewInMemoryCustomerRepository()
+
+	service, err := customers.NewCustomerService(times, nil, customers.Config{}, repository)
+	require.NoError(t, err)
+
+	counting := &countingCustomerService{CustomerService: service}
+	return customers.NewCachedCustomerService(times, config, time.Second, counting), counting, times, repository
+}
+
+func Test_CustomerCache_HitsAndExpiry(t *testing.T) {
+	a := require.New(t)
+	cached, counting, times, repository := setupCachedService(t, customers.CacheConfig{Size: 10, TTL: time.Minute})
+
+	m, err := repository.Add(context.Background(), NewCustomer())
+	a.NoError(err)
+
+	for i := 0; i < 3; i++ {
+		found, err := cached.Get(context.Background(), m.TenantID, m.CustomerID)
+		a.NoError(err)
+		a.Equal(*m, *found)
+	}
+	a.EqualValues(1, counting.gets)
+
+	// Another tenant asking for the same ID isn't served from the cache.
+	_, err = cached.Get(context.Background(), NewCustomer().TenantID, m.CustomerID)
+	a.Error(err)
+	a.EqualValues(2, counting.gets)
+
+	times.Add(time.Minute)
+	_, err = cached.Get(context.Background(), m.TenantID, m.CustomerID)
+	a.NoError(err)
+	a.EqualValues(3, counting.gets)
+}
+
+func Test_CustomerCache_Eviction(t *testing.T) {
+	a := require.New(t)
+	cached, counting, _, repository := setupCachedService(t, customers.CacheConfig{Size: 1})
+
+	first, err := repository.Add(context.Background(), NewCustomer())
+	a.NoError(err)
+	second, err := repository.Add(context.Background(), NewCustomer())
+	a.NoError(err)
+
+	_, err = cached.Get(context.Background(), first.TenantID, first.CustomerID)
+	a.NoError(err)
+	_, err = cached.Get(context.Background(), second.TenantID, second.CustomerID)
+	a.NoError(err)
+	_, err = cached.Get(context.Background(), first.TenantID, first.CustomerID)
+	a.NoError(err)
+
+	a.EqualValues(3, counting.gets)
+}
+
+func Test_CustomerCache_Invalidation(t *testing.T) {
+	a := require.New(t)
+	cached, _, _, repository := setupCachedService(t, customers.CacheConfig{Size: 10, TTL: time.Minute})
+
+	m, err := repository.Add(context.Background(), NewCustomer())
+	a.NoError(err)
+
+	_, err = cached.Get(context.Background(), m.TenantID, m.CustomerID)
+	a.NoError(err)
+
+	a.NoError(cached.Delete(context.Background(), m.TenantID, m.CustomerID))
+
+	found, err := cached.Get(context.Background(), m.TenantID, m.CustomerID)
+	a.NoError(err)
+	a.NotNil(found.DisabledOn)
+}
+
+func Test_CustomerCache_Coalescing(t *testing.T) {
+	a := require.New(t)
+	cached, counting, _, repository := setupCachedService(t, customers.CacheConfig{Size: 10})
+	counting.release = make(chan struct{})
+
+	m, err := repository.Add(context.Background(), NewCustomer())
+	a.NoError(err)
+
+	// Failing from the goroutines isn't allowed, what they found is checked once they're done.
+	results := make(chan *customers.Customer, 10)
+	errs := make(chan error, 10)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			found, err := cached.Get(context.Background(), m.TenantID, m.CustomerID)
+			results <- found
+			errs <- err
+		}()
+	}
+
+	// Give every caller a chance to join the in-flight lookup before it completes.
+	time.Sleep(50 * time.Millisecond)
+	close(counting.release)
+	wg.Wait()
+	close(results)
+	close(errs)
+
+	for err := range errs {
+		a.NoError(err)
+	}
+	for found := range results {
+		a.Equal(m.CustomerID, found.CustomerID)
+	}
+
+	a.EqualValues(1, counting.gets)
+}
+
+func Test_CustomerCache_CoalescingLeaderCancelled(t *testing.T) {
+	a := require.New(t)
+	cached, counting, _, repository := setupCachedService(t, customers.CacheConfig{Size: 10})
+	counting.release = make(chan struct{})
+
+	m, err := repository.Add(context.Background(), NewCustomer())
+	a.NoError(err)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leader := make(chan error, 1)
+	go func() {
+		_, err := cached.Get(leaderCtx, m.TenantID, m.CustomerID)
+		leader <- err
+	}()
+	a.Eventually(func() bool { return atomic.LoadInt32(&counting.gets) == 1 }, time.Second, time.Millisecond)
+
+	follower := make(chan error, 1)
+	go func() {
+		_, err := cached.Get(context.Background(), m.TenantID, m.CustomerID)
+		follower <- err
+	}()
+
+	// The leader gives up while the follower is waiting on its lookup.
+	time.Sleep(50 * time.Millisecond)
+	cancelLeader()
+	a.ErrorIs(<-leader, context.Canceled)
+
+	close(counting.release)
+	a.NoError(<-follower)
+	a.EqualValues(1, counting.gets)
+}
@@ -0,0 +1,188 @@
+package customers
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/moov-io/base/log"
+
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+// sniffSize - How much of a file http.DetectContentType looks at.
+const sniffSize = 512
+
+func (s *customerService) AddDocument(ctx context.Context, tenantID string, customerID string, create Document, content io.Reader) (*Document, error) {
+	if err := create.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.addressable(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	// Only the type comes from the request, everything else is worked out from the file.
+	create.TenantID = tenantID
+	create.CustomerID = customerID
+	create.DocumentID = uuid.NewString()
+	create.CreatedOn = s.time.Now()
+
+	buffered := bufio.NewReaderSize(content, sniffSize)
+	head, err := buffered.Peek(sniffSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(head) == 0 {
+		return nil, validation.Errors{"file": ErrDocumentEmpty}
+	}
+	create.ContentType = http.DetectContentType(head)
+	if err := documentContentType(create.ContentType, s.config.Documents.contentTypes()); err != nil {
+		return nil, err
+	}
+
+	dataKey, err := s.repository.DataKey(ctx, tenantID, customerID, create.CreatedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	size, checksum, err := s.storeDocument(ctx, dataKey, create, buffered)
+	if err != nil {
+		return nil, err
+	}
+	create.Size = size
+	create.SHA256 = checksum
+
+	result, err := s.repository.AddDocument(ctx, create)
+	if err != nil {
+		s.deleteDocumentBlob(ctx, create)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// storeDocument - Streams the file into the blob store encrypted with the customer's data key, returning its size
+// and checksum. Files over the size limit are rejected part way through and nothing is stored.
+func (s *customerService) storeDocument(ctx context.Context, dataKey *envelope.DataKey, d Document, content io.Reader) (int64, string, error) {
+	maxSize := s.config.Documents.maxSize()
+	checksum := sha256.New()
+	var size int64
+
+	pr, pw := io.Pipe()
+	encrypted := make(chan error, 1)
+	go func() {
+		err := func() error {
+			w, err := dataKey.EncryptStream(pw, documentBlobKey(d))
+			if err != nil {
+				return err
+			}
+
+			size, err = io.Copy(w, io.TeeReader(io.LimitReader(content, maxSize+1), checksum))
+			if err != nil {
+				return err
+			}
+			if size > maxSize {
+				return ErrDocumentTooLarge
+			}
+			return w.Close()
+		}()
+		pw.CloseWithError(err)
+		encrypted <- err
+	}()
+
+	err := s.documents.Put(ctx, documentBlobKey(d), pr)
+	// Stops the encryption if the store gave up before reading everything.
+	pr.CloseWithError(err)
+
+	if encryptErr := <-encrypted; encryptErr != nil && !errors.Is(encryptErr, io.ErrClosedPipe) {
+		return 0, "", encryptErr
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	return size, hex.EncodeToString(checksum.Sum(nil)), nil
+}
+
+func (s *customerService) ListDocuments(ctx context.Context, tenantID string, customerID string) ([]Document, error) {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListDocuments(ctx, tenantID, customerID)
+}
+
+func (s *customerService) GetDocument(ctx context.Context, tenantID string, customerID string, documentID string) (*Document, error) {
+	documents, err := s.repository.ListDocuments(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range documents {
+		if d.DocumentID == documentID {
+			return &d, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s *customerService) OpenDocument(ctx context.Context, tenantID string, customerID string, documentID string) (*Document, io.ReadCloser, error) {
+	doc, err := s.GetDocument(ctx, tenantID, customerID, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dataKey, err := s.repository.DataKey(ctx, tenantID, customerID, s.time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encrypted, err := s.documents.Get(ctx, documentBlobKey(*doc))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doc, &documentReader{
+		Reader: dataKey.DecryptStream(encrypted, documentBlobKey(*doc)),
+		Closer: encrypted,
+	}, nil
+}
+
+// documentReader - Decrypts a document as it's read, closing it closes the blob underneath.
+type documentReader struct {
+	io.Reader
+	io.Closer
+}
+
+func (s *customerService) DeleteDocument(ctx context.Context, tenantID string, customerID string, documentID string) error {
+	doc, err := s.GetDocument(ctx, tenantID, customerID, documentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteDocument(ctx, tenantID, customerID, documentID, s.time.Now()); err != nil {
+		return err
+	}
+
+	s.deleteDocumentBlob(ctx, *doc)
+	return nil
+}
+
+// deleteDocumentBlob - Removes a document's file once nothing refers to it. Failures are only logged, a file left
+// behind can't be read once the customer is erased and its data key deleted.
+func (s *customerService) deleteDocumentBlob(ctx context.Context, d Document) {
+	if err := s.documents.Delete(ctx, documentBlobKey(d)); err != nil {
+		s.logger.Warn().With(tracing.LogFields(ctx), log.Fields{
+			"tenant_id":   log.String(d.TenantID),
+			"customer_id": log.String(d.CustomerID),
+			"document_id": log.String(d.DocumentID),
+		}).LogErrorf("deleting document file: %w", err)
+	}
+}
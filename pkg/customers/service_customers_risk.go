@@ -0,0 +1,126 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/moovfinancial/backendhiring/pkg/risk"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+// newRiskRules - The rules from the configured file, nil when risk scoring is off.
+func newRiskRules(config RiskConfig) (*risk.Rules, error) {
+	if config.RulesPath == "" {
+		return nil, nil
+	}
+	return risk.Load(config.RulesPath)
+}
+
+func (s *customerService) ScoreRisk(ctx context.Context, tenantID string, customerID string) (*RiskScore, error) {
+	if s.rules == nil {
+		return nil, ErrRiskScoringOff
+	}
+
+	cur, err := s.Get(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if cur.DisabledOn != nil || cur.ErasedOn != nil {
+		return nil, sql.ErrNoRows
+	}
+
+	attributes, err := s.riskAttributes(ctx, *cur)
+	if err != nil {
+		return nil, err
+	}
+
+	score := newRiskScore(s.rules.Assess(tenantID, attributes), s.time.Now())
+	if score.sameAs(cur.Risk) {
+		return cur.Risk, nil
+	}
+
+	if err := s.repository.SetRiskScore(ctx, tenantID, customerID, score); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().With(tracing.LogFields(ctx), log.Fields{
+		"tenant_id":          log.String(tenantID),
+		"customer_id":        log.String(customerID),
+		"risk_tier":          log.String(score.Tier),
+		"risk_score":         log.Int(score.Score),
+		"risk_rules_version": log.String(score.RulesVersion),
+	}).Log("Customer risk scored")
+
+	return &score, nil
+}
+
+// rescore - Scores the customer again after a change to it, nil when it isn't scored. The change stands when the
+// score can't be saved, the customer keeps its old score until it's rescored.
+func (s *customerService) rescore(ctx context.Context, tenantID string, customerID string) *RiskScore {
+	if s.rules == nil {
+		return nil
+	}
+
+	score, err := s.ScoreRisk(ctx, tenantID, customerID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.logger.Warn().With(tracing.LogFields(ctx), log.Fields{
+				"tenant_id":   log.String(tenantID),
+				"customer_id": log.String(customerID),
+			}).LogErrorf("scoring customer risk: %v", err)
+		}
+		return nil
+	}
+	return score
+}
+
+// riskAttributes - What the risk rules can match on, from the customer and its latest identity check and primary
+// residential address.
+func (s *customerService) riskAttributes(ctx context.Context, c Customer) (risk.Attributes, error) {
+	attributes := risk.Attributes{
+		RiskCustomerType:    string(c.Type),
+		RiskScreeningStatus: string(c.ScreeningStatus),
+		RiskKYCStatus:       string(c.KYCStatus),
+	}
+	if c.Business != nil {
+		attributes[RiskEntityType] = string(c.Business.EntityType)
+	}
+
+	if birthDate, err := time.Parse("2006/01/02", valueOf(c.BirthDate)); err == nil {
+		attributes[RiskAge] = strconv.Itoa(age(birthDate, s.time.Now()))
+	}
+
+	verifications, err := s.repository.ListVerifications(ctx, c.TenantID, c.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(verifications) > 0 {
+		attributes[RiskVerificationStatus] = string(verifications[len(verifications)-1].Status)
+	}
+
+	addresses, err := s.repository.ListAddresses(ctx, c.TenantID, c.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range addresses {
+		if a.Type == AddressResidential && a.Primary {
+			attributes[RiskCountry] = a.Country
+		}
+	}
+
+	return attributes, nil
+}
+
+// age - Whole years from birthDate to now.
+func age(birthDate time.Time, now time.Time) int {
+	years := now.Year() - birthDate.Year()
+	if now.Month() < birthDate.Month() || (now.Month() == birthDate.Month() && now.Day() < birthDate.Day()) {
+		years--
+	}
+	return years
+}
@@ -0,0 +1,240 @@
+package customers
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/moov-io/base/log"
+
+	"github.com/moovfinancial/backendhiring/pkg/sanctions"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+// screeningLists - The loaded sanctions lists, swapped out when a new version of the files is found.
+type screeningLists struct {
+	config ScreeningConfig
+
+	mu       sync.RWMutex
+	version  string
+	screener *sanctions.Screener
+}
+
+// newScreeningLists - Loads the configured lists, screening is off when there aren't any.
+func newScreeningLists(config ScreeningConfig) (*screeningLists, error) {
+	lists := &screeningLists{config: config}
+	if _, err := lists.reload(); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// current - The screener for the latest version loaded, nil when screening is off.
+func (l *screeningLists) current() *sanctions.Screener {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.screener
+}
+
+// reload - Loads the lists again if their files have changed, returning the version in use. The version is empty
+// when screening is off.
+func (l *screeningLists) reload() (string, error) {
+	if len(l.config.Lists) == 0 {
+		return "", nil
+	}
+
+	version, err := sanctions.Version(l.config.Lists)
+	if err != nil {
+		return "", err
+	}
+
+	l.mu.RLock()
+	unchanged := version == l.version
+	l.mu.RUnlock()
+	if unchanged {
+		return version, nil
+	}
+
+	// A file replaced between hashing and loading is picked up again on the next reload.
+	entries, err := sanctions.Load(l.config.Lists)
+	if err != nil {
+		return "", err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.version = version
+	l.screener = sanctions.NewScreener(entries)
+	return version, nil
+}
+
+func (s *customerService) ListScreenings(ctx context.Context, tenantID string, customerID string) ([]Screening, error) {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListScreenings(ctx, tenantID, customerID)
+}
+
+func (s *customerService) ReloadSanctionsLists(ctx context.Context) (string, error) {
+	previous := s.lists.current()
+	version, err := s.lists.reload()
+	if err != nil {
+		return "", err
+	}
+
+	if s.lists.current() != previous {
+		s.logger.Info().With(tracing.LogFields(ctx), log.Fields{
+			"list_version": log.String(version),
+			"entries":      log.Int(s.lists.current().Len()),
+		}).Log("Loaded sanctions lists")
+	}
+	return version, nil
+}
+
+func (s *customerService) Rescreen(ctx context.Context, tenantID string, customerID string, runID string) ([]ScreeningAlert, error) {
+	cur, err := s.Get(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	// Disabled or erased since the run listed it.
+	if cur.DisabledOn != nil || cur.ErasedOn != nil {
+		return nil, nil
+	}
+
+	previous, err := s.repository.ListScreenings(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The customer already exists so a blocking match blocks it rather than failing.
+	screening, err := s.screen(ctx, *cur)
+	if err != nil && !errors.Is(err, ErrSanctionsMatch) {
+		return nil, err
+	}
+	if screening == nil {
+		return nil, nil
+	}
+
+	if screening.Status != cur.ScreeningStatus {
+		if err := s.repository.SetScreeningStatus(ctx, tenantID, customerID, screening.Status, screening.ScreenedOn); err != nil {
+			return nil, err
+		}
+		s.rescore(ctx, tenantID, customerID)
+	}
+
+	alerts := newScreeningAlerts(*screening, previous, runID)
+	if len(alerts) > 0 {
+		for i := range alerts {
+			alerts[i].AlertID = uuid.NewString()
+		}
+		if err := s.repository.AddScreeningAlerts(ctx, alerts); err != nil {
+			return nil, err
+		}
+	}
+
+	return alerts, nil
+}
+
+// newScreeningAlerts - The screening's matches that none of the earlier screenings had.
+func newScreeningAlerts(screening Screening, previous []Screening, runID string) []ScreeningAlert {
+	type entryKey struct{ list, entryID string }
+	seen := map[entryKey]bool{}
+	for _, p := range previous {
+		for _, m := range p.Matches {
+			seen[entryKey{m.List, m.EntryID}] = true
+		}
+	}
+
+	alerts := []ScreeningAlert{}
+	for _, m := range screening.Matches {
+		if seen[entryKey{m.List, m.EntryID}] {
+			continue
+		}
+		alerts = append(alerts, ScreeningAlert{
+			TenantID:    screening.TenantID,
+			CustomerID:  screening.CustomerID,
+			ScreeningID: screening.ScreeningID,
+			RunID:       runID,
+			Status:      screening.Status,
+			Match:       m,
+			CreatedOn:   screening.ScreenedOn,
+		})
+	}
+	return alerts
+}
+
+func (s *customerService) ListScreeningAlerts(ctx context.Context, tenantID string, filter ScreeningAlertFilter) ([]ScreeningAlert, error) {
+	if filter.CustomerID != "" {
+		if _, err := s.Get(ctx, tenantID, filter.CustomerID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repository.ListScreeningAlerts(ctx, tenantID, filter)
+}
+
+// screeningQuery - Individuals are screened by name and birth date against the people on the lists, businesses by
+// legal name against the entities.
+func screeningQuery(c Customer) sanctions.Query {
+	if c.Type == CustomerBusiness && c.Business != nil {
+		return sanctions.Query{Name: c.Business.LegalName, Type: sanctions.TypeEntity}
+	}
+	return sanctions.Query{Name: c.Name, BirthDate: valueOf(c.BirthDate), Type: sanctions.TypeIndividual}
+}
+
+// screen - Checks the customer against the lists and records the result, returning ErrSanctionsMatch when it's
+// blocked. Nothing is screened, and the screening is nil, when there are no lists configured.
+func (s *customerService) screen(ctx context.Context, c Customer) (*Screening, error) {
+	screener := s.lists.current()
+	if screener == nil {
+		return nil, nil
+	}
+
+	_, span := tracing.Start(ctx, "Customer.Screen", c.TenantID)
+	found := screener.Screen(screeningQuery(c), s.config.Screening.flagThreshold())
+	tracing.End(span, nil)
+
+	screening := Screening{
+		TenantID:    c.TenantID,
+		CustomerID:  c.CustomerID,
+		ScreeningID: uuid.NewString(),
+		Status:      ScreeningClear,
+		Matches:     []ScreeningMatch{},
+		ScreenedOn:  s.time.Now(),
+	}
+	for _, m := range found[:min(len(found), MaxScreeningMatches)] {
+		screening.Matches = append(screening.Matches, ScreeningMatch{
+			List:     m.List,
+			EntryID:  m.EntryID,
+			Name:     m.Name,
+			Programs: m.Programs,
+			Score:    m.Score,
+		})
+	}
+	if len(found) > 0 {
+		// Matches are sorted best first.
+		screening.Score = found[0].Score
+		screening.Status = ScreeningFlagged
+		if screening.Score >= s.config.Screening.blockThreshold() {
+			screening.Status = ScreeningBlocked
+		}
+	}
+
+	if err := s.repository.AddScreening(ctx, screening); err != nil {
+		return nil, err
+	}
+
+	if screening.Status != ScreeningClear {
+		s.logger.Warn().With(tracing.LogFields(ctx), log.Fields{
+			"tenant_id":        log.String(c.TenantID),
+			"customer_id":      log.String(c.CustomerID),
+			"screening_id":     log.String(screening.ScreeningID),
+			"screening_status": log.String(string(screening.Status)),
+		}).Log("Customer matched a sanctions list")
+	}
+
+	if screening.Status == ScreeningBlocked {
+		return &screening, ErrSanctionsMatch
+	}
+	return &screening, nil
+}
@@ -0,0 +1,110 @@
+package customers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moov-io/base/log"
+
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+func (s *customerService) VerifyIdentity(ctx context.Context, tenantID string, customerID string) (*Verification, error) {
+	if !s.verifying() {
+		return nil, ErrVerificationOff
+	}
+
+	cur, err := s.Get(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifiable(*cur); err != nil {
+		return nil, err
+	}
+	return s.requestVerification(ctx, *cur)
+}
+
+// verifiable - The identity provider checks individuals by their SSN or ITIN, anyone else is reviewed through KYC
+// transitions.
+func verifiable(c Customer) error {
+	switch {
+	case c.Type == CustomerBusiness:
+		return ErrVerificationIndividualsOnly
+	case c.TaxID == nil || (c.TaxID.Type != TaxIDSSN && c.TaxID.Type != TaxIDITIN):
+		return ErrVerificationTaxIDType
+	}
+	return nil
+}
+
+func (s *customerService) ListVerifications(ctx context.Context, tenantID string, customerID string) ([]Verification, error) {
+	if _, err := s.Get(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListVerifications(ctx, tenantID, customerID)
+}
+
+func (s *customerService) CompleteVerification(ctx context.Context, from Verification, verification Verification) (*Verification, error) {
+	if err := s.repository.UpdateVerification(ctx, from, verification); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().With(tracing.LogFields(ctx), log.Fields{
+		"tenant_id":           log.String(verification.TenantID),
+		"customer_id":         log.String(verification.CustomerID),
+		"verification_id":     log.String(verification.VerificationID),
+		"verification_status": log.String(string(verification.Status)),
+	}).Log("Customer identity verification completed")
+
+	// Only a customer still waiting on the check is moved on, not one that's been reviewed by hand in the meantime.
+	cur, err := s.Get(ctx, verification.TenantID, verification.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if cur.KYCStatus == KYCPending && cur.DisabledOn == nil && cur.ErasedOn == nil {
+		transition := KYCTransition{To: verification.kycStatus(), Reason: verification.kycReason()}
+		if _, err := s.transitionKYC(ctx, *cur, transition); err != nil {
+			return nil, err
+		}
+	}
+
+	s.rescore(ctx, verification.TenantID, verification.CustomerID)
+	return &verification, nil
+}
+
+// verifying - If there's an identity provider to verify customers with.
+func (s *customerService) verifying() bool {
+	return s.config.Verification.Identity.Provider != ""
+}
+
+// requestVerification - Moves the customer to pending, if it isn't already, and queues a check for the verification
+// worker. A check already queued is superseded since it was for the customer's old details.
+func (s *customerService) requestVerification(ctx context.Context, cur Customer) (*Verification, error) {
+	if cur.ErasedOn != nil {
+		return nil, ErrCustomerErased
+	}
+
+	if cur.KYCStatus != KYCPending {
+		if !cur.KYCStatus.CanTransitionTo(KYCPending) {
+			return nil, ErrKYCTransition
+		}
+		if _, err := s.transitionKYC(ctx, cur, KYCTransition{To: KYCPending, Reason: KYCVerificationStartedReason}); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.time.Now()
+	verification := Verification{
+		TenantID:       cur.TenantID,
+		CustomerID:     cur.CustomerID,
+		VerificationID: uuid.NewString(),
+		Provider:       s.config.Verification.Identity.Provider,
+		Status:         VerificationPending,
+		NextAttemptOn:  now,
+		CreatedOn:      now,
+	}
+	if err := s.repository.AddVerification(ctx, verification); err != nil {
+		return nil, err
+	}
+
+	return &verification, nil
+}
@@ -0,0 +1,105 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+const defaultRescoringBatchSize = 100
+
+// RiskRescoring - What scoring the book again came to.
+type RiskRescoring struct {
+	// Scored counts the customers scored, Changed those whose score is different from before.
+	Scored  int `json:"scored"`
+	Changed int `json:"changed"`
+	Failed  int `json:"failed"`
+	// Tiers counts the customers scored in each tier.
+	Tiers map[string]int `json:"tiers"`
+}
+
+// RiskRescorer - Scores every active customer again, after the risk rules change. Customers are otherwise only scored
+// when they change.
+type RiskRescorer struct {
+	logger    log.Logger
+	service   CustomerService
+	repo      CustomerRepository
+	batchSize int
+}
+
+// NewRiskRescorer - Customers are scored through service so its cache and logs apply to them, batchSize at a time.
+func NewRiskRescorer(logger log.Logger, service CustomerService, repository CustomerRepository, batchSize int) *RiskRescorer {
+	if batchSize <= 0 {
+		batchSize = defaultRescoringBatchSize
+	}
+	return &RiskRescorer{logger: logger, service: service, repo: repository, batchSize: batchSize}
+}
+
+// Run - Scores the tenant's active customers, or every tenant's when tenantID is empty. Customers that fail are
+// logged and counted, the rest are still scored.
+func (r *RiskRescorer) Run(ctx context.Context, tenantID string) (result *RiskRescoring, err error) {
+	ctx, span := tracing.Start(ctx, "RiskRescorer.Run", tenantID)
+	defer func() { tracing.End(span, err) }()
+
+	result = &RiskRescoring{Tiers: map[string]int{}}
+	after := ScreeningCandidate{TenantID: tenantID}
+	for {
+		candidates, err := r.repo.ListScreenable(ctx, ScreenableFilter{After: after, Limit: r.batchSize})
+		if err != nil {
+			return result, err
+		}
+
+		for _, candidate := range candidates {
+			if tenantID != "" && candidate.TenantID != tenantID {
+				return result, nil
+			}
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			if err := r.rescore(ctx, candidate, result); err != nil {
+				if errors.Is(err, ErrRiskScoringOff) {
+					return result, err
+				}
+				r.logger.With(log.Fields{
+					"tenant_id":   log.String(candidate.TenantID),
+					"customer_id": log.String(candidate.CustomerID),
+				}).LogErrorf("rescoring customer risk: %v", err)
+				result.Failed++
+			}
+			after = candidate
+		}
+
+		if len(candidates) < r.batchSize {
+			return result, nil
+		}
+	}
+}
+
+func (r *RiskRescorer) rescore(ctx context.Context, candidate ScreeningCandidate, result *RiskRescoring) error {
+	// Only the score from before is wanted, read from the repository so it isn't audited as a read of personal data.
+	cur, err := r.repo.Get(ctx, candidate.TenantID, candidate.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	score, err := r.service.ScoreRisk(ctx, candidate.TenantID, candidate.CustomerID)
+	// Disabled or erased since it was listed.
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	result.Scored++
+	result.Tiers[score.Tier]++
+	if !score.sameAs(cur.Risk) {
+		result.Changed++
+	}
+	return nil
+}
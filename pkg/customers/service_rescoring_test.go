@@ -0,0 +1,220 @@
+package customers_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/stime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+const testRiskRules = `
+version: "%s"
+tiers:
+  - name: high
+    minScore: 50
+  - name: medium
+    minScore: 20
+  - name: low
+    minScore: 0
+rules:
+  - factor: identity_unverified
+    attribute: kyc_status
+    in: ["unverified"]
+    score: 10
+  - factor: young_customer
+    attribute: age
+    below: 21
+    score: %d
+  - factor: missing_address
+    attribute: country
+    in: [""]
+    score: 15
+  - factor: tenant_domestic
+    tenantID: "%s"
+    attribute: country
+    in: ["US"]
+    score: 25
+`
+
+type riskScope struct {
+	times      stime.StaticTimeService
+	repository customers.CustomerRepository
+	rulesPath  string
+	tenantID   string
+}
+
+func setupRisk(t *testing.T) riskScope {
+	s := riskScope{
+		times:      stime.NewStaticTimeService(),
+		repository: customers.NewInMemoryCustomerRepository(),
+		rulesPath:  filepath.Join(t.TempDir(), "risk-rules.yml"),
+		tenantID:   NewCustomer().TenantID,
+	}
+	s.publish(t, "v1", 30)
+	return s
+}
+
+// publish - Writes a version of the rules, with the score for young customers.
+func (s riskScope) publish(t *testing.T, version string, young int) {
+	rules := []byte(fmt.Sprintf(testRiskRules, version, young, s.tenantID))
+	require.NoError(t, os.WriteFile(s.rulesPath, rules, 0o600))
+}
+
+// service - A service with the rules as they are now, like one started after they were published.
+func (s riskScope) service(t *testing.T) customers.CustomerService {
+	config := customers.Config{Risk: customers.RiskConfig{RulesPath: s.rulesPath}}
+	service, err := customers.NewCustomerService(s.times, log.NewNopLogger(), config, s.repository)
+	require.NoError(t, err)
+	return service
+}
+
+func (s riskScope) create(t *testing.T, service customers.CustomerService, tenantID string) customers.Customer {
+	c := NewCustomer()
+	c.TenantID = tenantID
+	birthDate := s.times.Now().AddDate(-20, 0, 0).Format("2006/01/02")
+	c.BirthDate = &birthDate
+	created, err := service.Create(context.Background(), tenantID, c)
+	require.NoError(t, err)
+	return *created
+}
+
+func riskFactors(score *customers.RiskScore) []string {
+	names := []string{}
+	for _, f := range score.Factors {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+func Test_Risk_Scoring(t *testing.T) {
+	a := require.New(t)
+	s := setupRisk(t)
+	service := s.service(t)
+	ctx := context.Background()
+
+	created := s.create(t, service, s.tenantID)
+	a.NotNil(created.Risk)
+	a.Equal(55, created.Risk.Score)
+	a.Equal("high", created.Risk.Tier)
+	a.Equal("v1", created.Risk.RulesVersion)
+	a.Equal([]string{"identity_unverified", "young_customer", "missing_address"}, riskFactors(created.Risk))
+
+	found, err := service.Get(ctx, created.TenantID, created.CustomerID)
+	a.NoError(err)
+	a.Equal(created.Risk, found.Risk)
+
+	// The tenant's own factor counts once the customer has an address.
+	_, err = service.AddAddress(ctx, created.TenantID, created.CustomerID, customers.Address{
+		Type: customers.AddressResidential, Primary: true, Line1: "123 Main St", City: "Cedar Rapids", State: "IA", PostalCode: "52401",
+	})
+	a.NoError(err)
+	found, err = service.Get(ctx, created.TenantID, created.CustomerID)
+	a.NoError(err)
+	a.Equal(65, found.Risk.Score)
+	a.Equal([]string{"identity_unverified", "young_customer", "tenant_domestic"}, riskFactors(found.Risk))
+
+	found, err = service.TransitionKYC(ctx, created.TenantID, created.CustomerID, customers.KYCTransition{To: customers.KYCPending, Reason: "documents received"})
+	a.NoError(err)
+	a.Equal(55, found.Risk.Score)
+
+	// Other tenants' factors don't count.
+	other := s.create(t, service, NewCustomer().TenantID)
+	_, err = service.AddAddress(ctx, other.TenantID, other.CustomerID, customers.Address{
+		Type: customers.AddressResidential, Primary: true, Line1: "123 Main St", City: "Cedar Rapids", State: "IA", PostalCode: "52401",
+	})
+	a.NoError(err)
+	found, err = service.Get(ctx, other.TenantID, other.CustomerID)
+	a.NoError(err)
+	a.Equal(40, found.Risk.Score)
+	a.Equal("medium", found.Risk.Tier)
+
+	// A change that doesn't affect the score leaves it as it was.
+	s.times.Add(time.Minute)
+	update := found
+	update.Email = "jane.doe@moov.io"
+	updated, err := service.Update(ctx, other.TenantID, other.CustomerID, *update)
+	a.NoError(err)
+	a.Equal(found.Risk, updated.Risk)
+
+	// Disabled customers aren't scored.
+	a.NoError(service.Delete(ctx, other.TenantID, other.CustomerID))
+	_, err = service.ScoreRisk(ctx, other.TenantID, other.CustomerID)
+	a.Error(err)
+}
+
+func Test_Risk_Off(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+
+	service, err := customers.NewCustomerService(stime.NewStaticTimeService(), log.NewNopLogger(), customers.Config{}, customers.NewInMemoryCustomerRepository())
+	a.NoError(err)
+
+	c := NewCustomer()
+	created, err := service.Create(ctx, c.TenantID, c)
+	a.NoError(err)
+	a.Nil(created.Risk)
+
+	_, err = service.ScoreRisk(ctx, c.TenantID, created.CustomerID)
+	a.ErrorIs(err, customers.ErrRiskScoringOff)
+
+	_, err = customers.NewCustomerService(stime.NewStaticTimeService(), log.NewNopLogger(), customers.Config{
+		Risk: customers.RiskConfig{RulesPath: "testdata/missing.yml"},
+	}, customers.NewInMemoryCustomerRepository())
+	a.Error(err)
+}
+
+func Test_Risk_Rescoring(t *testing.T) {
+	a := require.New(t)
+	s := setupRisk(t)
+	ctx := context.Background()
+
+	service := s.service(t)
+	for i := 0; i < 3; i++ {
+		s.create(t, service, s.tenantID)
+	}
+	other := s.create(t, service, NewCustomer().TenantID)
+	disabled := s.create(t, service, s.tenantID)
+	a.NoError(service.Delete(ctx, disabled.TenantID, disabled.CustomerID))
+
+	// Nothing's changed.
+	result, err := customers.NewRiskRescorer(log.NewNopLogger(), service, s.repository, 2).Run(ctx, "")
+	a.NoError(err)
+	a.Equal(customers.RiskRescoring{Scored: 4, Tiers: map[string]int{"high": 4}}, *result)
+
+	// New rules apply to everyone once they're rescored.
+	s.publish(t, "v2", 0)
+	service = s.service(t)
+	rescorer := customers.NewRiskRescorer(log.NewNopLogger(), service, s.repository, 2)
+
+	result, err = rescorer.Run(ctx, s.tenantID)
+	a.NoError(err)
+	a.Equal(customers.RiskRescoring{Scored: 3, Changed: 3, Tiers: map[string]int{"medium": 3}}, *result)
+
+	found, err := service.Get(ctx, other.TenantID, other.CustomerID)
+	a.NoError(err)
+	a.Equal("v1", found.Risk.RulesVersion)
+
+	result, err = rescorer.Run(ctx, "")
+	a.NoError(err)
+	a.Equal(customers.RiskRescoring{Scored: 4, Changed: 1, Tiers: map[string]int{"medium": 4}}, *result)
+
+	found, err = service.Get(ctx, other.TenantID, other.CustomerID)
+	a.NoError(err)
+	a.Equal("v2", found.Risk.RulesVersion)
+	a.Equal(25, found.Risk.Score)
+	a.Equal([]string{"identity_unverified", "young_customer", "missing_address"}, riskFactors(found.Risk))
+
+	// Scoring has to be on.
+	off, err := customers.NewCustomerService(s.times, log.NewNopLogger(), customers.Config{}, s.repository)
+	a.NoError(err)
+	_, err = customers.NewRiskRescorer(log.NewNopLogger(), off, s.repository, 2).Run(ctx, "")
+	a.ErrorIs(err, customers.ErrRiskScoringOff)
+}
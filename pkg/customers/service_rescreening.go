@@ -0,0 +1,244 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	kitprom "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/google/uuid"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/stime"
+	stdprom "github.com/prometheus/client_golang/prometheus"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+var (
+	customersRescreened = kitprom.NewCounterFrom(stdprom.CounterOpts{
+		Name: "customers_rescreened",
+		Help: "Number of customers screened again by the rescreening job.",
+	}, nil)
+
+	customerRescreeningFailures = kitprom.NewCounterFrom(stdprom.CounterOpts{
+		Name: "customer_rescreening_failures",
+		Help: "Number of customers the rescreening job failed to screen.",
+	}, nil)
+
+	screeningAlertsRaised = kitprom.NewCounterFrom(stdprom.CounterOpts{
+		Name: "screening_alerts_raised",
+		Help: "Number of new sanctions matches found by the rescreening job.",
+	}, nil)
+)
+
+const (
+	defaultRescreeningInterval   = 15 * time.Minute
+	defaultRescreeningBatchSize  = 100
+	defaultRescreeningBatchDelay = time.Second
+)
+
+// RescreeningJob - Screens every active customer again when a new version of the sanctions lists is found, so the
+// existing book is checked against each update and not only customers as they're onboarded.
+type RescreeningJob struct {
+	time    stime.TimeService
+	logger  log.Logger
+	config  RescreeningConfig
+	service CustomerService
+	repo    CustomerRepository
+}
+
+// NewRescreeningJob - Customers are screened through service so its cache and logs apply to them.
+func NewRescreeningJob(time stime.TimeService, logger log.Logger, config Config, service CustomerService, repository CustomerRepository) *RescreeningJob {
+	rescreening := config.Screening.Rescreening
+	if rescreening.Interval <= 0 {
+		rescreening.Interval = defaultRescreeningInterval
+	}
+	if rescreening.BatchSize <= 0 {
+		rescreening.BatchSize = defaultRescreeningBatchSize
+	}
+	if rescreening.BatchDelay < 0 {
+		rescreening.BatchDelay = 0
+	} else if rescreening.BatchDelay == 0 {
+		rescreening.BatchDelay = defaultRescreeningBatchDelay
+	}
+
+	return &RescreeningJob{
+		time:    time,
+		logger:  logger,
+		config:  rescreening,
+		service: service,
+		repo:    repository,
+	}
+}
+
+// Start - Checks for a new version of the lists straight away and then every Interval until the returned func is
+// called, which waits for a run in progress to stop. A stopped run carries on where it left off next time.
+func (j *RescreeningJob) Start() func() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(j.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			// Errors are logged by Run, the next check tries again.
+			j.Run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// Run - Rescreens every active customer if the lists have changed since the last completed run, or finishes a run
+// that was interrupted. The run is nil when screening is off.
+func (j *RescreeningJob) Run(ctx context.Context) (run *RescreeningRun, err error) {
+	ctx, span := tracing.Start(ctx, "RescreeningJob.Run", "")
+	defer func() { tracing.End(span, err) }()
+
+	ctx = audit.WithRequest(ctx, audit.Request{ActorID: RescreeningActorID, Route: "RescreeningJob.Run"})
+
+	version, err := j.service.ReloadSanctionsLists(ctx)
+	if err != nil {
+		j.logger.LogErrorf("rescreening: loading sanctions lists: %v", err)
+		return nil, err
+	}
+	if version == "" {
+		return nil, nil
+	}
+
+	run, err = j.next(ctx, version)
+	if err != nil {
+		j.logger.LogErrorf("rescreening: starting run: %v", err)
+		return nil, err
+	}
+	if run.Status == RescreeningCompleted {
+		return run, nil
+	}
+
+	err = j.rescreen(ctx, run)
+
+	logger := j.logger.With(log.Fields{
+		"run_id":       log.String(run.RunID),
+		"list_version": log.String(run.ListVersion),
+		"screened":     log.Int(run.Screened),
+		"alerts":       log.Int(run.Alerts),
+		"failed":       log.Int(run.Failed),
+	})
+	if err != nil {
+		logger.LogErrorf("rescreening run stopped: %v", err)
+		return run, err
+	}
+	logger.Info().Log("Rescreening run finished")
+
+	return run, nil
+}
+
+// Latest - The most recent run, sql.ErrNoRows when there hasn't been one.
+func (j *RescreeningJob) Latest(ctx context.Context) (*RescreeningRun, error) {
+	return j.repo.LatestRescreeningRun(ctx)
+}
+
+// next - The run for the version: the last one if it's for the same version, otherwise a new one that supersedes
+// any run left unfinished.
+func (j *RescreeningJob) next(ctx context.Context, version string) (*RescreeningRun, error) {
+	last, err := j.repo.LatestRescreeningRun(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if last != nil && last.ListVersion == version {
+		return last, nil
+	}
+
+	now := j.time.Now()
+	if last != nil && last.Status == RescreeningRunning {
+		last.Status = RescreeningSuperseded
+		last.UpdatedOn = now
+		last.FinishedOn = &now
+		if err := j.repo.UpdateRescreeningRun(ctx, *last); err != nil {
+			return nil, err
+		}
+	}
+
+	run := &RescreeningRun{
+		RunID:       uuid.NewString(),
+		ListVersion: version,
+		Status:      RescreeningRunning,
+		StartedOn:   now,
+		UpdatedOn:   now,
+	}
+	if err := j.repo.AddRescreeningRun(ctx, *run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// rescreen - Works through the customers a batch at a time from where the run is up to, saving its progress after
+// each batch and pausing before the next.
+func (j *RescreeningJob) rescreen(ctx context.Context, run *RescreeningRun) error {
+	for {
+		candidates, err := j.repo.ListScreenable(ctx, ScreenableFilter{After: run.After, Limit: j.config.BatchSize})
+		if err != nil {
+			return err
+		}
+
+		for _, candidate := range candidates {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			alerts, err := j.service.Rescreen(ctx, candidate.TenantID, candidate.CustomerID, run.RunID)
+			if err != nil {
+				j.logger.With(log.Fields{
+					"tenant_id":   log.String(candidate.TenantID),
+					"customer_id": log.String(candidate.CustomerID),
+					"run_id":      log.String(run.RunID),
+				}).LogErrorf("rescreening customer: %v", err)
+
+				customerRescreeningFailures.Add(1)
+				run.Failed++
+			} else {
+				customersRescreened.Add(1)
+				screeningAlertsRaised.Add(float64(len(alerts)))
+				run.Screened++
+				run.Alerts += len(alerts)
+			}
+			run.After = candidate
+		}
+
+		run.UpdatedOn = j.time.Now()
+		done := len(candidates) < j.config.BatchSize
+		if done {
+			run.Status = RescreeningCompleted
+			run.FinishedOn = &run.UpdatedOn
+		}
+		if err := j.repo.UpdateRescreeningRun(ctx, *run); err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(j.config.BatchDelay):
+		}
+	}
+}
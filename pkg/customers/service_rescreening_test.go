@@ -0,0 +1,241 @@
+package customers_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/stime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/sanctions"
+)
+
+type rescreeningScope struct {
+	sdnPath    string
+	times      stime.StaticTimeService
+	service    customers.CustomerService
+	auditor    audit.Service
+	repository *stoppingRepository
+	job        *customers.RescreeningJob
+}
+
+// stoppingRepository - Calls stop once a run has saved its progress, as if the service was shut down part way
+// through it.
+type stoppingRepository struct {
+	customers.CustomerRepository
+	stop func()
+}
+
+func (r *stoppingRepository) UpdateRescreeningRun(ctx context.Context, run customers.RescreeningRun) error {
+	err := r.CustomerRepository.UpdateRescreeningRun(ctx, run)
+	if r.stop != nil && run.Status == customers.RescreeningRunning {
+		r.stop()
+	}
+	return err
+}
+
+// setupRescreening - Screens against a copy of the SDN test list that the test can publish new versions of.
+func setupRescreening(t *testing.T) rescreeningScope {
+	sdn, err := os.ReadFile("../sanctions/testdata/sdn.csv")
+	require.NoError(t, err)
+	sdnPath := filepath.Join(t.TempDir(), "sdn.csv")
+	require.NoError(t, os.WriteFile(sdnPath, sdn, 0o600))
+
+	config := customers.Config{Screening: customers.ScreeningConfig{
+		Lists: []sanctions.ListConfig{{Name: "SDN", Path: sdnPath}},
+		Rescreening: customers.RescreeningConfig{
+			BatchSize:  2,
+			BatchDelay: -1,
+		},
+	}}
+
+	times := stime.NewStaticTimeService()
+	repository := &stoppingRepository{CustomerRepository: customers.NewInMemoryCustomerRepository()}
+	next, err := customers.NewCustomerService(times, log.NewNopLogger(), config, repository.CustomerRepository)
+	require.NoError(t, err)
+	chainKey, err := audit.NewRandomChainKey()
+	require.NoError(t, err)
+	auditor := audit.NewService(times, audit.NewInMemoryRepository(chainKey), chainKey)
+	service := customers.NewAuditedCustomerService(log.NewNopLogger(), auditor, next)
+
+	job := customers.NewRescreeningJob(times, log.NewNopLogger(), config, service, repository)
+	return rescreeningScope{sdnPath: sdnPath, times: times, service: service, auditor: auditor, repository: repository, job: job}
+}
+
+// publish - Appends the lines to the SDN list, making a new version of it.
+func (s rescreeningScope) publish(t *testing.T, lines ...string) {
+	f, err := os.OpenFile(s.sdnPath, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	defer f.Close()
+	for _, line := range lines {
+		_, err := f.WriteString(line + "\n")
+		require.NoError(t, err)
+	}
+}
+
+// run - Runs the job a minute after the last run so they're in order.
+func (s rescreeningScope) run(t *testing.T) (*customers.RescreeningRun, error) {
+	s.times.Add(time.Minute)
+	return s.job.Run(context.Background())
+}
+
+// stopped - Runs the job until it's saved its first batch.
+func (s rescreeningScope) stopped(t *testing.T) *customers.RescreeningRun {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.repository.stop = cancel
+	defer func() { s.repository.stop = nil }()
+
+	s.times.Add(time.Minute)
+	run, err := s.job.Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, customers.RescreeningRunning, run.Status)
+	return run
+}
+
+func (s rescreeningScope) create(t *testing.T, name string) customers.Customer {
+	c := NewCustomer()
+	c.Name = name
+	created, err := s.service.Create(context.Background(), c.TenantID, c)
+	require.NoError(t, err)
+	require.Equal(t, customers.ScreeningClear, created.ScreeningStatus)
+	return *created
+}
+
+func Test_Rescreening_Run(t *testing.T) {
+	a := require.New(t)
+	s := setupRescreening(t)
+	ctx := context.Background()
+
+	_, err := s.job.Latest(ctx)
+	a.Error(err)
+
+	cleared := s.create(t, "Joe J Doe")
+	blocked := s.create(t, "Maria Ines Castillo")
+	flagged := s.create(t, "Anton Petrov")
+	disabled := s.create(t, "Olga Ivanova")
+	a.NoError(s.service.Delete(ctx, disabled.TenantID, disabled.CustomerID))
+
+	// The first run screens everyone against the lists they were created with.
+	first, err := s.run(t)
+	a.NoError(err)
+	a.Equal(customers.RescreeningCompleted, first.Status)
+	a.Equal(3, first.Screened)
+	a.Zero(first.Alerts)
+	a.Zero(first.Failed)
+	a.NotNil(first.FinishedOn)
+
+	// Nothing's changed so there's nothing to do.
+	again, err := s.run(t)
+	a.NoError(err)
+	a.Equal(first.RunID, again.RunID)
+
+	s.publish(t,
+		`900,"CASTILLO, Maria Ines","individual","SDGT","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- "`,
+		`901,"PETROV, Anton","individual","RUSSIA-EO14024","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","DOB 01 Jan 1960."`,
+		`902,"IVANOVA, Olga","individual","RUSSIA-EO14024","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- "`,
+	)
+
+	second, err := s.run(t)
+	a.NoError(err)
+	a.NotEqual(first.RunID, second.RunID)
+	a.Equal(customers.RescreeningCompleted, second.Status)
+	a.Equal(3, second.Screened)
+	a.Equal(2, second.Alerts)
+
+	latest, err := s.job.Latest(ctx)
+	a.NoError(err)
+	a.Equal(*second, *latest)
+
+	for _, tc := range []struct {
+		customer customers.Customer
+		status   customers.ScreeningStatus
+		entryID  string
+	}{
+		{customer: cleared, status: customers.ScreeningClear},
+		{customer: blocked, status: customers.ScreeningBlocked, entryID: "900"},
+		{customer: flagged, status: customers.ScreeningFlagged, entryID: "901"},
+	} {
+		found, err := s.service.Get(ctx, tc.customer.TenantID, tc.customer.CustomerID)
+		a.NoError(err)
+		a.Equal(tc.status, found.ScreeningStatus, tc.customer.Name)
+
+		alerts, err := s.service.ListScreeningAlerts(ctx, tc.customer.TenantID, customers.ScreeningAlertFilter{RunID: second.RunID})
+		a.NoError(err)
+		if tc.entryID == "" {
+			a.Empty(alerts)
+			continue
+		}
+		a.Len(alerts, 1)
+		a.Equal(tc.customer.CustomerID, alerts[0].CustomerID)
+		a.Equal(tc.status, alerts[0].Status)
+		a.Equal(tc.entryID, alerts[0].Match.EntryID)
+	}
+
+	// The same matches on the next version aren't raised again.
+	s.publish(t, `903,"ZAMORA, Hector","individual","SDGT","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- "`)
+	third, err := s.run(t)
+	a.NoError(err)
+	a.Equal(customers.RescreeningCompleted, third.Status)
+	a.Equal(3, third.Screened)
+	a.Zero(third.Alerts)
+}
+
+func Test_Rescreening_Audited(t *testing.T) {
+	a := require.New(t)
+	s := setupRescreening(t)
+	ctx := context.Background()
+
+	blocked := s.create(t, "Maria Ines Castillo")
+	_, err := s.run(t)
+	a.NoError(err)
+	s.publish(t, `900,"CASTILLO, Maria Ines","individual","SDGT","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- "`)
+	_, err = s.run(t)
+	a.NoError(err)
+
+	page, err := s.auditor.List(ctx, blocked.TenantID, blocked.CustomerID, "", 0)
+	a.NoError(err)
+	a.Len(page.Events, 3)
+
+	event := page.Events[2]
+	a.Equal(customers.RescreeningActorID, event.ActorID)
+	a.Equal("RescreeningJob.Run", event.Route)
+	a.Equal(audit.ActionUpdate, event.Action)
+	a.Equal([]string{"screeningStatus"}, event.Fields)
+}
+
+func Test_Rescreening_Resume(t *testing.T) {
+	a := require.New(t)
+	s := setupRescreening(t)
+
+	for i := 0; i < 3; i++ {
+		s.create(t, "Joe J Doe")
+	}
+
+	// A stopped run carries on from the last batch it saved.
+	interrupted := s.stopped(t)
+	a.Equal(2, interrupted.Screened)
+
+	resumed, err := s.run(t)
+	a.NoError(err)
+	a.Equal(interrupted.RunID, resumed.RunID)
+	a.Equal(customers.RescreeningCompleted, resumed.Status)
+	a.Equal(3, resumed.Screened)
+
+	// A new version supersedes a run that didn't finish.
+	s.publish(t, `900,"CASTILLO, Maria Ines","individual","SDGT","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- "`)
+	interrupted = s.stopped(t)
+	a.NotEqual(resumed.RunID, interrupted.RunID)
+
+	s.publish(t, `901,"PETROV, Anton","individual","RUSSIA-EO14024","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- "`)
+	latest, err := s.run(t)
+	a.NoError(err)
+	a.NotEqual(interrupted.RunID, latest.RunID)
+	a.Equal(customers.RescreeningCompleted, latest.Status)
+	a.Equal(3, latest.Screened)
+}
@@ -0,0 +1,224 @@
+package customers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	kitprom "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/stime"
+	stdprom "github.com/prometheus/client_golang/prometheus"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+var (
+	customersPurged = kitprom.NewCounterFrom(stdprom.CounterOpts{
+		Name: "customers_purged",
+		Help: "Number of customers whose personal data was purged by the retention job.",
+	}, nil)
+
+	customerPurgeFailures = kitprom.NewCounterFrom(stdprom.CounterOpts{
+		Name: "customer_purge_failures",
+		Help: "Number of customers the retention job failed to purge.",
+	}, nil)
+
+	customerPurgeCandidates = kitprom.NewGaugeFrom(stdprom.GaugeOpts{
+		Name: "customer_purge_dry_run_candidates",
+		Help: "Number of customers the last dry run of the retention job would have purged.",
+	}, nil)
+)
+
+const (
+	defaultPurgeInterval   = time.Hour
+	defaultPurgeBatchSize  = 100
+	defaultPurgeMaxBatches = 10
+)
+
+// RetentionJob - Applies the retention rules by erasing customers disabled for longer than their tenant's rule.
+type RetentionJob struct {
+	time    stime.TimeService
+	logger  log.Logger
+	config  RetentionConfig
+	service CustomerService
+	repo    CustomerRepository
+}
+
+// NewRetentionJob - Erasures go through service so its checks, cache and logs apply to them. Rules can't purge
+// customers sooner than the erasure retention period allows.
+func NewRetentionJob(time stime.TimeService, logger log.Logger, config Config, service CustomerService, repository CustomerRepository) (*RetentionJob, error) {
+	tenants := map[string]bool{}
+	for _, rule := range config.Retention.Rules {
+		if tenants[rule.TenantID] {
+			return nil, fmt.Errorf("retention: more than one rule for tenant %q", rule.TenantID)
+		}
+		tenants[rule.TenantID] = true
+
+		if rule.PurgeAfter < config.Erasure.RetentionPeriod {
+			return nil, fmt.Errorf("retention: rule for tenant %q purges after %v, before the retention period of %v",
+				rule.TenantID, rule.PurgeAfter, config.Erasure.RetentionPeriod)
+		}
+	}
+
+	retention := config.Retention
+	if retention.Interval <= 0 {
+		retention.Interval = defaultPurgeInterval
+	}
+	if retention.BatchSize <= 0 {
+		retention.BatchSize = defaultPurgeBatchSize
+	}
+	if retention.MaxBatches <= 0 {
+		retention.MaxBatches = defaultPurgeMaxBatches
+	}
+
+	return &RetentionJob{
+		time:    time,
+		logger:  logger,
+		config:  retention,
+		service: service,
+		repo:    repository,
+	}, nil
+}
+
+// Start - Runs the job every Interval until the returned func is called, which waits for a run in progress to stop.
+func (j *RetentionJob) Start() func() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(j.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Errors are logged by Run, the next run tries again.
+				j.Run(ctx, j.config.DryRun)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// Run - Purges the customers each rule covers, or only reports them when dryRun is set.
+func (j *RetentionJob) Run(ctx context.Context, dryRun bool) (report *PurgeReport, err error) {
+	ctx, span := tracing.Start(ctx, "RetentionJob.Run", "")
+	defer func() { tracing.End(span, err) }()
+
+	ctx = audit.WithRequest(ctx, audit.Request{ActorID: PurgeActorID, Route: "RetentionJob.Run"})
+
+	report = &PurgeReport{
+		DryRun:    dryRun,
+		StartedOn: j.time.Now(),
+		Purged:    []PurgeCandidate{},
+	}
+
+	for _, rule := range j.config.Rules {
+		if err = j.apply(ctx, rule, report); err != nil {
+			break
+		}
+	}
+	report.FinishedOn = j.time.Now()
+
+	if dryRun {
+		customerPurgeCandidates.Set(float64(len(report.Purged)))
+	}
+
+	logger := j.logger.With(log.Fields{
+		"dry_run": log.Bool(dryRun),
+		"purged":  log.Int(len(report.Purged)),
+		"failed":  log.Int(report.Failed),
+	})
+	if err != nil {
+		logger.LogErrorf("retention run stopped: %v", err)
+		return report, err
+	}
+	logger.Info().Log("Retention run finished")
+
+	return report, nil
+}
+
+// apply - Erases the rule's customers a batch at a time, a dry run just lists as many as a run would erase.
+func (j *RetentionJob) apply(ctx context.Context, rule RetentionRule, report *PurgeReport) error {
+	filter := PurgeFilter{
+		TenantID:       rule.TenantID,
+		DisabledBefore: report.StartedOn.Add(-rule.PurgeAfter),
+		Limit:          j.config.BatchSize,
+	}
+	if rule.TenantID == "" {
+		// The default rule leaves the tenants with their own rule to it.
+		for _, other := range j.config.Rules {
+			if other.TenantID != "" {
+				filter.ExcludeTenantIDs = append(filter.ExcludeTenantIDs, other.TenantID)
+			}
+		}
+	}
+
+	if report.DryRun {
+		filter.Limit = j.config.BatchSize * j.config.MaxBatches
+		candidates, err := j.repo.ListPurgeable(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, candidate := range candidates {
+			j.logger.Info().With(log.Fields{
+				"tenant_id":   log.String(candidate.TenantID),
+				"customer_id": log.String(candidate.CustomerID),
+				"disabled_on": log.Time(candidate.DisabledOn),
+			}).Log("Retention dry run would purge customer")
+		}
+		report.Purged = append(report.Purged, candidates...)
+		return nil
+	}
+
+	for batch := 0; batch < j.config.MaxBatches; batch++ {
+		candidates, err := j.repo.ListPurgeable(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		purged := 0
+		for _, candidate := range candidates {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			// The service logs each erasure.
+			_, err := j.service.Erase(ctx, candidate.TenantID, candidate.CustomerID, ErasureRequest{Reason: PurgeReason})
+			if err != nil {
+				// A hold placed since the customer was listed ends up here too.
+				j.logger.With(log.Fields{
+					"tenant_id":   log.String(candidate.TenantID),
+					"customer_id": log.String(candidate.CustomerID),
+				}).LogErrorf("purging customer: %v", err)
+
+				customerPurgeFailures.Add(1)
+				report.Failed++
+				continue
+			}
+
+			customersPurged.Add(1)
+			report.Purged = append(report.Purged, candidate)
+			purged++
+		}
+
+		// Stop once the rule's caught up, or when nothing could be purged so the same customers aren't retried.
+		if len(candidates) < filter.Limit || purged == 0 {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package customers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/stime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+)
+
+const testRetentionPeriod = 365 * 24 * time.Hour
+
+type retentionScope struct {
+	times      stime.StaticTimeService
+	service    customers.CustomerService
+	repository customers.CustomerRepository
+}
+
+func setupRetention(t *testing.T) retentionScope {
+	times := stime.NewStaticTimeService()
+	repository := customers.NewInMemoryCustomerRepository()
+
+	config := customers.Config{Erasure: customers.ErasureConfig{RetentionPeriod: testRetentionPeriod}}
+	service, err := customers.NewCustomerService(times, log.NewNopLogger(), config, repository)
+	require.NoError(t, err)
+
+	return retentionScope{times: times, service: service, repository: repository}
+}
+
+func (s retentionScope) job(t *testing.T, config customers.RetentionConfig) *customers.RetentionJob {
+	job, err := customers.NewRetentionJob(s.times, log.NewNopLogger(), customers.Config{
+		Erasure:   customers.ErasureConfig{RetentionPeriod: testRetentionPeriod},
+		Retention: config,
+	}, s.service, s.repository)
+	require.NoError(t, err)
+	return job
+}
+
+// disabled - Adds a customer for the tenant and disables it now.
+func (s retentionScope) disabled(t *testing.T, tenantID string) customers.Customer {
+	c := NewCustomer()
+	if tenantID != "" {
+		c.TenantID = tenantID
+	}
+
+	created, err := s.service.Create(context.Background(), c.TenantID, c)
+	require.NoError(t, err)
+	require.NoError(t, s.service.Delete(context.Background(), created.TenantID, created.CustomerID))
+	return *created
+}
+
+func Test_Retention_Purge(t *testing.T) {
+	a := require.New(t)
+	s := setupRetention(t)
+	ctx := context.Background()
+
+	strict := s.disabled(t, "")
+	lenient := s.disabled(t, "")
+	held := s.disabled(t, "")
+	_, err := s.service.PlaceLegalHold(ctx, held.TenantID, held.CustomerID, customers.LegalHold{Reason: "Litigation"})
+	a.NoError(err)
+
+	job := s.job(t, customers.RetentionConfig{Rules: []customers.RetentionRule{
+		{PurgeAfter: testRetentionPeriod},
+		{TenantID: lenient.TenantID, PurgeAfter: 2 * testRetentionPeriod},
+	}})
+
+	// Nothing is old enough yet.
+	report, err := job.Run(ctx, false)
+	a.NoError(err)
+	a.Empty(report.Purged)
+
+	s.times.Add(testRetentionPeriod + time.Hour)
+
+	// A dry run reports without purging.
+	report, err = job.Run(ctx, true)
+	a.NoError(err)
+	a.True(report.DryRun)
+	a.Len(report.Purged, 1)
+	a.Equal(strict.CustomerID, report.Purged[0].CustomerID)
+
+	found, err := s.service.Get(ctx, strict.TenantID, strict.CustomerID)
+	a.NoError(err)
+	a.Nil(found.ErasedOn)
+
+	report, err = job.Run(ctx, false)
+	a.NoError(err)
+	a.Len(report.Purged, 1)
+	a.Zero(report.Failed)
+
+	erasure, err := s.service.GetErasure(ctx, strict.TenantID, strict.CustomerID)
+	a.NoError(err)
+	a.Equal(customers.PurgeReason, erasure.Reason)
+
+	// The tenant's own rule keeps its customer longer, and held customers are never purged.
+	s.times.Add(testRetentionPeriod)
+	report, err = job.Run(ctx, false)
+	a.NoError(err)
+	a.Len(report.Purged, 1)
+	a.Equal(lenient.CustomerID, report.Purged[0].CustomerID)
+
+	found, err = s.service.Get(ctx, held.TenantID, held.CustomerID)
+	a.NoError(err)
+	a.Nil(found.ErasedOn)
+}
+
+func Test_Retention_Batches(t *testing.T) {
+	a := require.New(t)
+	s := setupRetention(t)
+	ctx := context.Background()
+
+	tenantID := s.disabled(t, "").TenantID
+	for i := 0; i < 4; i++ {
+		s.disabled(t, tenantID)
+	}
+	s.times.Add(testRetentionPeriod + time.Hour)
+
+	job := s.job(t, customers.RetentionConfig{
+		BatchSize:  2,
+		MaxBatches: 2,
+		Rules:      []customers.RetentionRule{{PurgeAfter: testRetentionPeriod}},
+	})
+
+	// A run stops after MaxBatches, the rest are picked up by the next one.
+	report, err := job.Run(ctx, false)
+	a.NoError(err)
+	a.Len(report.Purged, 4)
+
+	report, err = job.Run(ctx, false)
+	a.NoError(err)
+	a.Len(report.Purged, 1)
+}
+
+func Test_Retention_InvalidRules(t *testing.T) {
+	s := setupRetention(t)
+
+	config := customers.Config{
+		Erasure: customers.ErasureConfig{RetentionPeriod: testRetentionPeriod},
+		Retention: customers.RetentionConfig{Rules: []customers.RetentionRule{
+			{PurgeAfter: testRetentionPeriod / 2},
+		}},
+	}
+	_, err := customers.NewRetentionJob(s.times, log.NewNopLogger(), config, s.service, s.repository)
+	require.ErrorContains(t, err, "before the retention period")
+
+	config.Retention.Rules = []customers.RetentionRule{
+		{TenantID: "a", PurgeAfter: testRetentionPeriod},
+		{TenantID: "a", PurgeAfter: 2 * testRetentionPeriod},
+	}
+	_, err = customers.NewRetentionJob(s.times, log.NewNopLogger(), config, s.service, s.repository)
+	require.ErrorContains(t, err, "more than one rule")
+}
@@ -0,0 +1,267 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	kitprom "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/stime"
+	stdprom "github.com/prometheus/client_golang/prometheus"
+
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/identity"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+var (
+	identityVerificationsCompleted = kitprom.NewCounterFrom(stdprom.CounterOpts{
+		Name: "identity_verifications_completed",
+		Help: "Number of customer identity checks the verification worker completed, including those that errored.",
+	}, nil)
+
+	identityVerificationAttemptFailures = kitprom.NewCounterFrom(stdprom.CounterOpts{
+		Name: "identity_verification_attempt_failures",
+		Help: "Number of calls to the identity provider that failed or timed out.",
+	}, nil)
+)
+
+const (
+	defaultVerificationPollInterval = 5 * time.Second
+	defaultVerificationBatchSize    = 50
+	defaultVerificationTimeout      = 10 * time.Second
+	defaultVerificationMaxAttempts  = 5
+	defaultVerificationRetryDelay   = 30 * time.Second
+
+	// maxVerificationError - The length of last_error, provider errors can be long.
+	maxVerificationError = 255
+)
+
+// VerificationWorker - Sends the identity checks queued by the service to the identity provider, retrying those
+// that fail until MaxAttempts.
+type VerificationWorker struct {
+	time     stime.TimeService
+	logger   log.Logger
+	config   VerificationConfig
+	verifier identity.Verifier
+	service  CustomerService
+	repo     CustomerRepository
+}
+
+// NewVerificationWorker - Answers are recorded through service so its cache and logs apply to them. The worker has
+// nothing to do when there's no identity provider.
+func NewVerificationWorker(time stime.TimeService, logger log.Logger, config Config, service CustomerService, repository CustomerRepository) (*VerificationWorker, error) {
+	verifier, err := identity.New(config.Verification.Identity)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := config.Verification
+	if verification.PollInterval <= 0 {
+		verification.PollInterval = defaultVerificationPollInterval
+	}
+	if verification.BatchSize <= 0 {
+		verification.BatchSize = defaultVerificationBatchSize
+	}
+	if verification.Timeout <= 0 {
+		verification.Timeout = defaultVerificationTimeout
+	}
+	if verification.MaxAttempts <= 0 {
+		verification.MaxAttempts = defaultVerificationMaxAttempts
+	}
+	if verification.RetryDelay <= 0 {
+		verification.RetryDelay = defaultVerificationRetryDelay
+	}
+
+	return &VerificationWorker{
+		time:     time,
+		logger:   logger,
+		config:   verification,
+		verifier: verifier,
+		service:  service,
+		repo:     repository,
+	}, nil
+}
+
+// Start - Looks for checks due an attempt every PollInterval until the returned func is called, which waits for the
+// check in progress to stop. A stopped check is tried again once its attempt would have timed out.
+func (w *VerificationWorker) Start() func() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(w.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Errors are logged by Run, the next poll tries again.
+				w.Run(ctx)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// Run - Makes an attempt at up to BatchSize of the checks that are due, returning how many were attempted.
+func (w *VerificationWorker) Run(ctx context.Context) (attempted int, err error) {
+	ctx, span := tracing.Start(ctx, "VerificationWorker.Run", "")
+	defer func() { tracing.End(span, err) }()
+
+	if w.verifier == nil {
+		return 0, nil
+	}
+
+	ctx = audit.WithRequest(ctx, audit.Request{ActorID: VerificationActorID, Route: "VerificationWorker.Run"})
+
+	due, err := w.repo.ListDueVerifications(ctx, w.time.Now(), w.config.BatchSize)
+	if err != nil {
+		w.logger.LogErrorf("verification: listing due checks: %v", err)
+		return 0, err
+	}
+
+	for _, verification := range due {
+		// Checks claimed by another worker, or superseded while they're being made, aren't found when they're saved.
+		// There's nothing to do for them.
+		if err := w.attempt(ctx, verification); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			if ctx.Err() != nil {
+				return attempted, ctx.Err()
+			}
+			w.logger.With(log.Fields{
+				"tenant_id":       log.String(verification.TenantID),
+				"customer_id":     log.String(verification.CustomerID),
+				"verification_id": log.String(verification.VerificationID),
+			}).LogErrorf("verifying customer identity: %v", err)
+		}
+		attempted++
+	}
+
+	return attempted, nil
+}
+
+// attempt - Sends the check to the provider once and records what came of it.
+func (w *VerificationWorker) attempt(ctx context.Context, due Verification) error {
+	// Claimed by pushing the next attempt out past this one's timeout, so a check the service stopped in the middle
+	// of is tried again rather than left pending. Only one worker finds it as it was listed, the others skip it. The
+	// next attempt is kept to the microseconds the databases store so the claim still matches once it's saved.
+	v := due
+	v.Attempts++
+	v.NextAttemptOn = w.time.Now().UTC().Truncate(time.Microsecond).Add(w.config.Timeout + w.retryDelay(v.Attempts))
+	if err := w.repo.UpdateVerification(ctx, due, v); err != nil {
+		return err
+	}
+	claimed := v
+
+	request, err := w.request(ctx, v)
+	if err != nil {
+		return err
+	}
+	if request == nil {
+		v.Status = VerificationErrored
+		v.LastError = "customer is no longer active"
+		return w.complete(ctx, claimed, v)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, w.config.Timeout)
+	result, err := w.verifier.Verify(attemptCtx, *request)
+	cancel()
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		identityVerificationAttemptFailures.Add(1)
+
+		v.LastError = err.Error()
+		if len(v.LastError) > maxVerificationError {
+			v.LastError = v.LastError[:maxVerificationError]
+		}
+
+		retryable := errors.Is(err, identity.ErrUnavailable) || errors.Is(err, context.DeadlineExceeded)
+		if !retryable || v.Attempts >= w.config.MaxAttempts {
+			v.Status = VerificationErrored
+			return w.complete(ctx, claimed, v)
+		}
+
+		v.NextAttemptOn = w.time.Now().UTC().Truncate(time.Microsecond).Add(w.retryDelay(v.Attempts))
+		return w.repo.UpdateVerification(ctx, claimed, v)
+	}
+
+	switch result.Outcome {
+	case identity.OutcomeVerified:
+		v.Status = VerificationVerified
+	case identity.OutcomeFailed:
+		v.Status = VerificationFailed
+	default:
+		v.Status = VerificationReviewRequired
+	}
+	v.Reference = result.Reference
+	v.Reasons = result.Reasons
+	v.LastError = ""
+	return w.complete(ctx, claimed, v)
+}
+
+// request - What the provider checks, from the customer as it is now and its primary residential address. It's nil
+// when the customer has been disabled or erased since the check was queued.
+func (w *VerificationWorker) request(ctx context.Context, v Verification) (*identity.Request, error) {
+	c, err := w.service.Get(ctx, v.TenantID, v.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if c.DisabledOn != nil || c.ErasedOn != nil {
+		return nil, nil
+	}
+
+	request := &identity.Request{
+		Name:      c.Name,
+		SSN:       c.TaxID.value(),
+		BirthDate: valueOf(c.BirthDate),
+	}
+
+	addresses, err := w.service.ListAddresses(ctx, v.TenantID, v.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range addresses {
+		if a.Type == AddressResidential && a.Primary {
+			request.Address = &identity.Address{
+				Line1:      a.Line1,
+				Line2:      a.Line2,
+				City:       a.City,
+				State:      a.State,
+				PostalCode: a.PostalCode,
+				Country:    a.Country,
+			}
+		}
+	}
+
+	return request, nil
+}
+
+func (w *VerificationWorker) complete(ctx context.Context, claimed Verification, v Verification) error {
+	completedOn := w.time.Now()
+	v.CompletedOn = &completedOn
+	if _, err := w.service.CompleteVerification(ctx, claimed, v); err != nil {
+		return err
+	}
+
+	identityVerificationsCompleted.Add(1)
+	return nil
+}
+
+// retryDelay - How long to wait after the attempt before the next, doubling each time.
+func (w *VerificationWorker) retryDelay(attempts int) time.Duration {
+	return w.config.RetryDelay << (attempts - 1)
+}
@@ -0,0 +1,234 @@
+package customers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/stime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/identity"
+)
+
+type verificationScope struct {
+	times   stime.StaticTimeService
+	service customers.CustomerService
+	worker  *customers.VerificationWorker
+}
+
+func setupVerification(t *testing.T) verificationScope {
+	config := customers.Config{Verification: customers.VerificationConfig{
+		Identity:    identity.Config{Provider: identity.ProviderSimulator},
+		Timeout:     20 * time.Millisecond,
+		MaxAttempts: 2,
+		RetryDelay:  time.Minute,
+	}}
+
+	times := stime.NewStaticTimeService()
+	repository := customers.NewInMemoryCustomerRepository()
+	service, err := customers.NewCustomerService(times, log.NewNopLogger(), config, repository)
+	require.NoError(t, err)
+
+	worker, err := customers.NewVerificationWorker(times, log.NewNopLogger(), config, service, repository)
+	require.NoError(t, err)
+	return verificationScope{times: times, service: service, worker: worker}
+}
+
+func (s verificationScope) create(t *testing.T, ssn string) customers.Customer {
+	c := NewCustomer()
+	c.TaxID = &customers.TaxID{Type: customers.TaxIDSSN, Value: ssn}
+	created, err := s.service.Create(context.Background(), c.TenantID, c)
+	require.NoError(t, err)
+	require.Equal(t, customers.KYCPending, created.KYCStatus)
+	return *created
+}
+
+// run - Runs the worker once the retries of the last run are due.
+func (s verificationScope) run(t *testing.T) int {
+	attempted, err := s.worker.Run(context.Background())
+	require.NoError(t, err)
+	s.times.Add(time.Hour)
+	return attempted
+}
+
+func (s verificationScope) latest(t *testing.T, c customers.Customer) (customers.KYCStatus, customers.Verification) {
+	found, err := s.service.Get(context.Background(), c.TenantID, c.CustomerID)
+	require.NoError(t, err)
+	verifications, err := s.service.ListVerifications(context.Background(), c.TenantID, c.CustomerID)
+	require.NoError(t, err)
+	require.NotEmpty(t, verifications)
+	return found.KYCStatus, verifications[len(verifications)-1]
+}
+
+func Test_Verification_Outcomes(t *testing.T) {
+	s := setupVerification(t)
+
+	for _, tc := range []struct {
+		ssn      string
+		attempts int
+		status   customers.VerificationStatus
+		kyc      customers.KYCStatus
+		reasons  []string
+	}{
+		{ssn: "123-45-6789", attempts: 1, status: customers.VerificationVerified, kyc: customers.KYCVerified, reasons: []string{}},
+		{ssn: identity.SimulatorSSNFailed, attempts: 1, status: customers.VerificationFailed, kyc: customers.KYCFailed, reasons: []string{"ssn_name_mismatch"}},
+		{ssn: identity.SimulatorSSNReviewRequired, attempts: 1, status: customers.VerificationReviewRequired, kyc: customers.KYCReviewRequired, reasons: []string{"birth_date_mismatch"}},
+		{ssn: identity.SimulatorSSNUnavailableOnce, attempts: 2, status: customers.VerificationVerified, kyc: customers.KYCVerified, reasons: []string{}},
+		{ssn: identity.SimulatorSSNUnavailable, attempts: 2, status: customers.VerificationErrored, kyc: customers.KYCReviewRequired},
+		{ssn: identity.SimulatorSSNTimeout, attempts: 2, status: customers.VerificationErrored, kyc: customers.KYCReviewRequired},
+	} {
+		t.Run(tc.ssn, func(t *testing.T) {
+			a := require.New(t)
+			c := s.create(t, tc.ssn)
+			for i := 0; i < tc.attempts; i++ {
+				a.Equal(1, s.run(t))
+			}
+			a.Zero(s.run(t))
+
+			kyc, v := s.latest(t, c)
+			a.Equal(tc.kyc, kyc)
+			a.Equal(tc.status, v.Status)
+			a.Equal(tc.attempts, v.Attempts)
+			a.NotNil(v.CompletedOn)
+			if tc.status == customers.VerificationErrored {
+				a.NotEmpty(v.LastError)
+				a.Empty(v.Reference)
+				return
+			}
+			a.Equal(tc.reasons, v.Reasons)
+			a.NotEmpty(v.Reference)
+			a.Empty(v.LastError)
+		})
+	}
+}
+
+func Test_Verification_Retry(t *testing.T) {
+	a := require.New(t)
+	s := setupVerification(t)
+	ctx := context.Background()
+
+	c := s.create(t, identity.SimulatorSSNUnavailableOnce)
+	a.Equal(1, s.run(t))
+
+	// A failed attempt is retried once its delay has passed, the customer stays pending until then.
+	kyc, v := s.latest(t, c)
+	a.Equal(customers.KYCPending, kyc)
+	a.Equal(customers.VerificationPending, v.Status)
+	a.Equal(1, v.Attempts)
+	a.NotEmpty(v.LastError)
+	a.Equal(s.times.Now().Add(-time.Hour+time.Minute), v.NextAttemptOn)
+
+	// Changing the customer's identity supersedes the check that's waiting.
+	c.Name = "Joseph J Doe"
+	updated, err := s.service.Update(ctx, c.TenantID, c.CustomerID, c)
+	a.NoError(err)
+	a.Equal(customers.KYCPending, updated.KYCStatus)
+
+	verifications, err := s.service.ListVerifications(ctx, c.TenantID, c.CustomerID)
+	a.NoError(err)
+	a.Len(verifications, 2)
+	a.Equal(customers.VerificationSuperseded, verifications[0].Status)
+	a.Equal(customers.VerificationPending, verifications[1].Status)
+
+	a.Equal(1, s.run(t))
+	kyc, v = s.latest(t, c)
+	a.Equal(customers.KYCVerified, kyc)
+	a.Equal(customers.VerificationVerified, v.Status)
+	a.Equal(verifications[1].VerificationID, v.VerificationID)
+
+	// Checks can be asked for again, once there's an answer.
+	requested, err := s.service.VerifyIdentity(ctx, c.TenantID, c.CustomerID)
+	a.NoError(err)
+	a.Equal(customers.VerificationPending, requested.Status)
+	kyc, _ = s.latest(t, c)
+	a.Equal(customers.KYCPending, kyc)
+
+	// A customer disabled before the check is made errors it.
+	a.NoError(s.service.Delete(ctx, c.TenantID, c.CustomerID))
+	a.Equal(1, s.run(t))
+	verifications, err = s.service.ListVerifications(ctx, c.TenantID, c.CustomerID)
+	a.NoError(err)
+	a.Equal(customers.VerificationErrored, verifications[len(verifications)-1].Status)
+}
+
+// claimedElsewhereRepository - Another worker claims every check between this one listing and attempting it.
+type claimedElsewhereRepository struct {
+	customers.CustomerRepository
+}
+
+func (r claimedElsewhereRepository) ListDueVerifications(ctx context.Context, dueBy time.Time, limit int) ([]customers.Verification, error) {
+	due, err := r.CustomerRepository.ListDueVerifications(ctx, dueBy, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range due {
+		claimed := v
+		claimed.Attempts++
+		claimed.NextAttemptOn = dueBy.Add(time.Minute)
+		if err := r.CustomerRepository.UpdateVerification(ctx, v, claimed); err != nil {
+			return nil, err
+		}
+	}
+	return due, nil
+}
+
+func Test_Verification_ClaimedElsewhere(t *testing.T) {
+	a := require.New(t)
+
+	config := customers.Config{Verification: customers.VerificationConfig{
+		Identity:    identity.Config{Provider: identity.ProviderSimulator},
+		Timeout:     20 * time.Millisecond,
+		MaxAttempts: 2,
+		RetryDelay:  time.Minute,
+	}}
+	times := stime.NewStaticTimeService()
+	repository := customers.NewInMemoryCustomerRepository()
+	service, err := customers.NewCustomerService(times, log.NewNopLogger(), config, repository)
+	a.NoError(err)
+	worker, err := customers.NewVerificationWorker(times, log.NewNopLogger(), config, service, claimedElsewhereRepository{repository})
+	a.NoError(err)
+	s := verificationScope{times: times, service: service, worker: worker}
+
+	// The check is skipped rather than attempted twice, it's left to the worker that claimed it.
+	c := s.create(t, "123-45-6789")
+	a.Equal(1, s.run(t))
+
+	kyc, v := s.latest(t, c)
+	a.Equal(customers.KYCPending, kyc)
+	a.Equal(customers.VerificationPending, v.Status)
+	a.Equal(1, v.Attempts)
+	a.Empty(v.Reference)
+	a.Equal(times.Now().Add(-time.Hour+time.Minute), v.NextAttemptOn)
+}
+
+func Test_Verification_Off(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+
+	times := stime.NewStaticTimeService()
+	repository := customers.NewInMemoryCustomerRepository()
+	service, err := customers.NewCustomerService(times, log.NewNopLogger(), customers.Config{}, repository)
+	a.NoError(err)
+	worker, err := customers.NewVerificationWorker(times, log.NewNopLogger(), customers.Config{}, service, repository)
+	a.NoError(err)
+
+	c := NewCustomer()
+	created, err := service.Create(ctx, c.TenantID, c)
+	a.NoError(err)
+	a.Equal(customers.KYCUnverified, created.KYCStatus)
+
+	_, err = service.VerifyIdentity(ctx, c.TenantID, c.CustomerID)
+	a.ErrorIs(err, customers.ErrVerificationOff)
+
+	attempted, err := worker.Run(ctx)
+	a.NoError(err)
+	a.Zero(attempted)
+
+	_, err = customers.NewVerificationWorker(times, log.NewNopLogger(), customers.Config{Verification: customers.VerificationConfig{
+		Identity: identity.Config{Provider: "unknown"},
+	}}, service, repository)
+	a.Error(err)
+}
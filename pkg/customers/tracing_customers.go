@@ -0,0 +1,789 @@
+package customers
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+// tracedCustomerService - Wraps every CustomerService call in a span.
+type tracedCustomerService struct {
+	next CustomerService
+}
+
+func (s *tracedCustomerService) Create(ctx context.Context, tenantID string, create Customer) (result *Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.Create", tenantID)
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.Create(ctx, tenantID, create)
+	if result != nil {
+		span.SetAttributes(attribute.String("customer.id", result.CustomerID))
+	}
+	return result, err
+}
+
+func (s *tracedCustomerService) List(ctx context.Context, tenantID string, filter ListFilter) (result []Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.List", tenantID, attribute.String("customer.kyc_status", string(filter.KYCStatus)))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.List(ctx, tenantID, filter)
+	span.SetAttributes(attribute.Int("customer.count", len(result)))
+	return result, err
+}
+
+func (s *tracedCustomerService) Get(ctx context.Context, tenantID string, customerID string) (result *Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.Get", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Get(ctx, tenantID, customerID)
+}
+
+func (s *tracedCustomerService) Update(ctx context.Context, tenantID string, customerID string, update Customer) (result *Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.Update", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Update(ctx, tenantID, customerID, update)
+}
+
+func (s *tracedCustomerService) Delete(ctx context.Context, tenantID string, customerID string) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.Delete", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Delete(ctx, tenantID, customerID)
+}
+
+func (s *tracedCustomerService) Changes(ctx context.Context, tenantID string, cursor string, limit int) (result *CustomerChanges, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.Changes", tenantID)
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.Changes(ctx, tenantID, cursor, limit)
+	if result != nil {
+		span.SetAttributes(attribute.Int("customer.change_count", len(result.Changes)))
+	}
+	return result, err
+}
+
+func (s *tracedCustomerService) LatestChangesCursor(ctx context.Context, tenantID string) (result string, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.LatestChangesCursor", tenantID)
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.LatestChangesCursor(ctx, tenantID)
+}
+
+func (s *tracedCustomerService) PlaceLegalHold(ctx context.Context, tenantID string, customerID string, hold LegalHold) (result *Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.PlaceLegalHold", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.PlaceLegalHold(ctx, tenantID, customerID, hold)
+}
+
+func (s *tracedCustomerService) ReleaseLegalHold(ctx context.Context, tenantID string, customerID string) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ReleaseLegalHold", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ReleaseLegalHold(ctx, tenantID, customerID)
+}
+
+func (s *tracedCustomerService) Erase(ctx context.Context, tenantID string, customerID string, request ErasureRequest) (result *CustomerErasure, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.Erase", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Erase(ctx, tenantID, customerID, request)
+}
+
+func (s *tracedCustomerService) GetErasure(ctx context.Context, tenantID string, customerID string) (result *CustomerErasure, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.GetErasure", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.GetErasure(ctx, tenantID, customerID)
+}
+
+func (s *tracedCustomerService) AddAddress(ctx context.Context, tenantID string, customerID string, create Address) (result *Address, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.AddAddress", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.AddAddress(ctx, tenantID, customerID, create)
+	if result != nil {
+		span.SetAttributes(attribute.String("address.id", result.AddressID))
+	}
+	return result, err
+}
+
+func (s *tracedCustomerService) ListAddresses(ctx context.Context, tenantID string, customerID string) (result []Address, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListAddresses", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.ListAddresses(ctx, tenantID, customerID)
+	span.SetAttributes(attribute.Int("address.count", len(result)))
+	return result, err
+}
+
+func (s *tracedCustomerService) UpdateAddress(ctx context.Context, tenantID string, customerID string, addressID string, update Address) (result *Address, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.UpdateAddress", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("address.id", addressID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.UpdateAddress(ctx, tenantID, customerID, addressID, update)
+}
+
+func (s *tracedCustomerService) DeleteAddress(ctx context.Context, tenantID string, customerID string, addressID string) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.DeleteAddress", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("address.id", addressID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.DeleteAddress(ctx, tenantID, customerID, addressID)
+}
+
+func (s *tracedCustomerService) AddBeneficialOwner(ctx context.Context, tenantID string, customerID string, create BeneficialOwner) (result *BeneficialOwner, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.AddBeneficialOwner", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("owner.id", create.OwnerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.AddBeneficialOwner(ctx, tenantID, customerID, create)
+}
+
+func (s *tracedCustomerService) ListBeneficialOwners(ctx context.Context, tenantID string, customerID string) (result []BeneficialOwner, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListBeneficialOwners", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.ListBeneficialOwners(ctx, tenantID, customerID)
+	span.SetAttributes(attribute.Int("owner.count", len(result)))
+	return result, err
+}
+
+func (s *tracedCustomerService) UpdateBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string, update BeneficialOwner) (result *BeneficialOwner, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.UpdateBeneficialOwner", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("owner.id", ownerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.UpdateBeneficialOwner(ctx, tenantID, customerID, ownerID, update)
+}
+
+func (s *tracedCustomerService) DeleteBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.DeleteBeneficialOwner", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("owner.id", ownerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.DeleteBeneficialOwner(ctx, tenantID, customerID, ownerID)
+}
+
+func (s *tracedCustomerService) AddPhone(ctx context.Context, tenantID string, customerID string, create Phone) (result *Phone, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.AddPhone", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.AddPhone(ctx, tenantID, customerID, create)
+	if result != nil {
+		span.SetAttributes(attribute.String("phone.id", result.PhoneID))
+	}
+	return result, err
+}
+
+func (s *tracedCustomerService) ListPhones(ctx context.Context, tenantID string, customerID string) (result []Phone, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListPhones", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.ListPhones(ctx, tenantID, customerID)
+	span.SetAttributes(attribute.Int("phone.count", len(result)))
+	return result, err
+}
+
+func (s *tracedCustomerService) UpdatePhone(ctx context.Context, tenantID string, customerID string, phoneID string, update Phone) (result *Phone, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.UpdatePhone", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("phone.id", phoneID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.UpdatePhone(ctx, tenantID, customerID, phoneID, update)
+}
+
+func (s *tracedCustomerService) DeletePhone(ctx context.Context, tenantID string, customerID string, phoneID string) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.DeletePhone", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("phone.id", phoneID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.DeletePhone(ctx, tenantID, customerID, phoneID)
+}
+
+func (s *tracedCustomerService) VerifyPhone(ctx context.Context, tenantID string, customerID string, phoneID string) (result *Phone, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.VerifyPhone", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("phone.id", phoneID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.VerifyPhone(ctx, tenantID, customerID, phoneID)
+}
+
+func (s *tracedCustomerService) AddBankAccount(ctx context.Context, tenantID string, customerID string, create BankAccount) (result *BankAccount, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.AddBankAccount", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.AddBankAccount(ctx, tenantID, customerID, create)
+	if result != nil {
+		span.SetAttributes(attribute.String("bank_account.id", result.BankAccountID))
+	}
+	return result, err
+}
+
+func (s *tracedCustomerService) ListBankAccounts(ctx context.Context, tenantID string, customerID string) (result []BankAccount, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListBankAccounts", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.ListBankAccounts(ctx, tenantID, customerID)
+	span.SetAttributes(attribute.Int("bank_account.count", len(result)))
+	return result, err
+}
+
+func (s *tracedCustomerService) GetBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string) (result *BankAccount, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.GetBankAccount", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("bank_account.id", bankAccountID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.GetBankAccount(ctx, tenantID, customerID, bankAccountID)
+}
+
+func (s *tracedCustomerService) DeleteBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.DeleteBankAccount", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("bank_account.id", bankAccountID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.DeleteBankAccount(ctx, tenantID, customerID, bankAccountID)
+}
+
+func (s *tracedCustomerService) SendMicroDeposits(ctx context.Context, tenantID string, customerID string, bankAccountID string) (result *BankAccount, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.SendMicroDeposits", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("bank_account.id", bankAccountID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.SendMicroDeposits(ctx, tenantID, customerID, bankAccountID)
+}
+
+func (s *tracedCustomerService) ConfirmMicroDeposits(ctx context.Context, tenantID string, customerID string, bankAccountID string, confirmation MicroDepositConfirmation) (result *BankAccount, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ConfirmMicroDeposits", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("bank_account.id", bankAccountID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.ConfirmMicroDeposits(ctx, tenantID, customerID, bankAccountID, confirmation)
+	if result != nil {
+		span.SetAttributes(attribute.String("bank_account.status", string(result.Status)))
+	}
+	return result, err
+}
+
+func (s *tracedCustomerService) TransitionKYC(ctx context.Context, tenantID string, customerID string, transition KYCTransition) (result *Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.TransitionKYC", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("customer.kyc_status", string(transition.To)))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.TransitionKYC(ctx, tenantID, customerID, transition)
+}
+
+func (s *tracedCustomerService) ListKYCTransitions(ctx context.Context, tenantID string, customerID string) (result []KYCTransition, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListKYCTransitions", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListKYCTransitions(ctx, tenantID, customerID)
+}
+
+func (s *tracedCustomerService) AddDocument(ctx context.Context, tenantID string, customerID string, create Document, content io.Reader) (result *Document, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.AddDocument", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("document.type", string(create.Type)))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.AddDocument(ctx, tenantID, customerID, create, content)
+	if result != nil {
+		span.SetAttributes(attribute.String("document.id", result.DocumentID), attribute.Int64("document.size", result.Size))
+	}
+	return result, err
+}
+
+func (s *tracedCustomerService) ListDocuments(ctx context.Context, tenantID string, customerID string) (result []Document, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListDocuments", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListDocuments(ctx, tenantID, customerID)
+}
+
+func (s *tracedCustomerService) GetDocument(ctx context.Context, tenantID string, customerID string, documentID string) (result *Document, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.GetDocument", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("document.id", documentID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.GetDocument(ctx, tenantID, customerID, documentID)
+}
+
+// OpenDocument - The span only covers finding the document, not reading it.
+func (s *tracedCustomerService) OpenDocument(ctx context.Context, tenantID string, customerID string, documentID string) (result *Document, content io.ReadCloser, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.OpenDocument", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("document.id", documentID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.OpenDocument(ctx, tenantID, customerID, documentID)
+}
+
+func (s *tracedCustomerService) DeleteDocument(ctx context.Context, tenantID string, customerID string, documentID string) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.DeleteDocument", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("document.id", documentID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.DeleteDocument(ctx, tenantID, customerID, documentID)
+}
+
+func (s *tracedCustomerService) ListScreenings(ctx context.Context, tenantID string, customerID string) (result []Screening, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListScreenings", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListScreenings(ctx, tenantID, customerID)
+}
+
+func (s *tracedCustomerService) ReloadSanctionsLists(ctx context.Context) (result string, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ReloadSanctionsLists", "")
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.ReloadSanctionsLists(ctx)
+	span.SetAttributes(attribute.String("sanctions.list_version", result))
+	return result, err
+}
+
+func (s *tracedCustomerService) Rescreen(ctx context.Context, tenantID string, customerID string, runID string) (result []ScreeningAlert, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.Rescreen", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("rescreening.run_id", runID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.Rescreen(ctx, tenantID, customerID, runID)
+	span.SetAttributes(attribute.Int("alert.count", len(result)))
+	return result, err
+}
+
+func (s *tracedCustomerService) ListScreeningAlerts(ctx context.Context, tenantID string, filter ScreeningAlertFilter) (result []ScreeningAlert, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListScreeningAlerts", tenantID,
+		attribute.String("customer.id", filter.CustomerID), attribute.String("rescreening.run_id", filter.RunID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListScreeningAlerts(ctx, tenantID, filter)
+}
+
+func (s *tracedCustomerService) VerifyIdentity(ctx context.Context, tenantID string, customerID string) (result *Verification, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.VerifyIdentity", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.VerifyIdentity(ctx, tenantID, customerID)
+}
+
+func (s *tracedCustomerService) ListVerifications(ctx context.Context, tenantID string, customerID string) (result []Verification, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListVerifications", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListVerifications(ctx, tenantID, customerID)
+}
+
+func (s *tracedCustomerService) CompleteVerification(ctx context.Context, from Verification, verification Verification) (result *Verification, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.CompleteVerification", verification.TenantID,
+		attribute.String("customer.id", verification.CustomerID), attribute.String("verification.id", verification.VerificationID),
+		attribute.String("verification.status", string(verification.Status)))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.CompleteVerification(ctx, from, verification)
+}
+
+func (s *tracedCustomerService) ScoreRisk(ctx context.Context, tenantID string, customerID string) (result *RiskScore, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ScoreRisk", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = s.next.ScoreRisk(ctx, tenantID, customerID)
+	if result != nil {
+		span.SetAttributes(attribute.String("risk.tier", result.Tier))
+	}
+	return result, err
+}
+
+// tracedCustomerRepository - Wraps every CustomerRepository call in a span.
+type tracedCustomerRepository struct {
+	next CustomerRepository
+}
+
+func (r *tracedCustomerRepository) Add(ctx context.Context, create Customer) (result *Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.Add", create.TenantID, attribute.String("customer.id", create.CustomerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.Add(ctx, create)
+}
+
+func (r *tracedCustomerRepository) List(ctx context.Context, tenantID string, filter ListFilter) (result []Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.List", tenantID, attribute.String("customer.kyc_status", string(filter.KYCStatus)))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = r.next.List(ctx, tenantID, filter)
+	span.SetAttributes(attribute.Int("customer.count", len(result)))
+	return result, err
+}
+
+func (r *tracedCustomerRepository) Get(ctx context.Context, tenantID string, customerID string) (result *Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.Get", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.Get(ctx, tenantID, customerID)
+}
+
+func (r *tracedCustomerRepository) Update(ctx context.Context, update Customer) (result *Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.Update", update.TenantID, attribute.String("customer.id", update.CustomerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.Update(ctx, update)
+}
+
+func (r *tracedCustomerRepository) Delete(ctx context.Context, update Customer) (result *Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.Delete", update.TenantID, attribute.String("customer.id", update.CustomerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.Delete(ctx, update)
+}
+
+func (r *tracedCustomerRepository) ListChanges(ctx context.Context, tenantID string, after int64, limit int) (result []CustomerChange, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListChanges", tenantID, attribute.Int64("customer.change_after", after))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = r.next.ListChanges(ctx, tenantID, after, limit)
+	span.SetAttributes(attribute.Int("customer.change_count", len(result)))
+	return result, err
+}
+
+func (r *tracedCustomerRepository) LatestChange(ctx context.Context, tenantID string) (result int64, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.LatestChange", tenantID)
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.LatestChange(ctx, tenantID)
+}
+
+func (r *tracedCustomerRepository) SetLegalHold(ctx context.Context, tenantID string, customerID string, hold *LegalHold, updatedOn time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.SetLegalHold", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.SetLegalHold(ctx, tenantID, customerID, hold, updatedOn)
+}
+
+func (r *tracedCustomerRepository) Erase(ctx context.Context, erasure CustomerErasure) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.Erase", erasure.TenantID, attribute.String("customer.id", erasure.CustomerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.Erase(ctx, erasure)
+}
+
+func (r *tracedCustomerRepository) GetErasure(ctx context.Context, tenantID string, customerID string) (result *CustomerErasure, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.GetErasure", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.GetErasure(ctx, tenantID, customerID)
+}
+
+func (r *tracedCustomerRepository) ListPurgeable(ctx context.Context, filter PurgeFilter) (result []PurgeCandidate, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListPurgeable", filter.TenantID,
+		attribute.String("customer.disabled_before", filter.DisabledBefore.Format(time.RFC3339)))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = r.next.ListPurgeable(ctx, filter)
+	span.SetAttributes(attribute.Int("customer.purgeable_count", len(result)))
+	return result, err
+}
+
+func (r *tracedCustomerRepository) AddAddress(ctx context.Context, create Address) (result *Address, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.AddAddress", create.TenantID,
+		attribute.String("customer.id", create.CustomerID), attribute.String("address.id", create.AddressID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.AddAddress(ctx, create)
+}
+
+func (r *tracedCustomerRepository) ListAddresses(ctx context.Context, tenantID string, customerID string) (result []Address, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListAddresses", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = r.next.ListAddresses(ctx, tenantID, customerID)
+	span.SetAttributes(attribute.Int("address.count", len(result)))
+	return result, err
+}
+
+func (r *tracedCustomerRepository) UpdateAddress(ctx context.Context, update Address) (result *Address, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.UpdateAddress", update.TenantID,
+		attribute.String("customer.id", update.CustomerID), attribute.String("address.id", update.AddressID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.UpdateAddress(ctx, update)
+}
+
+func (r *tracedCustomerRepository) DeleteAddress(ctx context.Context, tenantID string, customerID string, addressID string, deletedOn time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.DeleteAddress", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("address.id", addressID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.DeleteAddress(ctx, tenantID, customerID, addressID, deletedOn)
+}
+
+func (r *tracedCustomerRepository) AddBeneficialOwner(ctx context.Context, create BeneficialOwner) (result *BeneficialOwner, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.AddBeneficialOwner", create.TenantID,
+		attribute.String("customer.id", create.CustomerID), attribute.String("owner.id", create.OwnerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.AddBeneficialOwner(ctx, create)
+}
+
+func (r *tracedCustomerRepository) ListBeneficialOwners(ctx context.Context, tenantID string, customerID string) (result []BeneficialOwner, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListBeneficialOwners", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = r.next.ListBeneficialOwners(ctx, tenantID, customerID)
+	span.SetAttributes(attribute.Int("owner.count", len(result)))
+	return result, err
+}
+
+func (r *tracedCustomerRepository) UpdateBeneficialOwner(ctx context.Context, update BeneficialOwner) (result *BeneficialOwner, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.UpdateBeneficialOwner", update.TenantID,
+		attribute.String("customer.id", update.CustomerID), attribute.String("owner.id", update.OwnerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.UpdateBeneficialOwner(ctx, update)
+}
+
+func (r *tracedCustomerRepository) DeleteBeneficialOwner(ctx context.Context, tenantID string, customerID string, ownerID string, deletedOn time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.DeleteBeneficialOwner", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("owner.id", ownerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.DeleteBeneficialOwner(ctx, tenantID, customerID, ownerID, deletedOn)
+}
+
+func (r *tracedCustomerRepository) AddPhone(ctx context.Context, create Phone) (result *Phone, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.AddPhone", create.TenantID,
+		attribute.String("customer.id", create.CustomerID), attribute.String("phone.id", create.PhoneID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.AddPhone(ctx, create)
+}
+
+func (r *tracedCustomerRepository) ListPhones(ctx context.Context, tenantID string, customerID string) (result []Phone, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListPhones", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = r.next.ListPhones(ctx, tenantID, customerID)
+	span.SetAttributes(attribute.Int("phone.count", len(result)))
+	return result, err
+}
+
+func (r *tracedCustomerRepository) UpdatePhone(ctx context.Context, update Phone) (result *Phone, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.UpdatePhone", update.TenantID,
+		attribute.String("customer.id", update.CustomerID), attribute.String("phone.id", update.PhoneID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.UpdatePhone(ctx, update)
+}
+
+func (r *tracedCustomerRepository) DeletePhone(ctx context.Context, tenantID string, customerID string, phoneID string, deletedOn time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.DeletePhone", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("phone.id", phoneID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.DeletePhone(ctx, tenantID, customerID, phoneID, deletedOn)
+}
+
+func (r *tracedCustomerRepository) AddBankAccount(ctx context.Context, create BankAccount) (result *BankAccount, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.AddBankAccount", create.TenantID,
+		attribute.String("customer.id", create.CustomerID), attribute.String("bank_account.id", create.BankAccountID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.AddBankAccount(ctx, create)
+}
+
+func (r *tracedCustomerRepository) ListBankAccounts(ctx context.Context, tenantID string, customerID string) (result []BankAccount, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListBankAccounts", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = r.next.ListBankAccounts(ctx, tenantID, customerID)
+	span.SetAttributes(attribute.Int("bank_account.count", len(result)))
+	return result, err
+}
+
+func (r *tracedCustomerRepository) UpdateBankAccount(ctx context.Context, from BankAccount, update BankAccount) (result *BankAccount, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.UpdateBankAccount", update.TenantID,
+		attribute.String("customer.id", update.CustomerID), attribute.String("bank_account.id", update.BankAccountID),
+		attribute.String("bank_account.status", string(update.Status)))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.UpdateBankAccount(ctx, from, update)
+}
+
+func (r *tracedCustomerRepository) DeleteBankAccount(ctx context.Context, tenantID string, customerID string, bankAccountID string, deletedOn time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.DeleteBankAccount", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("bank_account.id", bankAccountID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.DeleteBankAccount(ctx, tenantID, customerID, bankAccountID, deletedOn)
+}
+
+func (r *tracedCustomerRepository) BankAccountFailedAttempts(ctx context.Context, tenantID string, customerID string, routingNumber string, accountNumber string) (result int, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.BankAccountFailedAttempts", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = r.next.BankAccountFailedAttempts(ctx, tenantID, customerID, routingNumber, accountNumber)
+	span.SetAttributes(attribute.Int("bank_account.failed_attempts", result))
+	return result, err
+}
+
+func (r *tracedCustomerRepository) TransitionKYC(ctx context.Context, transition KYCTransition) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.TransitionKYC", transition.TenantID,
+		attribute.String("customer.id", transition.CustomerID), attribute.String("customer.kyc_status", string(transition.To)))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.TransitionKYC(ctx, transition)
+}
+
+func (r *tracedCustomerRepository) ListKYCTransitions(ctx context.Context, tenantID string, customerID string) (result []KYCTransition, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListKYCTransitions", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.ListKYCTransitions(ctx, tenantID, customerID)
+}
+
+func (r *tracedCustomerRepository) DataKey(ctx context.Context, tenantID string, customerID string, createdOn time.Time) (result *envelope.DataKey, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.DataKey", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.DataKey(ctx, tenantID, customerID, createdOn)
+}
+
+func (r *tracedCustomerRepository) AddDocument(ctx context.Context, create Document) (result *Document, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.AddDocument", create.TenantID,
+		attribute.String("customer.id", create.CustomerID), attribute.String("document.id", create.DocumentID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.AddDocument(ctx, create)
+}
+
+func (r *tracedCustomerRepository) ListDocuments(ctx context.Context, tenantID string, customerID string) (result []Document, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListDocuments", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = r.next.ListDocuments(ctx, tenantID, customerID)
+	span.SetAttributes(attribute.Int("document.count", len(result)))
+	return result, err
+}
+
+func (r *tracedCustomerRepository) DeleteDocument(ctx context.Context, tenantID string, customerID string, documentID string, deletedOn time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.DeleteDocument", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("document.id", documentID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.DeleteDocument(ctx, tenantID, customerID, documentID, deletedOn)
+}
+
+func (r *tracedCustomerRepository) AddScreening(ctx context.Context, create Screening) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.AddScreening", create.TenantID,
+		attribute.String("customer.id", create.CustomerID), attribute.String("screening.id", create.ScreeningID),
+		attribute.String("screening.status", string(create.Status)))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.AddScreening(ctx, create)
+}
+
+func (r *tracedCustomerRepository) ListScreenings(ctx context.Context, tenantID string, customerID string) (result []Screening, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListScreenings", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.ListScreenings(ctx, tenantID, customerID)
+}
+
+func (r *tracedCustomerRepository) SetScreeningStatus(ctx context.Context, tenantID string, customerID string, status ScreeningStatus, updatedOn time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.SetScreeningStatus", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("screening.status", string(status)))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.SetScreeningStatus(ctx, tenantID, customerID, status, updatedOn)
+}
+
+func (r *tracedCustomerRepository) SetRiskScore(ctx context.Context, tenantID string, customerID string, score RiskScore) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.SetRiskScore", tenantID,
+		attribute.String("customer.id", customerID), attribute.String("risk.tier", score.Tier))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.SetRiskScore(ctx, tenantID, customerID, score)
+}
+
+func (r *tracedCustomerRepository) ListScreenable(ctx context.Context, filter ScreenableFilter) (result []ScreeningCandidate, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListScreenable", "", attribute.Int("limit", filter.Limit))
+	defer func() { tracing.End(span, err) }()
+
+	result, err = r.next.ListScreenable(ctx, filter)
+	span.SetAttributes(attribute.Int("customer.count", len(result)))
+	return result, err
+}
+
+func (r *tracedCustomerRepository) AddScreeningAlerts(ctx context.Context, alerts []ScreeningAlert) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.AddScreeningAlerts", "", attribute.Int("alert.count", len(alerts)))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.AddScreeningAlerts(ctx, alerts)
+}
+
+func (r *tracedCustomerRepository) ListScreeningAlerts(ctx context.Context, tenantID string, filter ScreeningAlertFilter) (result []ScreeningAlert, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListScreeningAlerts", tenantID,
+		attribute.String("customer.id", filter.CustomerID), attribute.String("rescreening.run_id", filter.RunID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.ListScreeningAlerts(ctx, tenantID, filter)
+}
+
+func (r *tracedCustomerRepository) AddRescreeningRun(ctx context.Context, run RescreeningRun) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.AddRescreeningRun", "", attribute.String("rescreening.run_id", run.RunID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.AddRescreeningRun(ctx, run)
+}
+
+func (r *tracedCustomerRepository) UpdateRescreeningRun(ctx context.Context, run RescreeningRun) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.UpdateRescreeningRun", "",
+		attribute.String("rescreening.run_id", run.RunID), attribute.String("rescreening.status", string(run.Status)))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.UpdateRescreeningRun(ctx, run)
+}
+
+func (r *tracedCustomerRepository) LatestRescreeningRun(ctx context.Context) (result *RescreeningRun, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.LatestRescreeningRun", "")
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.LatestRescreeningRun(ctx)
+}
+
+func (r *tracedCustomerRepository) AddVerification(ctx context.Context, create Verification) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.AddVerification", create.TenantID,
+		attribute.String("customer.id", create.CustomerID), attribute.String("verification.id", create.VerificationID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.AddVerification(ctx, create)
+}
+
+func (r *tracedCustomerRepository) UpdateVerification(ctx context.Context, from Verification, update Verification) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.UpdateVerification", update.TenantID,
+		attribute.String("customer.id", update.CustomerID), attribute.String("verification.id", update.VerificationID),
+		attribute.String("verification.status", string(update.Status)))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.UpdateVerification(ctx, from, update)
+}
+
+func (r *tracedCustomerRepository) ListVerifications(ctx context.Context, tenantID string, customerID string) (result []Verification, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListVerifications", tenantID, attribute.String("customer.id", customerID))
+	defer func() { tracing.End(span, err) }()
+
+	return r.next.ListVerifications(ctx, tenantID, customerID)
+}
+
+func (r *tracedCustomerRepository) ListDueVerifications(ctx context.Context, dueBy time.Time, limit int) (result []Verification, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ListDueVerifications", "")
+	defer func() { tracing.End(span, err) }()
+
+	result, err = r.next.ListDueVerifications(ctx, dueBy, limit)
+	span.SetAttributes(attribute.Int("verification.count", len(result)))
+	return result, err
+}
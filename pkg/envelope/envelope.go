@@ -0,0 +1,186 @@
+// Package envelope implements envelope encryption: every record is encrypted with its own data key, and data keys
+// are stored wrapped by a single key encryption key. Deleting a record's data key makes its ciphertext unreadable,
+// including copies in backups, which is what lets us crypto-shred personal data.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	keySize = 32
+
+	wrappedPrefix   = "v1:"
+	encryptedPrefix = "enc:v1:"
+)
+
+// ErrDecrypt - The ciphertext was corrupt, or encrypted with a different key or associated data.
+var ErrDecrypt = errors.New("unable to decrypt")
+
+// ErrNoKeyEncryptionKey - No key encryption key was configured. There's no default, every deployment needs its own.
+var ErrNoKeyEncryptionKey = errors.New("no key encryption key configured")
+
+// KeyEncryptionKey - Wraps and unwraps data keys.
+type KeyEncryptionKey struct {
+	aead cipher.AEAD
+	// fingerprints keys the Fingerprint HMAC, derived from the key so it isn't the key itself.
+	fingerprints []byte
+}
+
+// NewKeyEncryptionKey - Loads a key encryption key from 32 base64 encoded bytes.
+func NewKeyEncryptionKey(encoded string) (*KeyEncryptionKey, error) {
+	if encoded == "" {
+		return nil, ErrNoKeyEncryptionKey
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding key encryption key: %w", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key encryption key must be %d bytes, got %d", keySize, len(key))
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyEncryptionKey{aead: aead, fingerprints: mac(key, "fingerprint")}, nil
+}
+
+// Fingerprint - A keyed hash of the value for finding it again without decrypting anything, the same for the same
+// value and key encryption key. Unlike ciphertext it outlives the data key, so it's deleted with the record.
+func (k *KeyEncryptionKey) Fingerprint(value string) string {
+	return hex.EncodeToString(mac(k.fingerprints, value))
+}
+
+func mac(key []byte, value string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(value))
+	return h.Sum(nil)
+}
+
+// NewDataKey - Generates a data key and returns it along with its wrapped form for storage. The associated data,
+// usually the ID of the record the key belongs to, has to be given again to unwrap it.
+func (k *KeyEncryptionKey) NewDataKey(associatedData string) (*DataKey, string, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, "", err
+	}
+
+	wrapped, err := seal(k.aead, key, associatedData)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dataKey, err := newDataKey(key)
+	if err != nil {
+		return nil, "", err
+	}
+	return dataKey, wrappedPrefix + wrapped, nil
+}
+
+// Unwrap - Recovers a data key from its wrapped form.
+func (k *KeyEncryptionKey) Unwrap(wrapped string, associatedData string) (*DataKey, error) {
+	if !strings.HasPrefix(wrapped, wrappedPrefix) {
+		return nil, ErrDecrypt
+	}
+
+	key, err := open(k.aead, strings.TrimPrefix(wrapped, wrappedPrefix), associatedData)
+	if err != nil {
+		return nil, err
+	}
+	return newDataKey(key)
+}
+
+// DataKey - Encrypts the fields of a single record.
+type DataKey struct {
+	aead cipher.AEAD
+}
+
+// GenerateDataKey - A data key that's never wrapped, for data that only lives as long as the process.
+func GenerateDataKey() (*DataKey, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return newDataKey(key)
+}
+
+func newDataKey(key []byte) (*DataKey, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &DataKey{aead: aead}, nil
+}
+
+// Encrypt - Encrypts a value, the associated data (usually the field name) stops ciphertext being moved between
+// fields without detection.
+func (d *DataKey) Encrypt(plaintext string, associatedData string) (string, error) {
+	sealed, err := seal(d.aead, []byte(plaintext), associatedData)
+	if err != nil {
+		return "", err
+	}
+	return encryptedPrefix + sealed, nil
+}
+
+// Decrypt - Reverses Encrypt.
+func (d *DataKey) Decrypt(ciphertext string, associatedData string) (string, error) {
+	if !IsEncrypted(ciphertext) {
+		return "", ErrDecrypt
+	}
+
+	plaintext, err := open(d.aead, strings.TrimPrefix(ciphertext, encryptedPrefix), associatedData)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// IsEncrypted - Tells values written by Encrypt apart from ones stored before encryption was turned on.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal - AES-GCM with a random nonce prepended to the ciphertext.
+func seal(aead cipher.AEAD, plaintext []byte, associatedData string) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(associatedData))
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func open(aead cipher.AEAD, encoded string, associatedData string) ([]byte, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(associatedData))
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,88 @@
+package envelope_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
+)
+
+func newKEK(t *testing.T) *envelope.KeyEncryptionKey {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	kek, err := envelope.NewKeyEncryptionKey(base64.StdEncoding.EncodeToString(key))
+	require.NoError(t, err)
+	return kek
+}
+
+func Test_Envelope_RoundTrip(t *testing.T) {
+	a := require.New(t)
+	kek := newKEK(t)
+
+	dataKey, wrapped, err := kek.NewDataKey("customer-1")
+	a.NoError(err)
+
+	ciphertext, err := dataKey.Encrypt("123-45-6789", "ssn")
+	a.NoError(err)
+	a.True(envelope.IsEncrypted(ciphertext))
+	a.NotContains(ciphertext, "6789")
+
+	unwrapped, err := kek.Unwrap(wrapped, "customer-1")
+	a.NoError(err)
+
+	plaintext, err := unwrapped.Decrypt(ciphertext, "ssn")
+	a.NoError(err)
+	a.Equal("123-45-6789", plaintext)
+}
+
+func Test_Envelope_AssociatedData(t *testing.T) {
+	a := require.New(t)
+	kek := newKEK(t)
+
+	dataKey, wrapped, err := kek.NewDataKey("customer-1")
+	a.NoError(err)
+
+	// A key can't be used for another record.
+	_, err = kek.Unwrap(wrapped, "customer-2")
+	a.ErrorIs(err, envelope.ErrDecrypt)
+
+	// Or a field's ciphertext moved to another field.
+	ciphertext, err := dataKey.Encrypt("jane@moov.io", "email")
+	a.NoError(err)
+	_, err = dataKey.Decrypt(ciphertext, "name")
+	a.ErrorIs(err, envelope.ErrDecrypt)
+
+	// Or unwrapped with a different key encryption key.
+	_, err = newKEK(t).Unwrap(wrapped, "customer-1")
+	a.ErrorIs(err, envelope.ErrDecrypt)
+}
+
+func Test_Envelope_Fingerprint(t *testing.T) {
+	a := require.New(t)
+	kek := newKEK(t)
+
+	fingerprint := kek.Fingerprint("000123456789")
+	a.Len(fingerprint, 64)
+	a.Equal(fingerprint, kek.Fingerprint("000123456789"))
+	a.NotEqual(fingerprint, kek.Fingerprint("000123456780"))
+	a.NotContains(fingerprint, "6789")
+
+	// Only with the same key.
+	a.NotEqual(fingerprint, newKEK(t).Fingerprint("000123456789"))
+}
+
+func Test_Envelope_InvalidKey(t *testing.T) {
+	_, err := envelope.NewKeyEncryptionKey("")
+	require.ErrorIs(t, err, envelope.ErrNoKeyEncryptionKey)
+
+	_, err = envelope.NewKeyEncryptionKey("not base64!")
+	require.Error(t, err)
+
+	_, err = envelope.NewKeyEncryptionKey(base64.StdEncoding.EncodeToString([]byte("too short")))
+	require.Error(t, err)
+}
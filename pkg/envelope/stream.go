@@ -0,0 +1,208 @@
+package envelope
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Files are too big to seal in one go, so streams are split into segments that are sealed on their own. Each
+// segment's nonce is a random prefix chosen per stream, the segment's number and a flag marking the last segment,
+// which stops segments being reordered, dropped or the stream being cut short without detection.
+const (
+	segmentSize = 64 * 1024
+
+	streamMagic       = "ENC1"
+	streamPrefixSize  = 7
+	streamCounterSize = 4
+)
+
+// ErrStreamTooLong - The stream has more segments than its nonces can count.
+var ErrStreamTooLong = errors.New("stream too long to encrypt")
+
+// EncryptStream - Returns a writer encrypting everything written to it onto dst, the stream isn't complete until it's
+// closed. The associated data has to be given again to decrypt it.
+func (d *DataKey) EncryptStream(dst io.Writer, associatedData string) (io.WriteCloser, error) {
+	prefix := make([]byte, streamPrefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(dst, streamMagic); err != nil {
+		return nil, err
+	}
+	if _, err := dst.Write(prefix); err != nil {
+		return nil, err
+	}
+
+	return &encryptingWriter{
+		d:              d,
+		dst:            dst,
+		prefix:         prefix,
+		associatedData: []byte(associatedData),
+		buf:            make([]byte, 0, segmentSize),
+	}, nil
+}
+
+type encryptingWriter struct {
+	d              *DataKey
+	dst            io.Writer
+	prefix         []byte
+	associatedData []byte
+	counter        uint32
+	buf            []byte
+	closed         bool
+}
+
+func (w *encryptingWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	written := 0
+	for len(p) > 0 {
+		// A full segment is only sealed once more data arrives, the last one has to be sealed by Close.
+		if len(w.buf) == segmentSize {
+			if err := w.seal(false); err != nil {
+				return written, err
+			}
+		}
+
+		n := copy(w.buf[len(w.buf):segmentSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close - Seals the last segment, it doesn't close dst.
+func (w *encryptingWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.seal(true)
+}
+
+func (w *encryptingWriter) seal(last bool) error {
+	nonce, err := streamNonce(w.prefix, w.counter, last)
+	if err != nil {
+		return err
+	}
+
+	sealed := w.d.aead.Seal(nil, nonce, w.buf, w.associatedData)
+	if _, err := w.dst.Write(sealed); err != nil {
+		return err
+	}
+
+	w.counter++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// DecryptStream - Reverses EncryptStream. Reads return ErrDecrypt if the stream was corrupted or cut short.
+func (d *DataKey) DecryptStream(src io.Reader, associatedData string) io.Reader {
+	return &decryptingReader{
+		d:              d,
+		src:            bufio.NewReaderSize(src, segmentSize+d.aead.Overhead()+1),
+		associatedData: []byte(associatedData),
+	}
+}
+
+type decryptingReader struct {
+	d              *DataKey
+	src            *bufio.Reader
+	associatedData []byte
+	prefix         []byte
+	counter        uint32
+	segment        []byte
+	plaintext      []byte
+	done           bool
+	err            error
+}
+
+func (r *decryptingReader) Read(p []byte) (int, error) {
+	for len(r.plaintext) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.err = r.next()
+	}
+
+	n := copy(p, r.plaintext)
+	r.plaintext = r.plaintext[n:]
+	return n, nil
+}
+
+// next - Opens the next segment into plaintext.
+func (r *decryptingReader) next() error {
+	if r.prefix == nil {
+		header := make([]byte, len(streamMagic)+streamPrefixSize)
+		if _, err := io.ReadFull(r.src, header); err != nil {
+			return unexpectedEnd(err)
+		}
+		if string(header[:len(streamMagic)]) != streamMagic {
+			return ErrDecrypt
+		}
+		r.prefix = header[len(streamMagic):]
+		r.segment = make([]byte, segmentSize+r.d.aead.Overhead())
+	}
+
+	n, err := io.ReadFull(r.src, r.segment)
+	last := false
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		last = true
+	case err != nil:
+		return err
+	default:
+		// A full segment is the last one when nothing follows it.
+		if _, err := r.src.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	nonce, err := streamNonce(r.prefix, r.counter, last)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := r.d.aead.Open(r.segment[:0], nonce, r.segment[:n], r.associatedData)
+	if err != nil {
+		return ErrDecrypt
+	}
+
+	r.counter++
+	r.plaintext = plaintext
+	r.done = last
+	return nil
+}
+
+func streamNonce(prefix []byte, counter uint32, last bool) ([]byte, error) {
+	if counter == ^uint32(0) {
+		return nil, ErrStreamTooLong
+	}
+
+	nonce := make([]byte, 0, streamPrefixSize+streamCounterSize+1)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if last {
+		return append(nonce, 1), nil
+	}
+	return append(nonce, 0), nil
+}
+
+func unexpectedEnd(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrDecrypt
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+package envelope_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
+)
+
+func encryptStream(t *testing.T, dataKey *envelope.DataKey, plaintext []byte, associatedData string) []byte {
+	var buf bytes.Buffer
+	w, err := dataKey.EncryptStream(&buf, associatedData)
+	require.NoError(t, err)
+	_, err = w.Write(plaintext)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func Test_Envelope_StreamRoundTrip(t *testing.T) {
+	kek := newKEK(t)
+	dataKey, _, err := kek.NewDataKey("customer-1")
+	require.NoError(t, err)
+
+	// Empty, within a segment, exactly a segment and spanning several.
+	for _, size := range []int{0, 10, 64 * 1024, 200*1024 + 7} {
+		plaintext := make([]byte, size)
+		_, err := rand.Read(plaintext)
+		require.NoError(t, err)
+
+		ciphertext := encryptStream(t, dataKey, plaintext, "document-1")
+		if size > 0 {
+			require.NotContains(t, string(ciphertext), string(plaintext))
+		}
+
+		decrypted, err := io.ReadAll(dataKey.DecryptStream(bytes.NewReader(ciphertext), "document-1"))
+		require.NoError(t, err, "size %d", size)
+		require.Equal(t, plaintext, decrypted, "size %d", size)
+	}
+}
+
+func Test_Envelope_StreamTampering(t *testing.T) {
+	a := require.New(t)
+	kek := newKEK(t)
+	dataKey, _, err := kek.NewDataKey("customer-1")
+	a.NoError(err)
+
+	plaintext := bytes.Repeat([]byte("passport"), 20*1024)
+	ciphertext := encryptStream(t, dataKey, plaintext, "document-1")
+
+	decrypt := func(ciphertext []byte, associatedData string) error {
+		_, err := io.ReadAll(dataKey.DecryptStream(bytes.NewReader(ciphertext), associatedData))
+		return err
+	}
+
+	// Moved to another document.
+	a.ErrorIs(decrypt(ciphertext, "document-2"), envelope.ErrDecrypt)
+
+	// Cut short at a segment boundary, including after the header.
+	a.ErrorIs(decrypt(ciphertext[:11+64*1024+16], "document-1"), envelope.ErrDecrypt)
+	a.ErrorIs(decrypt(ciphertext[:11], "document-1"), envelope.ErrDecrypt)
+
+	// A flipped bit.
+	corrupt := append([]byte(nil), ciphertext...)
+	corrupt[len(corrupt)-20] ^= 1
+	a.ErrorIs(decrypt(corrupt, "document-1"), envelope.ErrDecrypt)
+
+	// Not a stream at all.
+	a.ErrorIs(decrypt([]byte("plain"), "document-1"), envelope.ErrDecrypt)
+}
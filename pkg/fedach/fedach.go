@@ -0,0 +1,191 @@
+// Package fedach validates ABA routing numbers and looks them up in the Federal Reserve's FedACH participant
+// directory, loaded from a local copy of the file the Fed publishes
+// (https://www.frbservices.org/resources/routing-number-directory).
+package fedach
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// RecordFederalReserveBank - The participant is a Federal Reserve Bank.
+	RecordFederalReserveBank = "0"
+	// RecordCustomer - Items are sent to the participant's own routing number.
+	RecordCustomer = "1"
+	// RecordNewRoutingNumber - The routing number has been replaced, items are sent to NewRoutingNumber instead.
+	RecordNewRoutingNumber = "2"
+)
+
+var (
+	ErrRoutingNumberFormat   = errors.New("must be 9 digits")
+	ErrRoutingNumberChecksum = errors.New("must have a valid check digit")
+)
+
+// Participant - A financial institution that can receive ACH entries.
+type Participant struct {
+	RoutingNumber string
+	RecordType    string
+	// NewRoutingNumber is only set for RecordNewRoutingNumber.
+	NewRoutingNumber string
+	Name             string
+	City             string
+	State            string
+}
+
+// Directory - The participants by routing number.
+type Directory struct {
+	participants map[string]Participant
+}
+
+// Validate - Checks the routing number is 9 digits whose check digit, the last, matches the ABA checksum of the
+// first eight.
+func Validate(routingNumber string) error {
+	if len(routingNumber) != 9 {
+		return ErrRoutingNumberFormat
+	}
+
+	weights := []int{3, 7, 1}
+	sum := 0
+	for i, r := range routingNumber {
+		if r < '0' || r > '9' {
+			return ErrRoutingNumberFormat
+		}
+		sum += int(r-'0') * weights[i%3]
+	}
+	if sum%10 != 0 {
+		return ErrRoutingNumberChecksum
+	}
+	return nil
+}
+
+// Load - Reads the directory in the Fed's fixed width text format or its JSON format, going by the file extension.
+func Load(path string) (*Directory, error) {
+	var participants []Participant
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".txt":
+		participants, err = loadText(path)
+	case ".json":
+		participants, err = loadJSON(path)
+	default:
+		return nil, fmt.Errorf("unknown FedACH directory format %q", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading FedACH directory: %w", err)
+	}
+
+	d := &Directory{participants: make(map[string]Participant, len(participants))}
+	for _, p := range participants {
+		d.participants[p.RoutingNumber] = p
+	}
+	return d, nil
+}
+
+// Lookup - The participant with the routing number, not found when it isn't in the directory.
+func (d *Directory) Lookup(routingNumber string) (Participant, bool) {
+	p, found := d.participants[routingNumber]
+	return p, found
+}
+
+// Len - How many participants are in the directory.
+func (d *Directory) Len() int {
+	return len(d.participants)
+}
+
+// textLineLength - Every record of the text format is 155 characters, the last 5 are filler.
+const textLineLength = 155
+
+func loadText(path string) ([]Participant, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var participants []Participant
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		record := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		// Some copies have the trailing filler trimmed.
+		if len(record) < textLineLength-5 {
+			return nil, fmt.Errorf("line %d is %d characters, expected %d", line, len(record), textLineLength)
+		}
+
+		// Routing number 1-9, office code 10, servicing FRB number 11-19, record type 20, change date 21-26, new
+		// routing number 27-35, name 36-71, address 72-107, city 108-127, state 128-129, then the zip code and phone.
+		participants = append(participants, newParticipant(
+			record[0:9],
+			record[19:20],
+			record[26:35],
+			record[35:71],
+			record[107:127],
+			record[127:129],
+		))
+	}
+	return participants, scanner.Err()
+}
+
+type jsonDirectory struct {
+	FedACHParticipants struct {
+		Participants []jsonParticipant `json:"fedACHParticipants"`
+	} `json:"fedACHParticipants"`
+}
+
+type jsonParticipant struct {
+	RoutingNumber    string `json:"routingNumber"`
+	RecordTypeCode   string `json:"recordTypeCode"`
+	NewRoutingNumber string `json:"newRoutingNumber"`
+	CustomerName     string `json:"customerName"`
+	CustomerCity     string `json:"customerCity"`
+	CustomerState    string `json:"customerState"`
+}
+
+func loadJSON(path string) ([]Participant, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	parsed := jsonDirectory{}
+	if err := json.NewDecoder(f).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	participants := make([]Participant, 0, len(parsed.FedACHParticipants.Participants))
+	for _, p := range parsed.FedACHParticipants.Participants {
+		participants = append(participants, newParticipant(
+			p.RoutingNumber,
+			p.RecordTypeCode,
+			p.NewRoutingNumber,
+			p.CustomerName,
+			p.CustomerCity,
+			p.CustomerState,
+		))
+	}
+	return participants, nil
+}
+
+func newParticipant(routingNumber, recordType, newRoutingNumber, name, city, state string) Participant {
+	p := Participant{
+		RoutingNumber: strings.TrimSpace(routingNumber),
+		RecordType:    strings.TrimSpace(recordType),
+		Name:          strings.TrimSpace(name),
+		City:          strings.TrimSpace(city),
+		State:         strings.TrimSpace(state),
+	}
+	// Participants without a new routing number have zeros in its place.
+	if p.RecordType == RecordNewRoutingNumber {
+		p.NewRoutingNumber = strings.TrimSpace(newRoutingNumber)
+	}
+	return p
+}
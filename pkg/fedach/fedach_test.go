@@ -0,0 +1,88 @@
+package fedach_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/fedach"
+)
+
+func Test_FedACH_Validate(t *testing.T) {
+	a := require.New(t)
+
+	for _, routingNumber := range []string{"021000021", "121000248", "011000015", "026009593"} {
+		a.NoError(fedach.Validate(routingNumber), routingNumber)
+	}
+
+	a.Equal(fedach.ErrRoutingNumberChecksum, fedach.Validate("021000022"))
+	a.Equal(fedach.ErrRoutingNumberChecksum, fedach.Validate("123456789"))
+	a.Equal(fedach.ErrRoutingNumberFormat, fedach.Validate("02100002"))
+	a.Equal(fedach.ErrRoutingNumberFormat, fedach.Validate("0210000211"))
+	a.Equal(fedach.ErrRoutingNumberFormat, fedach.Validate("02100002A"))
+	a.Equal(fedach.ErrRoutingNumberFormat, fedach.Validate(""))
+}
+
+func Test_FedACH_LoadText(t *testing.T) {
+	a := require.New(t)
+
+	directory, err := fedach.Load("testdata/FedACHdir.txt")
+	a.NoError(err)
+	a.Equal(5, directory.Len())
+
+	p, found := directory.Lookup("021000021")
+	a.True(found)
+	a.Equal(fedach.Participant{
+		RoutingNumber: "021000021",
+		RecordType:    fedach.RecordCustomer,
+		Name:          "JPMORGAN CHASE BANK, NA",
+		City:          "TAMPA",
+		State:         "FL",
+	}, p)
+
+	p, found = directory.Lookup("011000015")
+	a.True(found)
+	a.Equal(fedach.RecordFederalReserveBank, p.RecordType)
+
+	p, found = directory.Lookup("231380104")
+	a.True(found)
+	a.Equal(fedach.RecordNewRoutingNumber, p.RecordType)
+	a.Equal("021000021", p.NewRoutingNumber)
+
+	_, found = directory.Lookup("026009593")
+	a.False(found)
+}
+
+func Test_FedACH_LoadJSON(t *testing.T) {
+	a := require.New(t)
+
+	directory, err := fedach.Load("testdata/fedachdir.json")
+	a.NoError(err)
+	a.Equal(2, directory.Len())
+
+	p, found := directory.Lookup("026009593")
+	a.True(found)
+	a.Equal(fedach.Participant{
+		RoutingNumber: "026009593",
+		RecordType:    fedach.RecordCustomer,
+		Name:          "BANK OF AMERICA, N.A.",
+		City:          "HENRICO",
+		State:         "VA",
+	}, p)
+}
+
+func Test_FedACH_LoadInvalid(t *testing.T) {
+	a := require.New(t)
+
+	_, err := fedach.Load("testdata/missing.txt")
+	a.Error(err)
+	_, err = fedach.Load("testdata/FedACHdir.csv")
+	a.Error(err)
+
+	short := filepath.Join(t.TempDir(), "FedACHdir.txt")
+	a.NoError(os.WriteFile(short, []byte("021000021O0210012081072811000000000JPMORGAN CHASE BANK, NA\n"), 0o600))
+	_, err = fedach.Load(short)
+	a.Error(err)
+}
@@ -0,0 +1,81 @@
+// Package identity verifies who a person says they are against an identity bureau, checking their name, SSN, birth
+// date and address as CIP requires. Bureaus are behind the Verifier interface so they can be swapped, the simulator
+// lets the whole flow run offline.
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	// ProviderSimulator - Decides the outcome from the SSN without calling anyone, for tests and local demos.
+	ProviderSimulator = "simulator"
+)
+
+// Outcome - The bureau's decision.
+type Outcome string
+
+const (
+	OutcomeVerified Outcome = "verified"
+	OutcomeFailed   Outcome = "failed"
+	// OutcomeReviewRequired - Some of the details matched but not enough to decide, a person has to look.
+	OutcomeReviewRequired Outcome = "review_required"
+)
+
+// ErrUnavailable - The bureau couldn't be reached or didn't answer, trying again later may work.
+var ErrUnavailable = errors.New("identity provider unavailable")
+
+// Verifier - An identity bureau. Verify returns ErrUnavailable, or the context's error, when it's worth trying again.
+type Verifier interface {
+	// Name - Identifies the provider in the results kept.
+	Name() string
+	Verify(ctx context.Context, request Request) (*Result, error)
+}
+
+// Request - The details to check. BirthDate is formatted YYYY/MM/DD like a customer's.
+type Request struct {
+	Name string
+	// SSN is the person's SSN, or their ITIN when they can't get one. Both are formatted NNN-NN-NNNN.
+	SSN       string
+	BirthDate string
+	Address   *Address
+}
+
+// Address - Where the person lives, nil when they haven't given one.
+type Address struct {
+	Line1      string
+	Line2      string
+	City       string
+	State      string
+	PostalCode string
+	Country    string
+}
+
+// Result - The bureau's decision and why.
+type Result struct {
+	Outcome Outcome
+	// Reference is the bureau's ID for the check, for following it up with them.
+	Reference string
+	// Reasons are the bureau's codes for the details that didn't match, empty when everything did.
+	Reasons []string
+}
+
+// Config - Picks and configures the Verifier.
+type Config struct {
+	// Provider is ProviderSimulator, or empty for no identity verification.
+	Provider string
+}
+
+// New - The Verifier described by config, nil when there's no provider.
+func New(config Config) (Verifier, error) {
+	switch config.Provider {
+	case "":
+		return nil, nil
+	case ProviderSimulator:
+		return NewSimulator(), nil
+	default:
+		return nil, fmt.Errorf("unknown identity provider %q", config.Provider)
+	}
+}
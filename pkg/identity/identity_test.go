@@ -0,0 +1,72 @@
+package identity_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/identity"
+)
+
+func Test_Identity_New(t *testing.T) {
+	verifier, err := identity.New(identity.Config{})
+	require.NoError(t, err)
+	require.Nil(t, verifier)
+
+	verifier, err = identity.New(identity.Config{Provider: identity.ProviderSimulator})
+	require.NoError(t, err)
+	require.Equal(t, identity.ProviderSimulator, verifier.Name())
+
+	_, err = identity.New(identity.Config{Provider: "bureau"})
+	require.Error(t, err)
+}
+
+func Test_Identity_Simulator(t *testing.T) {
+	ctx := context.Background()
+	request := func(ssn string) identity.Request {
+		return identity.Request{Name: "Joe J Doe", SSN: ssn, BirthDate: "1980/03/31"}
+	}
+
+	cases := []struct {
+		ssn     string
+		outcome identity.Outcome
+		reasons []string
+	}{
+		{ssn: "123-45-6789", outcome: identity.OutcomeVerified, reasons: []string{}},
+		{ssn: identity.SimulatorSSNFailed, outcome: identity.OutcomeFailed, reasons: []string{"ssn_name_mismatch"}},
+		{ssn: identity.SimulatorSSNReviewRequired, outcome: identity.OutcomeReviewRequired, reasons: []string{"birth_date_mismatch"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.ssn, func(t *testing.T) {
+			sim := identity.NewSimulator()
+			result, err := sim.Verify(ctx, request(tc.ssn))
+			require.NoError(t, err)
+			require.Equal(t, tc.outcome, result.Outcome)
+			require.Equal(t, tc.reasons, result.Reasons)
+
+			// The outcome and reference only depend on the request.
+			again, err := sim.Verify(ctx, request(tc.ssn))
+			require.NoError(t, err)
+			require.Equal(t, result, again)
+		})
+	}
+
+	sim := identity.NewSimulator()
+	for i := 0; i < 3; i++ {
+		_, err := sim.Verify(ctx, request(identity.SimulatorSSNUnavailable))
+		require.ErrorIs(t, err, identity.ErrUnavailable)
+	}
+
+	_, err := sim.Verify(ctx, request(identity.SimulatorSSNUnavailableOnce))
+	require.ErrorIs(t, err, identity.ErrUnavailable)
+	result, err := sim.Verify(ctx, request(identity.SimulatorSSNUnavailableOnce))
+	require.NoError(t, err)
+	require.Equal(t, identity.OutcomeVerified, result.Outcome)
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = sim.Verify(timeout, request(identity.SimulatorSSNTimeout))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,86 @@
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Magic SSNs - The simulator decides the outcome from an SSN's last four digits, any SSN not ending in one of these
+// is verified.
+const (
+	SimulatorSSNFailed         = "123-45-0001"
+	SimulatorSSNReviewRequired = "123-45-0002"
+	// SimulatorSSNUnavailable - The simulated bureau is never available.
+	SimulatorSSNUnavailable = "123-45-0003"
+	// SimulatorSSNUnavailableOnce - Unavailable the first time the SSN is checked and verified after that.
+	SimulatorSSNUnavailableOnce = "123-45-0004"
+	// SimulatorSSNTimeout - The simulated bureau never answers, Verify waits for the context to be done.
+	SimulatorSSNTimeout = "123-45-0005"
+)
+
+// Simulator - A Verifier that doesn't call anyone, the outcome is driven by magic SSNs so every path can be tested.
+type Simulator struct {
+	mu sync.Mutex
+	// checked counts the checks of each SSN for SimulatorSSNUnavailableOnce.
+	checked map[string]int
+}
+
+// NewSimulator - A simulator that hasn't checked anyone yet.
+func NewSimulator() *Simulator {
+	return &Simulator{checked: map[string]int{}}
+}
+
+func (s *Simulator) Name() string {
+	return ProviderSimulator
+}
+
+func (s *Simulator) Verify(ctx context.Context, request Request) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ssn := digits(request.SSN)
+	s.mu.Lock()
+	s.checked[ssn]++
+	checks := s.checked[ssn]
+	s.mu.Unlock()
+
+	result := &Result{Outcome: OutcomeVerified, Reference: simulatorReference(ssn, request), Reasons: []string{}}
+	switch {
+	case strings.HasSuffix(ssn, "0001"):
+		result.Outcome = OutcomeFailed
+		result.Reasons = []string{"ssn_name_mismatch"}
+	case strings.HasSuffix(ssn, "0002"):
+		result.Outcome = OutcomeReviewRequired
+		result.Reasons = []string{"birth_date_mismatch"}
+	case strings.HasSuffix(ssn, "0003"):
+		return nil, ErrUnavailable
+	case strings.HasSuffix(ssn, "0004"):
+		if checks == 1 {
+			return nil, ErrUnavailable
+		}
+	case strings.HasSuffix(ssn, "0005"):
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return result, nil
+}
+
+// simulatorReference - The same details always get the same reference.
+func simulatorReference(ssn string, request Request) string {
+	sum := sha256.Sum256([]byte(ssn + "|" + request.Name + "|" + request.BirthDate))
+	return "sim_" + hex.EncodeToString(sum[:8])
+}
+
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
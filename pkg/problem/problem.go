@@ -0,0 +1,44 @@
+// Package problem writes the RFC 7807 problem details body that every error response from the service uses.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const ContentType = "application/problem+json"
+
+// Details - RFC 7807 problem details, Errors holds a message per invalid field when a request fails validation.
+type Details struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Errors   map[string]string `json:"errors,omitempty"`
+}
+
+func (d Details) Error() string {
+	if d.Detail != "" {
+		return d.Title + ": " + d.Detail
+	}
+	return d.Title
+}
+
+// New - Problem for the status, Instance is set from the request's X-Request-ID.
+func New(r *http.Request, status int, detail string) Details {
+	return Details{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.Header.Get("X-Request-ID"),
+	}
+}
+
+// Write - Sends the problem as the response.
+func Write(w http.ResponseWriter, details Details) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(details.Status)
+	json.NewEncoder(w).Encode(details)
+}
@@ -0,0 +1,186 @@
+// Package risk scores customers with rules the compliance team maintains in a YAML file. Each rule that matches one of
+// the customer's attributes adds its score, and the total decides the customer's tier. The file carries a version
+// that's kept with every score so it's clear which rules produced it.
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Attributes - What the rules are evaluated against, by name. Missing attributes are empty.
+type Attributes map[string]string
+
+// Rules - A version of the rules file.
+type Rules struct {
+	Version string `yaml:"version"`
+	// Tiers are matched highest MinScore first, a score below all of them is in the lowest tier.
+	Tiers []Tier `yaml:"tiers"`
+	Rules []Rule `yaml:"rules"`
+}
+
+// Tier - A risk rating, e.g. low, medium or high.
+type Tier struct {
+	Name     string `yaml:"name"`
+	MinScore int    `yaml:"minScore"`
+}
+
+// Rule - Adds Score when the attribute matches. Rules with a TenantID are that tenant's own factors, they're only
+// evaluated for its customers.
+type Rule struct {
+	// Factor names the rule in scores, lowercase letters, digits and underscores.
+	Factor   string `yaml:"factor"`
+	TenantID string `yaml:"tenantID"`
+	// When limits the rule to customers whose attributes have one of the values listed for each, e.g. only
+	// individuals.
+	When      map[string][]string `yaml:"when"`
+	Attribute string              `yaml:"attribute"`
+	// In matches any of the values, an empty value matches a missing attribute.
+	In []string `yaml:"in"`
+	// Below and AtLeast compare numeric attributes, a rule with both matches the range between them. Attributes that
+	// are missing or aren't numbers don't match.
+	Below   *float64 `yaml:"below"`
+	AtLeast *float64 `yaml:"atLeast"`
+	Score   int      `yaml:"score"`
+}
+
+// Factor - A rule that matched and what it added to the score.
+type Factor struct {
+	Name  string
+	Score int
+}
+
+// Assessment - A customer's score under a version of the rules.
+type Assessment struct {
+	RulesVersion string
+	Score        int
+	Tier         string
+	// Factors are in the order of the rules that matched.
+	Factors []Factor
+}
+
+var factorName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// maxName - The longest version and tier names, they're stored with every score.
+const maxName = 40
+
+// Load - Reads and checks the rules file.
+func Load(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading risk rules: %w", err)
+	}
+
+	rules, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("loading risk rules %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// Parse - Checks the rules, a mistake in them is an error rather than a customer scored wrongly.
+func Parse(data []byte) (*Rules, error) {
+	rules := &Rules{}
+	if err := yaml.Unmarshal(data, rules); err != nil {
+		return nil, err
+	}
+
+	if rules.Version == "" || len(rules.Version) > maxName {
+		return nil, fmt.Errorf("version must be 1 to %d characters", maxName)
+	}
+
+	if len(rules.Tiers) == 0 {
+		return nil, errors.New("no tiers")
+	}
+	tiers := map[string]bool{}
+	for _, tier := range rules.Tiers {
+		if tier.Name == "" || len(tier.Name) > maxName || tiers[tier.Name] {
+			return nil, fmt.Errorf("tier names must be unique and 1 to %d characters, found %q", maxName, tier.Name)
+		}
+		tiers[tier.Name] = true
+	}
+	sort.SliceStable(rules.Tiers, func(i, j int) bool { return rules.Tiers[i].MinScore > rules.Tiers[j].MinScore })
+
+	for i, rule := range rules.Rules {
+		if !factorName.MatchString(rule.Factor) {
+			return nil, fmt.Errorf("rule %d: factor %q must be lowercase letters, digits and underscores", i+1, rule.Factor)
+		}
+		if rule.Attribute == "" {
+			return nil, fmt.Errorf("rule %s: missing attribute", rule.Factor)
+		}
+		for attribute, values := range rule.When {
+			if attribute == "" || len(values) == 0 {
+				return nil, fmt.Errorf("rule %s: when needs values for each attribute", rule.Factor)
+			}
+		}
+		numeric := rule.Below != nil || rule.AtLeast != nil
+		if numeric == (len(rule.In) > 0) {
+			return nil, fmt.Errorf("rule %s: needs either in, or below and atLeast", rule.Factor)
+		}
+	}
+
+	return rules, nil
+}
+
+// Assess - Scores the tenant's customer with the rules for every tenant and the tenant's own.
+func (r *Rules) Assess(tenantID string, attributes Attributes) Assessment {
+	assessment := Assessment{RulesVersion: r.Version, Factors: []Factor{}}
+	for _, rule := range r.Rules {
+		if rule.TenantID != "" && rule.TenantID != tenantID {
+			continue
+		}
+		if !rule.applies(attributes) {
+			continue
+		}
+		if !rule.matches(attributes[rule.Attribute]) {
+			continue
+		}
+		assessment.Score += rule.Score
+		assessment.Factors = append(assessment.Factors, Factor{Name: rule.Factor, Score: rule.Score})
+	}
+
+	assessment.Tier = r.Tiers[len(r.Tiers)-1].Name
+	for _, tier := range r.Tiers {
+		if assessment.Score >= tier.MinScore {
+			assessment.Tier = tier.Name
+			break
+		}
+	}
+
+	return assessment
+}
+
+// applies - If the attributes meet every condition in When.
+func (r Rule) applies(attributes Attributes) bool {
+	for attribute, values := range r.When {
+		if !slices.Contains(values, attributes[attribute]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Rule) matches(value string) bool {
+	if len(r.In) > 0 {
+		return slices.Contains(r.In, value)
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+	if r.Below != nil && n >= *r.Below {
+		return false
+	}
+	if r.AtLeast != nil && n < *r.AtLeast {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package risk_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/risk"
+)
+
+const testRules = `
+version: "v1"
+tiers:
+  - name: low
+    minScore: 0
+  - name: high
+    minScore: 50
+  - name: medium
+    minScore: 20
+rules:
+  - factor: flagged
+    attribute: screening_status
+    in: ["flagged", "blocked"]
+    score: 40
+  - factor: young
+    attribute: age
+    below: 21
+    score: 10
+  - factor: retired
+    attribute: age
+    atLeast: 65
+    below: 120
+    score: 5
+  - factor: no_address
+    attribute: country
+    in: [""]
+    score: 15
+  - factor: tenant_country
+    tenantID: "tenant-a"
+    attribute: country
+    in: ["CA"]
+    score: 30
+  - factor: verified
+    attribute: kyc_status
+    in: ["verified"]
+    score: -20
+  - factor: no_birth_date
+    when:
+      customer_type: ["individual"]
+    attribute: age
+    in: [""]
+    score: 25
+`
+
+func Test_Risk_Assess(t *testing.T) {
+	a := require.New(t)
+
+	rules, err := risk.Parse([]byte(testRules))
+	a.NoError(err)
+	a.Equal([]risk.Tier{{Name: "high", MinScore: 50}, {Name: "medium", MinScore: 20}, {Name: "low", MinScore: 0}}, rules.Tiers)
+
+	assessment := rules.Assess("tenant-b", risk.Attributes{"screening_status": "flagged", "age": "19"})
+	a.Equal(risk.Assessment{
+		RulesVersion: "v1",
+		Score:        65,
+		Tier:         "high",
+		Factors:      []risk.Factor{{Name: "flagged", Score: 40}, {Name: "young", Score: 10}, {Name: "no_address", Score: 15}},
+	}, assessment)
+
+	// Tenant factors only count for the tenant.
+	assessment = rules.Assess("tenant-b", risk.Attributes{"age": "70", "country": "CA"})
+	a.Equal(5, assessment.Score)
+	a.Equal("low", assessment.Tier)
+	assessment = rules.Assess("tenant-a", risk.Attributes{"age": "70", "country": "CA"})
+	a.Equal(35, assessment.Score)
+	a.Equal("medium", assessment.Tier)
+
+	// Scores below every tier are in the lowest one, and attributes that aren't numbers don't match numeric rules.
+	assessment = rules.Assess("tenant-b", risk.Attributes{"age": "unknown", "country": "US", "kyc_status": "verified"})
+	a.Equal(-20, assessment.Score)
+	a.Equal("low", assessment.Tier)
+	a.Equal([]risk.Factor{{Name: "verified", Score: -20}}, assessment.Factors)
+
+	assessment = rules.Assess("tenant-b", risk.Attributes{"country": "US"})
+	a.Zero(assessment.Score)
+	a.Empty(assessment.Factors)
+
+	// Rules with conditions only count for the customers that meet them.
+	assessment = rules.Assess("tenant-b", risk.Attributes{"country": "US", "customer_type": "individual"})
+	a.Equal([]risk.Factor{{Name: "no_birth_date", Score: 25}}, assessment.Factors)
+	assessment = rules.Assess("tenant-b", risk.Attributes{"country": "US", "customer_type": "business"})
+	a.Empty(assessment.Factors)
+}
+
+func Test_Risk_Parse(t *testing.T) {
+	for name, rules := range map[string]string{
+		"yaml":        "version: [",
+		"version":     "tiers: [{name: low}]",
+		"tiers":       "version: v1",
+		"tier name":   "version: v1\ntiers: [{name: low}, {name: low, minScore: 10}]",
+		"factor":      "version: v1\ntiers: [{name: low}]\nrules: [{factor: High Country, attribute: country, in: [IR]}]",
+		"attribute":   "version: v1\ntiers: [{name: low}]\nrules: [{factor: country, in: [IR]}]",
+		"condition":   "version: v1\ntiers: [{name: low}]\nrules: [{factor: country, attribute: country}]",
+		"both":        "version: v1\ntiers: [{name: low}]\nrules: [{factor: age, attribute: age, in: [''], below: 21}]",
+		"wrong types": "version: v1\ntiers: [{name: low, minScore: high}]",
+		"when":        "version: v1\ntiers: [{name: low}]\nrules: [{factor: age, when: {customer_type: []}, attribute: age, in: ['']}]",
+	} {
+		_, err := risk.Parse([]byte(rules))
+		require.Error(t, err, name)
+	}
+}
+
+func Test_Risk_Load(t *testing.T) {
+	a := require.New(t)
+
+	rules, err := risk.Load("../../configs/risk-rules.yml")
+	a.NoError(err)
+	a.NotEmpty(rules.Version)
+	a.Equal("high", rules.Assess("", risk.Attributes{"screening_status": "blocked"}).Tier)
+
+	_, err = risk.Load("testdata/missing.yml")
+	a.Error(err)
+}
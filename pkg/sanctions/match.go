@@ -0,0 +1,250 @@
+package sanctions
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Query - Who to screen. BirthDate is optional, formatted YYYY/MM/DD like a customer's.
+type Query struct {
+	Name      string
+	BirthDate string
+	// Type limits the entries compared to those of the type, TypeIndividual for people.
+	Type string
+}
+
+// Match - An entry whose name scored at least the minimum asked for.
+type Match struct {
+	List     string
+	EntryID  string
+	Name     string
+	Programs []string
+	// Score is from 0 to 1, 1 being the same name once normalized.
+	Score float64
+}
+
+// BirthDateMismatchPenalty - Scales a name's score when the entry has birth dates and none of them is the query's,
+// a different person with the same name is far more likely than a wrong birth date.
+const BirthDateMismatchPenalty = 0.9
+
+// Screener - Compares names against the loaded entries. It's read only so it's safe to share.
+type Screener struct {
+	entries []screenedEntry
+}
+
+type screenedEntry struct {
+	Entry
+	names      []string
+	sorted     []string
+	birthYears [][2]int
+	birthDates []string
+}
+
+// NewScreener - Prepares the entries for screening, normalizing their names up front.
+func NewScreener(entries []Entry) *Screener {
+	s := &Screener{entries: make([]screenedEntry, 0, len(entries))}
+	for _, e := range entries {
+		screened := screenedEntry{Entry: e}
+		for _, name := range append([]string{e.Name}, e.AltNames...) {
+			if normalized := Normalize(name); normalized != "" {
+				screened.names = append(screened.names, normalized)
+				screened.sorted = append(screened.sorted, sortTokens(normalized))
+			}
+		}
+		for _, dob := range e.BirthDates {
+			if date, years, ok := parseBirthDate(dob); ok {
+				screened.birthDates = append(screened.birthDates, date)
+				screened.birthYears = append(screened.birthYears, years)
+			}
+		}
+		s.entries = append(s.entries, screened)
+	}
+	return s
+}
+
+// Len - How many entries are loaded.
+func (s *Screener) Len() int {
+	return len(s.entries)
+}
+
+// Screen - The entries scoring at least minScore, best first.
+func (s *Screener) Screen(query Query, minScore float64) []Match {
+	name := Normalize(query.Name)
+	if name == "" {
+		return nil
+	}
+	sortedName := sortTokens(name)
+
+	var matches []Match
+	for _, e := range s.entries {
+		if e.Type != query.Type {
+			continue
+		}
+
+		best := 0.0
+		for i, candidate := range e.names {
+			// Comparing sorted tokens too catches names written in a different order.
+			score := max(JaroWinkler(name, candidate), JaroWinkler(sortedName, e.sorted[i]))
+			best = max(best, score)
+		}
+		if query.BirthDate != "" && len(e.birthDates) > 0 && !e.bornOn(query.BirthDate) {
+			best *= BirthDateMismatchPenalty
+		}
+
+		if best >= minScore {
+			matches = append(matches, Match{
+				List:     e.List,
+				EntryID:  e.ID,
+				Name:     e.Name,
+				Programs: e.Programs,
+				Score:    best,
+			})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches
+}
+
+// bornOn - If any of the entry's birth dates could be the YYYY/MM/DD date, dates given as only a year or a range of
+// years match any date within them.
+func (e screenedEntry) bornOn(birthDate string) bool {
+	year, err := strconv.Atoi(strings.SplitN(birthDate, "/", 2)[0])
+	if err != nil {
+		return true
+	}
+	for i, date := range e.birthDates {
+		if date != "" {
+			if date == birthDate {
+				return true
+			}
+			continue
+		}
+		if years := e.birthYears[i]; year >= years[0] && year <= years[1] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	fullBirthDate = regexp.MustCompile(`^(\d{1,2}) ([A-Za-z]{3}) (\d{4})$`)
+	birthYears    = regexp.MustCompile(`^(?:circa )?(\d{4})(?: to (\d{4}))?$`)
+
+	months = map[string]string{
+		"jan": "01", "feb": "02", "mar": "03", "apr": "04", "may": "05", "jun": "06",
+		"jul": "07", "aug": "08", "sep": "09", "oct": "10", "nov": "11", "dec": "12",
+	}
+)
+
+// parseBirthDate - Reads one of OFAC's birth dates as either a full YYYY/MM/DD date or a range of years.
+func parseBirthDate(dob string) (string, [2]int, bool) {
+	dob = strings.TrimSpace(dob)
+	if m := fullBirthDate.FindStringSubmatch(dob); m != nil {
+		if month, found := months[strings.ToLower(m[2])]; found {
+			day := m[1]
+			if len(day) == 1 {
+				day = "0" + day
+			}
+			return m[3] + "/" + month + "/" + day, [2]int{}, true
+		}
+	}
+	if m := birthYears.FindStringSubmatch(strings.ToLower(dob)); m != nil {
+		from, _ := strconv.Atoi(m[1])
+		to := from
+		if m[2] != "" {
+			to, _ = strconv.Atoi(m[2])
+		}
+		// circa is taken as give or take a year.
+		if strings.HasPrefix(strings.ToLower(dob), "circa") {
+			from, to = from-1, to+1
+		}
+		return "", [2]int{from, to}, true
+	}
+	return "", [2]int{}, false
+}
+
+var (
+	stripMarks = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	nonAlnum   = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+)
+
+// Normalize - Lower cases the name and removes accents and punctuation so only the letters are compared.
+func Normalize(name string) string {
+	stripped, _, err := transform.String(stripMarks, name)
+	if err != nil {
+		stripped = name
+	}
+	stripped = strings.ReplaceAll(stripped, "'", "")
+	return strings.TrimSpace(nonAlnum.ReplaceAllString(strings.ToLower(stripped), " "))
+}
+
+func sortTokens(name string) string {
+	tokens := strings.Fields(name)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// JaroWinkler - Similarity of two strings from 0 to 1, favouring strings with a common prefix.
+func JaroWinkler(a string, b string) float64 {
+	s1, s2 := []rune(a), []rune(b)
+	if len(s1) == 0 && len(s2) == 0 {
+		return 1
+	}
+	if len(s1) == 0 || len(s2) == 0 {
+		return 0
+	}
+
+	window := max(len(s1), len(s2))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matched1 := make([]bool, len(s1))
+	matched2 := make([]bool, len(s2))
+	matches := 0
+	for i := range s1 {
+		from, to := max(0, i-window), min(len(s2), i+window+1)
+		for j := from; j < to; j++ {
+			if !matched2[j] && s1[i] == s2[j] {
+				matched1[i], matched2[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range s1 {
+		if !matched1[i] {
+			continue
+		}
+		for !matched2[j] {
+			j++
+		}
+		if s1[i] != s2[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(s1)) + m/float64(len(s2)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(s1), len(s2)) && s1[prefix] == s2[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,258 @@
+// Package sanctions screens people against sanctions lists published by OFAC, the SDN list and the consolidated
+// non-SDN list, loaded from local copies of the files OFAC publishes
+// (https://ofac.treasury.gov/specially-designated-nationals-list-data-formats-data-schemas).
+package sanctions
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// TypeIndividual - OFAC's type for people, entries without a type are entities such as companies.
+	TypeIndividual = "individual"
+	TypeEntity     = ""
+	TypeVessel     = "vessel"
+	TypeAircraft   = "aircraft"
+)
+
+// ListConfig - A local copy of one of OFAC's lists, in its CSV or XML format going by the file extension.
+type ListConfig struct {
+	// Name identifies the list in matches, e.g. SDN or CONS.
+	Name string
+	Path string
+	// AltNamesPath is the list's alt.csv, only used with the CSV format where aliases are kept in a separate file.
+	AltNamesPath string
+}
+
+// Entry - Someone or something on a list.
+type Entry struct {
+	List string
+	// ID is OFAC's unique ID for the entry, ent_num in the CSV and uid in the XML.
+	ID       string
+	Type     string
+	Name     string
+	AltNames []string
+	Programs []string
+	// BirthDates are as OFAC writes them, e.g. "12 Mar 1965", "1965" or "circa 1965".
+	BirthDates []string
+}
+
+// Load - Reads every configured list.
+func Load(lists []ListConfig) ([]Entry, error) {
+	var entries []Entry
+	for _, list := range lists {
+		loaded, err := loadList(list)
+		if err != nil {
+			return nil, fmt.Errorf("loading sanctions list %s: %w", list.Name, err)
+		}
+		entries = append(entries, loaded...)
+	}
+	return entries, nil
+}
+
+// Version - A hash of the lists' names and files, it changes whenever OFAC publishes a new version of a list and the
+// local copy is replaced.
+func Version(lists []ListConfig) (string, error) {
+	h := sha256.New()
+	for _, list := range lists {
+		fmt.Fprintf(h, "%s\n", list.Name)
+		for _, path := range []string{list.Path, list.AltNamesPath} {
+			if path == "" {
+				continue
+			}
+			if err := hashFile(h, path); err != nil {
+				return "", fmt.Errorf("reading sanctions list %s: %w", list.Name, err)
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+func loadList(list ListConfig) ([]Entry, error) {
+	switch strings.ToLower(filepath.Ext(list.Path)) {
+	case ".csv":
+		return loadCSV(list)
+	case ".xml":
+		return loadXML(list)
+	default:
+		return nil, fmt.Errorf("unknown sanctions list format %q", list.Path)
+	}
+}
+
+// csvNull - OFAC's CSV files write empty fields as -0-.
+const csvNull = "-0-"
+
+// remarksBirthDate - Birth dates are only found in the remarks of the CSV format, e.g. "DOB 12 Mar 1965; alt. DOB 1966;".
+var remarksBirthDate = regexp.MustCompile(`DOB ([^;]+)`)
+
+func loadCSV(list ListConfig) ([]Entry, error) {
+	var entries []Entry
+	byID := map[string]int{}
+
+	// ent_num, SDN_Name, SDN_Type, Program, Title, Call_Sign, Vess_type, Tonnage, GRT, Vess_flag, Vess_owner, Remarks
+	err := readCSV(list.Path, func(record []string) {
+		if len(record) < 12 {
+			return
+		}
+		entry := Entry{
+			List: list.Name,
+			ID:   csvValue(record[0]),
+			Name: csvName(record[1]),
+			Type: strings.ToLower(csvValue(record[2])),
+		}
+		if programs := csvValue(record[3]); programs != "" {
+			entry.Programs = strings.Fields(strings.NewReplacer("[", "", "]", "").Replace(programs))
+		}
+		for _, dob := range remarksBirthDate.FindAllStringSubmatch(csvValue(record[11]), -1) {
+			// The last of the remarks ends the sentence.
+			entry.BirthDates = append(entry.BirthDates, strings.TrimSuffix(strings.TrimSpace(dob[1]), "."))
+		}
+		if entry.ID == "" || entry.Name == "" {
+			return
+		}
+
+		byID[entry.ID] = len(entries)
+		entries = append(entries, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if list.AltNamesPath == "" {
+		return entries, nil
+	}
+
+	// ent_num, alt_num, alt_type, alt_name, alt_remarks
+	err = readCSV(list.AltNamesPath, func(record []string) {
+		if len(record) < 4 {
+			return
+		}
+		i, found := byID[csvValue(record[0])]
+		if name := csvName(record[3]); found && name != "" {
+			entries[i].AltNames = append(entries[i].AltNames, name)
+		}
+	})
+	return entries, err
+}
+
+func readCSV(path string, each func(record []string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		each(record)
+	}
+}
+
+func csvValue(value string) string {
+	value = strings.TrimSpace(value)
+	if value == csvNull {
+		return ""
+	}
+	return value
+}
+
+// csvName - Individuals are written "LAST, First", put back in the order people write them.
+func csvName(value string) string {
+	value = csvValue(value)
+	if last, first, found := strings.Cut(value, ", "); found {
+		return strings.TrimSpace(first) + " " + strings.TrimSpace(last)
+	}
+	return value
+}
+
+type xmlList struct {
+	Entries []xmlEntry `xml:"sdnEntry"`
+}
+
+type xmlEntry struct {
+	UID        string   `xml:"uid"`
+	FirstName  string   `xml:"firstName"`
+	LastName   string   `xml:"lastName"`
+	Type       string   `xml:"sdnType"`
+	Programs   []string `xml:"programList>program"`
+	AKAs       []xmlAKA `xml:"akaList>aka"`
+	BirthDates []string `xml:"dateOfBirthList>dateOfBirthItem>dateOfBirth"`
+}
+
+type xmlAKA struct {
+	FirstName string `xml:"firstName"`
+	LastName  string `xml:"lastName"`
+}
+
+func loadXML(list ListConfig) ([]Entry, error) {
+	f, err := os.Open(list.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	parsed := xmlList{}
+	if err := xml.NewDecoder(f).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(parsed.Entries))
+	for _, e := range parsed.Entries {
+		entry := Entry{
+			List:       list.Name,
+			ID:         strings.TrimSpace(e.UID),
+			Type:       strings.ToLower(strings.TrimSpace(e.Type)),
+			Name:       xmlName(e.FirstName, e.LastName),
+			Programs:   e.Programs,
+			BirthDates: e.BirthDates,
+		}
+		// Entities only have a last name, which is the whole name.
+		if entry.Type == "entity" {
+			entry.Type = TypeEntity
+		}
+		for _, aka := range e.AKAs {
+			if name := xmlName(aka.FirstName, aka.LastName); name != "" {
+				entry.AltNames = append(entry.AltNames, name)
+			}
+		}
+		if entry.ID == "" || entry.Name == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func xmlName(first string, last string) string {
+	return strings.TrimSpace(strings.TrimSpace(first) + " " + strings.TrimSpace(last))
+}
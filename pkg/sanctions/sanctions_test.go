@@ -0,0 +1,135 @@
+package sanctions_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/sanctions"
+)
+
+var testLists = []sanctions.ListConfig{
+	{Name: "SDN", Path: "testdata/sdn.csv", AltNamesPath: "testdata/alt.csv"},
+	{Name: "CONS", Path: "testdata/consolidated.xml"},
+}
+
+func Test_Sanctions_Load(t *testing.T) {
+	a := require.New(t)
+
+	entries, err := sanctions.Load(testLists)
+	a.NoError(err)
+	a.Len(entries, 6)
+
+	a.Equal(sanctions.Entry{
+		List:       "SDN",
+		ID:         "36",
+		Type:       sanctions.TypeIndividual,
+		Name:       "Ricardo Andres MORALES VEGA",
+		AltNames:   []string{"Ricky VEGA"},
+		Programs:   []string{"SDNTK"},
+		BirthDates: []string{"14 Feb 1968", "1969"},
+	}, entries[0])
+	a.Equal([]string{"SDGT"}, entries[1].Programs)
+	a.Equal([]string{"circa 1975"}, entries[1].BirthDates)
+	a.Equal(sanctions.TypeEntity, entries[2].Type)
+	a.Equal(sanctions.TypeVessel, entries[3].Type)
+
+	a.Equal(sanctions.Entry{
+		List:       "CONS",
+		ID:         "17001",
+		Type:       sanctions.TypeIndividual,
+		Name:       "Elena Zhukovskaya",
+		AltNames:   []string{"Jelena Zukovska"},
+		Programs:   []string{"RUSSIA-EO14024"},
+		BirthDates: []string{"03 Sep 1971"},
+	}, entries[4])
+	a.Equal(sanctions.TypeEntity, entries[5].Type)
+	a.Equal("Baltic Meridian Shipping", entries[5].Name)
+
+	_, err = sanctions.Load([]sanctions.ListConfig{{Name: "SDN", Path: "testdata/missing.csv"}})
+	a.Error(err)
+	_, err = sanctions.Load([]sanctions.ListConfig{{Name: "SDN", Path: "testdata/sdn.json"}})
+	a.Error(err)
+}
+
+func Test_Sanctions_Version(t *testing.T) {
+	a := require.New(t)
+
+	version, err := sanctions.Version(testLists)
+	a.NoError(err)
+	a.Len(version, 64)
+
+	again, err := sanctions.Version(testLists)
+	a.NoError(err)
+	a.Equal(version, again)
+
+	// A new copy of a list is a new version.
+	sdn, err := os.ReadFile("testdata/sdn.csv")
+	a.NoError(err)
+	updated := filepath.Join(t.TempDir(), "sdn.csv")
+	a.NoError(os.WriteFile(updated, append(sdn, "999,\"DOE, John\",individual,[SDGT],-0-,-0-,-0-,-0-,-0-,-0-,-0-,-0-\n"...), 0o600))
+
+	changed, err := sanctions.Version([]sanctions.ListConfig{
+		{Name: "SDN", Path: updated, AltNamesPath: "testdata/alt.csv"},
+		{Name: "CONS", Path: "testdata/consolidated.xml"},
+	})
+	a.NoError(err)
+	a.NotEqual(version, changed)
+
+	_, err = sanctions.Version([]sanctions.ListConfig{{Name: "SDN", Path: "testdata/missing.csv"}})
+	a.Error(err)
+}
+
+func Test_Sanctions_Screen(t *testing.T) {
+	entries, err := sanctions.Load(testLists)
+	require.NoError(t, err)
+	screener := sanctions.NewScreener(entries)
+
+	cases := []struct {
+		name    string
+		query   sanctions.Query
+		entryID string
+		atLeast float64
+	}{
+		{name: "Exact", query: sanctions.Query{Name: "Ricardo Andres Morales Vega", Type: sanctions.TypeIndividual}, entryID: "36", atLeast: 1},
+		{name: "Accents and punctuation", query: sanctions.Query{Name: "Ricardo-Andrés  Morales Vega.", Type: sanctions.TypeIndividual}, entryID: "36", atLeast: 1},
+		{name: "Reordered", query: sanctions.Query{Name: "Morales Vega Ricardo Andres", Type: sanctions.TypeIndividual}, entryID: "36", atLeast: 1},
+		{name: "Typo", query: sanctions.Query{Name: "Ricardo Andres Moralez Vega", Type: sanctions.TypeIndividual}, entryID: "36", atLeast: 0.95},
+		{name: "Alias", query: sanctions.Query{Name: "Ricky Vega", Type: sanctions.TypeIndividual}, entryID: "36", atLeast: 1},
+		{name: "Birth date", query: sanctions.Query{Name: "Ricky Vega", BirthDate: "1968/02/14", Type: sanctions.TypeIndividual}, entryID: "36", atLeast: 1},
+		{name: "Birth year", query: sanctions.Query{Name: "Ricky Vega", BirthDate: "1969/07/01", Type: sanctions.TypeIndividual}, entryID: "36", atLeast: 1},
+		{name: "Circa", query: sanctions.Query{Name: "Chidi Okafor", BirthDate: "1976/01/01", Type: sanctions.TypeIndividual}, entryID: "173", atLeast: 1},
+		{name: "XML alias", query: sanctions.Query{Name: "Jelena Zukovska", Type: sanctions.TypeIndividual}, entryID: "17001", atLeast: 1},
+		{name: "Entity", query: sanctions.Query{Name: "Northwind Trading, L.L.C.", Type: sanctions.TypeEntity}, entryID: "306", atLeast: 0.95},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			matches := screener.Screen(tc.query, 0.8)
+			require.NotEmpty(t, matches)
+			require.Equal(t, tc.entryID, matches[0].EntryID)
+			require.GreaterOrEqual(t, matches[0].Score, tc.atLeast)
+		})
+	}
+
+	// A different birth date lowers the score.
+	matches := screener.Screen(sanctions.Query{Name: "Ricky Vega", BirthDate: "1990/01/01", Type: sanctions.TypeIndividual}, 0.8)
+	require.Len(t, matches, 1)
+	require.InDelta(t, sanctions.BirthDateMismatchPenalty, matches[0].Score, 0.0001)
+
+	// Individuals aren't compared with entities or vessels.
+	require.Empty(t, screener.Screen(sanctions.Query{Name: "Sea Heron", Type: sanctions.TypeIndividual}, 0.8))
+	require.Empty(t, screener.Screen(sanctions.Query{Name: "Jane Doe", Type: sanctions.TypeIndividual}, 0.8))
+	require.Empty(t, screener.Screen(sanctions.Query{Name: "!!!"}, 0))
+}
+
+func Test_Sanctions_JaroWinkler(t *testing.T) {
+	// The examples from Winkler's paper.
+	require.InDelta(t, 0.961, sanctions.JaroWinkler("martha", "marhta"), 0.001)
+	require.InDelta(t, 0.840, sanctions.JaroWinkler("dwayne", "duane"), 0.001)
+	require.InDelta(t, 0.813, sanctions.JaroWinkler("dixon", "dicksonx"), 0.001)
+	require.Equal(t, 1.0, sanctions.JaroWinkler("", ""))
+	require.Equal(t, 0.0, sanctions.JaroWinkler("abc", ""))
+	require.Equal(t, 0.0, sanctions.JaroWinkler("abc", "xyz"))
+}
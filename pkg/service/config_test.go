@@ -1,7 +1,10 @@
 package service_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/moov-io/base/config"
 	"github.com/moov-io/base/log"
@@ -18,4 +21,34 @@ func Test_ConfigLoading(t *testing.T) {
 	gc := &service.GlobalConfig{}
 	err := ConfigService.Load(gc)
 	require.Nil(t, err)
+
+	// Nothing is purged until a deployment asks for it.
+	retention := gc.BackendHiring.Customers.Retention
+	require.False(t, retention.Enabled)
+	require.True(t, retention.DryRun)
+	require.Empty(t, retention.Rules)
+}
+
+func Test_ConfigLoading_RetentionRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.Nil(t, os.WriteFile(path, []byte(`
+BackendHiring:
+  Customers:
+    Retention:
+      Rules:
+        - TenantID: "tenant"
+          PurgeAfter: 43800h
+`), 0600))
+	t.Setenv("APP_CONFIG", path)
+
+	ConfigService := config.NewService(log.NewNopLogger())
+
+	gc := &service.GlobalConfig{}
+	require.Nil(t, ConfigService.Load(gc))
+
+	// Rules are a list of structs, make sure their durations are decoded.
+	rules := gc.BackendHiring.Customers.Retention.Rules
+	require.Len(t, rules, 1)
+	require.Equal(t, "tenant", rules[0].TenantID)
+	require.Equal(t, 43800*time.Hour, rules[0].PurgeAfter)
 }
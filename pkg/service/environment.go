@@ -3,15 +3,25 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
 	"github.com/moov-io/base/config"
-	"github.com/moov-io/base/database"
 	"github.com/moov-io/base/log"
 	"github.com/moov-io/base/stime"
-
-	_ "github.com/moovfinancial/backendhiring"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/moovfinancial/backendhiring"
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
 )
 
 // Environment - Contains everything thats been instantiated for this service.
@@ -20,9 +30,22 @@ type Environment struct {
 	Config              *Config
 	TimeService         stime.TimeService
 	ZeroTrustMiddleware mux.MiddlewareFunc
-	DB                  *sql.DB
+	// ZeroTrustInterceptor - Authenticates gRPC calls from their metadata, the counterpart of ZeroTrustMiddleware.
+	ZeroTrustInterceptor grpc.UnaryServerInterceptor
+	DB                   *sql.DB
+
+	CustomerRepository customers.CustomerRepository
+	CustomerService    customers.CustomerService
+	AuditRepository    audit.Repository
+	AuditService       audit.Service
+	AuditRelayJob      *audit.RelayJob
+	RetentionJob       *customers.RetentionJob
+	RescreeningJob     *customers.RescreeningJob
+	VerificationWorker *customers.VerificationWorker
 
 	PublicRouter *mux.Router
+	GRPCServer   *grpc.Server
+	GRPCHealth   *health.Server
 	Shutdown     func()
 }
 
@@ -47,9 +70,21 @@ func NewEnvironment(env *Environment) (*Environment, error) {
 		env.Config = cfg
 	}
 
-	// db setup
-	if env.DB == nil {
-		db, err := database.NewAndMigrate(context.Background(), env.Logger, env.Config.Database)
+	// tracing setup
+	shutdownTracing, err := tracing.Setup(context.Background(), env.Logger, env.Config.Tracing)
+	if err != nil {
+		return nil, err
+	}
+
+	prevShutdown := env.Shutdown
+	env.Shutdown = func() {
+		prevShutdown()
+		shutdownTracing()
+	}
+
+	// db setup, the in-memory repository doesn't need one
+	if env.DB == nil && env.Config.Customers.Repository != customers.RepositoryMemory {
+		db, err := sqldb.NewAndMigrate(context.Background(), env.Logger, env.Config.Database)
 		if err != nil {
 			return nil, err
 		}
@@ -68,6 +103,82 @@ func NewEnvironment(env *Environment) (*Environment, error) {
 		env.TimeService = stime.NewSystemTimeService()
 	}
 
+	if env.CustomerRepository == nil {
+		switch env.Config.Customers.Repository {
+		case "", customers.RepositorySQL:
+			keys, err := envelope.NewKeyEncryptionKey(env.Config.Customers.Encryption.KeyEncryptionKey)
+			if err != nil {
+				return nil, err
+			}
+			env.CustomerRepository = customers.NewCustomerRepository(env.DB, env.Config.Database.Dialect(), env.Config.Customers.Timeouts, keys)
+		case customers.RepositoryMemory:
+			env.CustomerRepository = customers.NewInMemoryCustomerRepository()
+		default:
+			return nil, fmt.Errorf("unknown customers repository %q", env.Config.Customers.Repository)
+		}
+	}
+
+	// The audit log is kept alongside the customers. A log kept in memory doesn't outlive the process, and neither
+	// does the key it's chained with when none is configured.
+	if env.AuditRepository == nil || env.AuditService == nil {
+		chainKey, err := audit.NewChainKey(env.Config.Audit.ChainKey)
+		if errors.Is(err, audit.ErrNoChainKey) && env.DB == nil {
+			chainKey, err = audit.NewRandomChainKey()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if env.AuditRepository == nil {
+			if env.DB != nil {
+				env.AuditRepository = audit.NewRepository(env.DB, env.Config.Database.Dialect(), chainKey)
+			} else {
+				env.AuditRepository = audit.NewInMemoryRepository(chainKey)
+			}
+		}
+
+		if env.AuditService == nil {
+			env.AuditService = audit.NewService(env.TimeService, env.AuditRepository, chainKey)
+		}
+	}
+
+	if env.AuditRelayJob == nil {
+		env.AuditRelayJob = audit.NewRelayJob(env.Logger, env.AuditService, audit.DefaultRelayInterval)
+	}
+
+	if env.CustomerService == nil {
+		service, err := customers.NewCustomerService(env.TimeService, env.Logger, env.Config.Customers, env.CustomerRepository)
+		if err != nil {
+			return nil, err
+		}
+
+		// Audited outside the cache so reads it answers are recorded too.
+		cached := customers.NewCachedCustomerService(env.TimeService, env.Config.Customers.Cache, env.Config.Customers.Timeouts.Get, service)
+		env.CustomerService = customers.NewAuditedCustomerService(env.Logger, env.AuditService, cached)
+	}
+
+	if env.RetentionJob == nil {
+		job, err := customers.NewRetentionJob(env.TimeService, env.Logger, env.Config.Customers, env.CustomerService, env.CustomerRepository)
+		if err != nil {
+			return nil, err
+		}
+
+		env.RetentionJob = job
+	}
+
+	if env.RescreeningJob == nil {
+		env.RescreeningJob = customers.NewRescreeningJob(env.TimeService, env.Logger, env.Config.Customers, env.CustomerService, env.CustomerRepository)
+	}
+
+	if env.VerificationWorker == nil {
+		worker, err := customers.NewVerificationWorker(env.TimeService, env.Logger, env.Config.Customers, env.CustomerService, env.CustomerRepository)
+		if err != nil {
+			return nil, err
+		}
+
+		env.VerificationWorker = worker
+	}
+
 	if env.ZeroTrustMiddleware == nil {
 		env.ZeroTrustMiddleware = mux.MiddlewareFunc(func(h http.Handler) http.Handler {
 			return h
@@ -81,6 +192,35 @@ func NewEnvironment(env *Environment) (*Environment, error) {
 
 	env.PublicRouter.Use(env.ZeroTrustMiddleware)
 
+	validator, err := OpenAPIValidator(backendhiring.OpenAPI)
+	if err != nil {
+		return nil, err
+	}
+	env.PublicRouter.Use(validator)
+	env.PublicRouter.Use(audit.Middleware)
+
+	// grpc
+	if env.ZeroTrustInterceptor == nil {
+		env.ZeroTrustInterceptor = func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(ctx, req)
+		}
+	}
+
+	if env.GRPCServer == nil {
+		env.GRPCServer = grpc.NewServer(grpc.ChainUnaryInterceptor(
+			// Same order as the HTTP server, tracing wraps the logger so the trace ID is available to the request log.
+			tracing.UnaryServerInterceptor(),
+			GRPCRequestLogger(env.Logger, "grpc"),
+			env.ZeroTrustInterceptor,
+			audit.UnaryServerInterceptor(),
+		))
+	}
+
+	env.GRPCHealth = health.NewServer()
+	healthpb.RegisterHealthServer(env.GRPCServer, env.GRPCHealth)
+	reflection.Register(env.GRPCServer)
+	customers.NewCustomerGRPCController(env.Logger, env.CustomerService).Register(env.GRPCServer)
+
 	return env, nil
 }
 
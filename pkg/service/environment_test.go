@@ -1,11 +1,15 @@
 package service_test
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/moov-io/base/database"
 	"github.com/moov-io/base/log"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/envelope"
 	"github.com/moovfinancial/backendhiring/pkg/service"
 )
 
@@ -14,6 +18,7 @@ func Test_Environment_Startup(t *testing.T) {
 
 	env := &service.Environment{
 		Logger: log.NewDefaultLogger(),
+		Config: testConfig(t),
 	}
 
 	env, err := service.NewEnvironment(env)
@@ -21,3 +26,42 @@ func Test_Environment_Startup(t *testing.T) {
 
 	t.Cleanup(env.Shutdown)
 }
+
+func Test_Environment_RequiresKeyEncryptionKey(t *testing.T) {
+	a := assert.New(t)
+
+	cfg := testConfig(t)
+	cfg.Customers.Encryption.KeyEncryptionKey = ""
+
+	_, err := service.NewEnvironment(&service.Environment{
+		Logger: log.NewNopLogger(),
+		Config: cfg,
+	})
+	a.ErrorIs(err, envelope.ErrNoKeyEncryptionKey)
+}
+
+func Test_Environment_RequiresAuditChainKey(t *testing.T) {
+	a := assert.New(t)
+
+	cfg := testConfig(t)
+	cfg.Audit.ChainKey = ""
+
+	_, err := service.NewEnvironment(&service.Environment{
+		Logger: log.NewNopLogger(),
+		Config: cfg,
+	})
+	a.ErrorIs(err, audit.ErrNoChainKey)
+}
+
+// testConfig - The default config with its own database, the default one is the local one under data/, and the
+// development keys the default config leaves out.
+func testConfig(t *testing.T) *service.Config {
+	cfg, err := service.LoadConfig(log.NewNopLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Database.SQLite = &database.SQLiteConfig{Path: filepath.Join(t.TempDir(), "backendhiring.db")}
+	cfg.Customers.Encryption.KeyEncryptionKey = "ZGV2ZWxvcG1lbnQta2V5LW5vdC1mb3ItcHJvZHVjdCE="
+	cfg.Audit.ChainKey = "ZGV2ZWxvcG1lbnQtYXVkaXQtY2hhaW4ta2V5LW9ubHk="
+	return cfg
+}
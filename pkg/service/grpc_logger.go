@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moov-io/base/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+// GRPCRequestLogger - The gRPC counterpart of RequestLogger, logs every call with its status code and latency.
+// A request ID is propagated from the x-request-id metadata or generated, and sent back as a header.
+// Panics are recovered and turned into INTERNAL errors.
+func GRPCRequestLogger(logger log.Logger, name string) grpc.UnaryServerInterceptor {
+	metadataKey := strings.ToLower(RequestIDHeader)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
+		start := time.Now()
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		requestID := firstMetadata(md, metadataKey)
+		if requestID == "" {
+			requestID = uuid.NewString()
+			// Passed on in the metadata, like the header RequestLogger sets, for interceptors further down.
+			md = md.Copy()
+			md.Set(metadataKey, requestID)
+			ctx = metadata.NewIncomingContext(ctx, md)
+		}
+		grpc.SetHeader(ctx, metadata.Pairs(metadataKey, requestID))
+
+		ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+
+		defer func() {
+			fields := log.Fields{
+				"request_id":  log.String(requestID),
+				"rpc_method":  log.String(info.FullMethod),
+				"server_name": log.String(name),
+				"tenant_id":   log.String(firstMetadata(md, "x-tenant-id")),
+			}
+			for k, v := range tracing.LogFields(ctx) {
+				fields[k] = v
+			}
+
+			if p := recover(); p != nil {
+				fields["panic"] = log.String(fmt.Sprintf("%v", p))
+				fields["stacktrace"] = log.String(string(debug.Stack()))
+				res, err = nil, status.Error(codes.Internal, "")
+			}
+
+			code := status.Code(err)
+			fields["response_status"] = log.String(code.String())
+			fields["response_time"] = log.TimeDuration(time.Since(start))
+
+			entry := logger.With(fields)
+			if _, panicked := fields["panic"]; panicked {
+				entry = entry.Error()
+			} else {
+				entry = entry.Info()
+			}
+
+			entry.Logf("%s %s", info.FullMethod, code)
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/moovfinancial/backendhiring/pkg/service"
+)
+
+func Test_GRPCRequestLogger_StatusAndRequestID(t *testing.T) {
+	a := require.New(t)
+	buf, logger := log.NewBufferLogger()
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "abc-123", "x-tenant-id", "tenant-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/backendhiring.customers.v1.CustomerService/GetCustomer"}
+
+	_, err := service.GRPCRequestLogger(logger, "grpc")(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		a.Equal("abc-123", service.RequestIDFromContext(ctx))
+		return nil, status.Error(codes.NotFound, "")
+	})
+	a.Equal(codes.NotFound, status.Code(err))
+
+	out := buf.String()
+	a.Contains(out, "request_id=abc-123")
+	a.Contains(out, "tenant_id=tenant-1")
+	a.Contains(out, "response_status=NotFound")
+	a.Contains(out, "rpc_method="+info.FullMethod)
+}
+
+func Test_GRPCRequestLogger_Panic(t *testing.T) {
+	a := require.New(t)
+	buf, logger := log.NewBufferLogger()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Panic"}
+	res, err := service.GRPCRequestLogger(logger, "grpc")(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	a.Nil(res)
+	a.Equal(codes.Internal, status.Code(err))
+
+	a.Contains(buf.String(), "panic=boom")
+	a.Contains(buf.String(), "response_status=Internal")
+}
@@ -1,7 +1,10 @@
 package service
 
 import (
-	"github.com/moov-io/base/database"
+	"github.com/moovfinancial/backendhiring/pkg/audit"
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
 )
 
 type GlobalConfig struct {
@@ -11,13 +14,18 @@ type GlobalConfig struct {
 // Config defines all the configuration for the app
 type Config struct {
 	Servers  ServerConfig
-	Database database.DatabaseConfig
+	Database sqldb.Config
+	Tracing  tracing.Config
+
+	Customers customers.Config
+	Audit     audit.Config
 }
 
-// ServerConfig - Groups all the http configs for the servers and ports that get opened.
+// ServerConfig - Groups all the configs for the servers and ports that get opened.
 type ServerConfig struct {
 	Public HTTPConfig
 	Admin  HTTPConfig
+	GRPC   GRPCConfig
 }
 
 // HTTPConfig configuration for running an http server
@@ -25,6 +33,11 @@ type HTTPConfig struct {
 	Bind BindAddress
 }
 
+// GRPCConfig configuration for running the gRPC server
+type GRPCConfig struct {
+	Bind BindAddress
+}
+
 // BindAddress specifies where the http server should bind to.
 type BindAddress struct {
 	Address string
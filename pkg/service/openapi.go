@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gorilla/mux"
+
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+)
+
+func init() {
+	// kin-openapi only checks the formats it's told about.
+	openapi3.DefineStringFormatValidator("email", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForEmail))
+}
+
+// LoadOpenAPI - Parses and validates an OpenAPI 3 specification.
+func LoadOpenAPI(spec []byte) (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// MaxRequestBodySize - The largest body read to validate a request, bigger ones are refused with a 413. Uploads are
+// streamed to their handlers instead and limited there.
+const MaxRequestBodySize = 1 << 20
+
+// OpenAPIValidator - Rejects requests that don't match the specification with a 400 problem listing what's wrong.
+// Requests to routes the specification doesn't describe or marks deprecated are passed through untouched.
+func OpenAPIValidator(spec []byte) (mux.MiddlewareFunc, error) {
+	doc, err := LoadOpenAPI(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &openapi3filter.Options{
+		// Read only fields like createdOn are documented on the shared schema and ignored on input.
+		ExcludeReadOnlyValidations: true,
+		MultiError:                 true,
+		AuthenticationFunc:         openapi3filter.NoopAuthenticationFunc,
+	}
+
+	// Uploads aren't read here since validating them would mean buffering the whole file, their handlers check them
+	// as they're streamed.
+	uploadOptions := *options
+	uploadOptions.ExcludeRequestBody = true
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				problem.Write(w, problem.New(r, http.StatusBadRequest, err.Error()))
+				return
+			}
+
+			// Deprecated routes keep accepting what they did before the specification existed.
+			if route.Operation != nil && route.Operation.Deprecated {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			validationOptions := options
+			if isUpload(route) {
+				validationOptions = &uploadOptions
+			} else {
+				r.Body = http.MaxBytesReader(w, r.Body, MaxRequestBodySize)
+			}
+			err = openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    validationOptions,
+			})
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				problem.Write(w, problem.New(r, http.StatusRequestEntityTooLarge, "request body is too large"))
+				return
+			}
+			if err != nil {
+				details := problem.New(r, http.StatusBadRequest, "request doesn't match the API specification")
+				details.Errors = map[string]string{}
+				collectValidationErrors(err, details.Errors)
+				problem.Write(w, details)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// isUpload - Whether the operation takes its body as multipart/form-data. Decided by the specification rather than the
+// request's Content-Type, which the JSON handlers don't look at.
+func isUpload(route *routers.Route) bool {
+	if route.Operation == nil || route.Operation.RequestBody == nil || route.Operation.RequestBody.Value == nil {
+		return false
+	}
+	return route.Operation.RequestBody.Value.Content.Get("multipart/form-data") != nil
+}
+
+// collectValidationErrors - Flattens kin-openapi errors into a message per parameter or body field.
+func collectValidationErrors(err error, into map[string]string) {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		for _, inner := range e {
+			collectValidationErrors(inner, into)
+		}
+		return
+
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			into[e.Parameter.Name] = requestErrorReason(e)
+			return
+		}
+		if e.Err != nil {
+			collectValidationErrors(e.Err, into)
+			return
+		}
+		into["body"] = e.Reason
+		return
+
+	case *openapi3.SchemaError:
+		field := strings.Join(e.JSONPointer(), ".")
+		if field == "" {
+			field = "body"
+		}
+		into[field] = e.Reason
+		return
+	}
+
+	into["request"] = err.Error()
+}
+
+func requestErrorReason(e *openapi3filter.RequestError) string {
+	var schemaErr *openapi3.SchemaError
+	if errors.As(e.Err, &schemaErr) {
+		return schemaErr.Reason
+	}
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Reason
+}
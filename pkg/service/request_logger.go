@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// RequestIDFromContext - Returns the request ID assigned by the RequestLogger, or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// RequestLogger - Logs every request that passes through the router with its status, size and latency.
+// A request ID is propagated from the X-Request-ID header or generated, and echoed back on the response.
+// Panics are recovered and turned into 500 problem responses.
+func RequestLogger(logger log.Logger, routes *mux.Router, name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+			r.Header.Set(RequestIDHeader, requestID)
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		r = r.WithContext(context.WithValue(r.Context(), requestIDKey{}, requestID))
+
+		// Reuses the route matched by the tracing middleware when it runs first.
+		r, routeName := tracing.MatchRoute(routes, r)
+
+		rw := &responseRecorder{ResponseWriter: w}
+
+		defer func() {
+			ctx := log.Fields{
+				"request_id":     log.String(requestID),
+				"request_method": log.String(r.Method),
+				"request_uri":    log.String(r.RequestURI),
+				"server_name":    log.String(name),
+				"route_name":     log.String(routeName),
+				"tenant_id":      log.String(r.Header.Get("X-Tenant-ID")),
+			}
+			for k, v := range tracing.LogFields(r.Context()) {
+				ctx[k] = v
+			}
+
+			if p := recover(); p != nil {
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+
+				ctx["panic"] = log.String(fmt.Sprintf("%v", p))
+				ctx["stacktrace"] = log.String(string(debug.Stack()))
+
+				// Once the headers are out the client has already been told the request succeeded,
+				// so the only honest option left is to abort the connection.
+				if rw.wroteHeader {
+					finishRequestLog(logger, ctx, r, rw, start)
+					panic(http.ErrAbortHandler)
+				}
+
+				problem.Write(rw, problem.New(r, http.StatusInternalServerError, ""))
+			}
+
+			finishRequestLog(logger, ctx, r, rw, start)
+		}()
+
+		routes.ServeHTTP(rw, r)
+	})
+}
+
+func finishRequestLog(logger log.Logger, ctx log.Fields, r *http.Request, rw *responseRecorder, start time.Time) {
+	ctx["response_status"] = log.Int(rw.Status())
+	ctx["response_size"] = log.Int(rw.size)
+	ctx["response_time"] = log.TimeDuration(time.Since(start))
+
+	entry := logger.With(ctx)
+	if _, panicked := ctx["panic"]; panicked {
+		entry = entry.Error()
+	} else {
+		entry = entry.Info()
+	}
+
+	entry.Logf("%s %s %d", r.Method, r.RequestURI, rw.Status())
+}
+
+// responseRecorder - Captures the status code and number of bytes written to the wrapped ResponseWriter.
+type responseRecorder struct {
+	http.ResponseWriter
+
+	status      int
+	size        int
+	wroteHeader bool
+}
+
+func (w *responseRecorder) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *responseRecorder) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	return n, err
+}
+
+func (w *responseRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if !w.wroteHeader {
+			w.WriteHeader(http.StatusOK)
+		}
+		f.Flush()
+	}
+}
+
+func (w *responseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/problem"
+	"github.com/moovfinancial/backendhiring/pkg/service"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+func Test_RequestLogger_StatusAndRequestID(t *testing.T) {
+	a := require.New(t)
+	buf, logger := log.NewBufferLogger()
+
+	router := mux.NewRouter()
+	router.Name("Teapot.get").Methods("GET").Path("/teapot").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.NotEmpty(service.RequestIDFromContext(r.Context()))
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("short and stout"))
+	})
+
+	req := httptest.NewRequest("GET", "/teapot", nil)
+	req.Header.Set("X-Tenant-ID", "tenant-1")
+	rec := httptest.NewRecorder()
+	service.RequestLogger(logger, router, "public").ServeHTTP(rec, req)
+
+	a.Equal(http.StatusTeapot, rec.Code)
+	a.NotEmpty(rec.Header().Get(service.RequestIDHeader))
+
+	out := buf.String()
+	a.Contains(out, "response_status=418")
+	a.Contains(out, "response_size=15")
+	a.Contains(out, "route_name=Teapot.get")
+	a.Contains(out, "tenant_id=tenant-1")
+	a.Contains(out, "request_id="+rec.Header().Get(service.RequestIDHeader))
+}
+
+func Test_RequestLogger_MatchesRouteOnce(t *testing.T) {
+	a := require.New(t)
+	buf, logger := log.NewBufferLogger()
+
+	matched := 0
+	router := mux.NewRouter()
+	router.Name("Teapot.get").Methods("GET").Path("/teapot").MatcherFunc(func(*http.Request, *mux.RouteMatch) bool {
+		matched++
+		return true
+	}).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tracing.Middleware(router, service.RequestLogger(logger, router, "public")).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/teapot", nil))
+
+	// Once for the middlewares and once by the router dispatching it.
+	a.Equal(2, matched)
+	a.Contains(buf.String(), "route_name=Teapot.get")
+}
+
+func Test_RequestLogger_PropagatesRequestID(t *testing.T) {
+	a := require.New(t)
+	_, logger := log.NewBufferLogger()
+
+	router := mux.NewRouter()
+	router.Methods("GET").Path("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("abc-123", service.RequestIDFromContext(r.Context()))
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(service.RequestIDHeader, "abc-123")
+	rec := httptest.NewRecorder()
+	service.RequestLogger(logger, router, "public").ServeHTTP(rec, req)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal("abc-123", rec.Header().Get(service.RequestIDHeader))
+}
+
+func Test_RequestLogger_Panic(t *testing.T) {
+	a := require.New(t)
+	buf, logger := log.NewBufferLogger()
+
+	router := mux.NewRouter()
+	router.Methods("GET").Path("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	service.RequestLogger(logger, router, "public").ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	a.Equal(http.StatusInternalServerError, rec.Code)
+	a.Equal("application/problem+json", rec.Header().Get("Content-Type"))
+
+	details := problem.Details{}
+	a.Nil(json.NewDecoder(rec.Body).Decode(&details))
+	a.Equal(http.StatusInternalServerError, details.Status)
+	a.Equal(rec.Header().Get(service.RequestIDHeader), details.Instance)
+
+	a.Contains(buf.String(), "panic=boom")
+	a.Contains(buf.String(), "response_status=500")
+}
+
+func Test_RequestLogger_PanicAfterWrite(t *testing.T) {
+	_, logger := log.NewBufferLogger()
+
+	router := mux.NewRouter()
+	router.Methods("GET").Path("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		panic("boom")
+	})
+
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		service.RequestLogger(logger, router, "public").ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	})
+}
@@ -3,38 +3,88 @@ package service
 import (
 	"context"
 	"crypto/tls"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/moov-io/base/admin"
 	"github.com/moov-io/base/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 
-	_ "github.com/moovfinancial/backendhiring"
+	"github.com/moovfinancial/backendhiring"
+	"github.com/moovfinancial/backendhiring/pkg/customers"
+	"github.com/moovfinancial/backendhiring/pkg/sse"
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
 )
 
+// shutdownGracePeriod - How long in-flight requests get to finish before their contexts are cancelled.
+const shutdownGracePeriod = 30 * time.Second
+
 // RunServers - Boots up all the servers and awaits till they are stopped.
 func (env *Environment) RunServers(terminationListener chan error) func() {
 
 	adminServer := bootAdminServer(terminationListener, env.Logger, env.Config.Servers.Admin)
+	adminServer.AddHandler("/retention/dry-run", retentionDryRun(env.RetentionJob))
+	adminServer.AddHandler("/rescreening/latest", rescreeningLatest(env.RescreeningJob))
 
 	_, shutdownPublicServer := bootHTTPServer("public", env.PublicRouter, terminationListener, env.Logger, env.Config.Servers.Public)
 
+	shutdownGRPCServer := bootGRPCServer(env.GRPCServer, env.GRPCHealth, terminationListener, env.Logger, env.Config.Servers.GRPC)
+
+	stopRetentionJob := func() {}
+	if env.Config.Customers.Retention.Enabled {
+		stopRetentionJob = env.RetentionJob.Start()
+	}
+
+	stopRescreeningJob := func() {}
+	if env.Config.Customers.Screening.Rescreening.Enabled {
+		stopRescreeningJob = env.RescreeningJob.Start()
+	}
+
+	stopVerificationWorker := func() {}
+	if env.Config.Customers.Verification.Identity.Provider != "" {
+		stopVerificationWorker = env.VerificationWorker.Start()
+	}
+
+	// Chains the audit events of changes whose own attempt to chain them failed.
+	stopAuditRelayJob := env.AuditRelayJob.Start()
+
 	return func() {
+		stopAuditRelayJob()
+		stopRetentionJob()
+		stopRescreeningJob()
+		stopVerificationWorker()
 		adminServer.Shutdown()
 		shutdownPublicServer()
+		shutdownGRPCServer()
 	}
 }
 
 func bootHTTPServer(name string, routes *mux.Router, errs chan<- error, logger log.Logger, config HTTPConfig) (*http.Server, func()) {
 
-	loggedHandler := RequestLogger(logger, routes, "http")
+	// Tracing wraps the logger so the trace ID is available to the request log.
+	tracedHandler := tracing.Middleware(routes, RequestLogger(logger, routes, name))
+
+	// Cancelled once the server has shutdown so any requests still running stop their queries.
+	baseCtx, cancelBaseCtx := context.WithCancel(context.Background())
+
+	// Closed as soon as shutdown starts so long lived streams end instead of holding it up.
+	shuttingDown := make(chan struct{})
+	baseCtx = sse.WithShutdown(baseCtx, shuttingDown)
 
 	// Create main HTTP server
 	serve := &http.Server{
 		Addr:    config.Bind.Address,
-		Handler: loggedHandler,
+		Handler: tracedHandler,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 		TLSConfig: &tls.Config{
 			InsecureSkipVerify:       false,
 			PreferServerCipherSuites: true,
@@ -44,6 +94,7 @@ func bootHTTPServer(name string, routes *mux.Router, errs chan<- error, logger l
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	serve.RegisterOnShutdown(func() { close(shuttingDown) })
 
 	// Start main HTTP server
 	go func() {
@@ -54,7 +105,12 @@ func bootHTTPServer(name string, routes *mux.Router, errs chan<- error, logger l
 	}()
 
 	shutdownServer := func() {
-		if err := serve.Shutdown(context.Background()); err != nil {
+		defer cancelBaseCtx()
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+		defer cancel()
+
+		if err := serve.Shutdown(ctx); err != nil {
 			logger.Fatal().LogErrorf("shutting down: %v", err)
 		}
 	}
@@ -62,8 +118,44 @@ func bootHTTPServer(name string, routes *mux.Router, errs chan<- error, logger l
 	return serve, shutdownServer
 }
 
+func bootGRPCServer(server *grpc.Server, health *health.Server, errs chan<- error, logger log.Logger, config GRPCConfig) func() {
+	listener, err := net.Listen("tcp", config.Bind.Address)
+	if err != nil {
+		errs <- logger.Fatal().LogErrorf("problem starting grpc: %w", err).Err()
+		return func() {}
+	}
+
+	go func() {
+		logger.Info().Log(fmt.Sprintf("grpc listening on %s", listener.Addr()))
+		if err := server.Serve(listener); err != nil {
+			errs <- logger.Fatal().LogErrorf("problem starting grpc: %w", err).Err()
+		}
+	}()
+
+	return func() {
+		// Tell load balancers to stop sending calls before draining the ones in flight.
+		health.Shutdown()
+
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(shutdownGracePeriod):
+			server.Stop()
+		}
+	}
+}
+
 func bootAdminServer(errs chan<- error, logger log.Logger, config HTTPConfig) *admin.Server {
 	adminServer := admin.NewServer(config.Bind.Address)
+	adminServer.AddHandler("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(backendhiring.OpenAPI)
+	})
 
 	go func() {
 		logger.Info().Log(fmt.Sprintf("listening on %s", adminServer.BindAddr()))
@@ -75,24 +167,34 @@ func bootAdminServer(errs chan<- error, logger log.Logger, config HTTPConfig) *a
 	return adminServer
 }
 
-func RequestLogger(logger log.Logger, inner http.Handler, name string) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-		ctx := log.Fields{
-			"request_method": log.String(r.Method),
-			"require_uri":    log.String(r.RequestURI),
-			"route_name":     log.String(name),
-			"response_time":  log.TimeDuration(time.Since(start)),
+// retentionDryRun - Reports what the retention job would purge right now, whether or not it's enabled.
+func retentionDryRun(job *customers.RetentionJob) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := job.Run(r.Context(), true)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 
-		defer func() {
-			if r := recover(); r != nil {
-				ctx["panic"] = log.String(fmt.Sprintf("%v", r))
-			}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(report)
+	}
+}
 
-			logger.With(ctx).Info().Logf("%s %s %s", r.Method, r.RequestURI, name)
-		}()
+// rescreeningLatest - The status of the most recent rescreening run.
+func rescreeningLatest(job *customers.RescreeningJob) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		run, err := job.Latest(r.Context())
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "no rescreening runs yet", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 
-		inner.ServeHTTP(w, r)
-	})
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(run)
+	}
 }
@@ -0,0 +1,39 @@
+package sqldb
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect - Identifies the SQL engine a query is written for.
+type Dialect string
+
+const (
+	SQLite   Dialect = "sqlite"
+	MySQL    Dialect = "mysql"
+	Postgres Dialect = "postgres"
+)
+
+// Rebind - Rewrites the `?` placeholders in query into the form the dialect expects.
+// Queries must not contain a literal `?`, pass those values as arguments instead.
+func (d Dialect) Rebind(query string) string {
+	if d != Postgres {
+		return query
+	}
+
+	out := strings.Builder{}
+	out.Grow(len(query) + 8)
+
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			out.WriteByte('$')
+			out.WriteString(strconv.Itoa(n))
+			continue
+		}
+		out.WriteRune(c)
+	}
+
+	return out.String()
+}
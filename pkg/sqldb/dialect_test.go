@@ -0,0 +1,17 @@
+package sqldb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/sqldb"
+)
+
+func Test_Dialect_Rebind(t *testing.T) {
+	qry := "SELECT a FROM t WHERE b = ? AND c = ? LIMIT 1"
+
+	require.Equal(t, qry, sqldb.SQLite.Rebind(qry))
+	require.Equal(t, qry, sqldb.MySQL.Rebind(qry))
+	require.Equal(t, "SELECT a FROM t WHERE b = $1 AND c = $2 LIMIT 1", sqldb.Postgres.Rebind(qry))
+}
@@ -0,0 +1,34 @@
+package sqldb
+
+import (
+	"github.com/moov-io/base/database"
+)
+
+// Config - Extends the moov-io/base database config with PostgreSQL, only one engine should be configured.
+type Config struct {
+	database.DatabaseConfig `mapstructure:",squash"`
+
+	Postgres *PostgresConfig
+}
+
+// PostgresConfig - Connection settings for a PostgreSQL server.
+type PostgresConfig struct {
+	// Address is the host:port of the server, ie. "localhost:5432"
+	Address  string
+	User     string
+	Password string
+	// SSLMode is passed through to lib/pq, defaults to "disable"
+	SSLMode string
+}
+
+// Dialect - Returns the SQL dialect of the configured engine.
+func (c Config) Dialect() Dialect {
+	switch {
+	case c.Postgres != nil:
+		return Postgres
+	case c.MySQL != nil:
+		return MySQL
+	default:
+		return SQLite
+	}
+}
@@ -0,0 +1,105 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	"github.com/golang-migrate/migrate/v4"
+	migpostgres "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/lib/pq"
+	"github.com/moov-io/base/database"
+	"github.com/moov-io/base/log"
+)
+
+// New - Opens a connection to whichever engine is configured.
+func New(ctx context.Context, logger log.Logger, config Config) (*sql.DB, error) {
+	if config.Postgres == nil {
+		return database.New(ctx, logger, config.DatabaseConfig)
+	}
+
+	db, err := sql.Open("postgres", postgresDSN(*config.Postgres, config.DatabaseName))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// NewAndMigrate - Runs the migrations for the configured engine and returns a connection to it.
+func NewAndMigrate(ctx context.Context, logger log.Logger, config Config) (*sql.DB, error) {
+	if config.Postgres == nil {
+		return database.NewAndMigrate(ctx, logger, config.DatabaseConfig)
+	}
+
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+
+	db, err := New(ctx, logger, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := runPostgresMigrations(logger, db, config.DatabaseName); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func runPostgresMigrations(logger log.Logger, db *sql.DB, databaseName string) error {
+	logger.Info().Log("Running Migrations")
+
+	// Only picks up files ending in .up.sql or .up.postgres.sql
+	source, err := database.NewPkgerSource(string(Postgres))
+	if err != nil {
+		return err
+	}
+
+	driver, err := migpostgres.WithInstance(db, &migpostgres.Config{})
+	if err != nil {
+		return err
+	}
+
+	m, err := migrate.NewWithInstance("filtering-pkger", source, databaseName, driver)
+	if err != nil {
+		return logger.Fatal().LogErrorf("Error running migration: %w", err).Err()
+	}
+
+	switch err := m.Up(); err {
+	case nil:
+	case migrate.ErrNoChange:
+		logger.Info().Log("Database already at version")
+	default:
+		return logger.Fatal().LogErrorf("Error running migrations: %w", err).Err()
+	}
+
+	logger.Info().Log("Migrations complete")
+
+	return nil
+}
+
+func postgresDSN(config PostgresConfig, databaseName string) string {
+	sslMode := config.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(config.User, config.Password),
+		Host:     config.Address,
+		Path:     "/" + databaseName,
+		RawQuery: fmt.Sprintf("sslmode=%s", url.QueryEscape(sslMode)),
+	}
+
+	return dsn.String()
+}
@@ -0,0 +1,94 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moov-io/base/database"
+	"github.com/moov-io/base/log"
+)
+
+// TestDB - A freshly migrated database for a single test.
+type TestDB struct {
+	*sql.DB
+	Dialect Dialect
+}
+
+// CreateTestDatabases - Returns a migrated database for every engine available to the tests. SQLite is always
+// included, MySQL and PostgreSQL are added when TEST_MYSQL_ADDRESS or TEST_POSTGRES_ADDRESS point at a local server.
+// The user (TEST_*_USER / TEST_*_PASSWORD) must be allowed to create databases, each test gets its own.
+func CreateTestDatabases(t *testing.T) []TestDB {
+	dbs := []TestDB{
+		{DB: database.CreateTestSQLiteDB(t).DB, Dialect: SQLite},
+	}
+
+	if address := os.Getenv("TEST_MYSQL_ADDRESS"); address != "" {
+		dbs = append(dbs, createTestDatabase(t, Config{
+			DatabaseConfig: database.DatabaseConfig{
+				MySQL: &database.MySQLConfig{
+					Address:  address,
+					User:     os.Getenv("TEST_MYSQL_USER"),
+					Password: os.Getenv("TEST_MYSQL_PASSWORD"),
+				},
+			},
+		}))
+	}
+
+	if address := os.Getenv("TEST_POSTGRES_ADDRESS"); address != "" {
+		dbs = append(dbs, createTestDatabase(t, Config{
+			Postgres: &PostgresConfig{
+				Address:  address,
+				User:     os.Getenv("TEST_POSTGRES_USER"),
+				Password: os.Getenv("TEST_POSTGRES_PASSWORD"),
+			},
+		}))
+	}
+
+	return dbs
+}
+
+func createTestDatabase(t *testing.T, config Config) TestDB {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+
+	// Connect to the server without a database so we can create one just for this test.
+	server, err := New(ctx, logger, config)
+	if err != nil {
+		t.Fatalf("connecting to %s: %v", config.Dialect(), err)
+	}
+	defer server.Close()
+
+	config.DatabaseName = "test" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if _, err := server.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE %s", config.DatabaseName)); err != nil {
+		t.Fatalf("creating %s test database: %v", config.Dialect(), err)
+	}
+
+	db, err := NewAndMigrate(ctx, logger, config)
+	if err != nil {
+		t.Fatalf("migrating %s test database: %v", config.Dialect(), err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+
+		server, err := New(ctx, logger, Config{DatabaseConfig: database.DatabaseConfig{MySQL: config.MySQL}, Postgres: config.Postgres})
+		if err != nil {
+			t.Logf("connecting to drop %s test database: %v", config.Dialect(), err)
+			return
+		}
+		defer server.Close()
+
+		if _, err := server.ExecContext(ctx, fmt.Sprintf("DROP DATABASE %s", config.DatabaseName)); err != nil {
+			t.Logf("dropping %s test database: %v", config.Dialect(), err)
+		}
+	})
+
+	return TestDB{DB: db, Dialect: config.Dialect()}
+}
@@ -0,0 +1,99 @@
+// Package sse writes Server-Sent Events (https://html.spec.whatwg.org/multipage/server-sent-events.html).
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const ContentType = "text/event-stream"
+
+// LastEventIDHeader - Sent by EventSource when it reconnects with the ID of the last event it received.
+const LastEventIDHeader = "Last-Event-ID"
+
+// Writer - Writes events to a response, every write is flushed straight to the client.
+type Writer struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// NewWriter - Starts the event stream. The server's write timeout is lifted since streams are expected to outlive it.
+func NewWriter(w http.ResponseWriter) (*Writer, error) {
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return nil, err
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	// Stops nginx from buffering the stream.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	sw := &Writer{w: w, rc: rc}
+	return sw, sw.flush()
+}
+
+// Event - Writes an event with data encoded as JSON.
+func (s *Writer) Event(id string, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	b := strings.Builder{}
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	fmt.Fprintf(&b, "data: %s\n\n", payload)
+
+	if _, err := s.w.Write([]byte(b.String())); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// Comment - Ignored by clients, used as a heartbeat so proxies don't close an idle stream.
+func (s *Writer) Comment(text string) error {
+	if _, err := fmt.Fprintf(s.w, ": %s\n\n", text); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// Retry - Tells the client how long to wait before reconnecting if the stream drops.
+func (s *Writer) Retry(d time.Duration) error {
+	if _, err := fmt.Fprintf(s.w, "retry: %d\n\n", d.Milliseconds()); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *Writer) flush() error {
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+type shutdownKey struct{}
+
+// WithShutdown - Attaches a channel that's closed when the server starts shutting down. Streams never finish on
+// their own so they watch it to end early and let the graceful shutdown complete.
+func WithShutdown(ctx context.Context, shutdown <-chan struct{}) context.Context {
+	return context.WithValue(ctx, shutdownKey{}, shutdown)
+}
+
+// Shutdown - The channel from WithShutdown, nil (blocks forever) when there isn't one.
+func Shutdown(ctx context.Context) <-chan struct{} {
+	shutdown, _ := ctx.Value(shutdownKey{}).(<-chan struct{})
+	return shutdown
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/moov-io/base/stime"
 	"github.com/stretchr/testify/require"
 
+	"github.com/moovfinancial/backendhiring/pkg/customers"
 	"github.com/moovfinancial/backendhiring/pkg/service"
 )
 
@@ -24,6 +25,11 @@ type TestEnvironment struct {
 }
 
 func NewEnvironment(t *testing.T, router *mux.Router) *TestEnvironment {
+	return NewEnvironmentWithConfig(t, router, nil)
+}
+
+// NewEnvironmentWithConfig - Lets the test change the config before the environment is built from it.
+func NewEnvironmentWithConfig(t *testing.T, router *mux.Router, configure func(cfg *service.Config)) *TestEnvironment {
 	assert := require.New(t)
 	logger := log.NewNopLogger() //log.NewDefaultLogger()
 
@@ -42,8 +48,20 @@ func NewEnvironment(t *testing.T, router *mux.Router) *TestEnvironment {
 		})
 	})
 
+	// Tests run against the in-memory repository, the SQL repositories have their own contract tests.
+	cfg, err := service.LoadConfig(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Customers.Repository = customers.RepositoryMemory
+	cfg.Customers.Documents.Storage.Filesystem.Directory = t.TempDir()
+	if configure != nil {
+		configure(cfg)
+	}
+
 	env, err := service.NewEnvironment(&service.Environment{
 		Logger:              logger,
+		Config:              cfg,
 		TimeService:         testEnv.StaticTime,
 		ZeroTrustMiddleware: mw,
 		PublicRouter:        router,
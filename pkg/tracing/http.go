@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type routeNameKey struct{}
+
+// WithRouteName - Records the name of the matched mux route so spans further down can be attributed to it.
+func WithRouteName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, routeNameKey{}, name)
+}
+
+// RouteName - Returns the route name recorded by the Middleware, or an empty string.
+func RouteName(ctx context.Context) string {
+	name, _ := ctx.Value(routeNameKey{}).(string)
+	return name
+}
+
+// MatchRoute - The name of the mux route matching the request. It's only matched once, the name is recorded on the
+// returned request's context for the handlers after it.
+func MatchRoute(routes *mux.Router, r *http.Request) (*http.Request, string) {
+	if name, found := r.Context().Value(routeNameKey{}).(string); found {
+		return r, name
+	}
+
+	name := ""
+	match := mux.RouteMatch{}
+	if routes.Match(r, &match) && match.Route != nil {
+		name = match.Route.GetName()
+	}
+	return r.WithContext(WithRouteName(r.Context(), name)), name
+}
+
+// Middleware - Continues any trace passed in the W3C traceparent header and wraps the request in a server span.
+func Middleware(routes *mux.Router, inner http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, routeName := MatchRoute(routes, r)
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		spanName := routeName
+		if spanName == "" {
+			spanName = r.Method
+		}
+
+		ctx, span := Tracer().Start(ctx, spanName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", routeName),
+				attribute.String("url.path", r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		rw := &statusRecorder{ResponseWriter: w}
+		inner.ServeHTTP(rw, r.WithContext(ctx))
+
+		// The tenant is only known once the zero trust middleware on the router has run.
+		span.SetAttributes(
+			attribute.String("tenant.id", r.Header.Get("X-Tenant-ID")),
+			attribute.Int("http.response.status_code", rw.Status()),
+		)
+		if rw.Status() >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rw.Status()))
+		}
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,69 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+func Test_Middleware_ContinuesTraceparent(t *testing.T) {
+	a := require.New(t)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	router := mux.NewRouter()
+	router.Name("Customer.get").Methods("GET").Path("/customers/{ID}").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("Customer.get", tracing.RouteName(r.Context()))
+
+		_, span := tracing.Start(r.Context(), "CustomerService.Get", r.Header.Get("X-Tenant-ID"))
+		tracing.End(span, nil)
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest("GET", "/customers/123", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("X-Tenant-ID", "tenant-1")
+
+	tracing.Middleware(router, router).ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	a.Len(spans, 2)
+
+	child, server := spans[0], spans[1]
+	a.Equal("Customer.get", server.Name())
+	a.Equal("4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+	a.Equal("00f067aa0ba902b7", server.Parent().SpanID().String())
+	a.Contains(server.Attributes(), attribute.String("tenant.id", "tenant-1"))
+	a.Contains(server.Attributes(), attribute.Int("http.response.status_code", 404))
+
+	a.Equal("CustomerService.Get", child.Name())
+	a.Equal(server.SpanContext().SpanID(), child.Parent().SpanID())
+	a.Contains(child.Attributes(), attribute.String("http.route", "Customer.get"))
+}
+
+func Test_LogFields(t *testing.T) {
+	a := require.New(t)
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	a.Empty(tracing.LogFields(context.Background()))
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "test")
+	defer span.End()
+
+	fields := tracing.LogFields(ctx)
+	a.Contains(fields, "trace_id")
+	a.Contains(fields, "span_id")
+}
@@ -0,0 +1,25 @@
+package tracing
+
+// Config - Configures where spans are exported. Tracing is disabled when no Exporter is set.
+type Config struct {
+	// Exporter is one of "otlp" or "file"
+	Exporter    string
+	ServiceName string
+	// SampleRatio is the fraction of new traces that are recorded, 0 or unset records all of them.
+	SampleRatio float64
+
+	OTLP OTLPConfig
+	File FileConfig
+}
+
+// OTLPConfig - Sends spans over OTLP/HTTP to a collector.
+type OTLPConfig struct {
+	// Endpoint is the host:port of the collector, ie. "localhost:4318"
+	Endpoint string
+	Insecure bool
+}
+
+// FileConfig - Writes spans as JSON lines to a local file.
+type FileConfig struct {
+	Path string
+}
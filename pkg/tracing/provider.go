@@ -0,0 +1,107 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/moov-io/base/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const InstrumentationName = "github.com/moovfinancial/backendhiring"
+
+// Tracer - Returns the tracer used by all the packages in this service.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Setup - Installs the global tracer provider and W3C trace context propagator.
+// The returned func flushes any pending spans and must be called on shutdown.
+func Setup(ctx context.Context, logger log.Logger, config Config) (func(), error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, cleanup, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if exporter == nil {
+		return func() {}, nil
+	}
+
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = "backendhiring"
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if config.SampleRatio > 0 && config.SampleRatio < 1 {
+		sampler = sdktrace.TraceIDRatioBased(config.SampleRatio)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	logger.Info().Logf("tracing spans exported to %s", config.Exporter)
+
+	return func() {
+		if err := provider.Shutdown(context.Background()); err != nil {
+			logger.Error().LogErrorf("shutting down tracing: %w", err)
+		}
+		cleanup()
+	}, nil
+}
+
+func newExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, func(), error) {
+	switch strings.ToLower(config.Exporter) {
+	case "":
+		return nil, func() {}, nil
+
+	case "otlp":
+		opts := []otlptracehttp.Option{}
+		if config.OTLP.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(config.OTLP.Endpoint))
+		}
+		if config.OTLP.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		return exporter, func() {}, nil
+
+	case "file":
+		if config.File.Path == "" {
+			return nil, nil, fmt.Errorf("tracing file exporter requires a path")
+		}
+
+		f, err := os.OpenFile(config.File.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return exporter, func() { f.Close() }, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown tracing exporter %q", config.Exporter)
+}
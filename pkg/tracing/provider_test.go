@@ -0,0 +1,37 @@
+package tracing_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/backendhiring/pkg/tracing"
+)
+
+func Test_Setup_FileExporter(t *testing.T) {
+	a := require.New(t)
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+
+	shutdown, err := tracing.Setup(context.Background(), log.NewNopLogger(), tracing.Config{
+		Exporter: "file",
+		File:     tracing.FileConfig{Path: path},
+	})
+	a.Nil(err)
+
+	_, span := tracing.Start(context.Background(), "CustomerRepository.Get", "tenant-1")
+	tracing.End(span, nil)
+	shutdown()
+
+	written, err := os.ReadFile(path)
+	a.Nil(err)
+	a.Contains(string(written), "CustomerRepository.Get")
+}
+
+func Test_Setup_UnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), log.NewNopLogger(), tracing.Config{Exporter: "carrier-pigeon"})
+	require.Error(t, err)
+}
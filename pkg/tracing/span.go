@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/moov-io/base/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Start - Starts a child span tagged with the tenant and the route that the request came in on.
+func Start(ctx context.Context, name string, tenantID string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		attribute.String("tenant.id", tenantID),
+		attribute.String("http.route", RouteName(ctx)),
+	)
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End - Records the error, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// IDs - Returns the trace and span ID in ctx so log lines can be correlated with their traces.
+func IDs(ctx context.Context) (traceID string, spanID string) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return "", ""
+	}
+	return sc.TraceID().String(), sc.SpanID().String()
+}
+
+// LogFields - Trace and span IDs in ctx formatted for the log, empty when ctx isn't being traced.
+func LogFields(ctx context.Context) log.Fields {
+	traceID, spanID := IDs(ctx)
+	if traceID == "" {
+		return log.Fields{}
+	}
+	return log.Fields{
+		"trace_id": log.String(traceID),
+		"span_id":  log.String(spanID),
+	}
+}